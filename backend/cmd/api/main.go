@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -15,6 +20,7 @@ import (
 	_ "github.com/wealthpath/backend/docs"
 	"github.com/wealthpath/backend/internal/handler"
 	"github.com/wealthpath/backend/internal/repository"
+	"github.com/wealthpath/backend/internal/scheduler"
 	"github.com/wealthpath/backend/internal/service"
 )
 
@@ -56,7 +62,15 @@ func main() {
 	savingsRepo := repository.NewSavingsGoalRepository(db)
 	debtRepo := repository.NewDebtRepository(db)
 	recurringRepo := repository.NewRecurringRepository(db)
+	recurringDraftRepo := repository.NewRecurringDraftRepository(db)
 	interestRateRepo := repository.NewInterestRateRepository(db)
+	savingsRuleRepo := repository.NewSavingsRuleRepository(db)
+	depositLadderRepo := repository.NewDepositLadderRepository(db)
+	savingsMemberRepo := repository.NewSavingsGoalMemberRepository(db)
+	jobRunRepo := repository.NewJobRunRepository(db)
+	calendarFeedRepo := repository.NewCalendarFeedRepository(db)
+	reportRepo := repository.NewReportRepository(db)
+	netWorthRepo := repository.NewNetWorthRepository(db)
 
 	// Initialize services
 	userService := service.NewUserService(userRepo)
@@ -68,6 +82,49 @@ func main() {
 	dashboardService := service.NewDashboardService(transactionRepo, budgetRepo, savingsRepo, debtRepo)
 	aiService := service.NewAIService(transactionService, budgetService, savingsService)
 	interestRateService := service.NewInterestRateService(interestRateRepo)
+	savingsService.SetRateProvider(interestRateService)
+	debtService.SetRateProvider(interestRateService)
+	savingsService.SetMemberRepo(savingsMemberRepo, userRepo)
+	savingsRuleService := service.NewSavingsRuleService(savingsRuleRepo, savingsRepo)
+	transactionService.SetCardRepo(debtRepo)
+	transactionService.AddCreatedHook(savingsRuleService)
+	recurringService.AddCreatedHook(savingsRuleService)
+	recurringService.SetInstallmentReminder(debtService)
+	recurringService.SetTransactionHistory(transactionRepo)
+	recurringService.SetDraftStore(recurringDraftRepo)
+	recurringService.SetTransferTargets(savingsService, debtService)
+	depositLadderService := service.NewDepositLadderService(depositLadderRepo, savingsRepo, interestRateService)
+	calendarService := service.NewCalendarService(calendarFeedRepo, recurringRepo, debtRepo, savingsRepo)
+	reportService := service.NewReportService(reportRepo)
+	netWorthService := service.NewNetWorthService(netWorthRepo)
+
+	// Background jobs - every replica runs the scheduler; advisory locks elect one per job
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	jobs := scheduler.New(jobRunRepo)
+	jobs.Register(scheduler.Job{
+		Name:     "recurring-transactions",
+		Interval: 15 * time.Minute,
+		Run:      recurringService.ProcessDueTransactions,
+	})
+	jobs.Register(scheduler.Job{
+		Name:     "savings-rules",
+		Interval: time.Hour,
+		Run:      savingsRuleService.ProcessScheduledRules,
+	})
+	jobs.Register(scheduler.Job{
+		Name:     "credit-card-statements",
+		Interval: time.Hour,
+		Run:      debtService.CloseDueStatements,
+	})
+	jobs.Register(scheduler.Job{
+		Name:     "net-worth-snapshots",
+		Interval: time.Hour,
+		Run:      netWorthService.TakeSnapshots,
+	})
+	if os.Getenv("SCHEDULER_ENABLED") != "false" {
+		jobs.Start(ctx)
+	}
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(userService)
@@ -75,8 +132,14 @@ func main() {
 	transactionHandler := handler.NewTransactionHandler(transactionService)
 	budgetHandler := handler.NewBudgetHandler(budgetService)
 	savingsHandler := handler.NewSavingsGoalHandler(savingsService)
+	savingsRuleHandler := handler.NewSavingsRuleHandler(savingsRuleService)
+	savingsShareHandler := handler.NewSavingsShareHandler(savingsService)
+	depositLadderHandler := handler.NewDepositLadderHandler(depositLadderService)
 	debtHandler := handler.NewDebtHandler(debtService)
 	recurringHandler := handler.NewRecurringHandler(recurringService)
+	calendarHandler := handler.NewCalendarHandler(calendarService)
+	reportHandler := handler.NewReportHandler(reportService)
+	netWorthHandler := handler.NewNetWorthHandler(netWorthService)
 	dashboardHandler := handler.NewDashboardHandler(dashboardService)
 	aiHandler := handler.NewAIHandler(aiService)
 	interestRateHandler := handler.NewInterestRateHandler(interestRateService)
@@ -128,6 +191,9 @@ func main() {
 	r.Get("/api/auth/{provider}/callback", oauthHandler.OAuthCallback)
 	r.Post("/api/auth/{provider}/token", oauthHandler.OAuthToken)
 
+	// Calendar feed (public - the secret token in the URL authenticates it)
+	r.Get("/api/calendar/{token}.ics", calendarHandler.Feed)
+
 	// Interest rates (public - no auth required)
 	r.Get("/api/interest-rates", interestRateHandler.ListRates)
 	r.Get("/api/interest-rates/best", interestRateHandler.GetBestRates)
@@ -170,6 +236,27 @@ func main() {
 		r.Put("/api/savings-goals/{id}", savingsHandler.Update)
 		r.Delete("/api/savings-goals/{id}", savingsHandler.Delete)
 		r.Post("/api/savings-goals/{id}/contribute", savingsHandler.Contribute)
+		r.Get("/api/savings-goals/{id}/projection", savingsHandler.GetProjection)
+		r.Get("/api/savings-goals/{id}/contributions", savingsHandler.ListContributions)
+		r.Get("/api/savings-goals/{id}/members", savingsShareHandler.ListMembers)
+		r.Put("/api/savings-goals/{id}/members/{userId}", savingsShareHandler.UpdateMember)
+		r.Delete("/api/savings-goals/{id}/members/{userId}", savingsShareHandler.RemoveMember)
+		r.Post("/api/savings-goals/{id}/invitations", savingsShareHandler.Invite)
+		r.Get("/api/savings-goals/{id}/rules", savingsRuleHandler.List)
+		r.Post("/api/savings-goals/{id}/rules", savingsRuleHandler.Create)
+		r.Put("/api/savings-goals/{id}/rules/{ruleId}", savingsRuleHandler.Update)
+		r.Delete("/api/savings-goals/{id}/rules/{ruleId}", savingsRuleHandler.Delete)
+		r.Get("/api/savings-invitations", savingsShareHandler.ListInvitations)
+		r.Post("/api/savings-invitations/{token}/accept", savingsShareHandler.AcceptInvitation)
+		r.Post("/api/savings-invitations/{token}/decline", savingsShareHandler.DeclineInvitation)
+
+		// Term Deposit Ladders
+		r.Post("/api/deposit-ladders/plan", depositLadderHandler.Plan)
+		r.Get("/api/deposit-ladders", depositLadderHandler.List)
+		r.Post("/api/deposit-ladders", depositLadderHandler.Create)
+		r.Get("/api/deposit-ladders/{id}", depositLadderHandler.Get)
+		r.Put("/api/deposit-ladders/{id}/goal", depositLadderHandler.AttachToGoal)
+		r.Delete("/api/deposit-ladders/{id}", depositLadderHandler.Delete)
 
 		// Debt Management
 		r.Get("/api/debts", debtHandler.List)
@@ -178,19 +265,49 @@ func main() {
 		r.Put("/api/debts/{id}", debtHandler.Update)
 		r.Delete("/api/debts/{id}", debtHandler.Delete)
 		r.Post("/api/debts/{id}/payment", debtHandler.MakePayment)
+		r.Get("/api/debts/{id}/payments", debtHandler.ListPayments)
+		r.Put("/api/debts/{id}/payments/{paymentId}", debtHandler.UpdatePayment)
+		r.Delete("/api/debts/{id}/payments/{paymentId}", debtHandler.DeletePayment)
 		r.Get("/api/debts/{id}/payoff-plan", debtHandler.GetPayoffPlan)
+		r.Post("/api/debts/{id}/payoff-plan/scenario", debtHandler.GetPayoffScenario)
+		r.Post("/api/debts/{id}/refinance", debtHandler.CompareRefinance)
+		r.Get("/api/debts/{id}/statements", debtHandler.ListStatements)
+		r.Get("/api/debts/{id}/statements/{statementId}", debtHandler.GetStatement)
+		r.Get("/api/debts/{id}/installments", debtHandler.GetInstallmentSchedule)
 		r.Get("/api/debts/calculator", debtHandler.InterestCalculator)
+		r.Post("/api/debts/payoff-strategy", debtHandler.PlanPayoffStrategy)
 
 		// Recurring Transactions
 		r.Get("/api/recurring", recurringHandler.List)
 		r.Post("/api/recurring", recurringHandler.Create)
 		r.Get("/api/recurring/upcoming", recurringHandler.Upcoming)
+		r.Get("/api/recurring/suggestions", recurringHandler.Suggestions)
+		r.Post("/api/recurring/suggestions/{id}/accept", recurringHandler.AcceptSuggestion)
+		r.Get("/api/recurring/drafts", recurringHandler.Drafts)
+		r.Post("/api/recurring/drafts/{id}/confirm", recurringHandler.ConfirmDraft)
+		r.Delete("/api/recurring/drafts/{id}", recurringHandler.DismissDraft)
 		r.Get("/api/recurring/{id}", recurringHandler.Get)
+		r.Get("/api/recurring/{id}/transactions", recurringHandler.Transactions)
+		r.Get("/api/recurring/{id}/exceptions", recurringHandler.Exceptions)
+		r.Put("/api/recurring/{id}/exceptions/{date}", recurringHandler.SetException)
+		r.Delete("/api/recurring/{id}/exceptions/{date}", recurringHandler.DeleteException)
 		r.Put("/api/recurring/{id}", recurringHandler.Update)
 		r.Delete("/api/recurring/{id}", recurringHandler.Delete)
 		r.Post("/api/recurring/{id}/pause", recurringHandler.Pause)
 		r.Post("/api/recurring/{id}/resume", recurringHandler.Resume)
 
+		// Calendar feed
+		r.Get("/api/calendar/feed", calendarHandler.GetFeed)
+		r.Post("/api/calendar/feed/rotate", calendarHandler.RotateFeed)
+		r.Delete("/api/calendar/feed", calendarHandler.DisableFeed)
+
+		// Reports
+		r.Get("/api/reports", reportHandler.GetReport)
+
+		// Net Worth
+		r.Get("/api/net-worth", netWorthHandler.GetNetWorth)
+		r.Get("/api/net-worth/history", netWorthHandler.GetHistory)
+
 		// AI Chat
 		r.Post("/api/chat", aiHandler.Chat)
 	})
@@ -200,8 +317,20 @@ func main() {
 		port = "8080"
 	}
 
+	server := &http.Server{Addr: ":" + port, Handler: r}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Server shutdown failed: %v", err)
+		}
+	}()
+
 	log.Printf("Server starting on port %s", port)
-	if err := http.ListenAndServe(":"+port, r); err != nil {
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Printf("Server failed: %v", err)
 	}
+	stop()
+	jobs.Wait()
 }
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/service"
+)
+
+type CalendarHandler struct {
+	calendarService CalendarServiceInterface
+}
+
+func NewCalendarHandler(calendarService CalendarServiceInterface) *CalendarHandler {
+	return &CalendarHandler{calendarService: calendarService}
+}
+
+// GetFeed godoc
+// @Summary Get the calendar feed
+// @Description Get the secret URL of the user's iCalendar feed of bills and due dates, creating it on first use
+// @Tags calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.CalendarFeed
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /calendar/feed [get]
+func (h *CalendarHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+	if userID == uuid.Nil {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	feed, err := h.calendarService.GetFeed(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get calendar feed")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, withFeedURL(r, feed))
+}
+
+// RotateFeed godoc
+// @Summary Rotate the calendar feed URL
+// @Description Give the calendar feed a new secret URL; the old URL stops working
+// @Tags calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.CalendarFeed
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /calendar/feed/rotate [post]
+func (h *CalendarHandler) RotateFeed(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+	if userID == uuid.Nil {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	feed, err := h.calendarService.RotateFeed(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to rotate calendar feed")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, withFeedURL(r, feed))
+}
+
+// DisableFeed godoc
+// @Summary Disable the calendar feed
+// @Description Remove the calendar feed; its URL stops working
+// @Tags calendar
+// @Security BearerAuth
+// @Success 204 "No Content"
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /calendar/feed [delete]
+func (h *CalendarHandler) DisableFeed(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+	if userID == uuid.Nil {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := h.calendarService.DisableFeed(r.Context(), userID); err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to disable calendar feed")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Feed godoc
+// @Summary Calendar feed
+// @Description iCalendar feed of upcoming bills, debt due dates and savings goal target dates. The secret token in the URL authenticates the request, so calendar apps can subscribe to it.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token"
+// @Success 200 {string} string "iCalendar data"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /calendar/{token}.ics [get]
+func (h *CalendarHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	cal, err := h.calendarService.Calendar(r.Context(), chi.URLParam(r, "token"))
+	if errors.Is(err, service.ErrCalendarFeedNotFound) {
+		respondError(w, http.StatusNotFound, "calendar feed not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get calendar feed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="wealthpath.ics"`)
+	w.Header().Set("Cache-Control", "private, max-age=900")
+	w.WriteHeader(http.StatusOK)
+	_, _ = cal.WriteTo(w)
+}
+
+// withFeedURL sets the subscription URL of a feed on the host the request came in on.
+func withFeedURL(r *http.Request, feed *model.CalendarFeed) *model.CalendarFeed {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	feed.URL = scheme + "://" + r.Host + "/api/calendar/" + feed.Token + ".ics"
+	return feed
+}
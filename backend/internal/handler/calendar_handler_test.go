@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/service"
+	"github.com/wealthpath/backend/pkg/ical"
+)
+
+// MockCalendarService implements CalendarServiceInterface for testing
+type MockCalendarService struct {
+	mock.Mock
+}
+
+func (m *MockCalendarService) GetFeed(ctx context.Context, userID uuid.UUID) (*model.CalendarFeed, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CalendarFeed), args.Error(1)
+}
+
+func (m *MockCalendarService) RotateFeed(ctx context.Context, userID uuid.UUID) (*model.CalendarFeed, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CalendarFeed), args.Error(1)
+}
+
+func (m *MockCalendarService) DisableFeed(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockCalendarService) Calendar(ctx context.Context, token string) (*ical.Calendar, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ical.Calendar), args.Error(1)
+}
+
+func TestCalendarHandler_GetFeed(t *testing.T) {
+	t.Parallel()
+
+	mockService := new(MockCalendarService)
+	h := NewCalendarHandler(mockService)
+	userID := uuid.New()
+	mockService.On("GetFeed", mock.Anything, userID).Return(&model.CalendarFeed{UserID: userID, Token: "abc123"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/calendar/feed", nil)
+	req.Host = "api.wealthpath.io"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req = req.WithContext(ctxWithUserID(userID))
+	w := httptest.NewRecorder()
+
+	h.GetFeed(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var feed model.CalendarFeed
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &feed))
+	assert.Equal(t, "https://api.wealthpath.io/api/calendar/abc123.ics", feed.URL)
+
+	w = httptest.NewRecorder()
+	h.GetFeed(w, httptest.NewRequest(http.MethodGet, "/api/calendar/feed", nil).WithContext(ctxWithUserID(uuid.Nil)))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestCalendarHandler_Feed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		token      string
+		setupMock  func(*MockCalendarService)
+		wantStatus int
+	}{
+		{
+			name:  "success",
+			token: "abc123",
+			setupMock: func(m *MockCalendarService) {
+				m.On("Calendar", mock.Anything, "abc123").Return(&ical.Calendar{
+					ProdID: "-//WealthPath//EN",
+					Stamp:  time.Now(),
+					Events: []ical.Event{{UID: "goal-1@wealthpath", Summary: "Tết", Date: time.Now()}},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "unknown token",
+			token: "stale",
+			setupMock: func(m *MockCalendarService) {
+				m.On("Calendar", mock.Anything, "stale").Return(nil, service.ErrCalendarFeedNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:  "service error",
+			token: "abc123",
+			setupMock: func(m *MockCalendarService) {
+				m.On("Calendar", mock.Anything, "abc123").Return(nil, errors.New("db error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockCalendarService)
+			tt.setupMock(mockService)
+			r := chi.NewRouter()
+			r.Get("/api/calendar/{token}.ics", NewCalendarHandler(mockService).Feed)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/calendar/"+tt.token+".ics", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+				assert.True(t, strings.HasPrefix(w.Body.String(), "BEGIN:VCALENDAR\r\n"))
+				assert.Contains(t, w.Body.String(), "UID:goal-1@wealthpath\r\n")
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCalendarHandler_RotateAndDisable(t *testing.T) {
+	t.Parallel()
+
+	mockService := new(MockCalendarService)
+	h := NewCalendarHandler(mockService)
+	userID := uuid.New()
+	mockService.On("RotateFeed", mock.Anything, userID).Return(&model.CalendarFeed{UserID: userID, Token: "new"}, nil)
+	mockService.On("DisableFeed", mock.Anything, userID).Return(nil)
+
+	w := httptest.NewRecorder()
+	h.RotateFeed(w, httptest.NewRequest(http.MethodPost, "/api/calendar/feed/rotate", nil).WithContext(ctxWithUserID(userID)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "/api/calendar/new.ics")
+
+	w = httptest.NewRecorder()
+	h.DisableFeed(w, httptest.NewRequest(http.MethodDelete, "/api/calendar/feed", nil).WithContext(ctxWithUserID(userID)))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockService.AssertExpectations(t)
+}
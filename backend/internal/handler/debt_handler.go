@@ -2,12 +2,14 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
-	_ "github.com/wealthpath/backend/internal/model" // swagger types
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
 	"github.com/wealthpath/backend/internal/service"
 )
 
@@ -43,6 +45,16 @@ func (h *DebtHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	debt, err := h.service.Create(r.Context(), userID, input)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidRateSchedule) ||
+			errors.Is(err, service.ErrInvalidInterestMethod) ||
+			errors.Is(err, service.ErrEqualPrincipalTerm) ||
+			errors.Is(err, service.ErrInvalidStatementCycle) ||
+			errors.Is(err, service.ErrInvalidCreditLimit) ||
+			errors.Is(err, service.ErrInvalidInstallmentPlan) ||
+			errors.Is(err, service.ErrInvalidInstallmentCard) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "failed to create debt")
 		return
 	}
@@ -131,6 +143,16 @@ func (h *DebtHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	debt, err := h.service.Update(r.Context(), id, userID, input)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidRateSchedule) ||
+			errors.Is(err, service.ErrInvalidInterestMethod) ||
+			errors.Is(err, service.ErrEqualPrincipalTerm) ||
+			errors.Is(err, service.ErrInvalidStatementCycle) ||
+			errors.Is(err, service.ErrInvalidCreditLimit) ||
+			errors.Is(err, service.ErrInvalidInstallmentPlan) ||
+			errors.Is(err, service.ErrInvalidInstallmentCard) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "failed to update debt")
 		return
 	}
@@ -168,7 +190,7 @@ func (h *DebtHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 // MakePayment godoc
 // @Summary Make a debt payment
-// @Description Record a payment against a debt
+// @Description Record a payment against a debt, optionally also recording it as a Debt Payments expense with the interest split out
 // @Tags debts
 // @Accept json
 // @Produce json
@@ -204,9 +226,139 @@ func (h *DebtHandler) MakePayment(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, debt)
 }
 
+// ListPayments godoc
+// @Summary List debt payments
+// @Description Get the payment history of a debt with cumulative principal and interest paid to date
+// @Tags debts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Debt ID"
+// @Success 200 {object} model.DebtPaymentHistory
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /debts/{id}/payments [get]
+func (h *DebtHandler) ListPayments(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	history, err := h.service.ListPayments(r.Context(), id, userID)
+	if err != nil {
+		h.handlePaymentError(w, err, "failed to list payments")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, history)
+}
+
+// UpdatePayment godoc
+// @Summary Correct a debt payment
+// @Description Change the amount or date of a debt's latest payment and adjust the debt balance and the expenses recording it
+// @Tags debts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Debt ID"
+// @Param paymentId path string true "Payment ID"
+// @Param input body service.MakePaymentInput true "Corrected payment data"
+// @Success 200 {object} model.DebtPayment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "Not the latest payment"
+// @Failure 500 {object} ErrorResponse
+// @Router /debts/{id}/payments/{paymentId} [put]
+func (h *DebtHandler) UpdatePayment(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	paymentID, err := uuid.Parse(chi.URLParam(r, "paymentId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payment id")
+		return
+	}
+
+	var input service.MakePaymentInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	payment, err := h.service.UpdatePayment(r.Context(), id, paymentID, userID, input)
+	if err != nil {
+		h.handlePaymentError(w, err, "failed to update payment")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, payment)
+}
+
+// DeletePayment godoc
+// @Summary Reverse a debt payment
+// @Description Delete a payment, add its principal back to the debt balance and remove the expenses recording it
+// @Tags debts
+// @Security BearerAuth
+// @Param id path string true "Debt ID"
+// @Param paymentId path string true "Payment ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /debts/{id}/payments/{paymentId} [delete]
+func (h *DebtHandler) DeletePayment(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	paymentID, err := uuid.Parse(chi.URLParam(r, "paymentId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid payment id")
+		return
+	}
+
+	if err := h.service.DeletePayment(r.Context(), id, paymentID, userID); err != nil {
+		h.handlePaymentError(w, err, "failed to delete payment")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handlePaymentError maps payment history errors to HTTP responses.
+func (h *DebtHandler) handlePaymentError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrDebtNotFound):
+		respondError(w, http.StatusNotFound, "debt not found")
+	case errors.Is(err, repository.ErrDebtPaymentNotFound):
+		respondError(w, http.StatusNotFound, "payment not found")
+	case errors.Is(err, service.ErrInvalidAmount):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrDebtPaymentNotLatest):
+		respondError(w, http.StatusConflict, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
 // GetPayoffPlan godoc
 // @Summary Get debt payoff plan
-// @Description Calculate a payoff plan for a debt
+// @Description Calculate a payoff plan for a debt. Variable-rate debts are re-amortized at each rate reset.
 // @Tags debts
 // @Produce json
 // @Security BearerAuth
@@ -233,6 +385,10 @@ func (h *DebtHandler) GetPayoffPlan(w http.ResponseWriter, r *http.Request) {
 
 	plan, err := h.service.GetPayoffPlan(r.Context(), id, monthlyPayment)
 	if err != nil {
+		if errors.Is(err, service.ErrReferenceRateNotFound) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "failed to get payoff plan")
 		return
 	}
@@ -240,15 +396,277 @@ func (h *DebtHandler) GetPayoffPlan(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, plan)
 }
 
+// GetPayoffScenario godoc
+// @Summary Compare a payoff plan with prepayments
+// @Description Calculate a debt's payoff plan with one-off lump sums and recurring extra payments, either shortening the term or reducing the payment, side by side with the baseline plan
+// @Tags debts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Debt ID"
+// @Param input body service.PayoffScenarioInput true "Lump sums, extra payments and what prepayments do"
+// @Success 200 {object} model.PayoffScenario
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /debts/{id}/payoff-plan/scenario [post]
+func (h *DebtHandler) GetPayoffScenario(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var input service.PayoffScenarioInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	scenario, err := h.service.GetPayoffScenario(r.Context(), id, userID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDebtNotFound):
+			respondError(w, http.StatusNotFound, "debt not found")
+		case errors.Is(err, service.ErrInvalidPrepayment),
+			errors.Is(err, service.ErrInvalidPrepaymentMode),
+			errors.Is(err, service.ErrNoPrepayments),
+			errors.Is(err, service.ErrReferenceRateNotFound):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to get payoff scenario")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, scenario)
+}
+
+// PlanPayoffStrategy godoc
+// @Summary Plan a multi-debt payoff strategy
+// @Description Simulate paying off all outstanding debts from one monthly budget using the snowball, avalanche or a custom order, compared with paying only the minimums
+// @Tags debts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body service.DebtStrategyInput true "Strategy options"
+// @Success 200 {object} model.DebtStrategyPlan
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /debts/payoff-strategy [post]
+func (h *DebtHandler) PlanPayoffStrategy(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	var input service.DebtStrategyInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	plan, err := h.service.PlanPayoffStrategy(r.Context(), userID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidDebtStrategy),
+			errors.Is(err, service.ErrInvalidDebtOrder),
+			errors.Is(err, service.ErrNoDebtsToPlan),
+			errors.Is(err, service.ErrDebtCurrencyMismatch),
+			errors.Is(err, service.ErrDebtBudgetTooLow):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to plan payoff strategy")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, plan)
+}
+
+// CompareRefinance godoc
+// @Summary Compare refinancing a debt
+// @Description Compare staying on the current terms with moving the balance to each bank's current loan or mortgage rate, including switching fees, prepayment penalties and the break-even month
+// @Tags debts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Debt ID"
+// @Param input body service.RefinanceInput true "Refinance costs and term"
+// @Success 200 {object} model.RefinanceComparison
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /debts/{id}/refinance [post]
+func (h *DebtHandler) CompareRefinance(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var input service.RefinanceInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	comparison, err := h.service.CompareRefinance(r.Context(), id, userID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDebtNotFound):
+			respondError(w, http.StatusNotFound, "debt not found")
+		case errors.Is(err, service.ErrInvalidRefinanceCosts),
+			errors.Is(err, service.ErrNoRepaymentPlan),
+			errors.Is(err, service.ErrNothingToRefinance),
+			errors.Is(err, service.ErrNoRefinanceRates),
+			errors.Is(err, service.ErrReferenceRateNotFound):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to compare refinance offers")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, comparison)
+}
+
+// ListStatements godoc
+// @Summary List credit card statements
+// @Description List the card's closed statements, newest first. Statements are closed hourly once their cycle ends
+// @Tags debts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Debt ID"
+// @Success 200 {array} model.CreditCardStatement
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /debts/{id}/statements [get]
+func (h *DebtHandler) ListStatements(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	statements, err := h.service.ListStatements(r.Context(), id, userID)
+	if err != nil {
+		h.handleStatementError(w, err, "failed to list statements")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, statements)
+}
+
+// GetStatement godoc
+// @Summary Get a credit card statement
+// @Description Get a statement with the spending and payments of its cycle and what is left to pay by the due date
+// @Tags debts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Debt ID"
+// @Param statementId path string true "Statement ID"
+// @Success 200 {object} model.CreditCardStatementDetail
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /debts/{id}/statements/{statementId} [get]
+func (h *DebtHandler) GetStatement(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	statementID, err := uuid.Parse(chi.URLParam(r, "statementId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid statement id")
+		return
+	}
+
+	statement, err := h.service.GetStatement(r.Context(), id, statementID, userID)
+	if err != nil {
+		h.handleStatementError(w, err, "failed to get statement")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, statement)
+}
+
+// GetInstallmentSchedule godoc
+// @Summary Get an installment plan's schedule
+// @Description Get the instalments of a buy-now-pay-later or installment plan, with the payments made so far applied to the earliest ones
+// @Tags debts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Debt ID"
+// @Success 200 {object} model.InstallmentSchedule
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /debts/{id}/installments [get]
+func (h *DebtHandler) GetInstallmentSchedule(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	schedule, err := h.service.GetInstallmentSchedule(r.Context(), id, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrDebtNotFound):
+			respondError(w, http.StatusNotFound, "debt not found")
+		case errors.Is(err, service.ErrNotInstallmentPlan):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to get installment schedule")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, schedule)
+}
+
+// handleStatementError maps credit card statement errors to HTTP responses.
+func (h *DebtHandler) handleStatementError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrDebtNotFound):
+		respondError(w, http.StatusNotFound, "debt not found")
+	case errors.Is(err, repository.ErrStatementNotFound):
+		respondError(w, http.StatusNotFound, "statement not found")
+	case errors.Is(err, service.ErrNoStatementCycle):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
 // InterestCalculator godoc
 // @Summary Calculate loan interest
-// @Description Calculate interest and payment schedule for a loan
+// @Description Calculate interest and payment schedule for a loan, with its effective APR and flat-rate equivalent
 // @Tags debts
 // @Produce json
 // @Param principal query number true "Loan principal amount"
 // @Param interestRate query number true "Annual interest rate (percentage)"
 // @Param termMonths query int true "Loan term in months"
 // @Param paymentType query string false "Payment type (fixed or interest_only)" default(fixed)
+// @Param method query string false "Interest method (declining_balance, flat or equal_principal)" default(declining_balance)
+// @Param currency query string false "Currency code used for rounding"
 // @Success 200 {object} service.InterestCalculatorResult
 // @Failure 400 {object} ErrorResponse
 // @Router /debts/calculator [get]
@@ -275,9 +693,12 @@ func (h *DebtHandler) InterestCalculator(w http.ResponseWriter, r *http.Request)
 		input.PaymentType = "fixed"
 	}
 
+	input.Method = model.InterestMethod(r.URL.Query().Get("method"))
+	input.Currency = r.URL.Query().Get("currency")
+
 	result, err := h.service.CalculateInterest(input)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "invalid calculator input")
+		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
 	"github.com/wealthpath/backend/internal/service"
 )
 
@@ -68,6 +69,27 @@ func (m *MockDebtService) MakePayment(ctx context.Context, id, userID uuid.UUID,
 	return args.Get(0).(*model.Debt), args.Error(1)
 }
 
+func (m *MockDebtService) ListPayments(ctx context.Context, id, userID uuid.UUID) (*model.DebtPaymentHistory, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DebtPaymentHistory), args.Error(1)
+}
+
+func (m *MockDebtService) UpdatePayment(ctx context.Context, id, paymentID, userID uuid.UUID, input service.MakePaymentInput) (*model.DebtPayment, error) {
+	args := m.Called(ctx, id, paymentID, userID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DebtPayment), args.Error(1)
+}
+
+func (m *MockDebtService) DeletePayment(ctx context.Context, id, paymentID, userID uuid.UUID) error {
+	args := m.Called(ctx, id, paymentID, userID)
+	return args.Error(0)
+}
+
 func (m *MockDebtService) GetPayoffPlan(ctx context.Context, id uuid.UUID, monthlyPayment decimal.Decimal) (*model.PayoffPlan, error) {
 	args := m.Called(ctx, id, monthlyPayment)
 	if args.Get(0) == nil {
@@ -84,6 +106,54 @@ func (m *MockDebtService) CalculateInterest(input service.InterestCalculatorInpu
 	return args.Get(0).(*service.InterestCalculatorResult), args.Error(1)
 }
 
+func (m *MockDebtService) PlanPayoffStrategy(ctx context.Context, userID uuid.UUID, input service.DebtStrategyInput) (*model.DebtStrategyPlan, error) {
+	args := m.Called(ctx, userID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DebtStrategyPlan), args.Error(1)
+}
+
+func (m *MockDebtService) GetPayoffScenario(ctx context.Context, id, userID uuid.UUID, input service.PayoffScenarioInput) (*model.PayoffScenario, error) {
+	args := m.Called(ctx, id, userID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PayoffScenario), args.Error(1)
+}
+
+func (m *MockDebtService) CompareRefinance(ctx context.Context, id, userID uuid.UUID, input service.RefinanceInput) (*model.RefinanceComparison, error) {
+	args := m.Called(ctx, id, userID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RefinanceComparison), args.Error(1)
+}
+
+func (m *MockDebtService) ListStatements(ctx context.Context, id, userID uuid.UUID) ([]model.CreditCardStatement, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.CreditCardStatement), args.Error(1)
+}
+
+func (m *MockDebtService) GetStatement(ctx context.Context, id, statementID, userID uuid.UUID) (*model.CreditCardStatementDetail, error) {
+	args := m.Called(ctx, id, statementID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CreditCardStatementDetail), args.Error(1)
+}
+
+func (m *MockDebtService) GetInstallmentSchedule(ctx context.Context, id, userID uuid.UUID) (*model.InstallmentSchedule, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.InstallmentSchedule), args.Error(1)
+}
+
 func TestNewDebtHandler(t *testing.T) {
 	mockService := new(MockDebtService)
 	handler := NewDebtHandler(mockService)
@@ -122,6 +192,18 @@ func TestDebtHandler_Create(t *testing.T) {
 			setupMock:  func(m *MockDebtService, userID uuid.UUID) {},
 			wantStatus: http.StatusBadRequest,
 		},
+		{
+			name: "invalid rate schedule",
+			body: map[string]interface{}{
+				"name":            "Mortgage",
+				"type":            "mortgage",
+				"fixedRateMonths": 12,
+			},
+			setupMock: func(m *MockDebtService, userID uuid.UUID) {
+				m.On("Create", mock.Anything, userID, mock.AnythingOfType("service.CreateDebtInput")).Return(nil, service.ErrInvalidRateSchedule)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
 		{
 			name: "service error",
 			body: map[string]interface{}{
@@ -551,3 +633,451 @@ func TestDebtHandler_InterestCalculator(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	mockService.AssertExpectations(t)
 }
+
+func TestDebtHandler_InterestCalculator_Method(t *testing.T) {
+	t.Parallel()
+
+	mockService := new(MockDebtService)
+	handler := NewDebtHandler(mockService)
+
+	mockService.On("CalculateInterest", mock.MatchedBy(func(input service.InterestCalculatorInput) bool {
+		return input.Method == model.InterestMethodFlat && input.Currency == "VND"
+	})).Return(nil, service.ErrInvalidLoanTerm)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/debts/calculator?principal=5000&interestRate=12&method=flat&currency=VND", nil)
+	w := httptest.NewRecorder()
+
+	handler.InterestCalculator(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDebtHandler_PlanPayoffStrategy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       string
+		setupMock  func(*MockDebtService)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			body: `{"strategy":"snowball","monthlyBudget":"1500"}`,
+			setupMock: func(m *MockDebtService) {
+				m.On("PlanPayoffStrategy", mock.Anything, mock.Anything, mock.AnythingOfType("service.DebtStrategyInput")).Return(&model.DebtStrategyPlan{
+					Strategy:       model.DebtStrategySnowball,
+					MonthsToPayoff: 18,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid body",
+			body:       "not json",
+			setupMock:  func(m *MockDebtService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "budget too low",
+			body: `{"strategy":"avalanche","monthlyBudget":"10"}`,
+			setupMock: func(m *MockDebtService) {
+				m.On("PlanPayoffStrategy", mock.Anything, mock.Anything, mock.Anything).Return(nil, service.ErrDebtBudgetTooLow)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			body: `{}`,
+			setupMock: func(m *MockDebtService) {
+				m.On("PlanPayoffStrategy", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockDebtService)
+			handler := NewDebtHandler(mockService)
+			tt.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/debts/payoff-strategy", bytes.NewBufferString(tt.body))
+			req = req.WithContext(ctxWithUserID(uuid.New()))
+			w := httptest.NewRecorder()
+
+			handler.PlanPayoffStrategy(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDebtHandler_GetPayoffScenario(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success", id: uuid.New().String(), body: `{"lumpSums":[{"amount":"20000000","month":4}]}`, wantStatus: http.StatusOK},
+		{name: "invalid id", id: "invalid", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid body", id: uuid.New().String(), body: "not json", wantStatus: http.StatusBadRequest},
+		{name: "debt not found", id: uuid.New().String(), body: `{}`, err: repository.ErrDebtNotFound, wantStatus: http.StatusNotFound},
+		{name: "invalid prepayment", id: uuid.New().String(), body: `{}`, err: service.ErrInvalidPrepayment, wantStatus: http.StatusBadRequest},
+		{name: "service error", id: uuid.New().String(), body: `{}`, err: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockDebtService)
+			handler := NewDebtHandler(mockService)
+
+			called := tt.wantStatus != http.StatusBadRequest || tt.err != nil
+			if called {
+				var result *model.PayoffScenario
+				if tt.err == nil {
+					result = &model.PayoffScenario{AfterPrepayment: model.PrepaymentShortenTerm, MonthsSaved: 3}
+				}
+				mockService.On("GetPayoffScenario", mock.Anything, mock.Anything, mock.Anything, mock.AnythingOfType("service.PayoffScenarioInput")).Return(result, tt.err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/debts/"+tt.id+"/payoff-plan/scenario", bytes.NewBufferString(tt.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			req = req.WithContext(context.WithValue(ctxWithUserID(uuid.New()), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.GetPayoffScenario(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDebtHandler_CompareRefinance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success", id: uuid.New().String(), body: `{"switchingFee":"2000000"}`, wantStatus: http.StatusOK},
+		{name: "invalid id", id: "invalid", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid body", id: uuid.New().String(), body: "not json", wantStatus: http.StatusBadRequest},
+		{name: "debt not found", id: uuid.New().String(), body: `{}`, err: repository.ErrDebtNotFound, wantStatus: http.StatusNotFound},
+		{name: "no rates", id: uuid.New().String(), body: `{}`, err: service.ErrNoRefinanceRates, wantStatus: http.StatusBadRequest},
+		{name: "service error", id: uuid.New().String(), body: `{}`, err: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockDebtService)
+			handler := NewDebtHandler(mockService)
+
+			called := tt.wantStatus != http.StatusBadRequest || tt.err != nil
+			if called {
+				var result *model.RefinanceComparison
+				if tt.err == nil {
+					result = &model.RefinanceComparison{Offers: []model.RefinanceOffer{{BankCode: "VCB"}}}
+				}
+				mockService.On("CompareRefinance", mock.Anything, mock.Anything, mock.Anything, mock.AnythingOfType("service.RefinanceInput")).Return(result, tt.err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/debts/"+tt.id+"/refinance", bytes.NewBufferString(tt.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.id)
+			req = req.WithContext(context.WithValue(ctxWithUserID(uuid.New()), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.CompareRefinance(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDebtHandler_ListStatements(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "debt not found", err: repository.ErrDebtNotFound, wantStatus: http.StatusNotFound},
+		{name: "not a statement card", err: service.ErrNoStatementCycle, wantStatus: http.StatusBadRequest},
+		{name: "service error", err: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockDebtService)
+			handler := NewDebtHandler(mockService)
+			debtID := uuid.New()
+
+			var result []model.CreditCardStatement
+			if tt.err == nil {
+				result = []model.CreditCardStatement{{DebtID: debtID}}
+			}
+			mockService.On("ListStatements", mock.Anything, debtID, mock.Anything).Return(result, tt.err)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/debts/"+debtID.String()+"/statements", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", debtID.String())
+			req = req.WithContext(context.WithValue(ctxWithUserID(uuid.New()), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.ListStatements(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDebtHandler_GetInstallmentSchedule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "debt not found", err: repository.ErrDebtNotFound, wantStatus: http.StatusNotFound},
+		{name: "not an installment plan", err: service.ErrNotInstallmentPlan, wantStatus: http.StatusBadRequest},
+		{name: "service error", err: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockDebtService)
+			handler := NewDebtHandler(mockService)
+			debtID := uuid.New()
+
+			var result *model.InstallmentSchedule
+			if tt.err == nil {
+				result = &model.InstallmentSchedule{DebtID: debtID, InstallmentCount: 12}
+			}
+			mockService.On("GetInstallmentSchedule", mock.Anything, debtID, mock.Anything).Return(result, tt.err)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/debts/"+debtID.String()+"/installments", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", debtID.String())
+			req = req.WithContext(context.WithValue(ctxWithUserID(uuid.New()), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.GetInstallmentSchedule(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDebtHandler_GetStatement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		statementID string
+		err         error
+		wantStatus  int
+	}{
+		{name: "success", statementID: uuid.New().String(), wantStatus: http.StatusOK},
+		{name: "invalid statement id", statementID: "invalid", wantStatus: http.StatusBadRequest},
+		{name: "statement not found", statementID: uuid.New().String(), err: repository.ErrStatementNotFound, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockDebtService)
+			handler := NewDebtHandler(mockService)
+			debtID := uuid.New()
+
+			if tt.wantStatus != http.StatusBadRequest {
+				var result *model.CreditCardStatementDetail
+				if tt.err == nil {
+					result = &model.CreditCardStatementDetail{Currency: "VND"}
+				}
+				mockService.On("GetStatement", mock.Anything, debtID, mock.Anything, mock.Anything).Return(result, tt.err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/debts/"+debtID.String()+"/statements/"+tt.statementID, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", debtID.String())
+			rctx.URLParams.Add("statementId", tt.statementID)
+			req = req.WithContext(context.WithValue(ctxWithUserID(uuid.New()), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.GetStatement(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDebtHandler_ListPayments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "debt not found", err: repository.ErrDebtNotFound, wantStatus: http.StatusNotFound},
+		{name: "service error", err: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockDebtService)
+			handler := NewDebtHandler(mockService)
+			userID := uuid.New()
+			debtID := uuid.New()
+
+			if tt.err != nil {
+				mockService.On("ListPayments", mock.Anything, debtID, userID).Return(nil, tt.err)
+			} else {
+				mockService.On("ListPayments", mock.Anything, debtID, userID).Return(&model.DebtPaymentHistory{DebtID: debtID}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/debts/"+debtID.String()+"/payments", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", debtID.String())
+			req = req.WithContext(context.WithValue(ctxWithUserID(userID), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.ListPayments(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDebtHandler_UpdatePayment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "payment not found", err: repository.ErrDebtPaymentNotFound, wantStatus: http.StatusNotFound},
+		{name: "invalid amount", err: service.ErrInvalidAmount, wantStatus: http.StatusBadRequest},
+		{name: "not the latest payment", err: repository.ErrDebtPaymentNotLatest, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockDebtService)
+			handler := NewDebtHandler(mockService)
+			userID := uuid.New()
+			debtID := uuid.New()
+			paymentID := uuid.New()
+
+			var payment *model.DebtPayment
+			if tt.err == nil {
+				payment = &model.DebtPayment{ID: paymentID, DebtID: debtID}
+			}
+			mockService.On("UpdatePayment", mock.Anything, debtID, paymentID, userID, mock.AnythingOfType("service.MakePaymentInput")).Return(payment, tt.err)
+
+			body, _ := json.Marshal(map[string]interface{}{"amount": 500})
+			req := httptest.NewRequest(http.MethodPut, "/api/debts/"+debtID.String()+"/payments/"+paymentID.String(), bytes.NewReader(body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", debtID.String())
+			rctx.URLParams.Add("paymentId", paymentID.String())
+			req = req.WithContext(context.WithValue(ctxWithUserID(userID), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.UpdatePayment(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDebtHandler_DeletePayment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		paymentID  string
+		err        error
+		callsSvc   bool
+		wantStatus int
+	}{
+		{name: "success", paymentID: uuid.New().String(), callsSvc: true, wantStatus: http.StatusNoContent},
+		{name: "invalid payment id", paymentID: "abc", wantStatus: http.StatusBadRequest},
+		{name: "payment not found", paymentID: uuid.New().String(), err: repository.ErrDebtPaymentNotFound, callsSvc: true, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockDebtService)
+			handler := NewDebtHandler(mockService)
+			userID := uuid.New()
+			debtID := uuid.New()
+
+			if tt.callsSvc {
+				mockService.On("DeletePayment", mock.Anything, debtID, uuid.MustParse(tt.paymentID), userID).Return(tt.err)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/debts/"+debtID.String()+"/payments/"+tt.paymentID, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", debtID.String())
+			rctx.URLParams.Add("paymentId", tt.paymentID)
+			req = req.WithContext(context.WithValue(ctxWithUserID(userID), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.DeletePayment(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
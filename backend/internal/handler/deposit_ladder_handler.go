@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/wealthpath/backend/internal/repository"
+	"github.com/wealthpath/backend/internal/service"
+)
+
+type DepositLadderHandler struct {
+	service DepositLadderServiceInterface
+}
+
+func NewDepositLadderHandler(service DepositLadderServiceInterface) *DepositLadderHandler {
+	return &DepositLadderHandler{service: service}
+}
+
+// Plan godoc
+// @Summary Plan a term deposit ladder
+// @Description Split an amount across bank term deposits using current deposit rates, without saving the plan
+// @Tags deposit-ladders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body service.DepositLadderInput true "Ladder parameters"
+// @Success 200 {object} model.DepositLadder
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /deposit-ladders/plan [post]
+func (h *DepositLadderHandler) Plan(w http.ResponseWriter, r *http.Request) {
+	var input service.DepositLadderInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	ladder, err := h.service.Plan(r.Context(), input)
+	if err != nil {
+		h.handleError(w, err, "failed to plan deposit ladder")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ladder)
+}
+
+// Create godoc
+// @Summary Save a term deposit ladder
+// @Description Plan a deposit ladder and save it, optionally attached to a savings goal
+// @Tags deposit-ladders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body service.SaveDepositLadderInput true "Ladder parameters"
+// @Success 201 {object} model.DepositLadder
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /deposit-ladders [post]
+func (h *DepositLadderHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	var input service.SaveDepositLadderInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	ladder, err := h.service.Save(r.Context(), userID, input)
+	if err != nil {
+		h.handleError(w, err, "failed to save deposit ladder")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, ladder)
+}
+
+// List godoc
+// @Summary List term deposit ladders
+// @Description Get saved deposit ladders for the current user
+// @Tags deposit-ladders
+// @Produce json
+// @Security BearerAuth
+// @Param goalId query string false "Only ladders attached to this savings goal"
+// @Success 200 {array} model.DepositLadder
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /deposit-ladders [get]
+func (h *DepositLadderHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	var goalID *uuid.UUID
+	if goalStr := r.URL.Query().Get("goalId"); goalStr != "" {
+		id, err := uuid.Parse(goalStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid goalId")
+			return
+		}
+		goalID = &id
+	}
+
+	ladders, err := h.service.List(r.Context(), userID, goalID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list deposit ladders")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ladders)
+}
+
+// Get godoc
+// @Summary Get a term deposit ladder
+// @Description Get a saved deposit ladder with its maturity schedule
+// @Tags deposit-ladders
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Deposit Ladder ID"
+// @Success 200 {object} model.DepositLadder
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /deposit-ladders/{id} [get]
+func (h *DepositLadderHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	ladder, err := h.service.Get(r.Context(), id, userID)
+	if err != nil {
+		h.handleError(w, err, "failed to get deposit ladder")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ladder)
+}
+
+// AttachToGoal godoc
+// @Summary Attach a ladder to a savings goal
+// @Description Link a saved deposit ladder to a savings goal, or unlink it with a null goalId
+// @Tags deposit-ladders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Deposit Ladder ID"
+// @Param input body service.AttachLadderInput true "Savings goal to attach"
+// @Success 200 {object} model.DepositLadder
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /deposit-ladders/{id}/goal [put]
+func (h *DepositLadderHandler) AttachToGoal(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var input service.AttachLadderInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	ladder, err := h.service.AttachToGoal(r.Context(), id, userID, input)
+	if err != nil {
+		h.handleError(w, err, "failed to attach deposit ladder")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ladder)
+}
+
+// Delete godoc
+// @Summary Delete a term deposit ladder
+// @Description Delete a saved deposit ladder by ID
+// @Tags deposit-ladders
+// @Security BearerAuth
+// @Param id path string true "Deposit Ladder ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /deposit-ladders/{id} [delete]
+func (h *DepositLadderHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id, userID); err != nil {
+		h.handleError(w, err, "failed to delete deposit ladder")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleError maps deposit ladder service errors to HTTP responses.
+func (h *DepositLadderHandler) handleError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrDepositLadderNotFound):
+		respondError(w, http.StatusNotFound, "deposit ladder not found")
+	case errors.Is(err, repository.ErrSavingsGoalNotFound):
+		respondError(w, http.StatusNotFound, "savings goal not found")
+	case errors.Is(err, service.ErrDepositRateNotFound),
+		errors.Is(err, service.ErrInvalidAmount),
+		errors.Is(err, service.ErrInvalidLadderHorizon),
+		errors.Is(err, service.ErrInvalidLadderRungs),
+		errors.Is(err, service.ErrInvalidLiquidAmount),
+		errors.Is(err, service.ErrUnsupportedCurrency),
+		errors.Is(err, service.ErrLadderCurrencyMismatch):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+	"github.com/wealthpath/backend/internal/service"
+)
+
+// MockDepositLadderService implements DepositLadderServiceInterface for testing
+type MockDepositLadderService struct {
+	mock.Mock
+}
+
+func (m *MockDepositLadderService) Plan(ctx context.Context, input service.DepositLadderInput) (*model.DepositLadder, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DepositLadder), args.Error(1)
+}
+
+func (m *MockDepositLadderService) Save(ctx context.Context, userID uuid.UUID, input service.SaveDepositLadderInput) (*model.DepositLadder, error) {
+	args := m.Called(ctx, userID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DepositLadder), args.Error(1)
+}
+
+func (m *MockDepositLadderService) Get(ctx context.Context, id, userID uuid.UUID) (*model.DepositLadder, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DepositLadder), args.Error(1)
+}
+
+func (m *MockDepositLadderService) List(ctx context.Context, userID uuid.UUID, goalID *uuid.UUID) ([]model.DepositLadder, error) {
+	args := m.Called(ctx, userID, goalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.DepositLadder), args.Error(1)
+}
+
+func (m *MockDepositLadderService) AttachToGoal(ctx context.Context, id, userID uuid.UUID, input service.AttachLadderInput) (*model.DepositLadder, error) {
+	args := m.Called(ctx, id, userID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DepositLadder), args.Error(1)
+}
+
+func (m *MockDepositLadderService) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func TestDepositLadderHandler_Plan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       string
+		setupMock  func(*MockDepositLadderService)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			body: `{"amount":"100000000","horizonMonths":12}`,
+			setupMock: func(m *MockDepositLadderService) {
+				m.On("Plan", mock.Anything, mock.AnythingOfType("service.DepositLadderInput")).Return(&model.DepositLadder{Currency: "VND"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid body",
+			body:       "not json",
+			setupMock:  func(m *MockDepositLadderService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "no rates",
+			body: `{"amount":"1000","currency":"USD","horizonMonths":12}`,
+			setupMock: func(m *MockDepositLadderService) {
+				m.On("Plan", mock.Anything, mock.Anything).Return(nil, service.ErrDepositRateNotFound)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			body: `{"amount":"1000","horizonMonths":12}`,
+			setupMock: func(m *MockDepositLadderService) {
+				m.On("Plan", mock.Anything, mock.Anything).Return(nil, errors.New("error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockDepositLadderService)
+			handler := NewDepositLadderHandler(mockService)
+			tt.setupMock(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/deposit-ladders/plan", bytes.NewBufferString(tt.body))
+			req = req.WithContext(ctxWithUserID(uuid.New()))
+			w := httptest.NewRecorder()
+
+			handler.Plan(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDepositLadderHandler_List(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		query      string
+		setupMock  func(*MockDepositLadderService, uuid.UUID)
+		wantStatus int
+	}{
+		{
+			name: "all ladders",
+			setupMock: func(m *MockDepositLadderService, userID uuid.UUID) {
+				m.On("List", mock.Anything, userID, (*uuid.UUID)(nil)).Return([]model.DepositLadder{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "filtered by goal",
+			query: "?goalId=" + uuid.New().String(),
+			setupMock: func(m *MockDepositLadderService, userID uuid.UUID) {
+				m.On("List", mock.Anything, userID, mock.AnythingOfType("*uuid.UUID")).Return([]model.DepositLadder{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid goal id",
+			query:      "?goalId=abc",
+			setupMock:  func(m *MockDepositLadderService, userID uuid.UUID) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockDepositLadderService)
+			handler := NewDepositLadderHandler(mockService)
+			userID := uuid.New()
+			tt.setupMock(mockService, userID)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/deposit-ladders"+tt.query, nil)
+			req = req.WithContext(ctxWithUserID(userID))
+			w := httptest.NewRecorder()
+
+			handler.List(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDepositLadderHandler_AttachToGoal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "ladder not found", err: repository.ErrDepositLadderNotFound, wantStatus: http.StatusNotFound},
+		{name: "currency mismatch", err: service.ErrLadderCurrencyMismatch, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockDepositLadderService)
+			handler := NewDepositLadderHandler(mockService)
+			userID := uuid.New()
+			ladderID := uuid.New()
+			goalID := uuid.New()
+
+			if tt.err != nil {
+				mockService.On("AttachToGoal", mock.Anything, ladderID, userID, service.AttachLadderInput{GoalID: &goalID}).Return(nil, tt.err)
+			} else {
+				mockService.On("AttachToGoal", mock.Anything, ladderID, userID, service.AttachLadderInput{GoalID: &goalID}).Return(&model.DepositLadder{ID: ladderID, GoalID: &goalID}, nil)
+			}
+
+			body := `{"goalId":"` + goalID.String() + `"}`
+			req := httptest.NewRequest(http.MethodPut, "/api/deposit-ladders/"+ladderID.String()+"/goal", bytes.NewBufferString(body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", ladderID.String())
+			req = req.WithContext(context.WithValue(ctxWithUserID(userID), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.AttachToGoal(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
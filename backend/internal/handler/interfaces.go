@@ -2,11 +2,13 @@ package handler
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/wealthpath/backend/internal/model"
 	"github.com/wealthpath/backend/internal/service"
+	"github.com/wealthpath/backend/pkg/ical"
 )
 
 // BudgetServiceInterface for handler testing
@@ -27,18 +29,58 @@ type DebtServiceInterface interface {
 	Update(ctx context.Context, id, userID uuid.UUID, input service.UpdateDebtInput) (*model.Debt, error)
 	Delete(ctx context.Context, id, userID uuid.UUID) error
 	MakePayment(ctx context.Context, id, userID uuid.UUID, input service.MakePaymentInput) (*model.Debt, error)
+	ListPayments(ctx context.Context, id, userID uuid.UUID) (*model.DebtPaymentHistory, error)
+	UpdatePayment(ctx context.Context, id, paymentID, userID uuid.UUID, input service.MakePaymentInput) (*model.DebtPayment, error)
+	DeletePayment(ctx context.Context, id, paymentID, userID uuid.UUID) error
 	GetPayoffPlan(ctx context.Context, id uuid.UUID, monthlyPayment decimal.Decimal) (*model.PayoffPlan, error)
+	GetPayoffScenario(ctx context.Context, id, userID uuid.UUID, input service.PayoffScenarioInput) (*model.PayoffScenario, error)
 	CalculateInterest(input service.InterestCalculatorInput) (*service.InterestCalculatorResult, error)
+	PlanPayoffStrategy(ctx context.Context, userID uuid.UUID, input service.DebtStrategyInput) (*model.DebtStrategyPlan, error)
+	CompareRefinance(ctx context.Context, id, userID uuid.UUID, input service.RefinanceInput) (*model.RefinanceComparison, error)
+	ListStatements(ctx context.Context, id, userID uuid.UUID) ([]model.CreditCardStatement, error)
+	GetStatement(ctx context.Context, id, statementID, userID uuid.UUID) (*model.CreditCardStatementDetail, error)
+	GetInstallmentSchedule(ctx context.Context, id, userID uuid.UUID) (*model.InstallmentSchedule, error)
 }
 
 // SavingsGoalServiceInterface for handler testing
 type SavingsGoalServiceInterface interface {
 	Create(ctx context.Context, userID uuid.UUID, input service.CreateSavingsGoalInput) (*model.SavingsGoal, error)
-	Get(ctx context.Context, id uuid.UUID) (*model.SavingsGoal, error)
+	Get(ctx context.Context, id, userID uuid.UUID) (*model.SavingsGoal, error)
 	List(ctx context.Context, userID uuid.UUID) ([]model.SavingsGoal, error)
 	Update(ctx context.Context, id, userID uuid.UUID, input service.UpdateSavingsGoalInput) (*model.SavingsGoal, error)
 	Delete(ctx context.Context, id, userID uuid.UUID) error
 	Contribute(ctx context.Context, id, userID uuid.UUID, amount decimal.Decimal) (*model.SavingsGoal, error)
+	ListContributions(ctx context.Context, id, userID uuid.UUID) ([]model.SavingsContribution, error)
+	GetProjection(ctx context.Context, id, userID uuid.UUID, opts service.ProjectionOptions) (*model.SavingsProjection, error)
+}
+
+// SavingsGoalSharingServiceInterface for handler testing
+type SavingsGoalSharingServiceInterface interface {
+	InviteMember(ctx context.Context, goalID, userID uuid.UUID, input service.InviteMemberInput) (*model.SavingsGoalInvitation, error)
+	ListInvitations(ctx context.Context, userID uuid.UUID) ([]model.SavingsGoalInvitation, error)
+	AcceptInvitation(ctx context.Context, token string, userID uuid.UUID) (*model.SavingsGoal, error)
+	DeclineInvitation(ctx context.Context, token string, userID uuid.UUID) error
+	ListMembers(ctx context.Context, goalID, userID uuid.UUID) ([]model.SavingsGoalMember, error)
+	UpdateMemberPermission(ctx context.Context, goalID, userID, memberID uuid.UUID, input service.UpdateMemberInput) error
+	RemoveMember(ctx context.Context, goalID, userID, memberID uuid.UUID) error
+}
+
+// SavingsRuleServiceInterface for handler testing
+type SavingsRuleServiceInterface interface {
+	Create(ctx context.Context, userID, goalID uuid.UUID, input service.CreateSavingsRuleInput) (*model.SavingsRule, error)
+	List(ctx context.Context, userID, goalID uuid.UUID) ([]model.SavingsRule, error)
+	Update(ctx context.Context, userID, goalID, ruleID uuid.UUID, input service.UpdateSavingsRuleInput) (*model.SavingsRule, error)
+	Delete(ctx context.Context, userID, goalID, ruleID uuid.UUID) error
+}
+
+// DepositLadderServiceInterface for handler testing
+type DepositLadderServiceInterface interface {
+	Plan(ctx context.Context, input service.DepositLadderInput) (*model.DepositLadder, error)
+	Save(ctx context.Context, userID uuid.UUID, input service.SaveDepositLadderInput) (*model.DepositLadder, error)
+	Get(ctx context.Context, id, userID uuid.UUID) (*model.DepositLadder, error)
+	List(ctx context.Context, userID uuid.UUID, goalID *uuid.UUID) ([]model.DepositLadder, error)
+	AttachToGoal(ctx context.Context, id, userID uuid.UUID, input service.AttachLadderInput) (*model.DepositLadder, error)
+	Delete(ctx context.Context, id, userID uuid.UUID) error
 }
 
 // RecurringServiceInterface for handler testing
@@ -46,11 +88,39 @@ type RecurringServiceInterface interface {
 	Create(ctx context.Context, userID uuid.UUID, input service.CreateRecurringInput) (*model.RecurringTransaction, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]model.RecurringTransaction, error)
 	GetByID(ctx context.Context, userID, id uuid.UUID) (*model.RecurringTransaction, error)
+	ListTransactions(ctx context.Context, userID, id uuid.UUID) ([]model.Transaction, error)
+	ListExceptions(ctx context.Context, userID, id uuid.UUID) ([]model.RecurringException, error)
+	SetException(ctx context.Context, userID, id uuid.UUID, occurrence time.Time, input service.OccurrenceExceptionInput) (*model.RecurringException, error)
+	DeleteException(ctx context.Context, userID, id uuid.UUID, occurrence time.Time) error
 	Update(ctx context.Context, userID, id uuid.UUID, input service.UpdateRecurringInput) (*model.RecurringTransaction, error)
 	Delete(ctx context.Context, userID, id uuid.UUID) error
 	Pause(ctx context.Context, userID, id uuid.UUID) (*model.RecurringTransaction, error)
 	Resume(ctx context.Context, userID, id uuid.UUID) (*model.RecurringTransaction, error)
 	GetUpcoming(ctx context.Context, userID uuid.UUID, limit int) ([]model.UpcomingBill, error)
+	Suggestions(ctx context.Context, userID uuid.UUID) ([]service.RecurringSuggestion, error)
+	AcceptSuggestion(ctx context.Context, userID uuid.UUID, id string) (*model.RecurringTransaction, error)
+	ListDrafts(ctx context.Context, userID uuid.UUID) ([]model.RecurringDraft, error)
+	ConfirmDraft(ctx context.Context, userID, id uuid.UUID, input service.ConfirmDraftInput) (*model.Transaction, error)
+	DismissDraft(ctx context.Context, userID, id uuid.UUID) error
+}
+
+// CalendarServiceInterface for handler testing
+type CalendarServiceInterface interface {
+	GetFeed(ctx context.Context, userID uuid.UUID) (*model.CalendarFeed, error)
+	RotateFeed(ctx context.Context, userID uuid.UUID) (*model.CalendarFeed, error)
+	DisableFeed(ctx context.Context, userID uuid.UUID) error
+	Calendar(ctx context.Context, token string) (*ical.Calendar, error)
+}
+
+// ReportServiceInterface for handler testing
+type ReportServiceInterface interface {
+	Generate(ctx context.Context, userID uuid.UUID, input service.ReportInput) (*model.Report, error)
+}
+
+// NetWorthServiceInterface for handler testing
+type NetWorthServiceInterface interface {
+	GetBreakdown(ctx context.Context, userID uuid.UUID) (*model.NetWorthBreakdown, error)
+	GetHistory(ctx context.Context, userID uuid.UUID, start, end time.Time) (*model.NetWorthHistory, error)
 }
 
 // Note: TransactionServiceInterface and UserServiceInterface are defined in their respective test files
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wealthpath/backend/internal/service"
+)
+
+type NetWorthHandler struct {
+	netWorthService NetWorthServiceInterface
+}
+
+func NewNetWorthHandler(netWorthService NetWorthServiceInterface) *NetWorthHandler {
+	return &NetWorthHandler{netWorthService: netWorthService}
+}
+
+// GetNetWorth godoc
+// @Summary Get net worth breakdown
+// @Description Get the current net worth in each currency with the savings goals and term deposits (assets) and debts (liabilities) it is made of
+// @Tags net-worth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.NetWorthBreakdown
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /net-worth [get]
+func (h *NetWorthHandler) GetNetWorth(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+	if userID == uuid.Nil {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	breakdown, err := h.netWorthService.GetBreakdown(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get net worth")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, breakdown)
+}
+
+// GetHistory godoc
+// @Summary Get net worth history
+// @Description Get the daily net worth snapshots over a date range, one series per currency
+// @Tags net-worth
+// @Produce json
+// @Security BearerAuth
+// @Param start query string false "First day (YYYY-MM-DD), defaults to a year before end"
+// @Param end query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} model.NetWorthHistory
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /net-worth/history [get]
+func (h *NetWorthHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+	if userID == uuid.Nil {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var start, end time.Time
+	for _, param := range []struct {
+		name string
+		date *time.Time
+	}{{"start", &start}, {"end", &end}} {
+		if v := r.URL.Query().Get(param.name); v != "" {
+			d, err := time.Parse("2006-01-02", v)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "invalid "+param.name+" date")
+				return
+			}
+			*param.date = d
+		}
+	}
+
+	history, err := h.netWorthService.GetHistory(r.Context(), userID, start, end)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidNetWorthRange) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "failed to get net worth history")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, history)
+}
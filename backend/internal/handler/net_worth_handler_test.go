@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/service"
+)
+
+// MockNetWorthService implements NetWorthServiceInterface for testing
+type MockNetWorthService struct {
+	mock.Mock
+}
+
+func (m *MockNetWorthService) GetBreakdown(ctx context.Context, userID uuid.UUID) (*model.NetWorthBreakdown, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.NetWorthBreakdown), args.Error(1)
+}
+
+func (m *MockNetWorthService) GetHistory(ctx context.Context, userID uuid.UUID, start, end time.Time) (*model.NetWorthHistory, error) {
+	args := m.Called(ctx, userID, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.NetWorthHistory), args.Error(1)
+}
+
+func TestNetWorthHandler_GetNetWorth(t *testing.T) {
+	t.Parallel()
+
+	mockService := new(MockNetWorthService)
+	h := NewNetWorthHandler(mockService)
+	userID := uuid.New()
+	mockService.On("GetBreakdown", mock.Anything, userID).Return(&model.NetWorthBreakdown{
+		Totals: []model.NetWorthSnapshot{{Currency: "VND", Assets: decimal.NewFromInt(700), Liabilities: decimal.NewFromInt(200), NetWorth: decimal.NewFromInt(500)}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/net-worth", nil).WithContext(ctxWithUserID(userID))
+	w := httptest.NewRecorder()
+
+	h.GetNetWorth(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var breakdown model.NetWorthBreakdown
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &breakdown))
+	require.Len(t, breakdown.Totals, 1)
+	assert.Equal(t, "500", breakdown.Totals[0].NetWorth.String())
+
+	w = httptest.NewRecorder()
+	h.GetNetWorth(w, httptest.NewRequest(http.MethodGet, "/api/net-worth", nil).WithContext(ctxWithUserID(uuid.Nil)))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestNetWorthHandler_GetHistory(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		query      string
+		setupMock  func(m *MockNetWorthService, userID uuid.UUID)
+		wantStatus int
+	}{
+		{
+			name:  "range",
+			query: "?start=2026-01-01&end=2026-06-30",
+			setupMock: func(m *MockNetWorthService, userID uuid.UUID) {
+				m.On("GetHistory", mock.Anything, userID, start, end).Return(&model.NetWorthHistory{Start: start, End: end}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "defaults",
+			query: "",
+			setupMock: func(m *MockNetWorthService, userID uuid.UUID) {
+				m.On("GetHistory", mock.Anything, userID, time.Time{}, time.Time{}).Return(&model.NetWorthHistory{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid date",
+			query:      "?start=2026-1-1",
+			setupMock:  func(m *MockNetWorthService, userID uuid.UUID) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "start after end",
+			query: "?start=2026-06-30&end=2026-01-01",
+			setupMock: func(m *MockNetWorthService, userID uuid.UUID) {
+				m.On("GetHistory", mock.Anything, userID, end, start).Return(nil, service.ErrInvalidNetWorthRange)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "service error",
+			query: "",
+			setupMock: func(m *MockNetWorthService, userID uuid.UUID) {
+				m.On("GetHistory", mock.Anything, userID, mock.Anything, mock.Anything).Return(nil, errors.New("connection reset"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockNetWorthService)
+			h := NewNetWorthHandler(mockService)
+			userID := uuid.New()
+			tt.setupMock(mockService, userID)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/net-worth/history"+tt.query, nil).WithContext(ctxWithUserID(userID))
+			w := httptest.NewRecorder()
+
+			h.GetHistory(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -2,7 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -20,7 +23,7 @@ func NewRecurringHandler(recurringService RecurringServiceInterface) *RecurringH
 
 // Create godoc
 // @Summary Create a recurring transaction
-// @Description Create a new recurring transaction (income or expense)
+// @Description Create a new recurring transaction (income or expense) repeating at a frequency or by an RFC 5545 rrule such as FREQ=MONTHLY;BYMONTHDAY=15,-1
 // @Tags recurring
 // @Accept json
 // @Produce json
@@ -112,6 +115,164 @@ func (h *RecurringHandler) Get(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, rt)
 }
 
+// Transactions godoc
+// @Summary List a recurring transaction's transactions
+// @Description List the transactions generated by a recurring transaction, newest first
+// @Tags recurring
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Recurring Transaction ID"
+// @Success 200 {array} model.Transaction
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /recurring/{id}/transactions [get]
+func (h *RecurringHandler) Transactions(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+	if userID == uuid.Nil {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	transactions, err := h.recurringService.ListTransactions(r.Context(), userID, id)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "recurring transaction not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, transactions)
+}
+
+// Exceptions godoc
+// @Summary List a recurring transaction's exceptions
+// @Description List the occurrences of a recurring transaction that are skipped, moved or re-priced
+// @Tags recurring
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Recurring Transaction ID"
+// @Success 200 {array} model.RecurringException
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /recurring/{id}/exceptions [get]
+func (h *RecurringHandler) Exceptions(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+	if userID == uuid.Nil {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	exceptions, err := h.recurringService.ListExceptions(r.Context(), userID, id)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "recurring transaction not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, exceptions)
+}
+
+// SetException godoc
+// @Summary Skip, move or re-price an occurrence
+// @Description Change a single occurrence of a recurring transaction, such as skipping rent one month or a gym fee of 0, without changing the others
+// @Tags recurring
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Recurring Transaction ID"
+// @Param date path string true "Scheduled occurrence (YYYY-MM-DD)"
+// @Param input body service.OccurrenceExceptionInput true "Exception"
+// @Success 200 {object} model.RecurringException
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /recurring/{id}/exceptions/{date} [put]
+func (h *RecurringHandler) SetException(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+	if userID == uuid.Nil {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	occurrence, err := time.Parse("2006-01-02", chi.URLParam(r, "date"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid date")
+		return
+	}
+
+	var input service.OccurrenceExceptionInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	exception, err := h.recurringService.SetException(r.Context(), userID, id, occurrence, input)
+	if errors.Is(err, service.ErrRecurringNotFound) {
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, exception)
+}
+
+// DeleteException godoc
+// @Summary Restore an occurrence
+// @Description Remove the exception of an occurrence so it follows the schedule again
+// @Tags recurring
+// @Security BearerAuth
+// @Param id path string true "Recurring Transaction ID"
+// @Param date path string true "Scheduled occurrence (YYYY-MM-DD)"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /recurring/{id}/exceptions/{date} [delete]
+func (h *RecurringHandler) DeleteException(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+	if userID == uuid.Nil {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	occurrence, err := time.Parse("2006-01-02", chi.URLParam(r, "date"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid date")
+		return
+	}
+
+	if err := h.recurringService.DeleteException(r.Context(), userID, id, occurrence); err != nil {
+		respondError(w, http.StatusNotFound, "occurrence exception not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Update godoc
 // @Summary Update a recurring transaction
 // @Description Update an existing recurring transaction
@@ -276,3 +437,165 @@ func (h *RecurringHandler) Upcoming(w http.ResponseWriter, r *http.Request) {
 
 	respondJSON(w, http.StatusOK, items)
 }
+
+// Suggestions godoc
+// @Summary Suggest recurring transactions
+// @Description Find series of similar transactions at regular intervals, such as rent or subscriptions, that could be set up as recurring transactions
+// @Tags recurring
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} service.RecurringSuggestion
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /recurring/suggestions [get]
+func (h *RecurringHandler) Suggestions(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+	if userID == uuid.Nil {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	suggestions, err := h.recurringService.Suggestions(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get suggestions")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, suggestions)
+}
+
+// AcceptSuggestion godoc
+// @Summary Accept a recurring suggestion
+// @Description Create the recurring transaction a suggestion proposes
+// @Tags recurring
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Suggestion ID"
+// @Success 201 {object} model.RecurringTransaction
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /recurring/suggestions/{id}/accept [post]
+func (h *RecurringHandler) AcceptSuggestion(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+	if userID == uuid.Nil {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	rt, err := h.recurringService.AcceptSuggestion(r.Context(), userID, chi.URLParam(r, "id"))
+	if errors.Is(err, service.ErrSuggestionNotFound) {
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, rt)
+}
+
+// Drafts godoc
+// @Summary List recurring drafts
+// @Description List the occurrences of variable-amount recurring transactions, such as utility bills, awaiting confirmation of their actual amount
+// @Tags recurring
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.RecurringDraft
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /recurring/drafts [get]
+func (h *RecurringHandler) Drafts(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+	if userID == uuid.Nil {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	drafts, err := h.recurringService.ListDrafts(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get recurring drafts")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, drafts)
+}
+
+// ConfirmDraft godoc
+// @Summary Confirm a recurring draft
+// @Description Turn a draft into a transaction, optionally adjusting its amount or date; an empty body confirms it as drafted
+// @Tags recurring
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Draft ID"
+// @Param input body service.ConfirmDraftInput false "Actual amount and date"
+// @Success 201 {object} model.Transaction
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /recurring/drafts/{id}/confirm [post]
+func (h *RecurringHandler) ConfirmDraft(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+	if userID == uuid.Nil {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var input service.ConfirmDraftInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	tx, err := h.recurringService.ConfirmDraft(r.Context(), userID, id, input)
+	if errors.Is(err, service.ErrDraftNotFound) {
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, tx)
+}
+
+// DismissDraft godoc
+// @Summary Dismiss a recurring draft
+// @Description Discard a draft for an occurrence that did not happen
+// @Tags recurring
+// @Security BearerAuth
+// @Param id path string true "Draft ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /recurring/drafts/{id} [delete]
+func (h *RecurringHandler) DismissDraft(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+	if userID == uuid.Nil {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	if err := h.recurringService.DismissDraft(r.Context(), userID, id); err != nil {
+		respondError(w, http.StatusNotFound, "recurring draft not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
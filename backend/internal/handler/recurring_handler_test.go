@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -47,6 +48,14 @@ func (m *MockRecurringService) GetByID(ctx context.Context, userID, id uuid.UUID
 	return args.Get(0).(*model.RecurringTransaction), args.Error(1)
 }
 
+func (m *MockRecurringService) ListTransactions(ctx context.Context, userID, id uuid.UUID) ([]model.Transaction, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Transaction), args.Error(1)
+}
+
 func (m *MockRecurringService) Update(ctx context.Context, userID, id uuid.UUID, input service.UpdateRecurringInput) (*model.RecurringTransaction, error) {
 	args := m.Called(ctx, userID, id, input)
 	if args.Get(0) == nil {
@@ -84,6 +93,64 @@ func (m *MockRecurringService) GetUpcoming(ctx context.Context, userID uuid.UUID
 	return args.Get(0).([]model.UpcomingBill), args.Error(1)
 }
 
+func (m *MockRecurringService) Suggestions(ctx context.Context, userID uuid.UUID) ([]service.RecurringSuggestion, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.RecurringSuggestion), args.Error(1)
+}
+
+func (m *MockRecurringService) AcceptSuggestion(ctx context.Context, userID uuid.UUID, id string) (*model.RecurringTransaction, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RecurringTransaction), args.Error(1)
+}
+
+func (m *MockRecurringService) ListDrafts(ctx context.Context, userID uuid.UUID) ([]model.RecurringDraft, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.RecurringDraft), args.Error(1)
+}
+
+func (m *MockRecurringService) ConfirmDraft(ctx context.Context, userID, id uuid.UUID, input service.ConfirmDraftInput) (*model.Transaction, error) {
+	args := m.Called(ctx, userID, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Transaction), args.Error(1)
+}
+
+func (m *MockRecurringService) DismissDraft(ctx context.Context, userID, id uuid.UUID) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockRecurringService) ListExceptions(ctx context.Context, userID, id uuid.UUID) ([]model.RecurringException, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.RecurringException), args.Error(1)
+}
+
+func (m *MockRecurringService) SetException(ctx context.Context, userID, id uuid.UUID, occurrence time.Time, input service.OccurrenceExceptionInput) (*model.RecurringException, error) {
+	args := m.Called(ctx, userID, id, occurrence, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RecurringException), args.Error(1)
+}
+
+func (m *MockRecurringService) DeleteException(ctx context.Context, userID, id uuid.UUID, occurrence time.Time) error {
+	args := m.Called(ctx, userID, id, occurrence)
+	return args.Error(0)
+}
+
 func TestNewRecurringHandler(t *testing.T) {
 	mockService := new(MockRecurringService)
 	handler := NewRecurringHandler(mockService)
@@ -301,6 +368,77 @@ func TestRecurringHandler_Get(t *testing.T) {
 	}
 }
 
+func TestRecurringHandler_Transactions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		userID     uuid.UUID
+		rtID       string
+		setupMock  func(*MockRecurringService, uuid.UUID, uuid.UUID)
+		wantStatus int
+	}{
+		{
+			name:   "success",
+			userID: uuid.New(),
+			rtID:   uuid.New().String(),
+			setupMock: func(m *MockRecurringService, userID, rtID uuid.UUID) {
+				m.On("ListTransactions", mock.Anything, userID, rtID).Return([]model.Transaction{
+					{ID: uuid.New(), UserID: userID, RecurringID: &rtID},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unauthorized",
+			userID:     uuid.Nil,
+			rtID:       uuid.New().String(),
+			setupMock:  func(m *MockRecurringService, userID, rtID uuid.UUID) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "invalid uuid",
+			userID:     uuid.New(),
+			rtID:       "invalid",
+			setupMock:  func(m *MockRecurringService, userID, rtID uuid.UUID) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "not found",
+			userID: uuid.New(),
+			rtID:   uuid.New().String(),
+			setupMock: func(m *MockRecurringService, userID, rtID uuid.UUID) {
+				m.On("ListTransactions", mock.Anything, userID, rtID).Return(nil, errors.New("not found"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockRecurringService)
+			handler := NewRecurringHandler(mockService)
+			rtID, _ := uuid.Parse(tt.rtID)
+
+			tt.setupMock(mockService, tt.userID, rtID)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/recurring/"+tt.rtID+"/transactions", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.rtID)
+			req = req.WithContext(context.WithValue(ctxWithUserID(tt.userID), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Transactions(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 func TestRecurringHandler_Update(t *testing.T) {
 	t.Parallel()
 
@@ -639,3 +777,280 @@ func TestRecurringHandler_Upcoming(t *testing.T) {
 		})
 	}
 }
+
+func TestRecurringHandler_Suggestions(t *testing.T) {
+	t.Parallel()
+
+	mockService := new(MockRecurringService)
+	handler := NewRecurringHandler(mockService)
+	userID := uuid.New()
+	mockService.On("Suggestions", mock.Anything, userID).Return([]service.RecurringSuggestion{
+		{ID: "a1b2", Frequency: "monthly", Confidence: 0.9, Input: service.CreateRecurringInput{Description: "Netflix"}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/recurring/suggestions", nil)
+	req = req.WithContext(ctxWithUserID(userID))
+	w := httptest.NewRecorder()
+
+	handler.Suggestions(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var suggestions []service.RecurringSuggestion
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &suggestions))
+	assert.Len(t, suggestions, 1)
+	mockService.AssertExpectations(t)
+}
+
+func TestRecurringHandler_AcceptSuggestion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		setupMock  func(*MockRecurringService, uuid.UUID)
+		wantStatus int
+	}{
+		{
+			name: "success",
+			setupMock: func(m *MockRecurringService, userID uuid.UUID) {
+				m.On("AcceptSuggestion", mock.Anything, userID, "a1b2").Return(&model.RecurringTransaction{ID: uuid.New(), UserID: userID}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "no longer suggested",
+			setupMock: func(m *MockRecurringService, userID uuid.UUID) {
+				m.On("AcceptSuggestion", mock.Anything, userID, "a1b2").Return(nil, service.ErrSuggestionNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockRecurringService)
+			handler := NewRecurringHandler(mockService)
+			userID := uuid.New()
+			tt.setupMock(mockService, userID)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/recurring/suggestions/a1b2/accept", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "a1b2")
+			req = req.WithContext(context.WithValue(ctxWithUserID(userID), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.AcceptSuggestion(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRecurringHandler_Drafts(t *testing.T) {
+	mockService := new(MockRecurringService)
+	handler := NewRecurringHandler(mockService)
+	userID := uuid.New()
+	mockService.On("ListDrafts", mock.Anything, userID).Return([]model.RecurringDraft{
+		{ID: uuid.New(), UserID: userID, Description: "Electricity", Amount: decimal.NewFromInt(850000), Status: model.DraftPending},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/recurring/drafts", nil)
+	req = req.WithContext(ctxWithUserID(userID))
+	w := httptest.NewRecorder()
+
+	handler.Drafts(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var drafts []model.RecurringDraft
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &drafts))
+	assert.Len(t, drafts, 1)
+	mockService.AssertExpectations(t)
+}
+
+func TestRecurringHandler_ConfirmDraft(t *testing.T) {
+	t.Parallel()
+
+	actual := decimal.NewFromInt(912000)
+	tests := []struct {
+		name       string
+		body       string
+		setupMock  func(*MockRecurringService, uuid.UUID, uuid.UUID)
+		wantStatus int
+	}{
+		{
+			name: "confirm as drafted",
+			setupMock: func(m *MockRecurringService, userID, id uuid.UUID) {
+				m.On("ConfirmDraft", mock.Anything, userID, id, service.ConfirmDraftInput{}).Return(&model.Transaction{ID: uuid.New()}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "adjust amount",
+			body: `{"amount": "912000"}`,
+			setupMock: func(m *MockRecurringService, userID, id uuid.UUID) {
+				m.On("ConfirmDraft", mock.Anything, userID, id, mock.MatchedBy(func(input service.ConfirmDraftInput) bool {
+					return input.Amount != nil && input.Amount.Equal(actual)
+				})).Return(&model.Transaction{ID: uuid.New(), Amount: actual}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "invalid amount",
+			body: `{"amount": "0"}`,
+			setupMock: func(m *MockRecurringService, userID, id uuid.UUID) {
+				m.On("ConfirmDraft", mock.Anything, userID, id, mock.Anything).Return(nil, service.ErrInvalidAmount)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "already confirmed",
+			setupMock: func(m *MockRecurringService, userID, id uuid.UUID) {
+				m.On("ConfirmDraft", mock.Anything, userID, id, mock.Anything).Return(nil, service.ErrDraftNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid body",
+			body:       `{`,
+			setupMock:  func(m *MockRecurringService, userID, id uuid.UUID) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockRecurringService)
+			handler := NewRecurringHandler(mockService)
+			userID, id := uuid.New(), uuid.New()
+			tt.setupMock(mockService, userID, id)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/recurring/drafts/"+id.String()+"/confirm", bytes.NewBufferString(tt.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", id.String())
+			req = req.WithContext(context.WithValue(ctxWithUserID(userID), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.ConfirmDraft(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRecurringHandler_DismissDraft(t *testing.T) {
+	mockService := new(MockRecurringService)
+	handler := NewRecurringHandler(mockService)
+	userID, id := uuid.New(), uuid.New()
+	mockService.On("DismissDraft", mock.Anything, userID, id).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/recurring/drafts/"+id.String(), nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id.String())
+	req = req.WithContext(context.WithValue(ctxWithUserID(userID), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.DismissDraft(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestRecurringHandler_SetException(t *testing.T) {
+	t.Parallel()
+
+	occurrence := time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		date       string
+		body       string
+		setupMock  func(*MockRecurringService, uuid.UUID, uuid.UUID)
+		wantStatus int
+	}{
+		{
+			name: "skip",
+			date: "2026-11-15",
+			body: `{"skip": true}`,
+			setupMock: func(m *MockRecurringService, userID, id uuid.UUID) {
+				m.On("SetException", mock.Anything, userID, id, occurrence, service.OccurrenceExceptionInput{Skip: true}).
+					Return(&model.RecurringException{RecurringID: id, OccurrenceDate: occurrence, Skip: true}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "not an occurrence",
+			date: "2026-11-16",
+			body: `{"amount": "0"}`,
+			setupMock: func(m *MockRecurringService, userID, id uuid.UUID) {
+				m.On("SetException", mock.Anything, userID, id, mock.Anything, mock.Anything).Return(nil, service.ErrNotAnOccurrence)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "not found",
+			date: "2026-11-15",
+			body: `{"skip": true}`,
+			setupMock: func(m *MockRecurringService, userID, id uuid.UUID) {
+				m.On("SetException", mock.Anything, userID, id, mock.Anything, mock.Anything).Return(nil, service.ErrRecurringNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid date",
+			date:       "15-11-2026",
+			body:       `{"skip": true}`,
+			setupMock:  func(m *MockRecurringService, userID, id uuid.UUID) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockRecurringService)
+			handler := NewRecurringHandler(mockService)
+			userID, id := uuid.New(), uuid.New()
+			tt.setupMock(mockService, userID, id)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/recurring/"+id.String()+"/exceptions/"+tt.date, bytes.NewBufferString(tt.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", id.String())
+			rctx.URLParams.Add("date", tt.date)
+			req = req.WithContext(context.WithValue(ctxWithUserID(userID), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.SetException(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRecurringHandler_DeleteException(t *testing.T) {
+	mockService := new(MockRecurringService)
+	handler := NewRecurringHandler(mockService)
+	userID, id := uuid.New(), uuid.New()
+	occurrence := time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC)
+	mockService.On("DeleteException", mock.Anything, userID, id, occurrence).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/recurring/"+id.String()+"/exceptions/2026-11-15", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id.String())
+	rctx.URLParams.Add("date", "2026-11-15")
+	req = req.WithContext(context.WithValue(ctxWithUserID(userID), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.DeleteException(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/service"
+)
+
+type ReportHandler struct {
+	reportService ReportServiceInterface
+}
+
+func NewReportHandler(reportService ReportServiceInterface) *ReportHandler {
+	return &ReportHandler{reportService: reportService}
+}
+
+// GetReport godoc
+// @Summary Get a transaction report
+// @Description Aggregate income or expenses over a date range by period and category, card or currency, as chartable time series with optional year-over-year or previous-period comparison. Amounts in different currencies are kept in separate series.
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Param start query string false "First day (YYYY-MM-DD), defaults to the start of the month a year ago"
+// @Param end query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Param type query string false "income or expense, defaults to expense"
+// @Param groupBy query string false "Comma-separated: one of day, week, month, quarter, year and one of category, account, currency"
+// @Param metrics query string false "Comma-separated: sum, count, average; defaults to sum"
+// @Param compare query string false "yoy or pop"
+// @Param currency query string false "Only transactions in this currency"
+// @Success 200 {object} model.Report
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reports [get]
+func (h *ReportHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+	if userID == uuid.Nil {
+		respondError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	q := r.URL.Query()
+	input := service.ReportInput{
+		Type:     model.TransactionType(q.Get("type")),
+		Compare:  model.ReportComparison(q.Get("compare")),
+		Currency: q.Get("currency"),
+	}
+	for _, param := range []struct {
+		name string
+		date *time.Time
+	}{{"start", &input.Start}, {"end", &input.End}} {
+		if v := q.Get(param.name); v != "" {
+			d, err := time.Parse("2006-01-02", v)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "invalid "+param.name+" date")
+				return
+			}
+			*param.date = d
+		}
+	}
+	for _, g := range splitList(q.Get("groupBy")) {
+		input.GroupBy = append(input.GroupBy, model.ReportGroupBy(g))
+	}
+	for _, m := range splitList(q.Get("metrics")) {
+		input.Metrics = append(input.Metrics, model.ReportMetric(m))
+	}
+
+	report, err := h.reportService.Generate(r.Context(), userID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidType), errors.Is(err, service.ErrInvalidReportRange),
+			errors.Is(err, service.ErrInvalidGroupBy), errors.Is(err, service.ErrConflictingGroupBy),
+			errors.Is(err, service.ErrTagsUnsupported), errors.Is(err, service.ErrInvalidMetric),
+			errors.Is(err, service.ErrInvalidComparison), errors.Is(err, service.ErrTooManyReportPoints):
+			respondError(w, http.StatusBadRequest, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to generate report")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
+// splitList splits a comma-separated query parameter, dropping empty items.
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
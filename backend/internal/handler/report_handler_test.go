@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/service"
+)
+
+// MockReportService implements ReportServiceInterface for testing
+type MockReportService struct {
+	mock.Mock
+}
+
+func (m *MockReportService) Generate(ctx context.Context, userID uuid.UUID, input service.ReportInput) (*model.Report, error) {
+	args := m.Called(ctx, userID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Report), args.Error(1)
+}
+
+func TestReportHandler_GetReport(t *testing.T) {
+	t.Parallel()
+
+	mockService := new(MockReportService)
+	h := NewReportHandler(mockService)
+	userID := uuid.New()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	expected := service.ReportInput{
+		Type:     model.TransactionTypeExpense,
+		Start:    start,
+		End:      end,
+		GroupBy:  []model.ReportGroupBy{model.ReportByMonth, model.ReportByCategory},
+		Metrics:  []model.ReportMetric{model.ReportSum, model.ReportAverage},
+		Compare:  model.CompareYearOverYear,
+		Currency: "VND",
+	}
+	mockService.On("Generate", mock.Anything, userID, expected).Return(&model.Report{
+		Type:   model.TransactionTypeExpense,
+		Start:  start,
+		End:    end,
+		Series: []model.ReportSeries{{Key: "Food & Dining", Currency: "VND"}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/reports?start=2026-01-01&end=2026-06-30&type=expense&groupBy=month,%20category&metrics=sum,average,&compare=yoy&currency=VND", nil)
+	req = req.WithContext(ctxWithUserID(userID))
+	w := httptest.NewRecorder()
+
+	h.GetReport(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var report model.Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	require.Len(t, report.Series, 1)
+	assert.Equal(t, "Food & Dining", report.Series[0].Key)
+	mockService.AssertExpectations(t)
+}
+
+func TestReportHandler_GetReport_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		query      string
+		serviceErr error
+		wantStatus int
+		wantError  string
+	}{
+		{"invalid start", "?start=01/01/2026", nil, http.StatusBadRequest, "invalid start date"},
+		{"invalid end", "?end=2026-13-01", nil, http.StatusBadRequest, "invalid end date"},
+		{"tags", "?groupBy=tag", service.ErrTagsUnsupported, http.StatusBadRequest, service.ErrTagsUnsupported.Error()},
+		{"conflicting", "?groupBy=month,week", service.ErrConflictingGroupBy, http.StatusBadRequest, service.ErrConflictingGroupBy.Error()},
+		{"range", "?start=2026-02-01&end=2026-01-01", service.ErrInvalidReportRange, http.StatusBadRequest, service.ErrInvalidReportRange.Error()},
+		{"database", "", errors.New("connection reset"), http.StatusInternalServerError, "failed to generate report"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockReportService)
+			h := NewReportHandler(mockService)
+			userID := uuid.New()
+			if tt.serviceErr != nil {
+				mockService.On("Generate", mock.Anything, userID, mock.Anything).Return(nil, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/reports"+tt.query, nil).WithContext(ctxWithUserID(userID))
+			w := httptest.NewRecorder()
+
+			h.GetReport(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			var resp ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantError, resp.Error)
+		})
+	}
+}
+
+func TestReportHandler_GetReport_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	h := NewReportHandler(new(MockReportService))
+	w := httptest.NewRecorder()
+
+	h.GetReport(w, httptest.NewRequest(http.MethodGet, "/api/reports", nil).WithContext(ctxWithUserID(uuid.Nil)))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -2,11 +2,14 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	_ "github.com/wealthpath/backend/internal/model" // swagger types
+	"github.com/wealthpath/backend/internal/repository"
 	"github.com/wealthpath/backend/internal/service"
 )
 
@@ -62,13 +65,15 @@ func (h *SavingsGoalHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {object} ErrorResponse
 // @Router /savings-goals/{id} [get]
 func (h *SavingsGoalHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		respondError(w, http.StatusBadRequest, "invalid id")
 		return
 	}
 
-	goal, err := h.service.Get(r.Context(), id)
+	goal, err := h.service.Get(r.Context(), id, userID)
 	if err != nil {
 		respondError(w, http.StatusNotFound, "savings goal not found")
 		return
@@ -79,7 +84,7 @@ func (h *SavingsGoalHandler) Get(w http.ResponseWriter, r *http.Request) {
 
 // List godoc
 // @Summary List savings goals
-// @Description Get all savings goals for the current user
+// @Description Get all savings goals owned by or shared with the current user
 // @Tags savings-goals
 // @Produce json
 // @Security BearerAuth
@@ -111,6 +116,8 @@ func (h *SavingsGoalHandler) List(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} model.SavingsGoal
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /savings-goals/{id} [put]
 func (h *SavingsGoalHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -130,7 +137,14 @@ func (h *SavingsGoalHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	goal, err := h.service.Update(r.Context(), id, userID, input)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "failed to update savings goal")
+		switch {
+		case errors.Is(err, repository.ErrSavingsGoalNotFound):
+			respondError(w, http.StatusNotFound, "savings goal not found")
+		case errors.Is(err, service.ErrGoalPermissionDenied):
+			respondError(w, http.StatusForbidden, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to update savings goal")
+		}
 		return
 	}
 
@@ -177,6 +191,8 @@ func (h *SavingsGoalHandler) Delete(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} model.SavingsGoal
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /savings-goals/{id}/contribute [post]
 func (h *SavingsGoalHandler) Contribute(w http.ResponseWriter, r *http.Request) {
@@ -196,9 +212,101 @@ func (h *SavingsGoalHandler) Contribute(w http.ResponseWriter, r *http.Request)
 
 	goal, err := h.service.Contribute(r.Context(), id, userID, input.Amount)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "failed to contribute")
+		switch {
+		case errors.Is(err, repository.ErrSavingsGoalNotFound):
+			respondError(w, http.StatusNotFound, "savings goal not found")
+		case errors.Is(err, service.ErrGoalPermissionDenied):
+			respondError(w, http.StatusForbidden, err.Error())
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to contribute")
+		}
 		return
 	}
 
 	respondJSON(w, http.StatusOK, goal)
 }
+
+// ListContributions godoc
+// @Summary List savings goal contributions
+// @Description Get the contribution history of a savings goal, including each member's contributions
+// @Tags savings-goals
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Savings Goal ID"
+// @Success 200 {array} model.SavingsContribution
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /savings-goals/{id}/contributions [get]
+func (h *SavingsGoalHandler) ListContributions(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	contributions, err := h.service.ListContributions(r.Context(), id, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrSavingsGoalNotFound) {
+			respondError(w, http.StatusNotFound, "savings goal not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "failed to list contributions")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, contributions)
+}
+
+// GetProjection godoc
+// @Summary Get savings goal projection
+// @Description Estimate required monthly contribution, projected completion date and on-track status for a savings goal
+// @Tags savings-goals
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Savings Goal ID"
+// @Param bank query string false "Bank code of the deposit rate to apply (best rate for the term if omitted)"
+// @Param term query int false "Deposit term in months used to look up the rate"
+// @Success 200 {object} model.SavingsProjection
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /savings-goals/{id}/projection [get]
+func (h *SavingsGoalHandler) GetProjection(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	opts := service.ProjectionOptions{BankCode: r.URL.Query().Get("bank")}
+	if termStr := r.URL.Query().Get("term"); termStr != "" {
+		term, err := strconv.Atoi(termStr)
+		if err != nil || term < 0 {
+			respondError(w, http.StatusBadRequest, "invalid term")
+			return
+		}
+		opts.TermMonths = term
+	}
+
+	projection, err := h.service.GetProjection(r.Context(), id, userID, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrSavingsGoalNotFound):
+			respondError(w, http.StatusNotFound, "savings goal not found")
+		case errors.Is(err, service.ErrDepositRateNotFound):
+			respondError(w, http.StatusBadRequest, "deposit rate not found")
+		default:
+			respondError(w, http.StatusInternalServerError, "failed to get projection")
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, projection)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
 	"github.com/wealthpath/backend/internal/service"
 )
 
@@ -31,8 +32,8 @@ func (m *MockSavingsGoalService) Create(ctx context.Context, userID uuid.UUID, i
 	return args.Get(0).(*model.SavingsGoal), args.Error(1)
 }
 
-func (m *MockSavingsGoalService) Get(ctx context.Context, id uuid.UUID) (*model.SavingsGoal, error) {
-	args := m.Called(ctx, id)
+func (m *MockSavingsGoalService) Get(ctx context.Context, id, userID uuid.UUID) (*model.SavingsGoal, error) {
+	args := m.Called(ctx, id, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -68,6 +69,22 @@ func (m *MockSavingsGoalService) Contribute(ctx context.Context, id, userID uuid
 	return args.Get(0).(*model.SavingsGoal), args.Error(1)
 }
 
+func (m *MockSavingsGoalService) ListContributions(ctx context.Context, id, userID uuid.UUID) ([]model.SavingsContribution, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SavingsContribution), args.Error(1)
+}
+
+func (m *MockSavingsGoalService) GetProjection(ctx context.Context, id, userID uuid.UUID, opts service.ProjectionOptions) (*model.SavingsProjection, error) {
+	args := m.Called(ctx, id, userID, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SavingsProjection), args.Error(1)
+}
+
 func TestNewSavingsGoalHandler(t *testing.T) {
 	mockService := new(MockSavingsGoalService)
 	handler := NewSavingsGoalHandler(mockService)
@@ -153,7 +170,7 @@ func TestSavingsGoalHandler_Get(t *testing.T) {
 			name:   "success",
 			goalID: uuid.New().String(),
 			setupMock: func(m *MockSavingsGoalService, id uuid.UUID) {
-				m.On("Get", mock.Anything, id).Return(&model.SavingsGoal{ID: id}, nil)
+				m.On("Get", mock.Anything, id, mock.Anything).Return(&model.SavingsGoal{ID: id}, nil)
 			},
 			wantStatus: http.StatusOK,
 		},
@@ -167,7 +184,7 @@ func TestSavingsGoalHandler_Get(t *testing.T) {
 			name:   "not found",
 			goalID: uuid.New().String(),
 			setupMock: func(m *MockSavingsGoalService, id uuid.UUID) {
-				m.On("Get", mock.Anything, id).Return(nil, errors.New("not found"))
+				m.On("Get", mock.Anything, id, mock.Anything).Return(nil, errors.New("not found"))
 			},
 			wantStatus: http.StatusNotFound,
 		},
@@ -447,3 +464,92 @@ func TestSavingsGoalHandler_Contribute(t *testing.T) {
 		})
 	}
 }
+
+func TestSavingsGoalHandler_GetProjection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		goalID     string
+		query      string
+		setupMock  func(*MockSavingsGoalService, uuid.UUID, uuid.UUID)
+		wantStatus int
+	}{
+		{
+			name:   "success",
+			goalID: uuid.New().String(),
+			query:  "?bank=vcb&term=12",
+			setupMock: func(m *MockSavingsGoalService, goalID, userID uuid.UUID) {
+				opts := service.ProjectionOptions{BankCode: "vcb", TermMonths: 12}
+				m.On("GetProjection", mock.Anything, goalID, userID, opts).Return(&model.SavingsProjection{
+					GoalID: goalID,
+					Status: model.SavingsGoalStatusOnTrack,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid uuid",
+			goalID:     "invalid",
+			setupMock:  func(m *MockSavingsGoalService, goalID, userID uuid.UUID) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid term",
+			goalID:     uuid.New().String(),
+			query:      "?term=abc",
+			setupMock:  func(m *MockSavingsGoalService, goalID, userID uuid.UUID) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "not found",
+			goalID: uuid.New().String(),
+			setupMock: func(m *MockSavingsGoalService, goalID, userID uuid.UUID) {
+				m.On("GetProjection", mock.Anything, goalID, userID, service.ProjectionOptions{}).Return(nil, repository.ErrSavingsGoalNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "rate not found",
+			goalID: uuid.New().String(),
+			query:  "?bank=xyz",
+			setupMock: func(m *MockSavingsGoalService, goalID, userID uuid.UUID) {
+				m.On("GetProjection", mock.Anything, goalID, userID, service.ProjectionOptions{BankCode: "xyz"}).Return(nil, service.ErrDepositRateNotFound)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "service error",
+			goalID: uuid.New().String(),
+			setupMock: func(m *MockSavingsGoalService, goalID, userID uuid.UUID) {
+				m.On("GetProjection", mock.Anything, goalID, userID, service.ProjectionOptions{}).Return(nil, errors.New("error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockSavingsGoalService)
+			handler := NewSavingsGoalHandler(mockService)
+			userID := uuid.New()
+			goalID, _ := uuid.Parse(tt.goalID)
+
+			tt.setupMock(mockService, goalID, userID)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/savings-goals/"+tt.goalID+"/projection"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tt.goalID)
+			req = req.WithContext(context.WithValue(ctxWithUserID(userID), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.GetProjection(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/wealthpath/backend/internal/repository"
+	"github.com/wealthpath/backend/internal/service"
+)
+
+type SavingsRuleHandler struct {
+	service SavingsRuleServiceInterface
+}
+
+func NewSavingsRuleHandler(service SavingsRuleServiceInterface) *SavingsRuleHandler {
+	return &SavingsRuleHandler{service: service}
+}
+
+// List godoc
+// @Summary List savings rules
+// @Description Get all automatic savings rules attached to a savings goal
+// @Tags savings-goals
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Savings Goal ID"
+// @Success 200 {array} model.SavingsRule
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /savings-goals/{id}/rules [get]
+func (h *SavingsRuleHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	goalID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	rules, err := h.service.List(r.Context(), userID, goalID)
+	if err != nil {
+		h.handleError(w, err, "failed to list savings rules")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rules)
+}
+
+// Create godoc
+// @Summary Create a savings rule
+// @Description Attach a round-up, percent-of-income or fixed-schedule rule to a savings goal
+// @Tags savings-goals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Savings Goal ID"
+// @Param input body service.CreateSavingsRuleInput true "Savings rule data"
+// @Success 201 {object} model.SavingsRule
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /savings-goals/{id}/rules [post]
+func (h *SavingsRuleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	goalID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var input service.CreateSavingsRuleInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	rule, err := h.service.Create(r.Context(), userID, goalID, input)
+	if err != nil {
+		h.handleError(w, err, "failed to create savings rule")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, rule)
+}
+
+// Update godoc
+// @Summary Update a savings rule
+// @Description Update an automatic savings rule; the rule type cannot be changed
+// @Tags savings-goals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Savings Goal ID"
+// @Param ruleId path string true "Savings Rule ID"
+// @Param input body service.UpdateSavingsRuleInput true "Updated savings rule data"
+// @Success 200 {object} model.SavingsRule
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /savings-goals/{id}/rules/{ruleId} [put]
+func (h *SavingsRuleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	goalID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	ruleID, err := uuid.Parse(chi.URLParam(r, "ruleId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid rule id")
+		return
+	}
+
+	var input service.UpdateSavingsRuleInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	rule, err := h.service.Update(r.Context(), userID, goalID, ruleID, input)
+	if err != nil {
+		h.handleError(w, err, "failed to update savings rule")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rule)
+}
+
+// Delete godoc
+// @Summary Delete a savings rule
+// @Description Remove an automatic savings rule from a savings goal
+// @Tags savings-goals
+// @Security BearerAuth
+// @Param id path string true "Savings Goal ID"
+// @Param ruleId path string true "Savings Rule ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /savings-goals/{id}/rules/{ruleId} [delete]
+func (h *SavingsRuleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	goalID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	ruleID, err := uuid.Parse(chi.URLParam(r, "ruleId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid rule id")
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), userID, goalID, ruleID); err != nil {
+		h.handleError(w, err, "failed to delete savings rule")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleError maps savings rule service errors to HTTP responses.
+func (h *SavingsRuleHandler) handleError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrSavingsGoalNotFound):
+		respondError(w, http.StatusNotFound, "savings goal not found")
+	case errors.Is(err, repository.ErrSavingsRuleNotFound):
+		respondError(w, http.StatusNotFound, "savings rule not found")
+	case errors.Is(err, service.ErrInvalidRuleType),
+		errors.Is(err, service.ErrInvalidRulePercentage),
+		errors.Is(err, service.ErrInvalidRuleRoundTo),
+		errors.Is(err, service.ErrInvalidAmount),
+		errors.Is(err, service.ErrInvalidFrequency):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
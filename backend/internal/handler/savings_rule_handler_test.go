@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+	"github.com/wealthpath/backend/internal/service"
+)
+
+// MockSavingsRuleService implements SavingsRuleServiceInterface for testing
+type MockSavingsRuleService struct {
+	mock.Mock
+}
+
+func (m *MockSavingsRuleService) Create(ctx context.Context, userID, goalID uuid.UUID, input service.CreateSavingsRuleInput) (*model.SavingsRule, error) {
+	args := m.Called(ctx, userID, goalID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SavingsRule), args.Error(1)
+}
+
+func (m *MockSavingsRuleService) List(ctx context.Context, userID, goalID uuid.UUID) ([]model.SavingsRule, error) {
+	args := m.Called(ctx, userID, goalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SavingsRule), args.Error(1)
+}
+
+func (m *MockSavingsRuleService) Update(ctx context.Context, userID, goalID, ruleID uuid.UUID, input service.UpdateSavingsRuleInput) (*model.SavingsRule, error) {
+	args := m.Called(ctx, userID, goalID, ruleID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SavingsRule), args.Error(1)
+}
+
+func (m *MockSavingsRuleService) Delete(ctx context.Context, userID, goalID, ruleID uuid.UUID) error {
+	args := m.Called(ctx, userID, goalID, ruleID)
+	return args.Error(0)
+}
+
+func savingsRuleRequest(method, goalID, ruleID string, body []byte, userID uuid.UUID) *http.Request {
+	target := "/api/savings-goals/" + goalID + "/rules"
+	if ruleID != "" {
+		target += "/" + ruleID
+	}
+	req := httptest.NewRequest(method, target, bytes.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", goalID)
+	if ruleID != "" {
+		rctx.URLParams.Add("ruleId", ruleID)
+	}
+	return req.WithContext(context.WithValue(ctxWithUserID(userID), chi.RouteCtxKey, rctx))
+}
+
+func TestSavingsRuleHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		goalID     string
+		body       interface{}
+		setupMock  func(*MockSavingsRuleService, uuid.UUID, uuid.UUID)
+		wantStatus int
+	}{
+		{
+			name:   "success",
+			goalID: uuid.New().String(),
+			body:   service.CreateSavingsRuleInput{Type: model.SavingsRuleRoundUp},
+			setupMock: func(m *MockSavingsRuleService, userID, goalID uuid.UUID) {
+				m.On("Create", mock.Anything, userID, goalID, mock.AnythingOfType("service.CreateSavingsRuleInput")).
+					Return(&model.SavingsRule{ID: uuid.New(), GoalID: goalID, Type: model.SavingsRuleRoundUp}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "invalid goal id",
+			goalID:     "invalid",
+			body:       service.CreateSavingsRuleInput{Type: model.SavingsRuleRoundUp},
+			setupMock:  func(m *MockSavingsRuleService, userID, goalID uuid.UUID) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "invalid body",
+			goalID:     uuid.New().String(),
+			body:       "not json",
+			setupMock:  func(m *MockSavingsRuleService, userID, goalID uuid.UUID) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "validation error",
+			goalID: uuid.New().String(),
+			body:   service.CreateSavingsRuleInput{Type: "lottery"},
+			setupMock: func(m *MockSavingsRuleService, userID, goalID uuid.UUID) {
+				m.On("Create", mock.Anything, userID, goalID, mock.Anything).Return(nil, service.ErrInvalidRuleType)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "goal not found",
+			goalID: uuid.New().String(),
+			body:   service.CreateSavingsRuleInput{Type: model.SavingsRuleRoundUp},
+			setupMock: func(m *MockSavingsRuleService, userID, goalID uuid.UUID) {
+				m.On("Create", mock.Anything, userID, goalID, mock.Anything).Return(nil, repository.ErrSavingsGoalNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "service error",
+			goalID: uuid.New().String(),
+			body:   service.CreateSavingsRuleInput{Type: model.SavingsRuleRoundUp},
+			setupMock: func(m *MockSavingsRuleService, userID, goalID uuid.UUID) {
+				m.On("Create", mock.Anything, userID, goalID, mock.Anything).Return(nil, errors.New("error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockSavingsRuleService)
+			handler := NewSavingsRuleHandler(mockService)
+			userID := uuid.New()
+			goalID, _ := uuid.Parse(tt.goalID)
+
+			tt.setupMock(mockService, userID, goalID)
+
+			var body []byte
+			if s, ok := tt.body.(string); ok {
+				body = []byte(s)
+			} else {
+				body, _ = json.Marshal(tt.body)
+			}
+			w := httptest.NewRecorder()
+
+			handler.Create(w, savingsRuleRequest(http.MethodPost, tt.goalID, "", body, userID))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSavingsRuleHandler_List(t *testing.T) {
+	t.Parallel()
+
+	mockService := new(MockSavingsRuleService)
+	handler := NewSavingsRuleHandler(mockService)
+	userID := uuid.New()
+	goalID := uuid.New()
+
+	mockService.On("List", mock.Anything, userID, goalID).Return([]model.SavingsRule{
+		{ID: uuid.New(), GoalID: goalID, Type: model.SavingsRuleRoundUp},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	handler.List(w, savingsRuleRequest(http.MethodGet, goalID.String(), "", nil, userID))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var rules []model.SavingsRule
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&rules))
+	assert.Len(t, rules, 1)
+}
+
+func TestSavingsRuleHandler_Update(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		ruleID     string
+		setupMock  func(*MockSavingsRuleService, uuid.UUID, uuid.UUID, uuid.UUID)
+		wantStatus int
+	}{
+		{
+			name:   "success",
+			ruleID: uuid.New().String(),
+			setupMock: func(m *MockSavingsRuleService, userID, goalID, ruleID uuid.UUID) {
+				m.On("Update", mock.Anything, userID, goalID, ruleID, mock.Anything).Return(&model.SavingsRule{ID: ruleID}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid rule id",
+			ruleID:     "invalid",
+			setupMock:  func(m *MockSavingsRuleService, userID, goalID, ruleID uuid.UUID) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "rule not found",
+			ruleID: uuid.New().String(),
+			setupMock: func(m *MockSavingsRuleService, userID, goalID, ruleID uuid.UUID) {
+				m.On("Update", mock.Anything, userID, goalID, ruleID, mock.Anything).Return(nil, repository.ErrSavingsRuleNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockSavingsRuleService)
+			handler := NewSavingsRuleHandler(mockService)
+			userID := uuid.New()
+			goalID := uuid.New()
+			ruleID, _ := uuid.Parse(tt.ruleID)
+
+			tt.setupMock(mockService, userID, goalID, ruleID)
+
+			w := httptest.NewRecorder()
+			handler.Update(w, savingsRuleRequest(http.MethodPut, goalID.String(), tt.ruleID, []byte(`{"isActive":false}`), userID))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSavingsRuleHandler_Delete(t *testing.T) {
+	t.Parallel()
+
+	mockService := new(MockSavingsRuleService)
+	handler := NewSavingsRuleHandler(mockService)
+	userID := uuid.New()
+	goalID := uuid.New()
+	ruleID := uuid.New()
+
+	mockService.On("Delete", mock.Anything, userID, goalID, ruleID).Return(nil)
+
+	w := httptest.NewRecorder()
+	handler.Delete(w, savingsRuleRequest(http.MethodDelete, goalID.String(), ruleID.String(), nil, userID))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/wealthpath/backend/internal/repository"
+	"github.com/wealthpath/backend/internal/service"
+)
+
+type SavingsShareHandler struct {
+	service SavingsGoalSharingServiceInterface
+}
+
+func NewSavingsShareHandler(service SavingsGoalSharingServiceInterface) *SavingsShareHandler {
+	return &SavingsShareHandler{service: service}
+}
+
+// Invite godoc
+// @Summary Invite a user to a savings goal
+// @Description Share a savings goal by email with view, contribute or edit permission
+// @Tags savings-goals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Savings Goal ID"
+// @Param input body service.InviteMemberInput true "Invitation data"
+// @Success 201 {object} model.SavingsGoalInvitation
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /savings-goals/{id}/invitations [post]
+func (h *SavingsShareHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	goalID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var input service.InviteMemberInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	invitation, err := h.service.InviteMember(r.Context(), goalID, userID, input)
+	if err != nil {
+		h.handleError(w, err, "failed to invite member")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, invitation)
+}
+
+// ListMembers godoc
+// @Summary List savings goal members
+// @Description Get the owner and members of a savings goal with each person's total contributions
+// @Tags savings-goals
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Savings Goal ID"
+// @Success 200 {array} model.SavingsGoalMember
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /savings-goals/{id}/members [get]
+func (h *SavingsShareHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	goalID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	members, err := h.service.ListMembers(r.Context(), goalID, userID)
+	if err != nil {
+		h.handleError(w, err, "failed to list members")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, members)
+}
+
+// UpdateMember godoc
+// @Summary Change a member's permission
+// @Description Change the permission of a savings goal member (owner only)
+// @Tags savings-goals
+// @Accept json
+// @Security BearerAuth
+// @Param id path string true "Savings Goal ID"
+// @Param userId path string true "Member User ID"
+// @Param input body service.UpdateMemberInput true "New permission"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /savings-goals/{id}/members/{userId} [put]
+func (h *SavingsShareHandler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	goalID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	memberID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	var input service.UpdateMemberInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.service.UpdateMemberPermission(r.Context(), goalID, userID, memberID, input); err != nil {
+		h.handleError(w, err, "failed to update member")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveMember godoc
+// @Summary Remove a member from a savings goal
+// @Description Revoke a member's access; members may remove themselves to leave a goal
+// @Tags savings-goals
+// @Security BearerAuth
+// @Param id path string true "Savings Goal ID"
+// @Param userId path string true "Member User ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /savings-goals/{id}/members/{userId} [delete]
+func (h *SavingsShareHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	goalID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	memberID, err := uuid.Parse(chi.URLParam(r, "userId"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	if err := h.service.RemoveMember(r.Context(), goalID, userID, memberID); err != nil {
+		h.handleError(w, err, "failed to remove member")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListInvitations godoc
+// @Summary List my savings goal invitations
+// @Description Get pending savings goal invitations sent to the current user's email
+// @Tags savings-goals
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.SavingsGoalInvitation
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /savings-invitations [get]
+func (h *SavingsShareHandler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	invitations, err := h.service.ListInvitations(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list invitations")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, invitations)
+}
+
+// AcceptInvitation godoc
+// @Summary Accept a savings goal invitation
+// @Description Join a shared savings goal using the invitation token
+// @Tags savings-goals
+// @Produce json
+// @Security BearerAuth
+// @Param token path string true "Invitation token"
+// @Success 200 {object} model.SavingsGoal
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /savings-invitations/{token}/accept [post]
+func (h *SavingsShareHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	goal, err := h.service.AcceptInvitation(r.Context(), chi.URLParam(r, "token"), userID)
+	if err != nil {
+		h.handleError(w, err, "failed to accept invitation")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, goal)
+}
+
+// DeclineInvitation godoc
+// @Summary Decline a savings goal invitation
+// @Description Reject an invitation to a shared savings goal
+// @Tags savings-goals
+// @Security BearerAuth
+// @Param token path string true "Invitation token"
+// @Success 204 "No Content"
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /savings-invitations/{token}/decline [post]
+func (h *SavingsShareHandler) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	userID := GetUserID(r.Context())
+
+	if err := h.service.DeclineInvitation(r.Context(), chi.URLParam(r, "token"), userID); err != nil {
+		h.handleError(w, err, "failed to decline invitation")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleError maps sharing service errors to HTTP responses.
+func (h *SavingsShareHandler) handleError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrSavingsGoalNotFound):
+		respondError(w, http.StatusNotFound, "savings goal not found")
+	case errors.Is(err, repository.ErrGoalMemberNotFound):
+		respondError(w, http.StatusNotFound, "member not found")
+	case errors.Is(err, repository.ErrInvitationNotFound):
+		respondError(w, http.StatusNotFound, "invitation not found")
+	case errors.Is(err, service.ErrGoalPermissionDenied),
+		errors.Is(err, service.ErrInvitationEmailMismatch):
+		respondError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrAlreadyGoalMember):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvitationExpired):
+		respondError(w, http.StatusGone, err.Error())
+	case errors.Is(err, service.ErrInvalidGoalPermission),
+		errors.Is(err, service.ErrInvalidInvitationEmail):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
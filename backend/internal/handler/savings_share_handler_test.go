@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+	"github.com/wealthpath/backend/internal/service"
+)
+
+// MockSavingsGoalSharingService implements SavingsGoalSharingServiceInterface for testing
+type MockSavingsGoalSharingService struct {
+	mock.Mock
+}
+
+func (m *MockSavingsGoalSharingService) InviteMember(ctx context.Context, goalID, userID uuid.UUID, input service.InviteMemberInput) (*model.SavingsGoalInvitation, error) {
+	args := m.Called(ctx, goalID, userID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SavingsGoalInvitation), args.Error(1)
+}
+
+func (m *MockSavingsGoalSharingService) ListInvitations(ctx context.Context, userID uuid.UUID) ([]model.SavingsGoalInvitation, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SavingsGoalInvitation), args.Error(1)
+}
+
+func (m *MockSavingsGoalSharingService) AcceptInvitation(ctx context.Context, token string, userID uuid.UUID) (*model.SavingsGoal, error) {
+	args := m.Called(ctx, token, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SavingsGoal), args.Error(1)
+}
+
+func (m *MockSavingsGoalSharingService) DeclineInvitation(ctx context.Context, token string, userID uuid.UUID) error {
+	args := m.Called(ctx, token, userID)
+	return args.Error(0)
+}
+
+func (m *MockSavingsGoalSharingService) ListMembers(ctx context.Context, goalID, userID uuid.UUID) ([]model.SavingsGoalMember, error) {
+	args := m.Called(ctx, goalID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SavingsGoalMember), args.Error(1)
+}
+
+func (m *MockSavingsGoalSharingService) UpdateMemberPermission(ctx context.Context, goalID, userID, memberID uuid.UUID, input service.UpdateMemberInput) error {
+	args := m.Called(ctx, goalID, userID, memberID, input)
+	return args.Error(0)
+}
+
+func (m *MockSavingsGoalSharingService) RemoveMember(ctx context.Context, goalID, userID, memberID uuid.UUID) error {
+	args := m.Called(ctx, goalID, userID, memberID)
+	return args.Error(0)
+}
+
+func TestSavingsShareHandler_Invite(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		callsSvc   bool
+		wantStatus int
+	}{
+		{name: "success", body: `{"email":"friend@example.com","permission":"contribute"}`, callsSvc: true, wantStatus: http.StatusCreated},
+		{name: "invalid body", body: "not json", wantStatus: http.StatusBadRequest},
+		{name: "not owner", body: `{"email":"friend@example.com","permission":"view"}`, err: service.ErrGoalPermissionDenied, callsSvc: true, wantStatus: http.StatusForbidden},
+		{name: "already member", body: `{"email":"friend@example.com","permission":"view"}`, err: service.ErrAlreadyGoalMember, callsSvc: true, wantStatus: http.StatusConflict},
+		{name: "invalid permission", body: `{"email":"friend@example.com","permission":"owner"}`, err: service.ErrInvalidGoalPermission, callsSvc: true, wantStatus: http.StatusBadRequest},
+		{name: "goal not found", body: `{"email":"friend@example.com","permission":"view"}`, err: repository.ErrSavingsGoalNotFound, callsSvc: true, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockSavingsGoalSharingService)
+			handler := NewSavingsShareHandler(mockService)
+			userID := uuid.New()
+			goalID := uuid.New()
+
+			if tt.callsSvc {
+				if tt.err != nil {
+					mockService.On("InviteMember", mock.Anything, goalID, userID, mock.AnythingOfType("service.InviteMemberInput")).Return(nil, tt.err)
+				} else {
+					mockService.On("InviteMember", mock.Anything, goalID, userID, mock.AnythingOfType("service.InviteMemberInput")).Return(&model.SavingsGoalInvitation{GoalID: goalID}, nil)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/savings-goals/"+goalID.String()+"/invitations", bytes.NewBufferString(tt.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", goalID.String())
+			req = req.WithContext(context.WithValue(ctxWithUserID(userID), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.Invite(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSavingsShareHandler_AcceptInvitation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "not found", err: repository.ErrInvitationNotFound, wantStatus: http.StatusNotFound},
+		{name: "expired", err: service.ErrInvitationExpired, wantStatus: http.StatusGone},
+		{name: "email mismatch", err: service.ErrInvitationEmailMismatch, wantStatus: http.StatusForbidden},
+		{name: "service error", err: errors.New("error"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockSavingsGoalSharingService)
+			handler := NewSavingsShareHandler(mockService)
+			userID := uuid.New()
+
+			if tt.err != nil {
+				mockService.On("AcceptInvitation", mock.Anything, "abc123", userID).Return(nil, tt.err)
+			} else {
+				mockService.On("AcceptInvitation", mock.Anything, "abc123", userID).Return(&model.SavingsGoal{ID: uuid.New()}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/savings-invitations/abc123/accept", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("token", "abc123")
+			req = req.WithContext(context.WithValue(ctxWithUserID(userID), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.AcceptInvitation(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSavingsShareHandler_RemoveMember(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		memberID   string
+		err        error
+		callsSvc   bool
+		wantStatus int
+	}{
+		{name: "success", memberID: uuid.New().String(), callsSvc: true, wantStatus: http.StatusNoContent},
+		{name: "invalid member id", memberID: "abc", wantStatus: http.StatusBadRequest},
+		{name: "member not found", memberID: uuid.New().String(), err: repository.ErrGoalMemberNotFound, callsSvc: true, wantStatus: http.StatusNotFound},
+		{name: "permission denied", memberID: uuid.New().String(), err: service.ErrGoalPermissionDenied, callsSvc: true, wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := new(MockSavingsGoalSharingService)
+			handler := NewSavingsShareHandler(mockService)
+			userID := uuid.New()
+			goalID := uuid.New()
+
+			if tt.callsSvc {
+				mockService.On("RemoveMember", mock.Anything, goalID, userID, uuid.MustParse(tt.memberID)).Return(tt.err)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/savings-goals/"+goalID.String()+"/members/"+tt.memberID, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", goalID.String())
+			rctx.URLParams.Add("userId", tt.memberID)
+			req = req.WithContext(context.WithValue(ctxWithUserID(userID), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.RemoveMember(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -76,6 +76,10 @@ func (h *TransactionHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	tx, err := h.service.Create(r.Context(), userID, input)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidCardCharge) {
+			respondAppError(w, apperror.ValidationError("debtId", err.Error()))
+			return
+		}
 		respondAppError(w, apperror.Internal(err))
 		return
 	}
@@ -189,6 +193,7 @@ func (h *TransactionHandler) List(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /transactions/{id} [put]
 func (h *TransactionHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -212,6 +217,14 @@ func (h *TransactionHandler) Update(w http.ResponseWriter, r *http.Request) {
 			respondAppError(w, apperror.NotFound("transaction"))
 			return
 		}
+		if errors.Is(err, service.ErrInvalidCardCharge) {
+			respondAppError(w, apperror.ValidationError("debtId", err.Error()))
+			return
+		}
+		if errors.Is(err, service.ErrDebtPaymentTransaction) {
+			respondAppError(w, apperror.Conflict(err.Error()))
+			return
+		}
 		respondAppError(w, apperror.Internal(err))
 		return
 	}
@@ -229,6 +242,7 @@ func (h *TransactionHandler) Update(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /transactions/{id} [delete]
 func (h *TransactionHandler) Delete(w http.ResponseWriter, r *http.Request) {
@@ -245,6 +259,10 @@ func (h *TransactionHandler) Delete(w http.ResponseWriter, r *http.Request) {
 			respondAppError(w, apperror.NotFound("transaction"))
 			return
 		}
+		if errors.Is(err, service.ErrDebtPaymentTransaction) {
+			respondAppError(w, apperror.Conflict(err.Error()))
+			return
+		}
 		respondAppError(w, apperror.Internal(err))
 		return
 	}
@@ -406,6 +406,28 @@ func TestTransactionHandler_Delete_Error(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestTransactionHandler_Delete_DebtPaymentTransaction(t *testing.T) {
+	mockService := new(MockTransactionService)
+	handler := NewTransactionHandler(mockService)
+
+	userID := uuid.New()
+	txID := uuid.New()
+
+	mockService.On("Delete", mock.Anything, txID, userID).Return(service.ErrDebtPaymentTransaction)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/transactions/"+txID.String(), nil)
+	req = req.WithContext(context.WithValue(req.Context(), UserIDKey, userID))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", txID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	rr := httptest.NewRecorder()
+	handler.Delete(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
 // Test List with query parameters
 func TestTransactionHandler_List_WithQueryParams(t *testing.T) {
 	t.Parallel()
@@ -221,11 +221,76 @@ func (m *DebtRepositoryInterface) Delete(ctx context.Context, id, userID uuid.UU
 	return ret.Error(0)
 }
 
-func (m *DebtRepositoryInterface) RecordPayment(ctx context.Context, payment *model.DebtPayment) error {
-	ret := m.Called(ctx, payment)
+func (m *DebtRepositoryInterface) RecordPayment(ctx context.Context, payment *model.DebtPayment, split model.DebtPaymentSplit) error {
+	ret := m.Called(ctx, payment, split)
 	return ret.Error(0)
 }
 
+func (m *DebtRepositoryInterface) GetPayments(ctx context.Context, debtID uuid.UUID) ([]model.DebtPaymentRecord, error) {
+	ret := m.Called(ctx, debtID)
+	var r0 []model.DebtPaymentRecord
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]model.DebtPaymentRecord)
+	}
+	return r0, ret.Error(1)
+}
+
+func (m *DebtRepositoryInterface) GetPayment(ctx context.Context, debtID, paymentID uuid.UUID) (*model.DebtPayment, error) {
+	ret := m.Called(ctx, debtID, paymentID)
+	var r0 *model.DebtPayment
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).(*model.DebtPayment)
+	}
+	return r0, ret.Error(1)
+}
+
+func (m *DebtRepositoryInterface) UpdatePayment(ctx context.Context, payment *model.DebtPayment, split model.DebtPaymentSplit) error {
+	ret := m.Called(ctx, payment, split)
+	return ret.Error(0)
+}
+
+func (m *DebtRepositoryInterface) DeletePayment(ctx context.Context, debtID, paymentID uuid.UUID) error {
+	ret := m.Called(ctx, debtID, paymentID)
+	return ret.Error(0)
+}
+
+func (m *DebtRepositoryInterface) ListStatementCards(ctx context.Context) ([]model.Debt, error) {
+	ret := m.Called(ctx)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).([]model.Debt), ret.Error(1)
+}
+
+func (m *DebtRepositoryInterface) ListStatements(ctx context.Context, debtID uuid.UUID) ([]model.CreditCardStatement, error) {
+	ret := m.Called(ctx, debtID)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).([]model.CreditCardStatement), ret.Error(1)
+}
+
+func (m *DebtRepositoryInterface) GetStatement(ctx context.Context, debtID, statementID uuid.UUID) (*model.CreditCardStatement, error) {
+	ret := m.Called(ctx, debtID, statementID)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).(*model.CreditCardStatement), ret.Error(1)
+}
+
+func (m *DebtRepositoryInterface) CloseStatement(ctx context.Context, statement *model.CreditCardStatement) error {
+	ret := m.Called(ctx, statement)
+	return ret.Error(0)
+}
+
+func (m *DebtRepositoryInterface) GetCardTransactions(ctx context.Context, debtID uuid.UUID, from time.Time, to *time.Time) ([]model.Transaction, error) {
+	ret := m.Called(ctx, debtID, from, to)
+	if ret.Get(0) == nil {
+		return nil, ret.Error(1)
+	}
+	return ret.Get(0).([]model.Transaction), ret.Error(1)
+}
+
 func (m *DebtRepositoryInterface) GetTotalDebt(ctx context.Context, userID uuid.UUID) (decimal.Decimal, error) {
 	ret := m.Called(ctx, userID)
 	return ret.Get(0).(decimal.Decimal), ret.Error(1)
@@ -274,6 +339,20 @@ func (m *SavingsGoalRepositoryInterface) AddContribution(ctx context.Context, id
 	return ret.Error(0)
 }
 
+func (m *SavingsGoalRepositoryInterface) ListContributions(ctx context.Context, goalID uuid.UUID) ([]model.SavingsContribution, error) {
+	ret := m.Called(ctx, goalID)
+	var r0 []model.SavingsContribution
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]model.SavingsContribution)
+	}
+	return r0, ret.Error(1)
+}
+
+func (m *SavingsGoalRepositoryInterface) RecordContribution(ctx context.Context, contribution *model.SavingsContribution) error {
+	ret := m.Called(ctx, contribution)
+	return ret.Error(0)
+}
+
 func (m *SavingsGoalRepositoryInterface) GetTotalSavings(ctx context.Context, userID uuid.UUID) (decimal.Decimal, error) {
 	ret := m.Called(ctx, userID)
 	return ret.Get(0).(decimal.Decimal), ret.Error(1)
@@ -317,11 +396,49 @@ func (m *RecurringRepositoryInterface) Delete(ctx context.Context, id uuid.UUID)
 	return ret.Error(0)
 }
 
-func (m *RecurringRepositoryInterface) GetUpcoming(ctx context.Context, userID uuid.UUID, limit int) ([]model.UpcomingBill, error) {
-	ret := m.Called(ctx, userID, limit)
-	var r0 []model.UpcomingBill
+func (m *RecurringRepositoryInterface) GetActiveByUserID(ctx context.Context, userID uuid.UUID) ([]model.RecurringTransaction, error) {
+	ret := m.Called(ctx, userID)
+	var r0 []model.RecurringTransaction
 	if ret.Get(0) != nil {
-		r0 = ret.Get(0).([]model.UpcomingBill)
+		r0 = ret.Get(0).([]model.RecurringTransaction)
+	}
+	return r0, ret.Error(1)
+}
+
+func (m *RecurringRepositoryInterface) Advance(ctx context.Context, id uuid.UUID, lastGenerated, nextOccurrence time.Time) error {
+	ret := m.Called(ctx, id, lastGenerated, nextOccurrence)
+	return ret.Error(0)
+}
+
+func (m *RecurringRepositoryInterface) Deactivate(ctx context.Context, id uuid.UUID, nextOccurrence time.Time) error {
+	ret := m.Called(ctx, id, nextOccurrence)
+	return ret.Error(0)
+}
+
+func (m *RecurringRepositoryInterface) SaveException(ctx context.Context, e *model.RecurringException) error {
+	ret := m.Called(ctx, e)
+	return ret.Error(0)
+}
+
+func (m *RecurringRepositoryInterface) DeleteException(ctx context.Context, recurringID uuid.UUID, occurrence time.Time) error {
+	ret := m.Called(ctx, recurringID, occurrence)
+	return ret.Error(0)
+}
+
+func (m *RecurringRepositoryInterface) ListExceptions(ctx context.Context, recurringID uuid.UUID) ([]model.RecurringException, error) {
+	ret := m.Called(ctx, recurringID)
+	var r0 []model.RecurringException
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]model.RecurringException)
+	}
+	return r0, ret.Error(1)
+}
+
+func (m *RecurringRepositoryInterface) ListExceptionsByUser(ctx context.Context, userID uuid.UUID) ([]model.RecurringException, error) {
+	ret := m.Called(ctx, userID)
+	var r0 []model.RecurringException
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]model.RecurringException)
 	}
 	return r0, ret.Error(1)
 }
@@ -334,8 +451,3 @@ func (m *RecurringRepositoryInterface) GetDueTransactions(ctx context.Context, n
 	}
 	return r0, ret.Error(1)
 }
-
-func (m *RecurringRepositoryInterface) UpdateLastGenerated(ctx context.Context, id uuid.UUID, lastGenerated, nextOccurrence time.Time) error {
-	ret := m.Called(ctx, id, lastGenerated, nextOccurrence)
-	return ret.Error(0)
-}
@@ -28,16 +28,20 @@ const (
 )
 
 type Transaction struct {
-	ID          uuid.UUID       `db:"id" json:"id"`
-	UserID      uuid.UUID       `db:"user_id" json:"userId"`
-	Type        TransactionType `db:"type" json:"type"`
-	Amount      decimal.Decimal `db:"amount" json:"amount"`
-	Currency    string          `db:"currency" json:"currency"`
-	Category    string          `db:"category" json:"category"`
-	Description string          `db:"description" json:"description"`
-	Date        time.Time       `db:"date" json:"date"`
-	CreatedAt   time.Time       `db:"created_at" json:"createdAt"`
-	UpdatedAt   time.Time       `db:"updated_at" json:"updatedAt"`
+	ID             uuid.UUID       `db:"id" json:"id"`
+	UserID         uuid.UUID       `db:"user_id" json:"userId"`
+	Type           TransactionType `db:"type" json:"type"`
+	Amount         decimal.Decimal `db:"amount" json:"amount"`
+	Currency       string          `db:"currency" json:"currency"`
+	Category       string          `db:"category" json:"category"`
+	Description    string          `db:"description" json:"description"`
+	Date           time.Time       `db:"date" json:"date"`
+	DebtID         *uuid.UUID      `db:"debt_id" json:"debtId,omitempty"`                 // credit card the expense was charged to
+	DebtPaymentID  *uuid.UUID      `db:"debt_payment_id" json:"debtPaymentId,omitempty"`  // debt payment the expense records
+	RecurringID    *uuid.UUID      `db:"recurring_id" json:"recurringId,omitempty"`       // recurring transaction that generated it
+	OccurrenceDate *time.Time      `db:"occurrence_date" json:"occurrenceDate,omitempty"` // occurrence of the recurring transaction
+	CreatedAt      time.Time       `db:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time       `db:"updated_at" json:"updatedAt"`
 }
 
 type Budget struct {
@@ -72,6 +76,162 @@ type SavingsGoal struct {
 	Icon          string          `db:"icon" json:"icon"`
 	CreatedAt     time.Time       `db:"created_at" json:"createdAt"`
 	UpdatedAt     time.Time       `db:"updated_at" json:"updatedAt"`
+	Permission    GoalPermission  `db:"-" json:"permission,omitempty"` // caller's access level
+}
+
+type SavingsContribution struct {
+	ID                  uuid.UUID       `db:"id" json:"id"`
+	GoalID              uuid.UUID       `db:"goal_id" json:"goalId"`
+	UserID              uuid.UUID       `db:"user_id" json:"userId"`
+	Amount              decimal.Decimal `db:"amount" json:"amount"`
+	Date                time.Time       `db:"date" json:"date"`
+	RuleID              *uuid.UUID      `db:"rule_id" json:"ruleId,omitempty"`
+	SourceTransactionID *uuid.UUID      `db:"source_transaction_id" json:"sourceTransactionId,omitempty"`
+	CreatedAt           time.Time       `db:"created_at" json:"createdAt"`
+}
+
+type GoalPermission string
+
+const (
+	GoalPermissionView       GoalPermission = "view"
+	GoalPermissionContribute GoalPermission = "contribute"
+	GoalPermissionEdit       GoalPermission = "edit"
+	GoalPermissionOwner      GoalPermission = "owner"
+)
+
+// SavingsGoalMember is a user with access to a savings goal
+type SavingsGoalMember struct {
+	GoalID           uuid.UUID       `db:"goal_id" json:"goalId"`
+	UserID           uuid.UUID       `db:"user_id" json:"userId"`
+	Email            string          `db:"email" json:"email"`
+	Name             string          `db:"name" json:"name"`
+	Permission       GoalPermission  `db:"permission" json:"permission"`
+	JoinedAt         time.Time       `db:"joined_at" json:"joinedAt"`
+	TotalContributed decimal.Decimal `db:"-" json:"totalContributed"`
+}
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusDeclined InvitationStatus = "declined"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+)
+
+// SavingsGoalInvitation invites a user by email to share a savings goal
+type SavingsGoalInvitation struct {
+	ID          uuid.UUID        `db:"id" json:"id"`
+	GoalID      uuid.UUID        `db:"goal_id" json:"goalId"`
+	GoalName    string           `db:"goal_name" json:"goalName,omitempty"`
+	InviterID   uuid.UUID        `db:"inviter_id" json:"inviterId"`
+	Email       string           `db:"email" json:"email"`
+	Permission  GoalPermission   `db:"permission" json:"permission"`
+	Token       string           `db:"token" json:"token"`
+	Status      InvitationStatus `db:"status" json:"status"`
+	ExpiresAt   time.Time        `db:"expires_at" json:"expiresAt"`
+	RespondedAt *time.Time       `db:"responded_at" json:"respondedAt,omitempty"`
+	CreatedAt   time.Time        `db:"created_at" json:"createdAt"`
+}
+
+type SavingsRuleType string
+
+const (
+	SavingsRuleRoundUp         SavingsRuleType = "round_up"
+	SavingsRulePercentOfIncome SavingsRuleType = "percent_of_income"
+	SavingsRuleFixedSchedule   SavingsRuleType = "fixed_schedule"
+)
+
+// SavingsRule automatically contributes to a savings goal
+type SavingsRule struct {
+	ID         uuid.UUID           `db:"id" json:"id"`
+	GoalID     uuid.UUID           `db:"goal_id" json:"goalId"`
+	UserID     uuid.UUID           `db:"user_id" json:"userId"`
+	Type       SavingsRuleType     `db:"type" json:"type"`
+	RoundTo    *decimal.Decimal    `db:"round_to" json:"roundTo,omitempty"`      // round_up
+	Percentage *decimal.Decimal    `db:"percentage" json:"percentage,omitempty"` // percent_of_income
+	Category   *string             `db:"category" json:"category,omitempty"`     // percent_of_income, nil matches any
+	Amount     *decimal.Decimal    `db:"amount" json:"amount,omitempty"`         // fixed_schedule
+	Frequency  *RecurringFrequency `db:"frequency" json:"frequency,omitempty"`   // fixed_schedule
+	NextRun    *time.Time          `db:"next_run" json:"nextRun,omitempty"`      // fixed_schedule
+	AnchorDay  *int                `db:"anchor_day" json:"anchorDay,omitempty"`  // fixed_schedule day of month, clamped to the month end
+	IsActive   bool                `db:"is_active" json:"isActive"`
+	CreatedAt  time.Time           `db:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time           `db:"updated_at" json:"updatedAt"`
+}
+
+type SavingsGoalStatus string
+
+const (
+	SavingsGoalStatusOnTrack      SavingsGoalStatus = "on_track"
+	SavingsGoalStatusAhead        SavingsGoalStatus = "ahead"
+	SavingsGoalStatusBehind       SavingsGoalStatus = "behind"
+	SavingsGoalStatusCompleted    SavingsGoalStatus = "completed"
+	SavingsGoalStatusNoTargetDate SavingsGoalStatus = "no_target_date"
+)
+
+// SavingsProjection estimates whether a savings goal will be reached by its target date
+type SavingsProjection struct {
+	GoalID                      uuid.UUID         `json:"goalId"`
+	TargetAmount                decimal.Decimal   `json:"targetAmount"`
+	CurrentAmount               decimal.Decimal   `json:"currentAmount"`
+	RemainingAmount             decimal.Decimal   `json:"remainingAmount"`
+	TargetDate                  *time.Time        `json:"targetDate,omitempty"`
+	MonthsRemaining             int               `json:"monthsRemaining"`
+	RequiredMonthlyContribution decimal.Decimal   `json:"requiredMonthlyContribution"`
+	AverageMonthlyContribution  decimal.Decimal   `json:"averageMonthlyContribution"`
+	ProjectedCompletionDate     *time.Time        `json:"projectedCompletionDate,omitempty"`
+	ProjectedAmountAtTarget     *decimal.Decimal  `json:"projectedAmountAtTarget,omitempty"`
+	AnnualInterestRate          decimal.Decimal   `json:"annualInterestRate"` // deposit rate used, as percentage
+	RateBankCode                string            `json:"rateBankCode,omitempty"`
+	RateTermMonths              int               `json:"rateTermMonths,omitempty"`
+	Status                      SavingsGoalStatus `json:"status"`
+}
+
+// DepositLadder splits an amount across term deposits so that portions mature at staggered dates
+type DepositLadder struct {
+	ID                    uuid.UUID           `db:"id" json:"id"`
+	UserID                uuid.UUID           `db:"user_id" json:"userId"`
+	GoalID                *uuid.UUID          `db:"goal_id" json:"goalId,omitempty"`
+	Amount                decimal.Decimal     `db:"amount" json:"amount"`
+	Currency              string              `db:"currency" json:"currency"`
+	HorizonMonths         int                 `db:"horizon_months" json:"horizonMonths"`
+	StartDate             time.Time           `db:"start_date" json:"startDate"`
+	TotalInterestSimple   decimal.Decimal     `db:"total_interest_simple" json:"totalInterestSimple"`     // interest withdrawn at each maturity
+	TotalInterestCompound decimal.Decimal     `db:"total_interest_compound" json:"totalInterestCompound"` // interest rolled over with principal
+	CreatedAt             time.Time           `db:"created_at" json:"createdAt"`
+	UpdatedAt             time.Time           `db:"updated_at" json:"updatedAt"`
+	Rungs                 []DepositLadderRung `db:"-" json:"rungs"`
+	Maturities            []DepositMaturity   `db:"-" json:"maturities"`
+}
+
+// DepositLadderRung is a single term deposit within a ladder
+type DepositLadderRung struct {
+	ID                uuid.UUID       `db:"id" json:"id"`
+	LadderID          uuid.UUID       `db:"ladder_id" json:"ladderId"`
+	Position          int             `db:"position" json:"position"`
+	BankCode          string          `db:"bank_code" json:"bankCode"`
+	BankName          string          `db:"bank_name" json:"bankName"`
+	TermMonths        int             `db:"term_months" json:"termMonths"`
+	Rate              decimal.Decimal `db:"rate" json:"rate"` // annual rate as percentage
+	Amount            decimal.Decimal `db:"amount" json:"amount"`
+	Rollovers         int             `db:"rollovers" json:"rollovers"`
+	InterestSimple    decimal.Decimal `db:"interest_simple" json:"interestSimple"`
+	InterestCompound  decimal.Decimal `db:"interest_compound" json:"interestCompound"`
+	FinalMaturityDate time.Time       `db:"final_maturity_date" json:"finalMaturityDate"`
+}
+
+// DepositMaturity is one maturity event in a ladder's schedule
+type DepositMaturity struct {
+	Date             time.Time       `json:"date"`
+	Position         int             `json:"position"`
+	BankCode         string          `json:"bankCode"`
+	TermMonths       int             `json:"termMonths"`
+	Principal        decimal.Decimal `json:"principal"`
+	InterestSimple   decimal.Decimal `json:"interestSimple"`
+	InterestCompound decimal.Decimal `json:"interestCompound"`
+	BalanceCompound  decimal.Decimal `json:"balanceCompound"` // balance after interest when rolling over
+	Final            bool            `json:"final"`           // last maturity within the horizon
 }
 
 type DebtType string
@@ -82,9 +242,19 @@ const (
 	DebtTypeStudentLoan  DebtType = "student_loan"
 	DebtTypeCreditCard   DebtType = "credit_card"
 	DebtTypePersonalLoan DebtType = "personal_loan"
+	DebtTypeInstallment  DebtType = "installment" // buy now pay later, trả góp
 	DebtTypeOther        DebtType = "other"
 )
 
+// InterestMethod is how a loan charges interest and splits its instalments.
+type InterestMethod string
+
+const (
+	InterestMethodDecliningBalance InterestMethod = "declining_balance" // level payments, interest on the outstanding balance
+	InterestMethodFlat             InterestMethod = "flat"              // interest on the original amount for the whole term
+	InterestMethodEqualPrincipal   InterestMethod = "equal_principal"   // fixed principal, interest on the outstanding balance
+)
+
 type Debt struct {
 	ID             uuid.UUID       `db:"id" json:"id"`
 	UserID         uuid.UUID       `db:"user_id" json:"userId"`
@@ -93,6 +263,7 @@ type Debt struct {
 	OriginalAmount decimal.Decimal `db:"original_amount" json:"originalAmount"`
 	CurrentBalance decimal.Decimal `db:"current_balance" json:"currentBalance"`
 	InterestRate   decimal.Decimal `db:"interest_rate" json:"interestRate"` // APR as percentage
+	InterestMethod InterestMethod  `db:"interest_method" json:"interestMethod"`
 	MinimumPayment decimal.Decimal `db:"minimum_payment" json:"minimumPayment"`
 	Currency       string          `db:"currency" json:"currency"`
 	DueDay         int             `db:"due_day" json:"dueDay"` // Day of month
@@ -100,6 +271,46 @@ type Debt struct {
 	ExpectedPayoff *time.Time      `db:"expected_payoff" json:"expectedPayoff,omitempty"`
 	CreatedAt      time.Time       `db:"created_at" json:"createdAt"`
 	UpdatedAt      time.Time       `db:"updated_at" json:"updatedAt"`
+
+	// Variable-rate schedule: InterestRate applies for FixedRateMonths from StartDate,
+	// then the rate floats at the reference mortgage rate plus RateMargin.
+	TermMonths          *int             `db:"term_months" json:"termMonths,omitempty"`
+	FixedRateMonths     *int             `db:"fixed_rate_months" json:"fixedRateMonths,omitempty"`
+	RateMargin          *decimal.Decimal `db:"rate_margin" json:"rateMargin,omitempty"`
+	ReferenceBankCode   *string          `db:"reference_bank_code" json:"referenceBankCode,omitempty"`
+	ReferenceTermMonths *int             `db:"reference_term_months" json:"referenceTermMonths,omitempty"`
+	RateResetMonths     *int             `db:"rate_reset_months" json:"rateResetMonths,omitempty"`
+
+	// Credit card statement cycle: a statement closes on StatementDay and is due
+	// GracePeriodDays later. The minimum due is MinimumPaymentPercent of the
+	// statement balance, but never less than MinimumPayment.
+	StatementDay          *int             `db:"statement_day" json:"statementDay,omitempty"`
+	GracePeriodDays       *int             `db:"grace_period_days" json:"gracePeriodDays,omitempty"`
+	MinimumPaymentPercent *decimal.Decimal `db:"minimum_payment_percent" json:"minimumPaymentPercent,omitempty"`
+
+	// Installment plan: OriginalAmount plus InstallmentFee repaid interest-free in
+	// TermMonths equal monthly instalments from StartDate. A plan charged to a credit
+	// card (CardID) uses up the card's CreditLimit until it is repaid.
+	Merchant        *string          `db:"merchant" json:"merchant,omitempty"`
+	InstallmentFee  *decimal.Decimal `db:"installment_fee" json:"installmentFee,omitempty"`
+	CardID          *uuid.UUID       `db:"card_id" json:"cardId,omitempty"`
+	CreditLimit     *decimal.Decimal `db:"credit_limit" json:"creditLimit,omitempty"`
+	AvailableCredit *decimal.Decimal `db:"-" json:"availableCredit,omitempty"` // credit limit less the card and linked plan balances
+}
+
+// HasRateSchedule reports whether the debt floats at a reference rate after a fixed period.
+func (d *Debt) HasRateSchedule() bool {
+	return d.FixedRateMonths != nil && d.ReferenceBankCode != nil && d.ReferenceTermMonths != nil
+}
+
+// HasStatementCycle reports whether the debt is a credit card billed in statement cycles.
+func (d *Debt) HasStatementCycle() bool {
+	return d.Type == DebtTypeCreditCard && d.StatementDay != nil
+}
+
+// IsInstallmentPlan reports whether the debt is a buy-now-pay-later or installment plan.
+func (d *Debt) IsInstallmentPlan() bool {
+	return d.Type == DebtTypeInstallment && d.TermMonths != nil
 }
 
 type DebtPayment struct {
@@ -110,6 +321,67 @@ type DebtPayment struct {
 	Interest  decimal.Decimal `db:"interest" json:"interest"`
 	Date      time.Time       `db:"date" json:"date"`
 	CreatedAt time.Time       `db:"created_at" json:"createdAt"`
+
+	SplitInterest bool          `db:"split_interest" json:"splitInterest"` // interest recorded as a separate Debt Interest expense
+	Transactions  []Transaction `db:"-" json:"transactions,omitempty"`     // expenses recording the payment
+}
+
+// DebtPaymentSplit divides a payment into principal and interest and sets the expenses
+// recording it, against the debt as read under the row lock taken to record the payment.
+type DebtPaymentSplit func(debt *Debt, payment *DebtPayment) error
+
+// Categories of the expenses that record debt payments
+const (
+	CategoryDebtPayments = "Debt Payments"
+	CategoryDebtInterest = "Debt Interest" // interest split out of a payment
+)
+
+// DebtPaymentRecord is a payment with the principal and interest paid up to and including it
+type DebtPaymentRecord struct {
+	DebtPayment
+	CumulativePrincipal decimal.Decimal `db:"cumulative_principal" json:"cumulativePrincipal"`
+	CumulativeInterest  decimal.Decimal `db:"cumulative_interest" json:"cumulativeInterest"`
+}
+
+// DebtPaymentHistory lists a debt's payments, newest first, with totals paid to date
+type DebtPaymentHistory struct {
+	DebtID         uuid.UUID           `json:"debtId"`
+	Currency       string              `json:"currency"`
+	CurrentBalance decimal.Decimal     `json:"currentBalance"`
+	TotalPaid      decimal.Decimal     `json:"totalPaid"`
+	TotalPrincipal decimal.Decimal     `json:"totalPrincipal"`
+	TotalInterest  decimal.Decimal     `json:"totalInterest"`
+	Payments       []DebtPaymentRecord `json:"payments"`
+}
+
+// CreditCardStatement is a closed credit card billing cycle covering the days after
+// PeriodStart up to and including PeriodEnd, the statement date
+type CreditCardStatement struct {
+	ID             uuid.UUID       `db:"id" json:"id"`
+	DebtID         uuid.UUID       `db:"debt_id" json:"debtId"`
+	PeriodStart    time.Time       `db:"period_start" json:"periodStart"`
+	PeriodEnd      time.Time       `db:"period_end" json:"periodEnd"`
+	DueDate        time.Time       `db:"due_date" json:"dueDate"`
+	OpeningBalance decimal.Decimal `db:"opening_balance" json:"openingBalance"`
+	Purchases      decimal.Decimal `db:"purchases" json:"purchases"`
+	Payments       decimal.Decimal `db:"payments" json:"payments"`
+	Interest       decimal.Decimal `db:"interest" json:"interest"`
+	ClosingBalance decimal.Decimal `db:"closing_balance" json:"closingBalance"` // statement balance
+	MinimumDue     decimal.Decimal `db:"minimum_due" json:"minimumDue"`
+	CreatedAt      time.Time       `db:"created_at" json:"createdAt"`
+}
+
+// CreditCardStatementDetail is a statement with its activity and what has been paid towards it
+type CreditCardStatementDetail struct {
+	CreditCardStatement
+	Currency         string          `json:"currency"`
+	Transactions     []Transaction   `json:"transactions"`
+	PaymentsInCycle  []DebtPayment   `json:"paymentsInCycle"`
+	AmountPaid       decimal.Decimal `json:"amountPaid"` // paid after the statement date, up to the due date
+	RemainingDue     decimal.Decimal `json:"remainingDue"`
+	MinimumRemaining decimal.Decimal `json:"minimumRemaining"`
+	PaidInFull       bool            `json:"paidInFull"`
+	Overdue          bool            `json:"overdue"`
 }
 
 type PayoffPlan struct {
@@ -120,17 +392,196 @@ type PayoffPlan struct {
 	TotalPayment     decimal.Decimal   `json:"totalPayment"`
 	PayoffDate       time.Time         `json:"payoffDate"`
 	MonthsToPayoff   int               `json:"monthsToPayoff"`
+	InterestMethod   InterestMethod    `json:"interestMethod"`
+	EffectiveAPR     decimal.Decimal   `json:"effectiveApr"` // declining-balance equivalent of the plan's cash flows
 	AmortizationPlan []AmortizationRow `json:"amortizationPlan"`
+	RateResets       []RateReset       `json:"rateResets,omitempty"`
+}
+
+// RateReset is a point in a payoff plan where a variable rate changes and the payment is recalculated
+type RateReset struct {
+	Month           int             `json:"month"`
+	Date            time.Time       `json:"date"`
+	PreviousRate    decimal.Decimal `json:"previousRate"`
+	Rate            decimal.Decimal `json:"rate"`
+	PreviousPayment decimal.Decimal `json:"previousPayment"`
+	Payment         decimal.Decimal `json:"payment"`
 }
 
 type AmortizationRow struct {
 	Month            int             `json:"month"`
+	Rate             decimal.Decimal `json:"rate"`
 	Payment          decimal.Decimal `json:"payment"`
+	ExtraPayment     decimal.Decimal `json:"extraPayment"` // lump sums and extra payments, included in payment
 	Principal        decimal.Decimal `json:"principal"`
 	Interest         decimal.Decimal `json:"interest"`
 	RemainingBalance decimal.Decimal `json:"remainingBalance"`
 }
 
+// PrepaymentMode chooses what a prepayment does to the rest of a payoff plan
+type PrepaymentMode string
+
+const (
+	PrepaymentShortenTerm   PrepaymentMode = "shorten_term"   // keep the payment, finish sooner
+	PrepaymentReducePayment PrepaymentMode = "reduce_payment" // keep the payoff month, pay less each month
+)
+
+// PayoffScenario compares a payoff plan with lump sums and extra payments against the baseline plan
+type PayoffScenario struct {
+	DebtID              uuid.UUID       `json:"debtId"`
+	AfterPrepayment     PrepaymentMode  `json:"afterPrepayment"`
+	Baseline            PayoffPlan      `json:"baseline"`
+	Scenario            PayoffPlan      `json:"scenario"`
+	TotalExtraPayment   decimal.Decimal `json:"totalExtraPayment"`
+	InterestSaved       decimal.Decimal `json:"interestSaved"`
+	MonthsSaved         int             `json:"monthsSaved"`
+	FinalRegularPayment decimal.Decimal `json:"finalRegularPayment"` // regular payment after the last prepayment
+}
+
+// InstallmentStatus is where an instalment of an installment plan stands.
+type InstallmentStatus string
+
+const (
+	InstallmentPaid     InstallmentStatus = "paid"
+	InstallmentDue      InstallmentStatus = "due"
+	InstallmentOverdue  InstallmentStatus = "overdue"
+	InstallmentUpcoming InstallmentStatus = "upcoming"
+)
+
+type Installment struct {
+	Number     int               `json:"number"`
+	DueDate    time.Time         `json:"dueDate"`
+	Amount     decimal.Decimal   `json:"amount"`
+	AmountPaid decimal.Decimal   `json:"amountPaid"`
+	Status     InstallmentStatus `json:"status"`
+}
+
+// InstallmentSchedule is the instalment schedule of an installment plan, with the
+// payments made so far applied to the earliest instalments.
+type InstallmentSchedule struct {
+	DebtID           uuid.UUID       `json:"debtId"`
+	Merchant         string          `json:"merchant"`
+	Currency         string          `json:"currency"`
+	PurchaseAmount   decimal.Decimal `json:"purchaseAmount"`
+	Fee              decimal.Decimal `json:"fee"`
+	TotalRepayable   decimal.Decimal `json:"totalRepayable"`
+	EffectiveAPR     decimal.Decimal `json:"effectiveApr"` // cost of the fee as an annual rate
+	InstallmentCount int             `json:"installmentCount"`
+	PaidCount        int             `json:"paidCount"`
+	RemainingCount   int             `json:"remainingCount"`
+	RemainingAmount  decimal.Decimal `json:"remainingAmount"`
+	NextDueDate      *time.Time      `json:"nextDueDate,omitempty"`
+	CardID           *uuid.UUID      `json:"cardId,omitempty"`
+	Installments     []Installment   `json:"installments"`
+}
+
+// RefinanceComparison compares keeping a debt with moving it to other banks' current rates
+type RefinanceComparison struct {
+	DebtID            uuid.UUID        `json:"debtId"`
+	Currency          string           `json:"currency"`
+	ProductType       string           `json:"productType"`
+	CurrentBalance    decimal.Decimal  `json:"currentBalance"`
+	CurrentRate       decimal.Decimal  `json:"currentRate"`
+	TermMonths        int              `json:"termMonths"`
+	SwitchingFee      decimal.Decimal  `json:"switchingFee"`
+	PrepaymentPenalty decimal.Decimal  `json:"prepaymentPenalty"`
+	Stay              RefinanceOption  `json:"stay"`
+	Offers            []RefinanceOffer `json:"offers"`
+}
+
+// RefinanceOption summarises the cost of repaying a debt on given terms
+type RefinanceOption struct {
+	Rate           decimal.Decimal `json:"rate"`
+	TermMonths     int             `json:"termMonths"`
+	MonthlyPayment decimal.Decimal `json:"monthlyPayment"`
+	TotalInterest  decimal.Decimal `json:"totalInterest"`
+	TotalPayment   decimal.Decimal `json:"totalPayment"`
+}
+
+// RefinanceOffer is the outcome of refinancing a debt at one bank's published rate
+type RefinanceOffer struct {
+	RefinanceOption
+	BankCode       string          `json:"bankCode"`
+	BankName       string          `json:"bankName"`
+	UpfrontCost    decimal.Decimal `json:"upfrontCost"` // switching fee plus prepayment penalty
+	TotalCost      decimal.Decimal `json:"totalCost"`   // total payment plus upfront cost
+	Savings        decimal.Decimal `json:"savings"`     // versus staying, negative when refinancing costs more
+	BreakEvenMonth *int            `json:"breakEvenMonth,omitempty"`
+	BreakEvenDate  *time.Time      `json:"breakEvenDate,omitempty"`
+}
+
+// DebtStrategy decides which debt receives the budget left after minimum payments.
+type DebtStrategy string
+
+const (
+	DebtStrategySnowball  DebtStrategy = "snowball"  // smallest balance first
+	DebtStrategyAvalanche DebtStrategy = "avalanche" // highest interest rate first
+	DebtStrategyCustom    DebtStrategy = "custom"    // user-defined order
+
+	// DebtStrategyMinimumOnly is the baseline that pays only each debt's minimum.
+	DebtStrategyMinimumOnly DebtStrategy = "minimum_only"
+)
+
+// DebtStrategyPlan is the result of simulating a multi-debt payoff strategy.
+type DebtStrategyPlan struct {
+	Strategy            DebtStrategy          `json:"strategy"`
+	Currency            string                `json:"currency"`
+	MonthlyBudget       decimal.Decimal       `json:"monthlyBudget"`
+	TotalMinimumPayment decimal.Decimal       `json:"totalMinimumPayment"`
+	TotalInterest       decimal.Decimal       `json:"totalInterest"`
+	TotalPayment        decimal.Decimal       `json:"totalPayment"`
+	MonthsToPayoff      int                   `json:"monthsToPayoff"`
+	PayoffDate          *time.Time            `json:"payoffDate,omitempty"`
+	Debts               []DebtStrategyResult  `json:"debts"`
+	Schedule            []DebtStrategyMonth   `json:"schedule"`
+	MinimumOnly         DebtStrategySummary   `json:"minimumOnly"`
+	InterestSaved       decimal.Decimal       `json:"interestSaved"`
+	MonthsSaved         int                   `json:"monthsSaved"`
+	Comparison          []DebtStrategySummary `json:"comparison"`
+}
+
+// DebtStrategyResult describes how a single debt is paid off under a strategy.
+type DebtStrategyResult struct {
+	DebtID          uuid.UUID       `json:"debtId"`
+	Name            string          `json:"name"`
+	Priority        int             `json:"priority"`
+	StartingBalance decimal.Decimal `json:"startingBalance"`
+	InterestRate    decimal.Decimal `json:"interestRate"`
+	MinimumPayment  decimal.Decimal `json:"minimumPayment"`
+	TotalInterest   decimal.Decimal `json:"totalInterest"`
+	TotalPayment    decimal.Decimal `json:"totalPayment"`
+	PayoffMonth     int             `json:"payoffMonth,omitempty"`
+	PayoffDate      *time.Time      `json:"payoffDate,omitempty"`
+}
+
+// DebtStrategyMonth is the allocation of the monthly budget across debts.
+type DebtStrategyMonth struct {
+	Month            int              `json:"month"`
+	Date             time.Time        `json:"date"`
+	Payment          decimal.Decimal  `json:"payment"`
+	Interest         decimal.Decimal  `json:"interest"`
+	RemainingBalance decimal.Decimal  `json:"remainingBalance"`
+	Allocations      []DebtAllocation `json:"allocations"`
+}
+
+type DebtAllocation struct {
+	DebtID           uuid.UUID       `json:"debtId"`
+	Payment          decimal.Decimal `json:"payment"`
+	Principal        decimal.Decimal `json:"principal"`
+	Interest         decimal.Decimal `json:"interest"`
+	RemainingBalance decimal.Decimal `json:"remainingBalance"`
+}
+
+// DebtStrategySummary holds the headline numbers of a simulated strategy.
+type DebtStrategySummary struct {
+	Strategy       DebtStrategy    `json:"strategy"`
+	TotalInterest  decimal.Decimal `json:"totalInterest"`
+	TotalPayment   decimal.Decimal `json:"totalPayment"`
+	MonthsToPayoff int             `json:"monthsToPayoff"`
+	PayoffDate     *time.Time      `json:"payoffDate,omitempty"`
+	PaidOff        bool            `json:"paidOff"`
+}
+
 // Dashboard aggregates
 type DashboardData struct {
 	TotalIncome        decimal.Decimal            `json:"totalIncome"`
@@ -151,6 +602,146 @@ type MonthlyComparison struct {
 	Expenses decimal.Decimal `json:"expenses"`
 }
 
+// Reports
+type ReportGroupBy string
+
+const (
+	ReportByDay      ReportGroupBy = "day"
+	ReportByWeek     ReportGroupBy = "week" // ISO weeks, starting on Monday
+	ReportByMonth    ReportGroupBy = "month"
+	ReportByQuarter  ReportGroupBy = "quarter"
+	ReportByYear     ReportGroupBy = "year"
+	ReportByCategory ReportGroupBy = "category"
+	ReportByTag      ReportGroupBy = "tag"
+	ReportByAccount  ReportGroupBy = "account" // credit card the transaction was charged to
+	ReportByCurrency ReportGroupBy = "currency"
+)
+
+// IsPeriod reports whether the grouping is by time period
+func (g ReportGroupBy) IsPeriod() bool {
+	switch g {
+	case ReportByDay, ReportByWeek, ReportByMonth, ReportByQuarter, ReportByYear:
+		return true
+	}
+	return false
+}
+
+type ReportMetric string
+
+const (
+	ReportSum     ReportMetric = "sum"
+	ReportCount   ReportMetric = "count"
+	ReportAverage ReportMetric = "average"
+)
+
+type ReportComparison string
+
+const (
+	CompareYearOverYear   ReportComparison = "yoy" // same range a year earlier
+	ComparePreviousPeriod ReportComparison = "pop" // range of the same length just before
+)
+
+// ReportRow is the aggregate of the transactions of one period and group in one currency
+type ReportRow struct {
+	Period   *time.Time      `db:"period"` // start of the period, nil without a period grouping
+	Key      string          `db:"key"`
+	Label    string          `db:"label"`
+	Currency string          `db:"currency"`
+	Sum      decimal.Decimal `db:"sum"`
+	Count    int             `db:"count"`
+	Average  decimal.Decimal `db:"average"`
+}
+
+// ReportValues holds the requested metrics; the others are omitted
+type ReportValues struct {
+	Sum     *decimal.Decimal `json:"sum,omitempty"`
+	Count   *int             `json:"count,omitempty"`
+	Average *decimal.Decimal `json:"average,omitempty"`
+}
+
+type ReportPoint struct {
+	Period *time.Time `json:"period,omitempty"` // start of the period, absent without a period grouping
+	ReportValues
+	Previous *ReportValues `json:"previous,omitempty"` // the matching period of the comparison range
+	Change   *ReportValues `json:"change,omitempty"`   // current minus previous
+}
+
+// ReportSeries is the time series of one group in one currency
+type ReportSeries struct {
+	Key      string        `json:"key,omitempty"`   // category, card ID or currency; empty for transactions on no card
+	Label    string        `json:"label,omitempty"` // card name when grouped by account
+	Currency string        `json:"currency"`
+	Points   []ReportPoint `json:"points"` // every period of the range, zero when empty
+	Total    ReportPoint   `json:"total"`
+}
+
+type Report struct {
+	Type         TransactionType  `json:"type"`
+	Start        time.Time        `json:"start"`
+	End          time.Time        `json:"end"`
+	GroupBy      []ReportGroupBy  `json:"groupBy"`
+	Metrics      []ReportMetric   `json:"metrics"`
+	Compare      ReportComparison `json:"compare,omitempty"`
+	CompareStart *time.Time       `json:"compareStart,omitempty"`
+	CompareEnd   *time.Time       `json:"compareEnd,omitempty"`
+	Series       []ReportSeries   `json:"series"`
+}
+
+// Net worth
+type NetWorthItemKind string
+
+const (
+	NetWorthSavingsGoal NetWorthItemKind = "savings_goal"
+	NetWorthTermDeposit NetWorthItemKind = "term_deposit" // deposit ladder not attached to a savings goal
+	NetWorthDebt        NetWorthItemKind = "debt"
+)
+
+// NetWorthItem is one asset or liability counted in a user's net worth
+type NetWorthItem struct {
+	Kind     NetWorthItemKind `db:"kind" json:"kind"`
+	ID       uuid.UUID        `db:"id" json:"id"`
+	Name     string           `db:"name" json:"name"`
+	Type     string           `db:"type" json:"type,omitempty"` // debt type of a liability
+	Currency string           `db:"currency" json:"currency"`
+	Amount   decimal.Decimal  `db:"amount" json:"amount"`
+}
+
+// IsLiability reports whether the item is owed rather than owned
+func (i NetWorthItem) IsLiability() bool {
+	return i.Kind == NetWorthDebt
+}
+
+// NetWorthSnapshot is a user's net worth in one currency on one day
+type NetWorthSnapshot struct {
+	UserID      uuid.UUID       `db:"user_id" json:"-"`
+	Date        time.Time       `db:"date" json:"date"`
+	Currency    string          `db:"currency" json:"currency"`
+	Assets      decimal.Decimal `db:"assets" json:"assets"`
+	Liabilities decimal.Decimal `db:"liabilities" json:"liabilities"`
+	NetWorth    decimal.Decimal `db:"net_worth" json:"netWorth"`
+}
+
+// NetWorthBreakdown is a user's current net worth and the assets and liabilities it is made of
+type NetWorthBreakdown struct {
+	Date        time.Time          `json:"date"`
+	Totals      []NetWorthSnapshot `json:"totals"` // one per currency
+	Assets      []NetWorthItem     `json:"assets"`
+	Liabilities []NetWorthItem     `json:"liabilities"`
+}
+
+// NetWorthSeries is the daily net worth of a user in one currency
+type NetWorthSeries struct {
+	Currency string             `json:"currency"`
+	Points   []NetWorthSnapshot `json:"points"` // days without a snapshot are absent
+	Change   decimal.Decimal    `json:"change"` // net worth of the last point less the first
+}
+
+type NetWorthHistory struct {
+	Start  time.Time        `json:"start"`
+	End    time.Time        `json:"end"`
+	Series []NetWorthSeries `json:"series"`
+}
+
 // Recurring Transactions
 type RecurringFrequency string
 
@@ -162,33 +753,131 @@ const (
 	FrequencyYearly   RecurringFrequency = "yearly"
 )
 
+// BusinessDayPolicy says where occurrences falling on a weekend or public holiday move to
+type BusinessDayPolicy string
+
+const (
+	BusinessDayKeep     BusinessDayPolicy = "keep"
+	BusinessDayPrevious BusinessDayPolicy = "previous"
+	BusinessDayNext     BusinessDayPolicy = "next"
+)
+
+// RecurringAmountType says how the amount of each occurrence is known
+type RecurringAmountType string
+
+const (
+	AmountFixed     RecurringAmountType = "fixed"     // generated as transactions
+	AmountEstimated RecurringAmountType = "estimated" // generated as drafts at the template amount
+	AmountAverage   RecurringAmountType = "average"   // generated as drafts at the average of recent confirmed amounts
+)
+
 type RecurringTransaction struct {
-	ID             uuid.UUID          `db:"id" json:"id"`
-	UserID         uuid.UUID          `db:"user_id" json:"userId"`
-	Type           TransactionType    `db:"type" json:"type"`
-	Amount         decimal.Decimal    `db:"amount" json:"amount"`
-	Currency       string             `db:"currency" json:"currency"`
-	Category       string             `db:"category" json:"category"`
-	Description    string             `db:"description" json:"description"`
-	Frequency      RecurringFrequency `db:"frequency" json:"frequency"`
-	StartDate      time.Time          `db:"start_date" json:"startDate"`
-	EndDate        *time.Time         `db:"end_date" json:"endDate,omitempty"`
-	NextOccurrence time.Time          `db:"next_occurrence" json:"nextOccurrence"`
-	LastGenerated  *time.Time         `db:"last_generated" json:"lastGenerated,omitempty"`
-	IsActive       bool               `db:"is_active" json:"isActive"`
-	CreatedAt      time.Time          `db:"created_at" json:"createdAt"`
-	UpdatedAt      time.Time          `db:"updated_at" json:"updatedAt"`
+	ID                uuid.UUID           `db:"id" json:"id"`
+	UserID            uuid.UUID           `db:"user_id" json:"userId"`
+	Type              TransactionType     `db:"type" json:"type"`
+	Amount            decimal.Decimal     `db:"amount" json:"amount"`
+	Currency          string              `db:"currency" json:"currency"`
+	Category          string              `db:"category" json:"category"`
+	Description       string              `db:"description" json:"description"`
+	Frequency         RecurringFrequency  `db:"frequency" json:"frequency"`
+	RRule             *string             `db:"rrule" json:"rrule,omitempty"`          // RFC 5545 rule; when set, Frequency is its closest shorthand
+	AnchorDay         *int                `db:"anchor_day" json:"anchorDay,omitempty"` // day of month of monthly and yearly shorthands, clamped to the month end
+	BusinessDayPolicy BusinessDayPolicy   `db:"business_day_policy" json:"businessDayPolicy"`
+	AmountType        RecurringAmountType `db:"amount_type" json:"amountType"`
+	GoalID            *uuid.UUID          `db:"goal_id" json:"goalId,omitempty"` // savings goal each occurrence contributes to
+	DebtID            *uuid.UUID          `db:"debt_id" json:"debtId,omitempty"` // debt each occurrence is a payment of
+	StartDate         time.Time           `db:"start_date" json:"startDate"`
+	EndDate           *time.Time          `db:"end_date" json:"endDate,omitempty"`
+	NextOccurrence    time.Time           `db:"next_occurrence" json:"nextOccurrence"`
+	LastGenerated     *time.Time          `db:"last_generated" json:"lastGenerated,omitempty"`
+	IsActive          bool                `db:"is_active" json:"isActive"`
+	CreatedAt         time.Time           `db:"created_at" json:"createdAt"`
+	UpdatedAt         time.Time           `db:"updated_at" json:"updatedAt"`
+}
+
+// IsVariable reports whether occurrences are generated as drafts awaiting confirmation
+func (rt *RecurringTransaction) IsVariable() bool {
+	return rt.AmountType == AmountEstimated || rt.AmountType == AmountAverage
+}
+
+// RecurringTransfer is the savings goal contribution or debt payment of a transaction
+// generated by a recurring transaction, recorded together with the transaction
+type RecurringTransfer struct {
+	Contribution *SavingsContribution
+	Payment      *DebtPayment
+	PaymentSplit DebtPaymentSplit // splits Payment against the locked debt
+}
+
+type RecurringDraftStatus string
+
+const (
+	DraftPending   RecurringDraftStatus = "pending"
+	DraftConfirmed RecurringDraftStatus = "confirmed"
+	DraftDismissed RecurringDraftStatus = "dismissed"
+)
+
+// RecurringDraft is an occurrence of a variable-amount recurring transaction awaiting
+// the user's confirmation of its actual amount
+type RecurringDraft struct {
+	ID             uuid.UUID            `db:"id" json:"id"`
+	RecurringID    uuid.UUID            `db:"recurring_id" json:"recurringId"`
+	UserID         uuid.UUID            `db:"user_id" json:"userId"`
+	Type           TransactionType      `db:"type" json:"type"`
+	Amount         decimal.Decimal      `db:"amount" json:"amount"` // estimated until confirmed
+	Currency       string               `db:"currency" json:"currency"`
+	Category       string               `db:"category" json:"category"`
+	Description    string               `db:"description" json:"description"`
+	OccurrenceDate time.Time            `db:"occurrence_date" json:"occurrenceDate"`
+	DueDate        time.Time            `db:"due_date" json:"dueDate"` // the occurrence date, or the day it was moved to
+	Status         RecurringDraftStatus `db:"status" json:"status"`
+	TransactionID  *uuid.UUID           `db:"transaction_id" json:"transactionId,omitempty"`
+	CreatedAt      time.Time            `db:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time            `db:"updated_at" json:"updatedAt"`
+}
+
+// RecurringException changes a single occurrence of a recurring transaction: skips it,
+// moves it to another day or overrides its amount
+type RecurringException struct {
+	ID             uuid.UUID        `db:"id" json:"id"`
+	RecurringID    uuid.UUID        `db:"recurring_id" json:"recurringId"`
+	OccurrenceDate time.Time        `db:"occurrence_date" json:"occurrenceDate"` // the scheduled occurrence
+	Skip           bool             `db:"skip" json:"skip"`
+	MovedTo        *time.Time       `db:"moved_to" json:"movedTo,omitempty"`
+	Amount         *decimal.Decimal `db:"amount" json:"amount,omitempty"`
+	CreatedAt      time.Time        `db:"created_at" json:"createdAt"`
+	UpdatedAt      time.Time        `db:"updated_at" json:"updatedAt"`
 }
 
 // UpcomingBill is a simplified view for dashboard widget
 type UpcomingBill struct {
-	ID          uuid.UUID       `json:"id"`
-	Description string          `json:"description"`
-	Amount      decimal.Decimal `json:"amount"`
-	Currency    string          `json:"currency"`
-	Category    string          `json:"category"`
-	DueDate     time.Time       `json:"dueDate"`
-	Type        TransactionType `json:"type"`
+	ID              uuid.UUID        `json:"id"`
+	Description     string           `json:"description"`
+	Amount          decimal.Decimal  `json:"amount"`
+	Currency        string           `json:"currency"`
+	Category        string           `json:"category"`
+	DueDate         time.Time        `json:"dueDate"`
+	Type            TransactionType  `json:"type"`
+	Skipped         bool             `json:"skipped,omitempty"`         // skipped by an exception, shown so the skip is visible
+	ScheduledDate   *time.Time       `json:"scheduledDate,omitempty"`   // the day it was moved from
+	ScheduledAmount *decimal.Decimal `json:"scheduledAmount,omitempty"` // the usual amount when overridden
+}
+
+// CalendarFeed is a user's secret-token iCalendar feed of bills and due dates
+type CalendarFeed struct {
+	UserID    uuid.UUID `db:"user_id" json:"userId"`
+	Token     string    `db:"token" json:"token"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	URL       string    `db:"-" json:"url,omitempty"` // subscription URL of the feed
+}
+
+// JobRun is one run of a scheduled background job
+type JobRun struct {
+	ID         uuid.UUID `db:"id" json:"id"`
+	Job        string    `db:"job" json:"job"`
+	StartedAt  time.Time `db:"started_at" json:"startedAt"`
+	FinishedAt time.Time `db:"finished_at" json:"finishedAt"`
+	Processed  int       `db:"processed" json:"processed"`
+	Error      *string   `db:"error" json:"error,omitempty"`
 }
 
 // Categories
@@ -207,6 +896,7 @@ var ExpenseCategories = []string{
 	"Gifts & Donations",
 	"Investments",
 	"Debt Payments",
+	"Debt Interest",
 	"Other",
 }
 
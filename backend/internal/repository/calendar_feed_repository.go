@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+var ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+
+type CalendarFeedRepository struct {
+	db *sqlx.DB
+}
+
+func NewCalendarFeedRepository(db *sqlx.DB) *CalendarFeedRepository {
+	return &CalendarFeedRepository{db: db}
+}
+
+func (r *CalendarFeedRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*model.CalendarFeed, error) {
+	var feed model.CalendarFeed
+	err := r.db.GetContext(ctx, &feed, `SELECT * FROM calendar_feeds WHERE user_id = $1`, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCalendarFeedNotFound
+	}
+	return &feed, err
+}
+
+func (r *CalendarFeedRepository) GetByToken(ctx context.Context, token string) (*model.CalendarFeed, error) {
+	var feed model.CalendarFeed
+	err := r.db.GetContext(ctx, &feed, `SELECT * FROM calendar_feeds WHERE token = $1`, token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCalendarFeedNotFound
+	}
+	return &feed, err
+}
+
+// Save stores the user's feed, replacing the token of an existing one
+func (r *CalendarFeedRepository) Save(ctx context.Context, feed *model.CalendarFeed) error {
+	query := `
+		INSERT INTO calendar_feeds (user_id, token, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET token = EXCLUDED.token, created_at = NOW()
+		RETURNING created_at`
+	return r.db.QueryRowxContext(ctx, query, feed.UserID, feed.Token).Scan(&feed.CreatedAt)
+}
+
+func (r *CalendarFeedRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM calendar_feeds WHERE user_id = $1`, userID)
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -11,7 +12,12 @@ import (
 	"github.com/wealthpath/backend/internal/model"
 )
 
-var ErrDebtNotFound = errors.New("debt not found")
+var (
+	ErrDebtNotFound         = errors.New("debt not found")
+	ErrDebtPaymentNotFound  = errors.New("debt payment not found")
+	ErrDebtPaymentNotLatest = errors.New("only a debt's latest payment can be corrected, and not to a date before the payment preceding it")
+	ErrStatementNotFound    = errors.New("credit card statement not found")
+)
 
 type DebtRepository struct {
 	db *sqlx.DB
@@ -23,14 +29,19 @@ func NewDebtRepository(db *sqlx.DB) *DebtRepository {
 
 func (r *DebtRepository) Create(ctx context.Context, debt *model.Debt) error {
 	query := `
-		INSERT INTO debts (id, user_id, name, type, original_amount, current_balance, interest_rate, minimum_payment, currency, due_day, start_date, expected_payoff, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW(), NOW())
+		INSERT INTO debts (id, user_id, name, type, original_amount, current_balance, interest_rate, minimum_payment, currency, due_day, start_date, expected_payoff,
+			term_months, fixed_rate_months, rate_margin, reference_bank_code, reference_term_months, rate_reset_months, interest_method,
+			statement_day, grace_period_days, minimum_payment_percent, merchant, installment_fee, card_id, credit_limit, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, NOW(), NOW())
 		RETURNING created_at, updated_at`
 
 	debt.ID = uuid.New()
 	return r.db.QueryRowxContext(ctx, query,
 		debt.ID, debt.UserID, debt.Name, debt.Type, debt.OriginalAmount, debt.CurrentBalance,
 		debt.InterestRate, debt.MinimumPayment, debt.Currency, debt.DueDay, debt.StartDate, debt.ExpectedPayoff,
+		debt.TermMonths, debt.FixedRateMonths, debt.RateMargin, debt.ReferenceBankCode, debt.ReferenceTermMonths, debt.RateResetMonths,
+		debt.InterestMethod, debt.StatementDay, debt.GracePeriodDays, debt.MinimumPaymentPercent,
+		debt.Merchant, debt.InstallmentFee, debt.CardID, debt.CreditLimit,
 	).Scan(&debt.CreatedAt, &debt.UpdatedAt)
 }
 
@@ -55,13 +66,20 @@ func (r *DebtRepository) Update(ctx context.Context, debt *model.Debt) error {
 	query := `
 		UPDATE debts 
 		SET name = $2, type = $3, original_amount = $4, current_balance = $5, interest_rate = $6, 
-			minimum_payment = $7, currency = $8, due_day = $9, start_date = $10, expected_payoff = $11, updated_at = NOW()
+			minimum_payment = $7, currency = $8, due_day = $9, start_date = $10, expected_payoff = $11,
+			term_months = $13, fixed_rate_months = $14, rate_margin = $15, reference_bank_code = $16,
+			reference_term_months = $17, rate_reset_months = $18, interest_method = $19,
+			statement_day = $20, grace_period_days = $21, minimum_payment_percent = $22,
+			merchant = $23, installment_fee = $24, card_id = $25, credit_limit = $26, updated_at = NOW()
 		WHERE id = $1 AND user_id = $12
 		RETURNING updated_at`
 	result := r.db.QueryRowxContext(ctx, query,
 		debt.ID, debt.Name, debt.Type, debt.OriginalAmount, debt.CurrentBalance,
 		debt.InterestRate, debt.MinimumPayment, debt.Currency, debt.DueDay,
 		debt.StartDate, debt.ExpectedPayoff, debt.UserID,
+		debt.TermMonths, debt.FixedRateMonths, debt.RateMargin, debt.ReferenceBankCode, debt.ReferenceTermMonths, debt.RateResetMonths,
+		debt.InterestMethod, debt.StatementDay, debt.GracePeriodDays, debt.MinimumPaymentPercent,
+		debt.Merchant, debt.InstallmentFee, debt.CardID, debt.CreditLimit,
 	)
 	return result.Scan(&debt.UpdatedAt)
 }
@@ -82,41 +100,344 @@ func (r *DebtRepository) Delete(ctx context.Context, id uuid.UUID, userID uuid.U
 	return nil
 }
 
-func (r *DebtRepository) RecordPayment(ctx context.Context, payment *model.DebtPayment) error {
+// RecordPayment inserts the payment and its expense transactions and reduces the debt
+// balance by its principal in one transaction. The debt row is locked so concurrent
+// payments cannot interleave, and split divides the payment against the locked balance.
+func (r *DebtRepository) RecordPayment(ctx context.Context, payment *model.DebtPayment, split model.DebtPaymentSplit) error {
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := recordPayment(ctx, tx, payment, split); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// recordPayment splits a payment against the locked debt, stores it with its expenses and
+// takes its principal off the debt balance within tx.
+func recordPayment(ctx context.Context, tx *sqlx.Tx, payment *model.DebtPayment, split model.DebtPaymentSplit) error {
+	debt, err := getLockedDebt(ctx, tx, payment.DebtID)
+	if err != nil {
+		return err
+	}
+	if err := split(debt, payment); err != nil {
+		return err
+	}
+
 	// Record the payment
 	paymentQuery := `
-		INSERT INTO debt_payments (id, debt_id, amount, principal, interest, date, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, NOW())`
+		INSERT INTO debt_payments (id, debt_id, amount, principal, interest, date, split_interest, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING created_at`
 
 	payment.ID = uuid.New()
-	_, err = tx.ExecContext(ctx, paymentQuery,
+	err = tx.QueryRowxContext(ctx, paymentQuery,
 		payment.ID, payment.DebtID, payment.Amount, payment.Principal, payment.Interest, payment.Date,
-	)
+		payment.SplitInterest,
+	).Scan(&payment.CreatedAt)
 	if err != nil {
 		return err
 	}
 
+	for i := range payment.Transactions {
+		if err := insertPaymentTransaction(ctx, tx, payment.ID, &payment.Transactions[i]); err != nil {
+			return err
+		}
+	}
+
 	// Update the debt balance
 	updateQuery := `UPDATE debts SET current_balance = current_balance - $2, updated_at = NOW() WHERE id = $1`
 	_, err = tx.ExecContext(ctx, updateQuery, payment.DebtID, payment.Principal)
+	return err
+}
+
+// GetPayments returns a debt's payments, newest first, with running principal and
+// interest totals accumulated in date order.
+func (r *DebtRepository) GetPayments(ctx context.Context, debtID uuid.UUID) ([]model.DebtPaymentRecord, error) {
+	var payments []model.DebtPaymentRecord
+	query := `
+		SELECT *,
+			SUM(principal) OVER w AS cumulative_principal,
+			SUM(interest) OVER w AS cumulative_interest
+		FROM debt_payments
+		WHERE debt_id = $1
+		WINDOW w AS (ORDER BY date, created_at, id ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)
+		ORDER BY date DESC, created_at DESC, id DESC`
+	err := r.db.SelectContext(ctx, &payments, query, debtID)
+	return payments, err
+}
+
+// GetPayment returns a payment with the expense transactions recording it.
+func (r *DebtRepository) GetPayment(ctx context.Context, debtID, paymentID uuid.UUID) (*model.DebtPayment, error) {
+	var payment model.DebtPayment
+	query := `SELECT * FROM debt_payments WHERE id = $1 AND debt_id = $2`
+	err := r.db.GetContext(ctx, &payment, query, paymentID, debtID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDebtPaymentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.SelectContext(ctx, &payment.Transactions,
+		`SELECT * FROM transactions WHERE debt_payment_id = $1 ORDER BY category`, paymentID)
+	return &payment, err
+}
+
+// UpdatePayment corrects a debt's latest payment, applies the difference in principal to
+// the debt balance and brings its expense transactions in line, in one transaction. The
+// debt row is locked and split divides the payment against the balance as it was before
+// it. Returns ErrDebtPaymentNotLatest for an older payment or a date before the payment
+// preceding it. Transactions without an ID are inserted and those whose amount dropped to
+// zero are removed.
+func (r *DebtRepository) UpdatePayment(ctx context.Context, payment *model.DebtPayment, split model.DebtPaymentSplit) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer func() { _ = tx.Rollback() }()
+
+	debt, err := getLockedDebt(ctx, tx, payment.DebtID)
+	if err != nil {
+		return err
+	}
+
+	var latest []struct {
+		ID        uuid.UUID       `db:"id"`
+		Principal decimal.Decimal `db:"principal"`
+		Date      time.Time       `db:"date"`
+	}
+	err = tx.SelectContext(ctx, &latest, `
+		SELECT id, principal, date FROM debt_payments WHERE debt_id = $1
+		ORDER BY date DESC, created_at DESC, id DESC
+		LIMIT 2`, payment.DebtID)
+	if err != nil {
+		return err
+	}
+	if len(latest) == 0 {
+		return ErrDebtPaymentNotFound
+	}
+	if latest[0].ID != payment.ID || (len(latest) > 1 && payment.Date.Before(latest[1].Date)) {
+		return ErrDebtPaymentNotLatest
+	}
+
+	// Only the latest payment came after the current balance was reached.
+	previous := latest[0].Principal
+	debt.CurrentBalance = debt.CurrentBalance.Add(previous)
+	if err := split(debt, payment); err != nil {
+		return err
+	}
+
+	paymentQuery := `
+		UPDATE debt_payments SET amount = $3, principal = $4, interest = $5, date = $6
+		WHERE id = $1 AND debt_id = $2`
+	_, err = tx.ExecContext(ctx, paymentQuery,
+		payment.ID, payment.DebtID, payment.Amount, payment.Principal, payment.Interest, payment.Date,
+	)
+	if err != nil {
+		return err
+	}
+
+	updateQuery := `UPDATE debts SET current_balance = current_balance + $2 - $3, updated_at = NOW() WHERE id = $1`
+	_, err = tx.ExecContext(ctx, updateQuery, payment.DebtID, previous, payment.Principal)
+	if err != nil {
+		return err
+	}
+
+	transactions := payment.Transactions[:0]
+	for _, t := range payment.Transactions {
+		switch {
+		case t.ID == uuid.Nil:
+			if !t.Amount.IsPositive() {
+				continue
+			}
+			if err := insertPaymentTransaction(ctx, tx, payment.ID, &t); err != nil {
+				return err
+			}
+		case !t.Amount.IsPositive():
+			if _, err := tx.ExecContext(ctx,
+				`DELETE FROM transactions WHERE id = $1 AND debt_payment_id = $2`, t.ID, payment.ID); err != nil {
+				return err
+			}
+			continue
+		default:
+			err := tx.QueryRowxContext(ctx, `
+				UPDATE transactions SET amount = $3, date = $4, updated_at = NOW()
+				WHERE id = $1 AND debt_payment_id = $2
+				RETURNING updated_at`,
+				t.ID, payment.ID, t.Amount, t.Date,
+			).Scan(&t.UpdatedAt)
+			if err != nil {
+				return err
+			}
+		}
+		transactions = append(transactions, t)
+	}
+	payment.Transactions = transactions
 
 	return tx.Commit()
 }
 
-func (r *DebtRepository) GetPayments(ctx context.Context, debtID uuid.UUID) ([]model.DebtPayment, error) {
-	var payments []model.DebtPayment
-	query := `SELECT * FROM debt_payments WHERE debt_id = $1 ORDER BY date DESC`
-	err := r.db.SelectContext(ctx, &payments, query, debtID)
-	return payments, err
+// DeletePayment reverses a payment, adding its principal back to the debt balance and
+// removing its expense transactions, in one transaction.
+func (r *DebtRepository) DeletePayment(ctx context.Context, debtID, paymentID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := lockDebt(ctx, tx, debtID); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM transactions
+		WHERE debt_payment_id = (SELECT id FROM debt_payments WHERE id = $1 AND debt_id = $2)`, paymentID, debtID)
+	if err != nil {
+		return err
+	}
+
+	var principal decimal.Decimal
+	err = tx.GetContext(ctx, &principal,
+		`DELETE FROM debt_payments WHERE id = $1 AND debt_id = $2 RETURNING principal`, paymentID, debtID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrDebtPaymentNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	updateQuery := `UPDATE debts SET current_balance = current_balance + $2, updated_at = NOW() WHERE id = $1`
+	_, err = tx.ExecContext(ctx, updateQuery, debtID, principal)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListStatementCards returns every user's credit cards billed in statement cycles.
+func (r *DebtRepository) ListStatementCards(ctx context.Context) ([]model.Debt, error) {
+	var debts []model.Debt
+	query := `SELECT * FROM debts WHERE type = $1 AND statement_day IS NOT NULL ORDER BY id`
+	err := r.db.SelectContext(ctx, &debts, query, model.DebtTypeCreditCard)
+	return debts, err
+}
+
+// ListStatements returns a credit card's closed statements, newest first.
+func (r *DebtRepository) ListStatements(ctx context.Context, debtID uuid.UUID) ([]model.CreditCardStatement, error) {
+	var statements []model.CreditCardStatement
+	query := `SELECT * FROM credit_card_statements WHERE debt_id = $1 ORDER BY period_end DESC`
+	err := r.db.SelectContext(ctx, &statements, query, debtID)
+	return statements, err
+}
+
+func (r *DebtRepository) GetStatement(ctx context.Context, debtID, statementID uuid.UUID) (*model.CreditCardStatement, error) {
+	var statement model.CreditCardStatement
+	query := `SELECT * FROM credit_card_statements WHERE id = $1 AND debt_id = $2`
+	err := r.db.GetContext(ctx, &statement, query, statementID, debtID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrStatementNotFound
+	}
+	return &statement, err
+}
+
+// CloseStatement stores a closed statement and adds its interest to the card balance
+// in one transaction. A cycle that is already closed is left untouched, so concurrent
+// callers cannot charge its interest twice.
+func (r *DebtRepository) CloseStatement(ctx context.Context, statement *model.CreditCardStatement) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := lockDebt(ctx, tx, statement.DebtID); err != nil {
+		return err
+	}
+
+	statementQuery := `
+		INSERT INTO credit_card_statements (id, debt_id, period_start, period_end, due_date, opening_balance,
+			purchases, payments, interest, closing_balance, minimum_due, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
+		ON CONFLICT (debt_id, period_end) DO NOTHING
+		RETURNING created_at`
+
+	statement.ID = uuid.New()
+	err = tx.QueryRowxContext(ctx, statementQuery,
+		statement.ID, statement.DebtID, statement.PeriodStart, statement.PeriodEnd, statement.DueDate,
+		statement.OpeningBalance, statement.Purchases, statement.Payments, statement.Interest,
+		statement.ClosingBalance, statement.MinimumDue,
+	).Scan(&statement.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if statement.Interest.IsPositive() {
+		updateQuery := `UPDATE debts SET current_balance = current_balance + $2, updated_at = NOW() WHERE id = $1`
+		if _, err := tx.ExecContext(ctx, updateQuery, statement.DebtID, statement.Interest); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetCardTransactions returns the expenses charged to a credit card after from, up to
+// and including to when given, in date order.
+func (r *DebtRepository) GetCardTransactions(ctx context.Context, debtID uuid.UUID, from time.Time, to *time.Time) ([]model.Transaction, error) {
+	var transactions []model.Transaction
+	query := `
+		SELECT * FROM transactions
+		WHERE debt_id = $1 AND date > $2
+		AND ($3::date IS NULL OR date <= $3)
+		ORDER BY date, created_at`
+	err := r.db.SelectContext(ctx, &transactions, query, debtID, from, to)
+	return transactions, err
+}
+
+// insertPaymentTransaction inserts an expense transaction recording a debt payment.
+func insertPaymentTransaction(ctx context.Context, tx *sqlx.Tx, paymentID uuid.UUID, t *model.Transaction) error {
+	query := `
+		INSERT INTO transactions (id, user_id, type, amount, currency, category, description, date, debt_payment_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+		RETURNING created_at, updated_at`
+
+	t.ID = uuid.New()
+	t.DebtPaymentID = &paymentID
+	return tx.QueryRowxContext(ctx, query,
+		t.ID, t.UserID, t.Type, t.Amount, t.Currency, t.Category, t.Description, t.Date, t.DebtPaymentID,
+	).Scan(&t.CreatedAt, &t.UpdatedAt)
+}
+
+// getLockedDebt reads the debt under a row lock held for the rest of the transaction.
+func getLockedDebt(ctx context.Context, tx *sqlx.Tx, debtID uuid.UUID) (*model.Debt, error) {
+	var debt model.Debt
+	err := tx.GetContext(ctx, &debt, `SELECT * FROM debts WHERE id = $1 FOR UPDATE`, debtID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDebtNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &debt, nil
+}
+
+// lockDebt takes a row lock on the debt for the rest of the transaction.
+func lockDebt(ctx context.Context, tx *sqlx.Tx, debtID uuid.UUID) error {
+	var id uuid.UUID
+	err := tx.GetContext(ctx, &id, `SELECT id FROM debts WHERE id = $1 FOR UPDATE`, debtID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrDebtNotFound
+	}
+	return err
 }
 
 func (r *DebtRepository) GetTotalDebt(ctx context.Context, userID uuid.UUID) (decimal.Decimal, error) {
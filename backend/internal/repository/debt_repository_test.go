@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+func TestDebtRepository_UpdatePayment(t *testing.T) {
+	t.Parallel()
+
+	march := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC)
+	paymentID := uuid.New()
+
+	tests := []struct {
+		name    string
+		latest  uuid.UUID
+		date    time.Time
+		wantErr error
+	}{
+		{name: "latest payment", latest: paymentID, date: march},
+		{name: "older payment", latest: uuid.New(), date: march, wantErr: ErrDebtPaymentNotLatest},
+		{name: "moved before previous payment", latest: paymentID, date: february.AddDate(0, 0, -1), wantErr: ErrDebtPaymentNotLatest},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock := newMockDB(t)
+			defer func() { _ = db.Close() }()
+			repo := NewDebtRepository(db)
+
+			debtID := uuid.New()
+			payment := &model.DebtPayment{ID: paymentID, DebtID: debtID, Amount: decimal.NewFromInt(1000), Date: tt.date}
+
+			// The balance read under the lock, 9000, is after the 500 principal of the payment.
+			var splitBalance decimal.Decimal
+			split := func(debt *model.Debt, payment *model.DebtPayment) error {
+				splitBalance = debt.CurrentBalance
+				payment.Interest = decimal.NewFromInt(95)
+				payment.Principal = payment.Amount.Sub(payment.Interest)
+				return nil
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(`SELECT \* FROM debts WHERE id = \$1 FOR UPDATE`).
+				WithArgs(debtID).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "current_balance"}).AddRow(debtID, "9000"))
+			mock.ExpectQuery(`SELECT id, principal, date FROM debt_payments WHERE debt_id = \$1`).
+				WithArgs(debtID).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "principal", "date"}).
+					AddRow(tt.latest, "500", march).
+					AddRow(uuid.New(), "450", february))
+			if tt.wantErr == nil {
+				mock.ExpectExec(`UPDATE debt_payments SET amount = \$3`).
+					WithArgs(paymentID, debtID, payment.Amount, decimal.NewFromInt(905), decimal.NewFromInt(95), tt.date).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE debts SET current_balance = current_balance \+ \$2 - \$3`).
+					WithArgs(debtID, decimal.NewFromInt(500), decimal.NewFromInt(905)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			err := repo.UpdatePayment(context.Background(), payment, split)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, splitBalance.Equal(decimal.NewFromInt(9500)), "split against %s", splitBalance)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+var ErrDepositLadderNotFound = errors.New("deposit ladder not found")
+
+type DepositLadderRepository struct {
+	db *sqlx.DB
+}
+
+func NewDepositLadderRepository(db *sqlx.DB) *DepositLadderRepository {
+	return &DepositLadderRepository{db: db}
+}
+
+// Create stores a ladder and its rungs in a single transaction
+func (r *DepositLadderRepository) Create(ctx context.Context, ladder *model.DepositLadder) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	ladderQuery := `
+		INSERT INTO deposit_ladders (id, user_id, goal_id, amount, currency, horizon_months, start_date,
+			total_interest_simple, total_interest_compound, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+		RETURNING created_at, updated_at`
+
+	ladder.ID = uuid.New()
+	err = tx.QueryRowxContext(ctx, ladderQuery,
+		ladder.ID, ladder.UserID, ladder.GoalID, ladder.Amount, ladder.Currency, ladder.HorizonMonths,
+		ladder.StartDate, ladder.TotalInterestSimple, ladder.TotalInterestCompound,
+	).Scan(&ladder.CreatedAt, &ladder.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	rungQuery := `
+		INSERT INTO deposit_ladder_rungs (id, ladder_id, position, bank_code, bank_name, term_months, rate,
+			amount, rollovers, interest_simple, interest_compound, final_maturity_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+	for i := range ladder.Rungs {
+		rung := &ladder.Rungs[i]
+		rung.ID = uuid.New()
+		rung.LadderID = ladder.ID
+		_, err := tx.ExecContext(ctx, rungQuery,
+			rung.ID, rung.LadderID, rung.Position, rung.BankCode, rung.BankName, rung.TermMonths, rung.Rate,
+			rung.Amount, rung.Rollovers, rung.InterestSimple, rung.InterestCompound, rung.FinalMaturityDate,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetByID returns a ladder with its rungs
+func (r *DepositLadderRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.DepositLadder, error) {
+	var ladder model.DepositLadder
+	query := `SELECT * FROM deposit_ladders WHERE id = $1`
+	err := r.db.GetContext(ctx, &ladder, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDepositLadderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ladder.Rungs, err = r.listRungs(ctx, ladder.ID); err != nil {
+		return nil, err
+	}
+	return &ladder, nil
+}
+
+// List returns a user's ladders with their rungs, optionally limited to one savings goal
+func (r *DepositLadderRepository) List(ctx context.Context, userID uuid.UUID, goalID *uuid.UUID) ([]model.DepositLadder, error) {
+	var ladders []model.DepositLadder
+	query := `SELECT * FROM deposit_ladders WHERE user_id = $1`
+	args := []interface{}{userID}
+	if goalID != nil {
+		query += ` AND goal_id = $2`
+		args = append(args, *goalID)
+	}
+	query += ` ORDER BY created_at DESC`
+
+	if err := r.db.SelectContext(ctx, &ladders, query, args...); err != nil {
+		return nil, err
+	}
+
+	for i := range ladders {
+		rungs, err := r.listRungs(ctx, ladders[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		ladders[i].Rungs = rungs
+	}
+	return ladders, nil
+}
+
+// UpdateGoal attaches a ladder to a savings goal, or detaches it when goalID is nil
+func (r *DepositLadderRepository) UpdateGoal(ctx context.Context, id, userID uuid.UUID, goalID *uuid.UUID) error {
+	query := `UPDATE deposit_ladders SET goal_id = $3, updated_at = NOW() WHERE id = $1 AND user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, id, userID, goalID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrDepositLadderNotFound
+	}
+	return nil
+}
+
+func (r *DepositLadderRepository) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	query := `DELETE FROM deposit_ladders WHERE id = $1 AND user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrDepositLadderNotFound
+	}
+	return nil
+}
+
+func (r *DepositLadderRepository) listRungs(ctx context.Context, ladderID uuid.UUID) ([]model.DepositLadderRung, error) {
+	var rungs []model.DepositLadderRung
+	query := `SELECT * FROM deposit_ladder_rungs WHERE ladder_id = $1 ORDER BY position ASC`
+	err := r.db.SelectContext(ctx, &rungs, query, ladderID)
+	return rungs, err
+}
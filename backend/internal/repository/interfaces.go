@@ -50,7 +50,16 @@ type DebtRepositoryInterface interface {
 	List(ctx context.Context, userID uuid.UUID) ([]model.Debt, error)
 	Update(ctx context.Context, debt *model.Debt) error
 	Delete(ctx context.Context, id, userID uuid.UUID) error
-	RecordPayment(ctx context.Context, payment *model.DebtPayment) error
+	RecordPayment(ctx context.Context, payment *model.DebtPayment, split model.DebtPaymentSplit) error
+	GetPayments(ctx context.Context, debtID uuid.UUID) ([]model.DebtPaymentRecord, error)
+	GetPayment(ctx context.Context, debtID, paymentID uuid.UUID) (*model.DebtPayment, error)
+	UpdatePayment(ctx context.Context, payment *model.DebtPayment, split model.DebtPaymentSplit) error
+	DeletePayment(ctx context.Context, debtID, paymentID uuid.UUID) error
+	ListStatementCards(ctx context.Context) ([]model.Debt, error)
+	ListStatements(ctx context.Context, debtID uuid.UUID) ([]model.CreditCardStatement, error)
+	GetStatement(ctx context.Context, debtID, statementID uuid.UUID) (*model.CreditCardStatement, error)
+	CloseStatement(ctx context.Context, statement *model.CreditCardStatement) error
+	GetCardTransactions(ctx context.Context, debtID uuid.UUID, from time.Time, to *time.Time) ([]model.Transaction, error)
 	GetTotalDebt(ctx context.Context, userID uuid.UUID) (decimal.Decimal, error)
 }
 
@@ -62,6 +71,8 @@ type SavingsGoalRepositoryInterface interface {
 	Update(ctx context.Context, goal *model.SavingsGoal) error
 	Delete(ctx context.Context, id, userID uuid.UUID) error
 	AddContribution(ctx context.Context, id, userID uuid.UUID, amount decimal.Decimal) error
+	ListContributions(ctx context.Context, goalID uuid.UUID) ([]model.SavingsContribution, error)
+	RecordContribution(ctx context.Context, contribution *model.SavingsContribution) error
 	GetTotalSavings(ctx context.Context, userID uuid.UUID) (decimal.Decimal, error)
 }
 
@@ -72,7 +83,12 @@ type RecurringRepositoryInterface interface {
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]model.RecurringTransaction, error)
 	Update(ctx context.Context, rt *model.RecurringTransaction) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	GetUpcoming(ctx context.Context, userID uuid.UUID, limit int) ([]model.UpcomingBill, error)
+	GetActiveByUserID(ctx context.Context, userID uuid.UUID) ([]model.RecurringTransaction, error)
 	GetDueTransactions(ctx context.Context, now time.Time) ([]model.RecurringTransaction, error)
-	UpdateLastGenerated(ctx context.Context, id uuid.UUID, lastGenerated, nextOccurrence time.Time) error
+	Advance(ctx context.Context, id uuid.UUID, lastGenerated, nextOccurrence time.Time) error
+	Deactivate(ctx context.Context, id uuid.UUID, nextOccurrence time.Time) error
+	SaveException(ctx context.Context, e *model.RecurringException) error
+	DeleteException(ctx context.Context, recurringID uuid.UUID, occurrence time.Time) error
+	ListExceptions(ctx context.Context, recurringID uuid.UUID) ([]model.RecurringException, error)
+	ListExceptionsByUser(ctx context.Context, userID uuid.UUID) ([]model.RecurringException, error)
 }
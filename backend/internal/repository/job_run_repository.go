@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+type JobRunRepository struct {
+	db *sqlx.DB
+}
+
+func NewJobRunRepository(db *sqlx.DB) *JobRunRepository {
+	return &JobRunRepository{db: db}
+}
+
+// TryLock takes the Postgres advisory lock of a job without waiting, so only one
+// replica runs the job at a time. The lock is held by a dedicated connection until
+// release is called; acquired is false when another replica holds it.
+func (r *JobRunRepository) TryLock(ctx context.Context, job string) (release func(), acquired bool, err error) {
+	conn, err := r.db.Connx(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("opening lock connection: %w", err)
+	}
+	if err := conn.GetContext(ctx, &acquired, `SELECT pg_try_advisory_lock(hashtext($1))`, job); err != nil {
+		_ = conn.Close()
+		return nil, false, fmt.Errorf("taking lock for job %s: %w", job, err)
+	}
+	if !acquired {
+		_ = conn.Close()
+		return nil, false, nil
+	}
+	return func() {
+		// Closing returns the connection to the pool with its session, and the lock, still
+		// open. When the unlock fails the connection is discarded instead, ending the session.
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock(hashtext($1))`, job); err != nil {
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		_ = conn.Close()
+	}, true, nil
+}
+
+// RecordRun stores the outcome of a job run
+func (r *JobRunRepository) RecordRun(ctx context.Context, run *model.JobRun) error {
+	query := `
+		INSERT INTO job_runs (id, job, started_at, finished_at, processed, error)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	run.ID = uuid.New()
+	_, err := r.db.ExecContext(ctx, query, run.ID, run.Job, run.StartedAt, run.FinishedAt, run.Processed, run.Error)
+	return err
+}
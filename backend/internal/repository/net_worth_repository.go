@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+type NetWorthRepository struct {
+	db *sqlx.DB
+}
+
+func NewNetWorthRepository(db *sqlx.DB) *NetWorthRepository {
+	return &NetWorthRepository{db: db}
+}
+
+// netWorthItems lists the assets and liabilities of every user: the savings goals they
+// own, their deposit ladders not attached to a goal, which the goal already counts, and
+// their debts. Members of a shared goal do not count it; its owner does.
+const netWorthItems = `
+	SELECT 'savings_goal' AS kind, id, user_id, name, '' AS type, currency, current_amount AS amount
+	FROM savings_goals
+	UNION ALL
+	SELECT 'term_deposit', id, user_id, 'Term deposits from ' || to_char(start_date, 'YYYY-MM-DD'), '', currency, amount
+	FROM deposit_ladders WHERE goal_id IS NULL
+	UNION ALL
+	SELECT 'debt', id, user_id, name, type, currency, current_balance
+	FROM debts`
+
+// Items returns a user's assets and liabilities with a non-zero amount, largest first
+func (r *NetWorthRepository) Items(ctx context.Context, userID uuid.UUID) ([]model.NetWorthItem, error) {
+	query := `
+		SELECT kind, id, name, type, currency, amount
+		FROM (` + netWorthItems + `) items
+		WHERE user_id = $1 AND amount <> 0
+		ORDER BY kind, currency, amount DESC, name`
+
+	var items []model.NetWorthItem
+	err := r.db.SelectContext(ctx, &items, query, userID)
+	return items, err
+}
+
+// SaveSnapshots replaces the snapshots of a day with the current net worth of every user
+// with assets or liabilities, one per user and currency, and returns how many it saved.
+func (r *NetWorthRepository) SaveSnapshots(ctx context.Context, date time.Time) (int, error) {
+	dbTx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = dbTx.Rollback() }()
+
+	if _, err := dbTx.ExecContext(ctx, `DELETE FROM net_worth_snapshots WHERE date = $1`, date); err != nil {
+		return 0, err
+	}
+	res, err := dbTx.ExecContext(ctx, `
+		INSERT INTO net_worth_snapshots (user_id, date, currency, assets, liabilities)
+		SELECT user_id, $1, currency,
+			COALESCE(SUM(amount) FILTER (WHERE kind <> 'debt'), 0),
+			COALESCE(SUM(amount) FILTER (WHERE kind = 'debt'), 0)
+		FROM (`+netWorthItems+`) items
+		GROUP BY user_id, currency
+		HAVING bool_or(amount <> 0)`, date)
+	if err != nil {
+		return 0, err
+	}
+	saved, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(saved), dbTx.Commit()
+}
+
+// ListSnapshots returns a user's snapshots between two days, inclusive, by currency and day
+func (r *NetWorthRepository) ListSnapshots(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]model.NetWorthSnapshot, error) {
+	query := `
+		SELECT user_id, date, currency, assets, liabilities, assets - liabilities AS net_worth
+		FROM net_worth_snapshots
+		WHERE user_id = $1 AND date >= $2 AND date <= $3
+		ORDER BY currency, date`
+
+	var snapshots []model.NetWorthSnapshot
+	err := r.db.SelectContext(ctx, &snapshots, query, userID, start, end)
+	return snapshots, err
+}
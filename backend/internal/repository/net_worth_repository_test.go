@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetWorthRepository_SaveSnapshots(t *testing.T) {
+	t.Parallel()
+
+	db, mock := newMockDB(t)
+	defer func() { _ = db.Close() }()
+	repo := NewNetWorthRepository(db)
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM net_worth_snapshots WHERE date = \$1`).
+		WithArgs(date).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(`INSERT INTO net_worth_snapshots .* FROM savings_goals .* FROM deposit_ladders WHERE goal_id IS NULL .* FROM debts`).
+		WithArgs(date).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectCommit()
+
+	saved, err := repo.SaveSnapshots(context.Background(), date)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, saved)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+var ErrRecurringDraftNotFound = errors.New("recurring draft not found")
+
+type RecurringDraftRepository struct {
+	db *sqlx.DB
+}
+
+func NewRecurringDraftRepository(db *sqlx.DB) *RecurringDraftRepository {
+	return &RecurringDraftRepository{db: db}
+}
+
+// Create inserts a draft for an occurrence of a variable-amount recurring transaction and
+// moves the recurring transaction on to its next occurrence in the same database
+// transaction. Each occurrence is drafted at most once: created is false when it already
+// was, and the recurring transaction is still moved on.
+func (r *RecurringDraftRepository) Create(ctx context.Context, draft *model.RecurringDraft, lastGenerated, nextOccurrence time.Time) (created bool, err error) {
+	dbTx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = dbTx.Rollback() }()
+
+	query := `
+		INSERT INTO recurring_drafts (id, recurring_id, user_id, type, amount, currency, category,
+			description, occurrence_date, due_date, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW(), NOW())
+		ON CONFLICT (recurring_id, occurrence_date) DO NOTHING
+		RETURNING created_at, updated_at`
+
+	draft.ID = uuid.New()
+	draft.Status = model.DraftPending
+	err = dbTx.QueryRowxContext(ctx, query,
+		draft.ID, draft.RecurringID, draft.UserID, draft.Type, draft.Amount, draft.Currency,
+		draft.Category, draft.Description, draft.OccurrenceDate, draft.DueDate, draft.Status,
+	).Scan(&draft.CreatedAt, &draft.UpdatedAt)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		draft.ID = uuid.Nil
+	case err != nil:
+		return false, err
+	default:
+		created = true
+	}
+
+	_, err = dbTx.ExecContext(ctx, `
+		UPDATE recurring_transactions
+		SET last_generated = $2, next_occurrence = $3, updated_at = NOW()
+		WHERE id = $1`, draft.RecurringID, lastGenerated, nextOccurrence)
+	if err != nil {
+		return false, err
+	}
+
+	return created, dbTx.Commit()
+}
+
+func (r *RecurringDraftRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.RecurringDraft, error) {
+	var draft model.RecurringDraft
+	err := r.db.GetContext(ctx, &draft, `SELECT * FROM recurring_drafts WHERE id = $1`, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrRecurringDraftNotFound
+	}
+	return &draft, err
+}
+
+// ListPending returns a user's drafts awaiting confirmation, oldest occurrence first
+func (r *RecurringDraftRepository) ListPending(ctx context.Context, userID uuid.UUID) ([]model.RecurringDraft, error) {
+	var drafts []model.RecurringDraft
+	query := `
+		SELECT * FROM recurring_drafts
+		WHERE user_id = $1 AND status = 'pending'
+		ORDER BY occurrence_date ASC, created_at ASC`
+	err := r.db.SelectContext(ctx, &drafts, query, userID)
+	return drafts, err
+}
+
+// Confirm inserts the transaction a pending draft becomes with its transfer into a
+// savings goal or debt, if any, and marks the draft confirmed with it in the same database
+// transaction. The draft is locked first; returns ErrRecurringDraftNotFound when it is no
+// longer pending, so a draft is confirmed at most once.
+func (r *RecurringDraftRepository) Confirm(ctx context.Context, draft *model.RecurringDraft, tx *model.Transaction, transfer *model.RecurringTransfer) error {
+	dbTx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dbTx.Rollback() }()
+
+	var id uuid.UUID
+	err = dbTx.GetContext(ctx, &id,
+		`SELECT id FROM recurring_drafts WHERE id = $1 AND status = 'pending' FOR UPDATE`, draft.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrRecurringDraftNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	// The transaction goes in first: the draft references it.
+	tx.ID = uuid.New()
+	err = dbTx.QueryRowxContext(ctx, `
+		INSERT INTO transactions (id, user_id, type, amount, currency, category, description, date,
+			recurring_id, occurrence_date, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW())
+		RETURNING created_at, updated_at`,
+		tx.ID, tx.UserID, tx.Type, tx.Amount, tx.Currency, tx.Category, tx.Description, tx.Date,
+		tx.RecurringID, tx.OccurrenceDate,
+	).Scan(&tx.CreatedAt, &tx.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if err := recordTransfer(ctx, dbTx, tx, transfer); err != nil {
+		return err
+	}
+
+	_, err = dbTx.ExecContext(ctx, `
+		UPDATE recurring_drafts
+		SET status = 'confirmed', amount = $2, transaction_id = $3, updated_at = NOW()
+		WHERE id = $1`, draft.ID, tx.Amount, tx.ID)
+	if err != nil {
+		return err
+	}
+	if err := dbTx.Commit(); err != nil {
+		return err
+	}
+
+	draft.Status = model.DraftConfirmed
+	draft.Amount = tx.Amount
+	draft.TransactionID = &tx.ID
+	return nil
+}
+
+// Dismiss marks a pending draft dismissed, for an occurrence that did not happen.
+// Returns ErrRecurringDraftNotFound when the draft is no longer pending.
+func (r *RecurringDraftRepository) Dismiss(ctx context.Context, id uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE recurring_drafts SET status = 'dismissed', updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrRecurringDraftNotFound
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+// sameArg matches the first value it sees and then only that value, so a test can check
+// that two statements use the same generated id.
+type sameArg struct{ value driver.Value }
+
+func (a *sameArg) Match(v driver.Value) bool {
+	if a.value == nil {
+		a.value = v
+		return true
+	}
+	return a.value == v
+}
+
+func TestRecurringDraftRepository_Confirm(t *testing.T) {
+	t.Parallel()
+
+	occurrence := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	goalID := uuid.New()
+
+	tests := []struct {
+		name     string
+		pending  bool
+		transfer *model.RecurringTransfer
+		wantErr  error
+	}{
+		{name: "pending draft", pending: true},
+		{
+			name:    "pending draft contributing to a goal",
+			pending: true,
+			transfer: &model.RecurringTransfer{Contribution: &model.SavingsContribution{
+				GoalID: goalID, UserID: uuid.New(), Amount: decimal.NewFromInt(912000), Date: occurrence,
+			}},
+		},
+		{name: "no longer pending", wantErr: ErrRecurringDraftNotFound},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock := newMockDB(t)
+			defer func() { _ = db.Close() }()
+			repo := NewRecurringDraftRepository(db)
+
+			draft := &model.RecurringDraft{ID: uuid.New(), RecurringID: uuid.New(), Status: model.DraftPending}
+			tx := &model.Transaction{
+				UserID:         uuid.New(),
+				Type:           model.TransactionTypeExpense,
+				Amount:         decimal.NewFromInt(912000),
+				Currency:       "VND",
+				Category:       "Utilities",
+				Description:    "Electricity (recurring)",
+				Date:           occurrence,
+				RecurringID:    &draft.RecurringID,
+				OccurrenceDate: &occurrence,
+			}
+
+			// The draft references the transaction, so the transaction and its transfer are
+			// inserted before the draft is marked confirmed. Expectations are matched in order.
+			rows := sqlmock.NewRows([]string{"id"})
+			if tt.pending {
+				rows.AddRow(draft.ID)
+			}
+			mock.ExpectBegin()
+			mock.ExpectQuery(`SELECT id FROM recurring_drafts WHERE id = \$1 AND status = 'pending' FOR UPDATE`).
+				WithArgs(draft.ID).
+				WillReturnRows(rows)
+			if tt.wantErr == nil {
+				txID := &sameArg{}
+				mock.ExpectQuery(`INSERT INTO transactions`).
+					WithArgs(txID, tx.UserID, tx.Type, tx.Amount, tx.Currency, tx.Category, tx.Description, tx.Date,
+						tx.RecurringID, tx.OccurrenceDate).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(occurrence, occurrence))
+				if tt.transfer != nil {
+					mock.ExpectExec(`UPDATE savings_goals`).
+						WithArgs(goalID, tt.transfer.Contribution.Amount).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectQuery(`INSERT INTO savings_contributions`).
+						WithArgs(sqlmock.AnyArg(), goalID, tt.transfer.Contribution.UserID, tt.transfer.Contribution.Amount,
+							occurrence, nil, txID).
+						WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(occurrence))
+				}
+				mock.ExpectExec(`UPDATE recurring_drafts\s+SET status = 'confirmed'.*WHERE id = \$1`).
+					WithArgs(draft.ID, tx.Amount, txID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			err := repo.Confirm(context.Background(), draft, tx, tt.transfer)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, model.DraftPending, draft.Status)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, model.DraftConfirmed, draft.Status)
+				assert.Equal(t, tx.ID, *draft.TransactionID)
+				assert.True(t, draft.Amount.Equal(tx.Amount))
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/wealthpath/backend/internal/model"
 )
 
+var ErrRecurringExceptionNotFound = errors.New("recurring exception not found")
+
 type RecurringRepository struct {
 	db *sqlx.DB
 }
@@ -22,14 +24,16 @@ func NewRecurringRepository(db *sqlx.DB) *RecurringRepository {
 func (r *RecurringRepository) Create(ctx context.Context, rt *model.RecurringTransaction) error {
 	query := `
 		INSERT INTO recurring_transactions (id, user_id, type, amount, currency, category, description, 
-			frequency, start_date, end_date, next_occurrence, is_active, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW(), NOW())
+			frequency, start_date, end_date, next_occurrence, is_active, rrule, amount_type, anchor_day,
+			business_day_policy, goal_id, debt_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, NOW(), NOW())
 		RETURNING created_at, updated_at`
 
 	rt.ID = uuid.New()
 	return r.db.QueryRowxContext(ctx, query,
 		rt.ID, rt.UserID, rt.Type, rt.Amount, rt.Currency, rt.Category, rt.Description,
-		rt.Frequency, rt.StartDate, rt.EndDate, rt.NextOccurrence, rt.IsActive,
+		rt.Frequency, rt.StartDate, rt.EndDate, rt.NextOccurrence, rt.IsActive, rt.RRule, rt.AmountType,
+		rt.AnchorDay, rt.BusinessDayPolicy, rt.GoalID, rt.DebtID,
 	).Scan(&rt.CreatedAt, &rt.UpdatedAt)
 }
 
@@ -62,12 +66,14 @@ func (r *RecurringRepository) Update(ctx context.Context, rt *model.RecurringTra
 		UPDATE recurring_transactions 
 		SET type = $2, amount = $3, currency = $4, category = $5, description = $6,
 			frequency = $7, start_date = $8, end_date = $9, next_occurrence = $10, 
-			is_active = $11, updated_at = NOW()
+			is_active = $11, rrule = $12, amount_type = $13,
+			anchor_day = $14, business_day_policy = $15, goal_id = $16, debt_id = $17, updated_at = NOW()
 		WHERE id = $1
 		RETURNING updated_at`
 	return r.db.QueryRowxContext(ctx, query,
 		rt.ID, rt.Type, rt.Amount, rt.Currency, rt.Category, rt.Description,
-		rt.Frequency, rt.StartDate, rt.EndDate, rt.NextOccurrence, rt.IsActive,
+		rt.Frequency, rt.StartDate, rt.EndDate, rt.NextOccurrence, rt.IsActive, rt.RRule, rt.AmountType,
+		rt.AnchorDay, rt.BusinessDayPolicy, rt.GoalID, rt.DebtID,
 	).Scan(&rt.UpdatedAt)
 }
 
@@ -77,40 +83,92 @@ func (r *RecurringRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
-// GetDueTransactions returns all active recurring transactions that are due
-func (r *RecurringRepository) GetDueTransactions(ctx context.Context, before time.Time) ([]model.RecurringTransaction, error) {
-	var items []model.RecurringTransaction
+// Advance moves a recurring transaction on to its next occurrence without generating the
+// current one, for skipped occurrences
+func (r *RecurringRepository) Advance(ctx context.Context, id uuid.UUID, lastGenerated, nextOccurrence time.Time) error {
 	query := `
-		SELECT * FROM recurring_transactions 
-		WHERE is_active = true 
-			AND next_occurrence <= $1
-			AND (end_date IS NULL OR end_date >= $1)
-		ORDER BY next_occurrence ASC`
-	err := r.db.SelectContext(ctx, &items, query, before)
-	return items, err
+		UPDATE recurring_transactions 
+		SET last_generated = $2, next_occurrence = $3, updated_at = NOW()
+		WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id, lastGenerated, nextOccurrence)
+	return err
 }
 
-// UpdateLastGenerated updates the last_generated and next_occurrence after generating a transaction
-func (r *RecurringRepository) UpdateLastGenerated(ctx context.Context, id uuid.UUID, lastGenerated time.Time, nextOccurrence time.Time) error {
+// Deactivate stops a recurring transaction whose series has ended at its last occurrence,
+// leaving the rest of it as it is
+func (r *RecurringRepository) Deactivate(ctx context.Context, id uuid.UUID, nextOccurrence time.Time) error {
 	query := `
 		UPDATE recurring_transactions 
-		SET last_generated = $2, next_occurrence = $3, updated_at = NOW()
+		SET is_active = false, next_occurrence = $2, updated_at = NOW()
 		WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id, lastGenerated, nextOccurrence)
+	_, err := r.db.ExecContext(ctx, query, id, nextOccurrence)
 	return err
 }
 
-// GetUpcoming returns upcoming bills/income for dashboard widget
-func (r *RecurringRepository) GetUpcoming(ctx context.Context, userID uuid.UUID, limit int) ([]model.UpcomingBill, error) {
-	var items []model.UpcomingBill
+// GetDueTransactions returns all active recurring transactions that are due, including
+// those whose end date has passed since their next occurrence and those whose next
+// occurrence was moved earlier
+func (r *RecurringRepository) GetDueTransactions(ctx context.Context, before time.Time) ([]model.RecurringTransaction, error) {
+	var items []model.RecurringTransaction
 	query := `
-		SELECT id, description, amount, currency, category, next_occurrence as due_date, type
-		FROM recurring_transactions 
-		WHERE user_id = $1 
-			AND is_active = true 
-			AND next_occurrence >= CURRENT_DATE
-		ORDER BY next_occurrence ASC
-		LIMIT $2`
-	err := r.db.SelectContext(ctx, &items, query, userID, limit)
+		SELECT r.* FROM recurring_transactions r
+		WHERE r.is_active = true 
+			AND (r.next_occurrence <= $1 OR EXISTS (
+				SELECT 1 FROM recurring_exceptions e
+				WHERE e.recurring_id = r.id AND e.occurrence_date = r.next_occurrence AND e.moved_to <= $1))
+		ORDER BY r.next_occurrence ASC`
+	err := r.db.SelectContext(ctx, &items, query, before)
 	return items, err
 }
+
+// SaveException creates the exception of an occurrence or replaces the existing one
+func (r *RecurringRepository) SaveException(ctx context.Context, e *model.RecurringException) error {
+	query := `
+		INSERT INTO recurring_exceptions (id, recurring_id, occurrence_date, skip, moved_to, amount,
+			created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		ON CONFLICT (recurring_id, occurrence_date) DO UPDATE
+		SET skip = EXCLUDED.skip, moved_to = EXCLUDED.moved_to, amount = EXCLUDED.amount, updated_at = NOW()
+		RETURNING id, created_at, updated_at`
+	return r.db.QueryRowxContext(ctx, query,
+		uuid.New(), e.RecurringID, e.OccurrenceDate, e.Skip, e.MovedTo, e.Amount,
+	).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
+}
+
+// DeleteException removes the exception of an occurrence
+func (r *RecurringRepository) DeleteException(ctx context.Context, recurringID uuid.UUID, occurrence time.Time) error {
+	res, err := r.db.ExecContext(ctx,
+		`DELETE FROM recurring_exceptions WHERE recurring_id = $1 AND occurrence_date = $2`, recurringID, occurrence)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrRecurringExceptionNotFound
+	}
+	return nil
+}
+
+// ListExceptions returns the exceptions of a recurring transaction, by occurrence
+func (r *RecurringRepository) ListExceptions(ctx context.Context, recurringID uuid.UUID) ([]model.RecurringException, error) {
+	var exceptions []model.RecurringException
+	query := `SELECT * FROM recurring_exceptions WHERE recurring_id = $1 ORDER BY occurrence_date ASC`
+	err := r.db.SelectContext(ctx, &exceptions, query, recurringID)
+	return exceptions, err
+}
+
+// ListExceptionsByUser returns the exceptions of a user's active recurring transactions
+// for occurrences still to be generated
+func (r *RecurringRepository) ListExceptionsByUser(ctx context.Context, userID uuid.UUID) ([]model.RecurringException, error) {
+	var exceptions []model.RecurringException
+	query := `
+		SELECT e.* FROM recurring_exceptions e
+		JOIN recurring_transactions r ON r.id = e.recurring_id
+		WHERE r.user_id = $1 AND r.is_active = true AND e.occurrence_date >= r.next_occurrence
+		ORDER BY e.occurrence_date ASC`
+	err := r.db.SelectContext(ctx, &exceptions, query, userID)
+	return exceptions, err
+}
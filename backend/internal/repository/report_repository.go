@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+type ReportRepository struct {
+	db *sqlx.DB
+}
+
+func NewReportRepository(db *sqlx.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// ReportQuery selects the transactions a report aggregates and how they are grouped
+type ReportQuery struct {
+	UserID    uuid.UUID
+	Type      model.TransactionType
+	Start     time.Time // inclusive
+	End       time.Time // inclusive
+	Currency  *string
+	Period    model.ReportGroupBy // day to year, empty for the whole range
+	Dimension model.ReportGroupBy // category, account or currency, empty for all transactions
+}
+
+// reportPeriods are the date_trunc fields of period groupings
+var reportPeriods = map[model.ReportGroupBy]string{
+	model.ReportByDay:     "day",
+	model.ReportByWeek:    "week",
+	model.ReportByMonth:   "month",
+	model.ReportByQuarter: "quarter",
+	model.ReportByYear:    "year",
+}
+
+// reportDimensions are the key and label expressions of the other groupings
+var reportDimensions = map[model.ReportGroupBy][2]string{
+	"":                     {"''", "''"},
+	model.ReportByCategory: {"t.category", "''"},
+	model.ReportByAccount:  {"COALESCE(t.debt_id::text, '')", "COALESCE(d.name, '')"},
+	model.ReportByCurrency: {"t.currency", "''"},
+}
+
+// Aggregate sums, counts and averages the amounts of a user's transactions by period,
+// group and currency, ordered by currency, group and period. Empty periods have no row.
+func (r *ReportRepository) Aggregate(ctx context.Context, q ReportQuery) ([]model.ReportRow, error) {
+	period := "NULL::date"
+	if q.Period != "" {
+		field, ok := reportPeriods[q.Period]
+		if !ok {
+			return nil, fmt.Errorf("unsupported report period %q", q.Period)
+		}
+		period = fmt.Sprintf("date_trunc('%s', t.date)::date", field)
+	}
+	dimension, ok := reportDimensions[q.Dimension]
+	if !ok {
+		return nil, fmt.Errorf("unsupported report grouping %q", q.Dimension)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s AS period, %s AS key, %s AS label, t.currency,
+			SUM(t.amount) AS sum, COUNT(*) AS count, AVG(t.amount) AS average
+		FROM transactions t
+		LEFT JOIN debts d ON d.id = t.debt_id
+		WHERE t.user_id = $1 AND t.type = $2 AND t.date >= $3 AND t.date <= $4
+			AND ($5::text IS NULL OR t.currency = $5)
+		GROUP BY 1, 2, 3, 4
+		ORDER BY 4, 2, 1`, period, dimension[0], dimension[1])
+
+	var rows []model.ReportRow
+	err := r.db.SelectContext(ctx, &rows, query, q.UserID, q.Type, q.Start, q.End, q.Currency)
+	return rows, err
+}
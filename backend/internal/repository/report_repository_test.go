@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+func TestReportRepository_Aggregate(t *testing.T) {
+	t.Parallel()
+
+	db, mock := newMockDB(t)
+	defer func() { _ = db.Close() }()
+	repo := NewReportRepository(db)
+
+	userID := uuid.New()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	currency := "VND"
+
+	mock.ExpectQuery(`SELECT date_trunc\('quarter', t.date\)::date AS period, COALESCE\(t.debt_id::text, ''\) AS key, COALESCE\(d.name, ''\) AS label`).
+		WithArgs(userID, model.TransactionTypeExpense, start, end, &currency).
+		WillReturnRows(sqlmock.NewRows([]string{"period", "key", "label", "currency", "sum", "count", "average"}).
+			AddRow(start, "", "", "VND", "1500000", 3, "500000"))
+
+	rows, err := repo.Aggregate(context.Background(), ReportQuery{
+		UserID:    userID,
+		Type:      model.TransactionTypeExpense,
+		Start:     start,
+		End:       end,
+		Currency:  &currency,
+		Period:    model.ReportByQuarter,
+		Dimension: model.ReportByAccount,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, start, *rows[0].Period)
+	assert.Equal(t, 3, rows[0].Count)
+	assert.Equal(t, "1500000", rows[0].Sum.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepository_Aggregate_UnsupportedGrouping(t *testing.T) {
+	t.Parallel()
+
+	db, _ := newMockDB(t)
+	defer func() { _ = db.Close() }()
+	repo := NewReportRepository(db)
+
+	_, err := repo.Aggregate(context.Background(), ReportQuery{Period: "decade"})
+	assert.Error(t, err)
+
+	_, err = repo.Aggregate(context.Background(), ReportQuery{Dimension: model.ReportByTag})
+	assert.Error(t, err)
+}
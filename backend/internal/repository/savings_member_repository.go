@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+var (
+	ErrGoalMemberNotFound = errors.New("savings goal member not found")
+	ErrInvitationNotFound = errors.New("invitation not found")
+)
+
+type SavingsGoalMemberRepository struct {
+	db *sqlx.DB
+}
+
+func NewSavingsGoalMemberRepository(db *sqlx.DB) *SavingsGoalMemberRepository {
+	return &SavingsGoalMemberRepository{db: db}
+}
+
+// GetPermission returns the member's permission on a shared goal
+func (r *SavingsGoalMemberRepository) GetPermission(ctx context.Context, goalID, userID uuid.UUID) (model.GoalPermission, error) {
+	var permission model.GoalPermission
+	query := `SELECT permission FROM savings_goal_members WHERE goal_id = $1 AND user_id = $2`
+	err := r.db.GetContext(ctx, &permission, query, goalID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrGoalMemberNotFound
+	}
+	return permission, err
+}
+
+// ListMembers returns the owner followed by every member of a goal
+func (r *SavingsGoalMemberRepository) ListMembers(ctx context.Context, goalID uuid.UUID) ([]model.SavingsGoalMember, error) {
+	var members []model.SavingsGoalMember
+	query := `
+		SELECT g.id AS goal_id, g.user_id, u.email, u.name, 'owner' AS permission, g.created_at AS joined_at
+		FROM savings_goals g
+		JOIN users u ON u.id = g.user_id
+		WHERE g.id = $1
+		UNION ALL
+		SELECT m.goal_id, m.user_id, u.email, u.name, m.permission, m.joined_at
+		FROM savings_goal_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.goal_id = $1
+		ORDER BY joined_at ASC`
+	err := r.db.SelectContext(ctx, &members, query, goalID)
+	return members, err
+}
+
+// ListSharedGoals returns goals shared with the user, with the user's permission set
+func (r *SavingsGoalMemberRepository) ListSharedGoals(ctx context.Context, userID uuid.UUID) ([]model.SavingsGoal, error) {
+	var rows []struct {
+		model.SavingsGoal
+		MemberPermission model.GoalPermission `db:"member_permission"`
+	}
+	query := `
+		SELECT g.*, m.permission AS member_permission
+		FROM savings_goals g
+		JOIN savings_goal_members m ON m.goal_id = g.id
+		WHERE m.user_id = $1
+		ORDER BY g.created_at DESC`
+	if err := r.db.SelectContext(ctx, &rows, query, userID); err != nil {
+		return nil, err
+	}
+
+	goals := make([]model.SavingsGoal, len(rows))
+	for i, row := range rows {
+		goals[i] = row.SavingsGoal
+		goals[i].Permission = row.MemberPermission
+	}
+	return goals, nil
+}
+
+func (r *SavingsGoalMemberRepository) UpdatePermission(ctx context.Context, goalID, userID uuid.UUID, permission model.GoalPermission) error {
+	query := `UPDATE savings_goal_members SET permission = $3 WHERE goal_id = $1 AND user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, goalID, userID, permission)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrGoalMemberNotFound
+	}
+	return nil
+}
+
+func (r *SavingsGoalMemberRepository) RemoveMember(ctx context.Context, goalID, userID uuid.UUID) error {
+	query := `DELETE FROM savings_goal_members WHERE goal_id = $1 AND user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, goalID, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrGoalMemberNotFound
+	}
+	return nil
+}
+
+func (r *SavingsGoalMemberRepository) CreateInvitation(ctx context.Context, invitation *model.SavingsGoalInvitation) error {
+	query := `
+		INSERT INTO savings_goal_invitations (id, goal_id, inviter_id, email, permission, token, status, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING created_at`
+
+	invitation.ID = uuid.New()
+	return r.db.QueryRowxContext(ctx, query,
+		invitation.ID, invitation.GoalID, invitation.InviterID, invitation.Email, invitation.Permission,
+		invitation.Token, invitation.Status, invitation.ExpiresAt,
+	).Scan(&invitation.CreatedAt)
+}
+
+func (r *SavingsGoalMemberRepository) GetInvitationByToken(ctx context.Context, token string) (*model.SavingsGoalInvitation, error) {
+	var invitation model.SavingsGoalInvitation
+	query := `
+		SELECT i.*, g.name AS goal_name
+		FROM savings_goal_invitations i
+		JOIN savings_goals g ON g.id = i.goal_id
+		WHERE i.token = $1`
+	err := r.db.GetContext(ctx, &invitation, query, token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvitationNotFound
+	}
+	return &invitation, err
+}
+
+// ListPendingInvitations returns unexpired pending invitations addressed to an email
+func (r *SavingsGoalMemberRepository) ListPendingInvitations(ctx context.Context, email string) ([]model.SavingsGoalInvitation, error) {
+	var invitations []model.SavingsGoalInvitation
+	query := `
+		SELECT i.*, g.name AS goal_name
+		FROM savings_goal_invitations i
+		JOIN savings_goals g ON g.id = i.goal_id
+		WHERE LOWER(i.email) = LOWER($1) AND i.status = 'pending' AND i.expires_at > NOW()
+		ORDER BY i.created_at DESC`
+	err := r.db.SelectContext(ctx, &invitations, query, email)
+	return invitations, err
+}
+
+// AcceptInvitation marks the invitation accepted and adds the user as a member in a single transaction
+func (r *SavingsGoalMemberRepository) AcceptInvitation(ctx context.Context, invitation *model.SavingsGoalInvitation, userID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	updateQuery := `
+		UPDATE savings_goal_invitations SET status = 'accepted', responded_at = NOW()
+		WHERE id = $1 AND status = 'pending'`
+	result, err := tx.ExecContext(ctx, updateQuery, invitation.ID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrInvitationNotFound
+	}
+
+	memberQuery := `
+		INSERT INTO savings_goal_members (goal_id, user_id, permission, joined_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (goal_id, user_id) DO UPDATE SET permission = EXCLUDED.permission`
+	if _, err := tx.ExecContext(ctx, memberQuery, invitation.GoalID, userID, invitation.Permission); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UpdateInvitationStatus moves a pending invitation to declined or revoked
+func (r *SavingsGoalMemberRepository) UpdateInvitationStatus(ctx context.Context, id uuid.UUID, status model.InvitationStatus) error {
+	query := `
+		UPDATE savings_goal_invitations SET status = $2, responded_at = NOW()
+		WHERE id = $1 AND status = 'pending'`
+	result, err := r.db.ExecContext(ctx, query, id, status)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
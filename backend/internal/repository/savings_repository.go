@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -84,12 +85,64 @@ func (r *SavingsGoalRepository) Delete(ctx context.Context, id uuid.UUID, userID
 }
 
 func (r *SavingsGoalRepository) AddContribution(ctx context.Context, id uuid.UUID, userID uuid.UUID, amount decimal.Decimal) error {
-	query := `
+	return r.RecordContribution(ctx, &model.SavingsContribution{
+		GoalID: id,
+		UserID: userID,
+		Amount: amount,
+		Date:   time.Now(),
+	})
+}
+
+// RecordContribution adds a contribution to the goal balance and stores it in the
+// contribution history in a single transaction. The contributor may be the owner or
+// a member of a shared goal; access is checked by the caller.
+func (r *SavingsGoalRepository) RecordContribution(ctx context.Context, contribution *model.SavingsContribution) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := recordContribution(ctx, tx, contribution); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// recordContribution adds a contribution to the goal balance and stores it within tx.
+func recordContribution(ctx context.Context, tx *sqlx.Tx, contribution *model.SavingsContribution) error {
+	updateQuery := `
 		UPDATE savings_goals 
-		SET current_amount = current_amount + $3, updated_at = NOW()
-		WHERE id = $1 AND user_id = $2`
-	_, err := r.db.ExecContext(ctx, query, id, userID, amount)
-	return err
+		SET current_amount = current_amount + $2, updated_at = NOW()
+		WHERE id = $1`
+	result, err := tx.ExecContext(ctx, updateQuery, contribution.GoalID, contribution.Amount)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrSavingsGoalNotFound
+	}
+
+	contributionQuery := `
+		INSERT INTO savings_contributions (id, goal_id, user_id, amount, date, rule_id, source_transaction_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING created_at`
+	contribution.ID = uuid.New()
+	return tx.QueryRowxContext(ctx, contributionQuery,
+		contribution.ID, contribution.GoalID, contribution.UserID, contribution.Amount,
+		contribution.Date, contribution.RuleID, contribution.SourceTransactionID,
+	).Scan(&contribution.CreatedAt)
+}
+
+func (r *SavingsGoalRepository) ListContributions(ctx context.Context, goalID uuid.UUID) ([]model.SavingsContribution, error) {
+	var contributions []model.SavingsContribution
+	query := `SELECT * FROM savings_contributions WHERE goal_id = $1 ORDER BY date ASC, created_at ASC`
+	err := r.db.SelectContext(ctx, &contributions, query, goalID)
+	return contributions, err
 }
 
 func (r *SavingsGoalRepository) GetTotalSavings(ctx context.Context, userID uuid.UUID) (decimal.Decimal, error) {
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+var ErrSavingsRuleNotFound = errors.New("savings rule not found")
+
+type SavingsRuleRepository struct {
+	db *sqlx.DB
+}
+
+func NewSavingsRuleRepository(db *sqlx.DB) *SavingsRuleRepository {
+	return &SavingsRuleRepository{db: db}
+}
+
+func (r *SavingsRuleRepository) Create(ctx context.Context, rule *model.SavingsRule) error {
+	query := `
+		INSERT INTO savings_rules (id, goal_id, user_id, type, round_to, percentage, category, amount,
+			frequency, next_run, anchor_day, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW(), NOW())
+		RETURNING created_at, updated_at`
+
+	rule.ID = uuid.New()
+	return r.db.QueryRowxContext(ctx, query,
+		rule.ID, rule.GoalID, rule.UserID, rule.Type, rule.RoundTo, rule.Percentage, rule.Category,
+		rule.Amount, rule.Frequency, rule.NextRun, rule.AnchorDay, rule.IsActive,
+	).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+}
+
+func (r *SavingsRuleRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.SavingsRule, error) {
+	var rule model.SavingsRule
+	query := `SELECT * FROM savings_rules WHERE id = $1`
+	err := r.db.GetContext(ctx, &rule, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSavingsRuleNotFound
+	}
+	return &rule, err
+}
+
+func (r *SavingsRuleRepository) ListByGoal(ctx context.Context, goalID uuid.UUID) ([]model.SavingsRule, error) {
+	var rules []model.SavingsRule
+	query := `SELECT * FROM savings_rules WHERE goal_id = $1 ORDER BY created_at ASC`
+	err := r.db.SelectContext(ctx, &rules, query, goalID)
+	return rules, err
+}
+
+// ListActiveByUser returns all active rules for a user
+func (r *SavingsRuleRepository) ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]model.SavingsRule, error) {
+	var rules []model.SavingsRule
+	query := `SELECT * FROM savings_rules WHERE user_id = $1 AND is_active = true ORDER BY created_at ASC`
+	err := r.db.SelectContext(ctx, &rules, query, userID)
+	return rules, err
+}
+
+func (r *SavingsRuleRepository) Update(ctx context.Context, rule *model.SavingsRule) error {
+	query := `
+		UPDATE savings_rules 
+		SET round_to = $2, percentage = $3, category = $4, amount = $5, frequency = $6,
+			next_run = $7, is_active = $8, anchor_day = $10, updated_at = NOW()
+		WHERE id = $1 AND user_id = $9
+		RETURNING updated_at`
+	return r.db.QueryRowxContext(ctx, query,
+		rule.ID, rule.RoundTo, rule.Percentage, rule.Category, rule.Amount, rule.Frequency,
+		rule.NextRun, rule.IsActive, rule.UserID, rule.AnchorDay,
+	).Scan(&rule.UpdatedAt)
+}
+
+func (r *SavingsRuleRepository) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	query := `DELETE FROM savings_rules WHERE id = $1 AND user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrSavingsRuleNotFound
+	}
+	return nil
+}
+
+// GetDueScheduled returns active fixed-schedule rules whose next run is on or before the given time
+func (r *SavingsRuleRepository) GetDueScheduled(ctx context.Context, before time.Time) ([]model.SavingsRule, error) {
+	var rules []model.SavingsRule
+	query := `
+		SELECT * FROM savings_rules 
+		WHERE is_active = true 
+			AND type = 'fixed_schedule'
+			AND next_run <= $1
+		ORDER BY next_run ASC`
+	err := r.db.SelectContext(ctx, &rules, query, before)
+	return rules, err
+}
+
+// RecordScheduledRun records the contribution of a scheduled rule's run and moves the
+// rule on to its next run in a single transaction, so a run is never recorded twice.
+// Returns ErrSavingsRuleNotFound if the rule no longer runs on the contribution's date.
+func (r *SavingsRuleRepository) RecordScheduledRun(ctx context.Context, contribution *model.SavingsContribution, nextRun time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	query := `UPDATE savings_rules SET next_run = $3, updated_at = NOW() WHERE id = $1 AND next_run = $2`
+	result, err := tx.ExecContext(ctx, query, contribution.RuleID, contribution.Date, nextRun)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrSavingsRuleNotFound
+	}
+
+	if err := recordContribution(ctx, tx, contribution); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
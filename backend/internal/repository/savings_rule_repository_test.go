@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+func TestSavingsRuleRepository_RecordScheduledRun(t *testing.T) {
+	t.Parallel()
+
+	run := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	next := run.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "due run", affected: 1},
+		{name: "already recorded", affected: 0, wantErr: ErrSavingsRuleNotFound},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock := newMockDB(t)
+			defer func() { _ = db.Close() }()
+			repo := NewSavingsRuleRepository(db)
+
+			ruleID := uuid.New()
+			contribution := &model.SavingsContribution{
+				GoalID: uuid.New(),
+				UserID: uuid.New(),
+				Amount: decimal.NewFromInt(2000000),
+				Date:   run,
+				RuleID: &ruleID,
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectExec(`UPDATE savings_rules SET next_run = \$3, updated_at = NOW\(\) WHERE id = \$1 AND next_run = \$2`).
+				WithArgs(&ruleID, run, next).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			if tt.wantErr == nil {
+				mock.ExpectExec(`UPDATE savings_goals`).
+					WithArgs(contribution.GoalID, contribution.Amount).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(`INSERT INTO savings_contributions`).
+					WithArgs(sqlmock.AnyArg(), contribution.GoalID, contribution.UserID, contribution.Amount, run, &ruleID, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(run))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			err := repo.RecordScheduledRun(context.Background(), contribution, next)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
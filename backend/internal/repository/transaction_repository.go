@@ -22,16 +22,33 @@ func NewTransactionRepository(db *sqlx.DB) *TransactionRepository {
 	return &TransactionRepository{db: db}
 }
 
+// Create inserts the transaction. An expense charged to a credit card is added to
+// the card balance in the same database transaction.
 func (r *TransactionRepository) Create(ctx context.Context, tx *model.Transaction) error {
+	dbTx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dbTx.Rollback() }()
+
 	query := `
-		INSERT INTO transactions (id, user_id, type, amount, currency, category, description, date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+		INSERT INTO transactions (id, user_id, type, amount, currency, category, description, date, debt_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
 		RETURNING created_at, updated_at`
 
 	tx.ID = uuid.New()
-	return r.db.QueryRowxContext(ctx, query,
-		tx.ID, tx.UserID, tx.Type, tx.Amount, tx.Currency, tx.Category, tx.Description, tx.Date,
+	err = dbTx.QueryRowxContext(ctx, query,
+		tx.ID, tx.UserID, tx.Type, tx.Amount, tx.Currency, tx.Category, tx.Description, tx.Date, tx.DebtID,
 	).Scan(&tx.CreatedAt, &tx.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := adjustCardBalance(ctx, dbTx, tx.DebtID, tx.Amount); err != nil {
+		return err
+	}
+
+	return dbTx.Commit()
 }
 
 func (r *TransactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*model.Transaction, error) {
@@ -44,6 +61,87 @@ func (r *TransactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*mod
 	return &tx, err
 }
 
+// CreateRecurring inserts a transaction generated by a recurring transaction with its
+// transfer into a savings goal or debt, if any, and moves the recurring transaction on to
+// its next occurrence in the same database transaction.
+// Each occurrence is generated at most once: created is false when it already was, and
+// the recurring transaction is still moved on without recording the transfer again.
+func (r *TransactionRepository) CreateRecurring(ctx context.Context, tx *model.Transaction, transfer *model.RecurringTransfer, lastGenerated, nextOccurrence time.Time) (created bool, err error) {
+	dbTx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = dbTx.Rollback() }()
+
+	query := `
+		INSERT INTO transactions (id, user_id, type, amount, currency, category, description, date,
+			recurring_id, occurrence_date, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW())
+		ON CONFLICT (recurring_id, occurrence_date) WHERE recurring_id IS NOT NULL DO NOTHING
+		RETURNING created_at, updated_at`
+
+	tx.ID = uuid.New()
+	err = dbTx.QueryRowxContext(ctx, query,
+		tx.ID, tx.UserID, tx.Type, tx.Amount, tx.Currency, tx.Category, tx.Description, tx.Date,
+		tx.RecurringID, tx.OccurrenceDate,
+	).Scan(&tx.CreatedAt, &tx.UpdatedAt)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		tx.ID = uuid.Nil
+	case err != nil:
+		return false, err
+	default:
+		created = true
+		if err := recordTransfer(ctx, dbTx, tx, transfer); err != nil {
+			return false, err
+		}
+	}
+
+	_, err = dbTx.ExecContext(ctx, `
+		UPDATE recurring_transactions 
+		SET last_generated = $2, next_occurrence = $3, updated_at = NOW()
+		WHERE id = $1`, tx.RecurringID, lastGenerated, nextOccurrence)
+	if err != nil {
+		return false, err
+	}
+
+	return created, dbTx.Commit()
+}
+
+// recordTransfer records the contribution to a savings goal or the payment of a debt
+// that a transaction generated by a recurring transaction makes, within dbTx. The
+// transaction is the expense recording a debt payment, so it is linked to the payment.
+func recordTransfer(ctx context.Context, dbTx *sqlx.Tx, tx *model.Transaction, transfer *model.RecurringTransfer) error {
+	switch {
+	case transfer == nil:
+		return nil
+	case transfer.Contribution != nil:
+		transfer.Contribution.SourceTransactionID = &tx.ID
+		return recordContribution(ctx, dbTx, transfer.Contribution)
+	case transfer.Payment != nil:
+		if err := recordPayment(ctx, dbTx, transfer.Payment, transfer.PaymentSplit); err != nil {
+			return err
+		}
+		// The payment may have been cut down to the debt balance; the expense follows it.
+		if _, err := dbTx.ExecContext(ctx,
+			`UPDATE transactions SET amount = $2, debt_payment_id = $3 WHERE id = $1`,
+			tx.ID, transfer.Payment.Amount, transfer.Payment.ID); err != nil {
+			return err
+		}
+		tx.Amount = transfer.Payment.Amount
+		tx.DebtPaymentID = &transfer.Payment.ID
+	}
+	return nil
+}
+
+// ListByRecurringID returns the transactions generated by a recurring transaction, newest first
+func (r *TransactionRepository) ListByRecurringID(ctx context.Context, recurringID uuid.UUID) ([]model.Transaction, error) {
+	var transactions []model.Transaction
+	query := `SELECT * FROM transactions WHERE recurring_id = $1 ORDER BY occurrence_date DESC`
+	err := r.db.SelectContext(ctx, &transactions, query, recurringID)
+	return transactions, err
+}
+
 func (r *TransactionRepository) List(ctx context.Context, userID uuid.UUID, filters TransactionFilters) ([]model.Transaction, error) {
 	var transactions []model.Transaction
 	query := `
@@ -62,32 +160,88 @@ func (r *TransactionRepository) List(ctx context.Context, userID uuid.UUID, filt
 	return transactions, err
 }
 
+// Update modifies the transaction and moves its amount between credit card balances
+// when the charged card or the amount changes, in one database transaction.
 func (r *TransactionRepository) Update(ctx context.Context, tx *model.Transaction) error {
+	dbTx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dbTx.Rollback() }()
+
+	var previous struct {
+		DebtID *uuid.UUID      `db:"debt_id"`
+		Amount decimal.Decimal `db:"amount"`
+	}
+	err = dbTx.GetContext(ctx, &previous,
+		`SELECT debt_id, amount FROM transactions WHERE id = $1 AND user_id = $2 FOR UPDATE`, tx.ID, tx.UserID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTransactionNotFound
+	}
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE transactions 
-		SET type = $2, amount = $3, currency = $4, category = $5, description = $6, date = $7, updated_at = NOW()
+		SET type = $2, amount = $3, currency = $4, category = $5, description = $6, date = $7, debt_id = $9, updated_at = NOW()
 		WHERE id = $1 AND user_id = $8
 		RETURNING updated_at`
-	result := r.db.QueryRowxContext(ctx, query,
-		tx.ID, tx.Type, tx.Amount, tx.Currency, tx.Category, tx.Description, tx.Date, tx.UserID,
-	)
-	return result.Scan(&tx.UpdatedAt)
+	err = dbTx.QueryRowxContext(ctx, query,
+		tx.ID, tx.Type, tx.Amount, tx.Currency, tx.Category, tx.Description, tx.Date, tx.UserID, tx.DebtID,
+	).Scan(&tx.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := adjustCardBalance(ctx, dbTx, previous.DebtID, previous.Amount.Neg()); err != nil {
+		return err
+	}
+	if err := adjustCardBalance(ctx, dbTx, tx.DebtID, tx.Amount); err != nil {
+		return err
+	}
+
+	return dbTx.Commit()
 }
 
+// Delete removes the transaction and takes a card charge back off the card balance
+// in one database transaction.
 func (r *TransactionRepository) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
-	query := `DELETE FROM transactions WHERE id = $1 AND user_id = $2`
-	result, err := r.db.ExecContext(ctx, query, id, userID)
+	dbTx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
-	rows, err := result.RowsAffected()
+	defer func() { _ = dbTx.Rollback() }()
+
+	var deleted struct {
+		DebtID *uuid.UUID      `db:"debt_id"`
+		Amount decimal.Decimal `db:"amount"`
+	}
+	query := `DELETE FROM transactions WHERE id = $1 AND user_id = $2 RETURNING debt_id, amount`
+	err = dbTx.GetContext(ctx, &deleted, query, id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTransactionNotFound
+	}
 	if err != nil {
 		return err
 	}
-	if rows == 0 {
-		return ErrTransactionNotFound
+
+	if err := adjustCardBalance(ctx, dbTx, deleted.DebtID, deleted.Amount.Neg()); err != nil {
+		return err
 	}
-	return nil
+
+	return dbTx.Commit()
+}
+
+// adjustCardBalance adds delta to the balance of the credit card a transaction is
+// charged to. Transactions not charged to a card leave debts untouched.
+func adjustCardBalance(ctx context.Context, dbTx *sqlx.Tx, debtID *uuid.UUID, delta decimal.Decimal) error {
+	if debtID == nil || delta.IsZero() {
+		return nil
+	}
+	query := `UPDATE debts SET current_balance = current_balance + $2, updated_at = NOW() WHERE id = $1`
+	_, err := dbTx.ExecContext(ctx, query, *debtID, delta)
+	return err
 }
 
 func (r *TransactionRepository) GetMonthlyTotals(ctx context.Context, userID uuid.UUID, year int, month int) (income, expenses decimal.Decimal, err error) {
@@ -11,6 +11,7 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/wealthpath/backend/internal/model"
 )
 
@@ -55,9 +56,11 @@ func TestTransactionRepository_Create(t *testing.T) {
 	now := time.Now()
 	rows := sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now)
 
+	mock.ExpectBegin()
 	mock.ExpectQuery(`INSERT INTO transactions`).
-		WithArgs(sqlmock.AnyArg(), tx.UserID, tx.Type, tx.Amount, tx.Currency, tx.Category, tx.Description, tx.Date).
+		WithArgs(sqlmock.AnyArg(), tx.UserID, tx.Type, tx.Amount, tx.Currency, tx.Category, tx.Description, tx.Date, tx.DebtID).
 		WillReturnRows(rows)
+	mock.ExpectCommit()
 
 	err := repo.Create(ctx, tx)
 
@@ -66,6 +69,181 @@ func TestTransactionRepository_Create(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestTransactionRepository_Create_CardCharge(t *testing.T) {
+	t.Parallel()
+
+	db, mock := newMockDB(t)
+	defer func() { _ = db.Close() }()
+	repo := NewTransactionRepository(db)
+
+	cardID := uuid.New()
+	tx := &model.Transaction{
+		UserID:   uuid.New(),
+		Type:     model.TransactionTypeExpense,
+		Amount:   decimal.NewFromFloat(120),
+		Currency: "USD",
+		Category: "Shopping",
+		Date:     time.Now(),
+		DebtID:   &cardID,
+	}
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO transactions`).
+		WithArgs(sqlmock.AnyArg(), tx.UserID, tx.Type, tx.Amount, tx.Currency, tx.Category, tx.Description, tx.Date, tx.DebtID).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+	mock.ExpectExec(`UPDATE debts SET current_balance = current_balance \+ \$2`).
+		WithArgs(cardID, tx.Amount).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Create(context.Background(), tx)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionRepository_CreateRecurring(t *testing.T) {
+	t.Parallel()
+
+	recurringID := uuid.New()
+	debtID := uuid.New()
+	goalID := uuid.New()
+	occurrence := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	next := occurrence.AddDate(0, 1, 0)
+	lastGenerated := time.Now()
+	inserted := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(lastGenerated, lastGenerated)
+	}
+
+	tests := []struct {
+		name        string
+		rows        *sqlmock.Rows
+		transfer    func() *model.RecurringTransfer
+		expect      func(sqlmock.Sqlmock, *model.RecurringTransfer)
+		wantCreated bool
+	}{
+		{
+			name:        "generates the occurrence",
+			rows:        inserted(),
+			wantCreated: true,
+		},
+		{
+			name: "pays the debt",
+			rows: inserted(),
+			transfer: func() *model.RecurringTransfer {
+				return &model.RecurringTransfer{
+					Payment: &model.DebtPayment{DebtID: debtID, Amount: decimal.NewFromFloat(500), Date: occurrence},
+					// One percent interest on the locked balance.
+					PaymentSplit: func(debt *model.Debt, payment *model.DebtPayment) error {
+						payment.Interest = debt.CurrentBalance.Div(decimal.NewFromInt(100))
+						payment.Principal = payment.Amount.Sub(payment.Interest)
+						return nil
+					},
+				}
+			},
+			expect: func(mock sqlmock.Sqlmock, transfer *model.RecurringTransfer) {
+				mock.ExpectQuery(`SELECT \* FROM debts WHERE id = \$1 FOR UPDATE`).
+					WithArgs(debtID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "current_balance"}).AddRow(debtID, "5000"))
+				mock.ExpectQuery(`INSERT INTO debt_payments`).
+					WithArgs(sqlmock.AnyArg(), debtID, transfer.Payment.Amount, decimal.NewFromFloat(450), decimal.NewFromFloat(50), occurrence, false).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(lastGenerated))
+				mock.ExpectExec(`UPDATE debts SET current_balance = current_balance - \$2`).
+					WithArgs(debtID, decimal.NewFromFloat(450)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE transactions SET amount = \$2, debt_payment_id = \$3 WHERE id = \$1`).
+					WithArgs(sqlmock.AnyArg(), transfer.Payment.Amount, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantCreated: true,
+		},
+		{
+			name: "contributes to the goal",
+			rows: inserted(),
+			transfer: func() *model.RecurringTransfer {
+				return &model.RecurringTransfer{Contribution: &model.SavingsContribution{
+					GoalID: goalID, UserID: uuid.New(), Amount: decimal.NewFromFloat(500), Date: occurrence,
+				}}
+			},
+			expect: func(mock sqlmock.Sqlmock, transfer *model.RecurringTransfer) {
+				mock.ExpectExec(`UPDATE savings_goals`).
+					WithArgs(goalID, transfer.Contribution.Amount).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(`INSERT INTO savings_contributions`).
+					WithArgs(sqlmock.AnyArg(), goalID, transfer.Contribution.UserID, transfer.Contribution.Amount,
+						occurrence, nil, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(lastGenerated))
+			},
+			wantCreated: true,
+		},
+		{
+			name: "occurrence already generated",
+			rows: sqlmock.NewRows([]string{"created_at", "updated_at"}),
+			transfer: func() *model.RecurringTransfer {
+				return &model.RecurringTransfer{Payment: &model.DebtPayment{DebtID: debtID, Amount: decimal.NewFromFloat(500)}}
+			},
+			wantCreated: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock := newMockDB(t)
+			defer func() { _ = db.Close() }()
+			repo := NewTransactionRepository(db)
+
+			tx := &model.Transaction{
+				UserID:         uuid.New(),
+				Type:           model.TransactionTypeExpense,
+				Amount:         decimal.NewFromFloat(500),
+				Currency:       "USD",
+				Category:       "Housing",
+				Description:    "Rent (recurring)",
+				Date:           occurrence,
+				RecurringID:    &recurringID,
+				OccurrenceDate: &occurrence,
+			}
+			var transfer *model.RecurringTransfer
+			if tt.transfer != nil {
+				transfer = tt.transfer()
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(`INSERT INTO transactions .* ON CONFLICT \(recurring_id, occurrence_date\)`).
+				WithArgs(sqlmock.AnyArg(), tx.UserID, tx.Type, tx.Amount, tx.Currency, tx.Category, tx.Description, tx.Date,
+					tx.RecurringID, tx.OccurrenceDate).
+				WillReturnRows(tt.rows)
+			if tt.expect != nil {
+				tt.expect(mock, transfer)
+			}
+			mock.ExpectExec(`UPDATE recurring_transactions`).
+				WithArgs(&recurringID, lastGenerated, next).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+
+			created, err := repo.CreateRecurring(context.Background(), tx, transfer, lastGenerated, next)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCreated, created)
+			assert.Equal(t, tt.wantCreated, tx.ID != uuid.Nil)
+			switch {
+			case transfer != nil && transfer.Payment != nil && created:
+				require.NotNil(t, tx.DebtPaymentID)
+				assert.Equal(t, transfer.Payment.ID, *tx.DebtPaymentID)
+			case transfer != nil && transfer.Contribution != nil:
+				assert.Equal(t, tx.ID, *transfer.Contribution.SourceTransactionID)
+			default:
+				assert.Nil(t, tx.DebtPaymentID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestTransactionRepository_GetByID(t *testing.T) {
 	t.Parallel()
 
@@ -179,9 +357,14 @@ func TestTransactionRepository_Update(t *testing.T) {
 	now := time.Now()
 	rows := sqlmock.NewRows([]string{"updated_at"}).AddRow(now)
 
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT debt_id, amount FROM transactions`).
+		WithArgs(tx.ID, tx.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"debt_id", "amount"}).AddRow(nil, decimal.NewFromFloat(70)))
 	mock.ExpectQuery(`UPDATE transactions`).
-		WithArgs(tx.ID, tx.Type, tx.Amount, tx.Currency, tx.Category, tx.Description, tx.Date, tx.UserID).
+		WithArgs(tx.ID, tx.Type, tx.Amount, tx.Currency, tx.Category, tx.Description, tx.Date, tx.UserID, tx.DebtID).
 		WillReturnRows(rows)
+	mock.ExpectCommit()
 
 	err := repo.Update(ctx, tx)
 
@@ -189,6 +372,45 @@ func TestTransactionRepository_Update(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestTransactionRepository_Update_MovesCardCharge(t *testing.T) {
+	t.Parallel()
+
+	db, mock := newMockDB(t)
+	defer func() { _ = db.Close() }()
+	repo := NewTransactionRepository(db)
+
+	oldCard, newCard := uuid.New(), uuid.New()
+	tx := &model.Transaction{
+		ID:       uuid.New(),
+		UserID:   uuid.New(),
+		Type:     model.TransactionTypeExpense,
+		Amount:   decimal.NewFromFloat(80),
+		Currency: "USD",
+		Category: "Shopping",
+		Date:     time.Now(),
+		DebtID:   &newCard,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT debt_id, amount FROM transactions`).
+		WithArgs(tx.ID, tx.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"debt_id", "amount"}).AddRow(oldCard, decimal.NewFromFloat(50)))
+	mock.ExpectQuery(`UPDATE transactions`).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Now()))
+	mock.ExpectExec(`UPDATE debts SET current_balance = current_balance \+ \$2`).
+		WithArgs(oldCard, decimal.NewFromFloat(-50)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE debts SET current_balance = current_balance \+ \$2`).
+		WithArgs(newCard, tx.Amount).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Update(context.Background(), tx)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestTransactionRepository_Delete(t *testing.T) {
 	t.Parallel()
 
@@ -201,18 +423,37 @@ func TestTransactionRepository_Delete(t *testing.T) {
 		{
 			name: "success",
 			setupMock: func(mock sqlmock.Sqlmock, id, userID uuid.UUID) {
-				mock.ExpectExec(`DELETE FROM transactions WHERE id = \$1 AND user_id = \$2`).
+				mock.ExpectBegin()
+				mock.ExpectQuery(`DELETE FROM transactions WHERE id = \$1 AND user_id = \$2`).
+					WithArgs(id, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"debt_id", "amount"}).AddRow(nil, decimal.NewFromFloat(50)))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "card charge",
+			setupMock: func(mock sqlmock.Sqlmock, id, userID uuid.UUID) {
+				cardID := uuid.New()
+				mock.ExpectBegin()
+				mock.ExpectQuery(`DELETE FROM transactions WHERE id = \$1 AND user_id = \$2`).
 					WithArgs(id, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"debt_id", "amount"}).AddRow(cardID, decimal.NewFromFloat(50)))
+				mock.ExpectExec(`UPDATE debts SET current_balance = current_balance \+ \$2`).
+					WithArgs(cardID, decimal.NewFromFloat(-50)).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 			wantErr: false,
 		},
 		{
 			name: "not found",
 			setupMock: func(mock sqlmock.Sqlmock, id, userID uuid.UUID) {
-				mock.ExpectExec(`DELETE FROM transactions WHERE id = \$1 AND user_id = \$2`).
+				mock.ExpectBegin()
+				mock.ExpectQuery(`DELETE FROM transactions WHERE id = \$1 AND user_id = \$2`).
 					WithArgs(id, userID).
-					WillReturnResult(sqlmock.NewResult(0, 0))
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
 			},
 			wantErr: true,
 			errType: ErrTransactionNotFound,
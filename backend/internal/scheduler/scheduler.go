@@ -0,0 +1,105 @@
+// Package scheduler runs background jobs in-process on fixed intervals. Each run takes
+// a Postgres advisory lock so that when several API replicas are up only one of them
+// runs a job at a time, and every run is recorded with what it processed.
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/wealthpath/backend/internal/model"
+)
+
+// Job is a background job run every Interval. Run returns how many items it processed.
+// Jobs must catch up on whatever they missed while no replica was running them.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) (int, error)
+}
+
+// Store takes the job locks and records runs.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	TryLock(ctx context.Context, job string) (release func(), acquired bool, err error)
+	RecordRun(ctx context.Context, run *model.JobRun) error
+}
+
+type Scheduler struct {
+	store Store
+	jobs  []Job
+	wg    sync.WaitGroup
+	now   func() time.Time
+}
+
+func New(store Store) *Scheduler {
+	return &Scheduler{store: store, now: time.Now}
+}
+
+// Register adds a job. Jobs must be registered before Start.
+func (s *Scheduler) Register(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs every registered job once and then on its interval until ctx is cancelled.
+// It does not block; call Wait to wait for running jobs to finish after cancelling.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func(job Job) {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+}
+
+// Wait blocks until all job loops have stopped.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.runJob(ctx, job)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runJob runs a job if no other replica is running it and records the run. It reports
+// whether the job ran.
+func (s *Scheduler) runJob(ctx context.Context, job Job) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	release, acquired, err := s.store.TryLock(ctx, job.Name)
+	if err != nil {
+		log.Printf("Job %s: %v", job.Name, err)
+		return false
+	}
+	if !acquired {
+		return false
+	}
+	defer release()
+
+	run := &model.JobRun{Job: job.Name, StartedAt: s.now()}
+	run.Processed, err = job.Run(ctx)
+	run.FinishedAt = s.now()
+	if err != nil {
+		msg := err.Error()
+		run.Error = &msg
+		log.Printf("Job %s failed after processing %d: %v", job.Name, run.Processed, err)
+	}
+	if err := s.store.RecordRun(context.WithoutCancel(ctx), run); err != nil {
+		log.Printf("Recording run of job %s failed: %v", job.Name, err)
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wealthpath/backend/internal/model"
+)
+
+// fakeStore holds advisory locks in memory, like several replicas sharing one database.
+type fakeStore struct {
+	mu      sync.Mutex
+	locked  map[string]bool
+	runs    []model.JobRun
+	lockErr error
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{locked: map[string]bool{}}
+}
+
+func (f *fakeStore) TryLock(_ context.Context, job string) (func(), bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.lockErr != nil {
+		return nil, false, f.lockErr
+	}
+	if f.locked[job] {
+		return nil, false, nil
+	}
+	f.locked[job] = true
+	return func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.locked, job)
+	}, true, nil
+}
+
+func (f *fakeStore) RecordRun(_ context.Context, run *model.JobRun) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.runs = append(f.runs, *run)
+	return nil
+}
+
+func (f *fakeStore) recorded() []model.JobRun {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]model.JobRun(nil), f.runs...)
+}
+
+func TestScheduler_RunJob_RecordsRun(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeStore()
+	s := New(store)
+
+	ran := s.runJob(context.Background(), Job{Name: "generate", Run: func(context.Context) (int, error) { return 3, nil }})
+	require.True(t, ran)
+
+	ran = s.runJob(context.Background(), Job{Name: "generate", Run: func(context.Context) (int, error) {
+		return 1, errors.New("db down")
+	}})
+	require.True(t, ran)
+
+	runs := store.recorded()
+	require.Len(t, runs, 2)
+	assert.Equal(t, "generate", runs[0].Job)
+	assert.Equal(t, 3, runs[0].Processed)
+	assert.Nil(t, runs[0].Error)
+	assert.False(t, runs[0].FinishedAt.Before(runs[0].StartedAt))
+	assert.Equal(t, 1, runs[1].Processed)
+	require.NotNil(t, runs[1].Error)
+	assert.Equal(t, "db down", *runs[1].Error)
+	assert.Empty(t, store.locked, "lock released after each run")
+}
+
+func TestScheduler_RunJob_OneReplicaAtATime(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeStore()
+	leader, follower := New(store), New(store)
+
+	started, finish := make(chan struct{}), make(chan struct{})
+	job := Job{Name: "generate", Run: func(context.Context) (int, error) {
+		close(started)
+		<-finish
+		return 1, nil
+	}}
+
+	done := make(chan bool)
+	go func() { done <- leader.runJob(context.Background(), job) }()
+	<-started
+
+	assert.False(t, follower.runJob(context.Background(), Job{Name: "generate", Run: func(context.Context) (int, error) {
+		t.Error("follower ran a job the leader holds")
+		return 0, nil
+	}}))
+	close(finish)
+	assert.True(t, <-done)
+	assert.Len(t, store.recorded(), 1)
+}
+
+func TestScheduler_RunJob_LockError(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeStore()
+	store.lockErr = errors.New("connection refused")
+	s := New(store)
+
+	assert.False(t, s.runJob(context.Background(), Job{Name: "generate", Run: func(context.Context) (int, error) {
+		t.Error("job ran without the lock")
+		return 0, nil
+	}}))
+	assert.Empty(t, store.recorded())
+}
+
+func TestScheduler_StartAndStop(t *testing.T) {
+	t.Parallel()
+
+	store := newFakeStore()
+	s := New(store)
+	runs := make(chan struct{}, 10)
+	s.Register(Job{Name: "generate", Interval: 10 * time.Millisecond, Run: func(context.Context) (int, error) {
+		select {
+		case runs <- struct{}{}:
+		default:
+		}
+		return 0, nil
+	}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx)
+	<-runs // runs at start
+	<-runs // and again on the interval
+	cancel()
+	s.Wait()
+
+	assert.GreaterOrEqual(t, len(store.recorded()), 2)
+}
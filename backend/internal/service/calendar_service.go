@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+	"github.com/wealthpath/backend/pkg/currency"
+	"github.com/wealthpath/backend/pkg/ical"
+	"github.com/wealthpath/backend/pkg/rrule"
+)
+
+// Service-level errors for calendar feeds.
+var (
+	ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+)
+
+const (
+	// calendarRefresh is how often calendar clients are asked to refresh the feed.
+	calendarRefresh = 12 * time.Hour
+	// billReminder fires at 9:00 the day before an all-day bill event.
+	billReminder = 15 * time.Hour
+	// goalReminder fires a week before a savings goal's target date.
+	goalReminder = 7 * 24 * time.Hour
+)
+
+// CalendarFeedRepo stores the secret tokens of calendar feeds.
+type CalendarFeedRepo interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*model.CalendarFeed, error)
+	GetByToken(ctx context.Context, token string) (*model.CalendarFeed, error)
+	Save(ctx context.Context, feed *model.CalendarFeed) error
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+// CalendarRecurringRepo provides the recurring transactions shown in the calendar feed.
+type CalendarRecurringRepo interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]model.RecurringTransaction, error)
+}
+
+// CalendarDebtRepo provides the debts whose due dates are shown in the calendar feed.
+type CalendarDebtRepo interface {
+	List(ctx context.Context, userID uuid.UUID) ([]model.Debt, error)
+}
+
+// CalendarSavingsRepo provides the savings goals whose target dates are shown in the calendar feed.
+type CalendarSavingsRepo interface {
+	List(ctx context.Context, userID uuid.UUID) ([]model.SavingsGoal, error)
+}
+
+// CalendarService serves iCalendar feeds of upcoming bills, debt due dates and savings
+// goal target dates behind a per-user secret token.
+type CalendarService struct {
+	feeds         CalendarFeedRepo
+	recurringRepo CalendarRecurringRepo
+	debtRepo      CalendarDebtRepo
+	savingsRepo   CalendarSavingsRepo
+}
+
+// NewCalendarService creates a new CalendarService with the required repository dependencies.
+func NewCalendarService(
+	feeds CalendarFeedRepo,
+	recurringRepo CalendarRecurringRepo,
+	debtRepo CalendarDebtRepo,
+	savingsRepo CalendarSavingsRepo,
+) *CalendarService {
+	return &CalendarService{
+		feeds:         feeds,
+		recurringRepo: recurringRepo,
+		debtRepo:      debtRepo,
+		savingsRepo:   savingsRepo,
+	}
+}
+
+// GetFeed returns the user's calendar feed, creating it on first use.
+func (s *CalendarService) GetFeed(ctx context.Context, userID uuid.UUID) (*model.CalendarFeed, error) {
+	feed, err := s.feeds.GetByUserID(ctx, userID)
+	if errors.Is(err, repository.ErrCalendarFeedNotFound) {
+		return s.RotateFeed(ctx, userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting calendar feed for user %s: %w", userID, err)
+	}
+	return feed, nil
+}
+
+// RotateFeed gives the user's calendar feed a new token, so the old URL stops working.
+func (s *CalendarService) RotateFeed(ctx context.Context, userID uuid.UUID) (*model.CalendarFeed, error) {
+	token, err := newFeedToken()
+	if err != nil {
+		return nil, fmt.Errorf("generating calendar feed token: %w", err)
+	}
+	feed := &model.CalendarFeed{UserID: userID, Token: token}
+	if err := s.feeds.Save(ctx, feed); err != nil {
+		return nil, fmt.Errorf("saving calendar feed for user %s: %w", userID, err)
+	}
+	return feed, nil
+}
+
+// DisableFeed removes the user's calendar feed.
+func (s *CalendarService) DisableFeed(ctx context.Context, userID uuid.UUID) error {
+	if err := s.feeds.Delete(ctx, userID); err != nil {
+		return fmt.Errorf("deleting calendar feed for user %s: %w", userID, err)
+	}
+	return nil
+}
+
+// Calendar returns the calendar of the feed with the given token.
+// Returns ErrCalendarFeedNotFound for unknown tokens.
+func (s *CalendarService) Calendar(ctx context.Context, token string) (*ical.Calendar, error) {
+	feed, err := s.feeds.GetByToken(ctx, token)
+	if errors.Is(err, repository.ErrCalendarFeedNotFound) {
+		return nil, ErrCalendarFeedNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting calendar feed: %w", err)
+	}
+
+	recurring, err := s.recurringRepo.GetByUserID(ctx, feed.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("listing recurring transactions for user %s: %w", feed.UserID, err)
+	}
+	debts, err := s.debtRepo.List(ctx, feed.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("listing debts for user %s: %w", feed.UserID, err)
+	}
+	goals, err := s.savingsRepo.List(ctx, feed.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("listing savings goals for user %s: %w", feed.UserID, err)
+	}
+	return buildCalendar(recurring, debts, goals, time.Now()), nil
+}
+
+// buildCalendar turns the user's recurring transactions, debts and savings goals into
+// calendar events. Event UIDs are derived from the record IDs so that clients update
+// events in place when the feed is refreshed.
+func buildCalendar(recurring []model.RecurringTransaction, debts []model.Debt, goals []model.SavingsGoal, now time.Time) *ical.Calendar {
+	cal := &ical.Calendar{
+		ProdID:  "-//WealthPath//Bills and due dates//EN",
+		Name:    "WealthPath",
+		Refresh: calendarRefresh,
+		Stamp:   now,
+	}
+	for i := range recurring {
+		if event, ok := recurringEvent(&recurring[i]); ok {
+			cal.Events = append(cal.Events, event)
+		}
+	}
+	for i := range debts {
+		if event, ok := debtEvent(&debts[i]); ok {
+			cal.Events = append(cal.Events, event)
+		}
+	}
+	for i := range goals {
+		if event, ok := goalEvent(&goals[i]); ok {
+			cal.Events = append(cal.Events, event)
+		}
+	}
+	return cal
+}
+
+// recurringEvent is a repeating event for an active recurring transaction, starting at
+// its first occurrence and ending at its end date. It is on the scheduled days, before
+// any business-day policy moves them, which an RRULE cannot express.
+func recurringEvent(rt *model.RecurringTransaction) (ical.Event, bool) {
+	if !rt.IsActive {
+		return ical.Event{}, false
+	}
+	rule := *recurrenceRule(rt)
+	first, ok := rule.After(rt.StartDate, rt.StartDate, true)
+	if !ok {
+		return ical.Event{}, false
+	}
+
+	if rule.Until == nil && rule.Count == 0 && rt.EndDate != nil {
+		rule.Until = rt.EndDate
+	}
+	if rule.Until != nil {
+		// An all-day series must end on a date.
+		until := dateOf(*rule.Until)
+		rule.Until = &until
+	}
+
+	summary := fmt.Sprintf("%s (%s)", rt.Description, formatMoney(rt.Amount, rt.Currency))
+	if rt.Type == model.TransactionTypeIncome {
+		summary = fmt.Sprintf("%s (+%s)", rt.Description, formatMoney(rt.Amount, rt.Currency))
+	}
+	return ical.Event{
+		UID:         fmt.Sprintf("recurring-%s@wealthpath", rt.ID),
+		Summary:     summary,
+		Description: rt.Category,
+		Date:        dateOf(first),
+		RRule:       rule.String(),
+		Alarms:      []ical.Alarm{{Before: billReminder, Description: rt.Description + " is due tomorrow"}},
+	}, true
+}
+
+// debtEvent is a monthly event on the due day of a debt that is still owed. Installment
+// plans end at their last instalment.
+func debtEvent(debt *model.Debt) (ical.Event, bool) {
+	if debt.DueDay < 1 || debt.DueDay > 31 || !debt.CurrentBalance.IsPositive() {
+		return ical.Event{}, false
+	}
+
+	rule := dueDayRule(debt.DueDay)
+	start := dateOf(debt.StartDate)
+	first, ok := rule.After(start, start, true)
+	if debt.IsInstallmentPlan() {
+		first, ok = installmentDueDate(debt, 1), true
+		until := installmentDueDate(debt, *debt.TermMonths)
+		rule.Until = &until
+	}
+	if !ok {
+		return ical.Event{}, false
+	}
+
+	summary := debt.Name + " payment due"
+	if debt.MinimumPayment.IsPositive() {
+		summary = fmt.Sprintf("%s (%s)", summary, formatMoney(debt.MinimumPayment, debt.Currency))
+	}
+	return ical.Event{
+		UID:     fmt.Sprintf("debt-%s@wealthpath", debt.ID),
+		Summary: summary,
+		Date:    first,
+		RRule:   rule.String(),
+		Alarms:  []ical.Alarm{{Before: billReminder, Description: debt.Name + " payment is due tomorrow"}},
+	}, true
+}
+
+// dueDayRule is the monthly rule of a due day, on the last day of months too short for it.
+func dueDayRule(day int) *rrule.Rule {
+	rule := frequencyRule(model.FrequencyMonthly)
+	anchorMonthDay(rule, day)
+	return rule
+}
+
+// goalEvent is an event on the target date of a savings goal that is not yet reached.
+func goalEvent(goal *model.SavingsGoal) (ical.Event, bool) {
+	if goal.TargetDate == nil || goal.CurrentAmount.GreaterThanOrEqual(goal.TargetAmount) {
+		return ical.Event{}, false
+	}
+	return ical.Event{
+		UID:     fmt.Sprintf("goal-%s@wealthpath", goal.ID),
+		Summary: goal.Name + " target date",
+		Description: fmt.Sprintf("Saved %s of %s",
+			formatMoney(goal.CurrentAmount, goal.Currency), formatMoney(goal.TargetAmount, goal.Currency)),
+		Date:   dateOf(*goal.TargetDate),
+		Alarms: []ical.Alarm{{Before: goalReminder, Description: goal.Name + " target date is in a week"}},
+	}, true
+}
+
+// formatMoney formats an amount in the currency's standard style, such as 1500000₫.
+func formatMoney(amount decimal.Decimal, code string) string {
+	return currency.NewMoney(amount, currency.Currency(code)).Format()
+}
+
+// newFeedToken returns a random hex token for calendar feed URLs.
+func newFeedToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
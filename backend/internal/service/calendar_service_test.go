@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+	"github.com/wealthpath/backend/pkg/ical"
+)
+
+type MockCalendarFeedRepo struct {
+	mock.Mock
+}
+
+func (m *MockCalendarFeedRepo) GetByUserID(ctx context.Context, userID uuid.UUID) (*model.CalendarFeed, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CalendarFeed), args.Error(1)
+}
+
+func (m *MockCalendarFeedRepo) GetByToken(ctx context.Context, token string) (*model.CalendarFeed, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CalendarFeed), args.Error(1)
+}
+
+func (m *MockCalendarFeedRepo) Save(ctx context.Context, feed *model.CalendarFeed) error {
+	args := m.Called(ctx, feed)
+	return args.Error(0)
+}
+
+func (m *MockCalendarFeedRepo) Delete(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func TestCalendarService_GetFeed_CreatesOnFirstUse(t *testing.T) {
+	t.Parallel()
+
+	feeds := new(MockCalendarFeedRepo)
+	svc := NewCalendarService(feeds, new(MockRecurringRepo), new(MockDebtRepo), new(MockSavingsGoalRepo))
+	userID := uuid.New()
+
+	feeds.On("GetByUserID", mock.Anything, userID).Return(nil, repository.ErrCalendarFeedNotFound)
+	feeds.On("Save", mock.Anything, mock.MatchedBy(func(feed *model.CalendarFeed) bool {
+		return feed.UserID == userID && len(feed.Token) == 64
+	})).Return(nil)
+
+	feed, err := svc.GetFeed(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.Len(t, feed.Token, 64)
+	feeds.AssertExpectations(t)
+}
+
+func TestCalendarService_RotateFeed_NewToken(t *testing.T) {
+	t.Parallel()
+
+	feeds := new(MockCalendarFeedRepo)
+	svc := NewCalendarService(feeds, new(MockRecurringRepo), new(MockDebtRepo), new(MockSavingsGoalRepo))
+	userID := uuid.New()
+	feeds.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	first, err := svc.RotateFeed(context.Background(), userID)
+	require.NoError(t, err)
+	second, err := svc.RotateFeed(context.Background(), userID)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.Token, second.Token)
+}
+
+func TestCalendarService_Calendar(t *testing.T) {
+	t.Parallel()
+
+	feeds := new(MockCalendarFeedRepo)
+	recurringRepo := new(MockRecurringRepo)
+	debtRepo := new(MockDebtRepo)
+	savingsRepo := new(MockSavingsGoalRepo)
+	svc := NewCalendarService(feeds, recurringRepo, debtRepo, savingsRepo)
+	userID := uuid.New()
+
+	rent := model.RecurringTransaction{
+		ID: uuid.New(), UserID: userID, Type: model.TransactionTypeExpense, Amount: decimal.NewFromInt(500),
+		Currency: "USD", Description: "Rent", Frequency: model.FrequencyMonthly, StartDate: day(2026, time.January, 1), IsActive: true,
+	}
+	feeds.On("GetByToken", mock.Anything, "secret").Return(&model.CalendarFeed{UserID: userID, Token: "secret"}, nil)
+	feeds.On("GetByToken", mock.Anything, "stale").Return(nil, repository.ErrCalendarFeedNotFound)
+	recurringRepo.On("GetByUserID", mock.Anything, userID).Return([]model.RecurringTransaction{rent}, nil)
+	debtRepo.On("List", mock.Anything, userID).Return([]model.Debt{}, nil)
+	savingsRepo.On("List", mock.Anything, userID).Return([]model.SavingsGoal{}, nil)
+
+	cal, err := svc.Calendar(context.Background(), "secret")
+	require.NoError(t, err)
+	require.Len(t, cal.Events, 1)
+	assert.Equal(t, "recurring-"+rent.ID.String()+"@wealthpath", cal.Events[0].UID)
+
+	_, err = svc.Calendar(context.Background(), "stale")
+	assert.ErrorIs(t, err, ErrCalendarFeedNotFound)
+}
+
+func TestBuildCalendar(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	end := day(2027, time.June, 30)
+	recurring := []model.RecurringTransaction{
+		{
+			ID: uuid.New(), Type: model.TransactionTypeExpense, Amount: decimal.NewFromInt(8000000), Currency: "VND",
+			Category: "Housing", Description: "Rent", Frequency: model.FrequencyMonthly,
+			RRule: strPtr("FREQ=MONTHLY;BYMONTHDAY=15,-1"), StartDate: day(2026, time.October, 20), IsActive: true,
+		},
+		{
+			ID: uuid.New(), Type: model.TransactionTypeIncome, Amount: decimal.NewFromInt(2500), Currency: "USD",
+			Category: "Salary", Description: "Salary", Frequency: model.FrequencyBiweekly,
+			StartDate: day(2026, time.October, 2), EndDate: &end, IsActive: true,
+		},
+		{
+			ID: uuid.New(), Type: model.TransactionTypeExpense, Amount: decimal.NewFromInt(10), Currency: "USD",
+			Description: "Paused", Frequency: model.FrequencyMonthly, StartDate: day(2026, time.October, 1),
+		},
+	}
+
+	card := creditCard(userID)
+	card.Name = "Visa"
+	card.DueDay = 31
+	paidOff := personalLoan(userID, model.InterestMethodFlat)
+	paidOff.DueDay = 5
+	paidOff.CurrentBalance = decimal.Zero
+	plan := installmentPlan(userID, nil)
+	debts := []model.Debt{*card, *paidOff, *plan}
+
+	target := day(2027, time.March, 1)
+	goals := []model.SavingsGoal{
+		{ID: uuid.New(), Name: "Tết", TargetAmount: decimal.NewFromInt(20000000), CurrentAmount: decimal.NewFromInt(5000000), Currency: "VND", TargetDate: &target},
+		{ID: uuid.New(), Name: "Reached", TargetAmount: decimal.NewFromInt(100), CurrentAmount: decimal.NewFromInt(100), Currency: "USD", TargetDate: &target},
+		{ID: uuid.New(), Name: "Someday", TargetAmount: decimal.NewFromInt(100), Currency: "USD"},
+	}
+
+	now := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	cal := buildCalendar(recurring, debts, goals, now)
+
+	require.Len(t, cal.Events, 5)
+	assert.Equal(t, now, cal.Stamp)
+
+	rent := cal.Events[0]
+	assert.Equal(t, "recurring-"+recurring[0].ID.String()+"@wealthpath", rent.UID)
+	assert.Equal(t, "Rent (8000000₫)", rent.Summary)
+	assert.Equal(t, day(2026, time.October, 31), rent.Date)
+	assert.Equal(t, "FREQ=MONTHLY;BYMONTHDAY=15,-1", rent.RRule)
+	assert.Equal(t, []ical.Alarm{{Before: 15 * time.Hour, Description: "Rent is due tomorrow"}}, rent.Alarms)
+
+	salary := cal.Events[1]
+	assert.Equal(t, "Salary (+$2500.00)", salary.Summary)
+	assert.Equal(t, day(2026, time.October, 2), salary.Date)
+	assert.Equal(t, "FREQ=WEEKLY;INTERVAL=2;UNTIL=20270630", salary.RRule)
+
+	visa := cal.Events[2]
+	assert.Equal(t, "debt-"+card.ID.String()+"@wealthpath", visa.UID)
+	assert.Equal(t, "Visa payment due ($50.00)", visa.Summary)
+	assert.Equal(t, day(2026, time.January, 31), visa.Date)
+	assert.Equal(t, "FREQ=MONTHLY;BYMONTHDAY=28,29,30,31;BYSETPOS=-1", visa.RRule)
+
+	iphone := cal.Events[3]
+	assert.Equal(t, day(2026, time.August, 15), iphone.Date)
+	assert.Equal(t, "FREQ=MONTHLY;UNTIL=20270715;BYMONTHDAY=15", iphone.RRule)
+
+	tet := cal.Events[4]
+	assert.Equal(t, "goal-"+goals[0].ID.String()+"@wealthpath", tet.UID)
+	assert.Equal(t, target, tet.Date)
+	assert.Empty(t, tet.RRule)
+	assert.Equal(t, "Saved 5000000₫ of 20000000₫", tet.Description)
+	assert.Equal(t, 7*24*time.Hour, tet.Alarms[0].Before)
+}
+
+func TestDueDayRule_ClampsToMonthEnd(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		day  int
+		want []time.Time
+	}{
+		{15, []time.Time{day(2027, time.January, 15), day(2027, time.February, 15), day(2027, time.March, 15), day(2027, time.April, 15)}},
+		{30, []time.Time{day(2027, time.January, 30), day(2027, time.February, 28), day(2027, time.March, 30), day(2027, time.April, 30)}},
+		{31, []time.Time{day(2027, time.January, 31), day(2027, time.February, 28), day(2027, time.March, 31), day(2027, time.April, 30)}},
+	}
+
+	for _, tt := range tests {
+		rule := dueDayRule(tt.day)
+		start := day(2027, time.January, 1)
+		assert.Equal(t, tt.want, rule.Between(start, start, day(2027, time.April, 30)), "due day %d", tt.day)
+	}
+}
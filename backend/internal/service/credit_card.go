@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/pkg/datetime"
+)
+
+// Service-level errors for credit card statements.
+var (
+	ErrInvalidStatementCycle = errors.New("statement cycles are for credit cards: statementDay 1-31, gracePeriodDays 0-28, minimumPaymentPercent above 0 and at most 100")
+	ErrNoStatementCycle      = errors.New("debt is not a credit card with a statement day")
+	ErrInvalidCardCharge     = errors.New("only expenses in the card's currency can be charged to one of your credit cards")
+)
+
+const (
+	// defaultGracePeriodDays is the usual time Vietnamese issuers give between the
+	// statement date and the payment due date.
+	defaultGracePeriodDays = 15
+
+	// maxGracePeriodDays keeps a statement's due date no later than the next statement
+	// date, so whether it was paid in full is known when the next cycle closes.
+	maxGracePeriodDays = 28
+
+	// maxBackfilledStatements caps how many past cycles are closed for a card that
+	// has never had a statement.
+	maxBackfilledStatements = 12
+)
+
+// applyStatementCycle copies the statement cycle fields onto the debt, applying the
+// default grace period, and validates them. Clearing statementDay clears the cycle.
+func applyStatementCycle(debt *model.Debt, statementDay, graceDays *int, minimumPercent *decimal.Decimal) error {
+	debt.StatementDay = statementDay
+	debt.GracePeriodDays = graceDays
+	debt.MinimumPaymentPercent = minimumPercent
+
+	if statementDay == nil {
+		if graceDays != nil || minimumPercent != nil {
+			return ErrInvalidStatementCycle
+		}
+		return nil
+	}
+
+	if debt.Type != model.DebtTypeCreditCard || *statementDay < 1 || *statementDay > 31 {
+		return ErrInvalidStatementCycle
+	}
+	if graceDays == nil {
+		grace := defaultGracePeriodDays
+		debt.GracePeriodDays = &grace
+	} else if *graceDays < 0 || *graceDays > maxGracePeriodDays {
+		return ErrInvalidStatementCycle
+	}
+	if minimumPercent != nil && (!minimumPercent.IsPositive() || minimumPercent.GreaterThan(decimal.NewFromInt(100))) {
+		return ErrInvalidStatementCycle
+	}
+
+	return nil
+}
+
+// ListStatements returns a credit card's statements, newest first. Statements are
+// closed by CloseDueStatements, not when they are listed.
+func (s *DebtService) ListStatements(ctx context.Context, debtID, userID uuid.UUID) ([]model.CreditCardStatement, error) {
+	if _, err := s.getStatementCard(ctx, debtID, userID); err != nil {
+		return nil, err
+	}
+
+	statements, err := s.repo.ListStatements(ctx, debtID)
+	if err != nil {
+		return nil, fmt.Errorf("listing statements for debt %s: %w", debtID, err)
+	}
+	if statements == nil {
+		statements = []model.CreditCardStatement{}
+	}
+	return statements, nil
+}
+
+// GetStatement returns a statement with the spending and payments of its cycle and
+// how much of it has been paid by the due date.
+func (s *DebtService) GetStatement(ctx context.Context, debtID, statementID, userID uuid.UUID) (*model.CreditCardStatementDetail, error) {
+	debt, err := s.getStatementCard(ctx, debtID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := s.repo.GetStatement(ctx, debtID, statementID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching statement %s: %w", statementID, err)
+	}
+
+	transactions, err := s.repo.GetCardTransactions(ctx, debtID, statement.PeriodStart, &statement.PeriodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("listing transactions for statement %s: %w", statementID, err)
+	}
+	if transactions == nil {
+		transactions = []model.Transaction{}
+	}
+
+	payments, err := s.repo.GetPayments(ctx, debtID)
+	if err != nil {
+		return nil, fmt.Errorf("listing payments for debt %s: %w", debtID, err)
+	}
+
+	detail := &model.CreditCardStatementDetail{
+		CreditCardStatement: *statement,
+		Currency:            debt.Currency,
+		Transactions:        transactions,
+		PaymentsInCycle:     []model.DebtPayment{},
+		AmountPaid:          paidBetween(payments, statement.PeriodEnd, statement.DueDate),
+	}
+	for i := len(payments) - 1; i >= 0; i-- {
+		if inPeriod(payments[i].Date, statement.PeriodStart, statement.PeriodEnd) {
+			detail.PaymentsInCycle = append(detail.PaymentsInCycle, payments[i].DebtPayment)
+		}
+	}
+
+	detail.RemainingDue = decimal.Max(statement.ClosingBalance.Sub(detail.AmountPaid), decimal.Zero)
+	detail.MinimumRemaining = decimal.Max(statement.MinimumDue.Sub(detail.AmountPaid), decimal.Zero)
+	detail.PaidInFull = !detail.RemainingDue.IsPositive()
+	detail.Overdue = datetime.Today().After(statement.DueDate) && detail.MinimumRemaining.IsPositive()
+
+	return detail, nil
+}
+
+// CloseDueStatements closes the statement cycles of every credit card that have ended
+// and returns how many statements it closed. A card that fails is reported and the
+// rest are still closed.
+func (s *DebtService) CloseDueStatements(ctx context.Context) (int, error) {
+	cards, err := s.repo.ListStatementCards(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("listing credit cards with statement cycles: %w", err)
+	}
+
+	now := time.Now()
+	count := 0
+	var errs []error
+	for i := range cards {
+		closed, err := s.closeStatements(ctx, &cards[i], now)
+		count += closed
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return count, errors.Join(errs...)
+}
+
+// getStatementCard fetches a user's credit card and checks it is billed in statement cycles.
+func (s *DebtService) getStatementCard(ctx context.Context, debtID, userID uuid.UUID) (*model.Debt, error) {
+	debt, err := s.getOwnedDebt(ctx, debtID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !debt.HasStatementCycle() {
+		return nil, ErrNoStatementCycle
+	}
+	return debt, nil
+}
+
+// closeStatements closes every cycle whose statement date has passed since the last
+// statement. A card without statements starts from its start date, backfilling at most
+// maxBackfilledStatements cycles, with an opening balance derived from its current
+// balance less the spending and payments recorded since.
+// Interest for a cycle is only charged when the previous statement was not paid in full
+// by its due date; it is then a month's interest on that statement balance.
+// It returns how many statements it closed.
+func (s *DebtService) closeStatements(ctx context.Context, debt *model.Debt, now time.Time) (int, error) {
+	statements, err := s.repo.ListStatements(ctx, debt.ID)
+	if err != nil {
+		return 0, fmt.Errorf("listing statements for debt %s: %w", debt.ID, err)
+	}
+
+	var previous *model.CreditCardStatement
+	start := dateOf(debt.StartDate)
+	if len(statements) > 0 {
+		previous = &statements[0]
+		start = previous.PeriodEnd
+	}
+
+	ends := statementDatesBetween(*debt.StatementDay, start, dateOf(now))
+	if len(ends) == 0 {
+		return 0, nil
+	}
+	if previous == nil && len(ends) > maxBackfilledStatements {
+		start = ends[len(ends)-maxBackfilledStatements-1]
+		ends = ends[len(ends)-maxBackfilledStatements:]
+	}
+
+	charges, err := s.repo.GetCardTransactions(ctx, debt.ID, start, nil)
+	if err != nil {
+		return 0, fmt.Errorf("listing card transactions for debt %s: %w", debt.ID, err)
+	}
+	payments, err := s.repo.GetPayments(ctx, debt.ID)
+	if err != nil {
+		return 0, fmt.Errorf("listing payments for debt %s: %w", debt.ID, err)
+	}
+
+	places := currencyPlaces(debt.Currency)
+	grace := defaultGracePeriodDays
+	if debt.GracePeriodDays != nil {
+		grace = *debt.GracePeriodDays
+	}
+
+	var opening decimal.Decimal
+	if previous != nil {
+		opening = previous.ClosingBalance
+	} else {
+		opening = debt.CurrentBalance
+		for _, tx := range charges {
+			opening = opening.Sub(tx.Amount)
+		}
+		for _, p := range payments {
+			if p.Date.After(start) {
+				opening = opening.Add(p.Principal)
+			}
+		}
+	}
+
+	closed := 0
+	for _, end := range ends {
+		statement := &model.CreditCardStatement{
+			DebtID:         debt.ID,
+			PeriodStart:    start,
+			PeriodEnd:      end,
+			DueDate:        end.AddDate(0, 0, grace),
+			OpeningBalance: opening,
+			Purchases:      decimal.Zero,
+			Payments:       paidBetween(payments, start, end),
+			Interest:       statementInterest(debt, previous, payments, places),
+		}
+		for _, tx := range charges {
+			if inPeriod(tx.Date, start, end) {
+				statement.Purchases = statement.Purchases.Add(tx.Amount)
+			}
+		}
+		statement.ClosingBalance = opening.Add(statement.Purchases).Sub(statement.Payments).Add(statement.Interest)
+		statement.MinimumDue = minimumDue(debt, statement.ClosingBalance, places)
+
+		if err := s.repo.CloseStatement(ctx, statement); err != nil {
+			return 0, fmt.Errorf("closing statement %s for debt %s: %w", end.Format(datetime.DateFormat), debt.ID, err)
+		}
+
+		closed++
+
+		previous = statement
+		start = end
+		opening = statement.ClosingBalance
+	}
+
+	return closed, nil
+}
+
+// statementInterest is the interest charged on a cycle: a month's interest on the
+// previous statement balance when it was not paid in full by its due date.
+func statementInterest(debt *model.Debt, previous *model.CreditCardStatement, payments []model.DebtPaymentRecord, places int32) decimal.Decimal {
+	if previous == nil || !previous.ClosingBalance.IsPositive() {
+		return decimal.Zero
+	}
+	if paidBetween(payments, previous.PeriodEnd, previous.DueDate).GreaterThanOrEqual(previous.ClosingBalance) {
+		return decimal.Zero
+	}
+	monthlyRate := debt.InterestRate.Div(decimal.NewFromInt(100)).Div(decimal.NewFromInt(12))
+	return previous.ClosingBalance.Mul(monthlyRate).Round(places)
+}
+
+// minimumDue is the percentage of the statement balance, rounded up, but at least the
+// card's fixed minimum payment and never more than the statement balance.
+func minimumDue(debt *model.Debt, balance decimal.Decimal, places int32) decimal.Decimal {
+	if !balance.IsPositive() {
+		return decimal.Zero
+	}
+	due := debt.MinimumPayment
+	if debt.MinimumPaymentPercent != nil {
+		due = decimal.Max(due, balance.Mul(*debt.MinimumPaymentPercent).Div(decimal.NewFromInt(100)).RoundCeil(places))
+	}
+	return decimal.Min(due, balance)
+}
+
+// paidBetween sums the payments dated after from, up to and including to.
+func paidBetween(payments []model.DebtPaymentRecord, from, to time.Time) decimal.Decimal {
+	total := decimal.Zero
+	for _, p := range payments {
+		if inPeriod(p.Date, from, to) {
+			total = total.Add(p.Amount)
+		}
+	}
+	return total
+}
+
+// inPeriod reports whether date falls after start, up to and including end.
+func inPeriod(date, start, end time.Time) bool {
+	d := dateOf(date)
+	return d.After(start) && !d.After(end)
+}
+
+// statementDatesBetween lists the statement dates after start and before today.
+// The statement day is clamped to the end of shorter months.
+func statementDatesBetween(day int, start, today time.Time) []time.Time {
+	var dates []time.Time
+	for d := nextStatementDate(day, start); d.Before(today); d = nextStatementDate(day, d) {
+		dates = append(dates, d)
+	}
+	return dates
+}
+
+// nextStatementDate returns the first statement date after t.
+func nextStatementDate(day int, t time.Time) time.Time {
+	d := statementDate(t.Year(), t.Month(), day)
+	if !d.After(t) {
+		d = statementDate(t.Year(), t.Month()+1, day)
+	}
+	return d
+}
+
+func statementDate(year int, month time.Month, day int) time.Time {
+	first := datetime.NewDate(year, month, 1).Time
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, last)-1)
+}
+
+// dateOf drops the time of day, keeping the calendar date in UTC.
+func dateOf(t time.Time) time.Time {
+	return datetime.NewDate(t.Year(), t.Month(), t.Day()).Time
+}
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func creditCard(userID uuid.UUID) *model.Debt {
+	percent := decimal.NewFromInt(5)
+	return &model.Debt{
+		ID:                    uuid.New(),
+		UserID:                userID,
+		Type:                  model.DebtTypeCreditCard,
+		CurrentBalance:        decimal.NewFromInt(300),
+		InterestRate:          decimal.NewFromInt(24),
+		MinimumPayment:        decimal.NewFromInt(50),
+		Currency:              "USD",
+		StartDate:             day(2026, time.January, 1),
+		StatementDay:          intPtr(31),
+		GracePeriodDays:       intPtr(15),
+		MinimumPaymentPercent: &percent,
+	}
+}
+
+func cardPayment(date time.Time, amount int64) model.DebtPaymentRecord {
+	return model.DebtPaymentRecord{DebtPayment: model.DebtPayment{
+		ID:        uuid.New(),
+		Amount:    decimal.NewFromInt(amount),
+		Principal: decimal.NewFromInt(amount),
+		Interest:  decimal.Zero,
+		Date:      date,
+	}}
+}
+
+func TestApplyStatementCycle(t *testing.T) {
+	t.Parallel()
+
+	percent := decimal.NewFromInt(5)
+	tooHigh := decimal.NewFromInt(101)
+
+	tests := []struct {
+		name      string
+		debtType  model.DebtType
+		day       *int
+		grace     *int
+		percent   *decimal.Decimal
+		wantErr   bool
+		wantGrace *int
+	}{
+		{name: "no cycle", debtType: model.DebtTypeCreditCard},
+		{name: "default grace", debtType: model.DebtTypeCreditCard, day: intPtr(25), percent: &percent, wantGrace: intPtr(defaultGracePeriodDays)},
+		{name: "custom grace", debtType: model.DebtTypeCreditCard, day: intPtr(25), grace: intPtr(20), wantGrace: intPtr(20)},
+		{name: "not a card", debtType: model.DebtTypePersonalLoan, day: intPtr(25), wantErr: true},
+		{name: "day out of range", debtType: model.DebtTypeCreditCard, day: intPtr(32), wantErr: true},
+		{name: "grace past next statement", debtType: model.DebtTypeCreditCard, day: intPtr(25), grace: intPtr(29), wantErr: true},
+		{name: "percent over 100", debtType: model.DebtTypeCreditCard, day: intPtr(25), percent: &tooHigh, wantErr: true},
+		{name: "grace without day", debtType: model.DebtTypeCreditCard, grace: intPtr(15), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			debt := &model.Debt{Type: tt.debtType}
+			err := applyStatementCycle(debt, tt.day, tt.grace, tt.percent)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidStatementCycle)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantGrace, debt.GracePeriodDays)
+		})
+	}
+}
+
+func TestStatementDatesBetween(t *testing.T) {
+	t.Parallel()
+
+	// The 31st is clamped to the end of shorter months.
+	dates := statementDatesBetween(31, day(2026, time.January, 15), day(2026, time.May, 1))
+	assert.Equal(t, []time.Time{
+		day(2026, time.January, 31),
+		day(2026, time.February, 28),
+		day(2026, time.March, 31),
+		day(2026, time.April, 30),
+	}, dates)
+
+	// A statement closes the day after its statement date.
+	assert.Len(t, statementDatesBetween(10, day(2026, time.March, 10), day(2026, time.April, 10)), 0)
+	assert.Len(t, statementDatesBetween(10, day(2026, time.March, 10), day(2026, time.April, 11)), 1)
+}
+
+func TestMinimumDue(t *testing.T) {
+	t.Parallel()
+
+	card := creditCard(uuid.New())
+
+	tests := []struct {
+		name    string
+		balance decimal.Decimal
+		want    decimal.Decimal
+	}{
+		{name: "percentage", balance: decimal.NewFromInt(2000), want: decimal.NewFromInt(100)},
+		{name: "fixed floor", balance: decimal.NewFromInt(400), want: decimal.NewFromInt(50)},
+		{name: "capped at balance", balance: decimal.NewFromInt(30), want: decimal.NewFromInt(30)},
+		{name: "rounded up", balance: decimal.NewFromFloat(1234.5), want: decimal.NewFromFloat(61.73)},
+		{name: "credit balance", balance: decimal.NewFromInt(-20), want: decimal.Zero},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.True(t, tt.want.Equal(minimumDue(card, tt.balance, 2)), "got %s", minimumDue(card, tt.balance, 2))
+		})
+	}
+}
+
+func TestDebtService_CloseStatements(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	card := creditCard(uuid.New())
+
+	mockRepo.On("ListStatements", mock.Anything, card.ID).Return([]model.CreditCardStatement{}, nil)
+	mockRepo.On("GetCardTransactions", mock.Anything, card.ID, card.StartDate, (*time.Time)(nil)).Return([]model.Transaction{
+		{Amount: decimal.NewFromInt(600), Date: day(2026, time.January, 10)},
+		{Amount: decimal.NewFromInt(400), Date: day(2026, time.February, 5)},
+	}, nil)
+	mockRepo.On("GetPayments", mock.Anything, card.ID).Return([]model.DebtPaymentRecord{
+		cardPayment(day(2026, time.March, 10), 100),
+		cardPayment(day(2026, time.February, 10), 600),
+	}, nil)
+
+	var closed []model.CreditCardStatement
+	mockRepo.On("CloseStatement", mock.Anything, mock.AnythingOfType("*model.CreditCardStatement")).
+		Run(func(args mock.Arguments) {
+			closed = append(closed, *args.Get(1).(*model.CreditCardStatement))
+		}).Return(nil)
+
+	count, err := svc.closeStatements(context.Background(), card, day(2026, time.April, 5))
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	require.Len(t, closed, 3)
+
+	// January is paid in full by its due date, so February is interest free.
+	jan, feb, mar := closed[0], closed[1], closed[2]
+	assert.Equal(t, day(2026, time.January, 31), jan.PeriodEnd)
+	assert.Equal(t, day(2026, time.February, 15), jan.DueDate)
+	assert.True(t, jan.OpeningBalance.IsZero())
+	assert.True(t, jan.ClosingBalance.Equal(decimal.NewFromInt(600)))
+	assert.True(t, jan.MinimumDue.Equal(decimal.NewFromInt(50)))
+
+	assert.Equal(t, day(2026, time.February, 28), feb.PeriodEnd)
+	assert.True(t, feb.Purchases.Equal(decimal.NewFromInt(400)))
+	assert.True(t, feb.Payments.Equal(decimal.NewFromInt(600)))
+	assert.True(t, feb.Interest.IsZero())
+	assert.True(t, feb.ClosingBalance.Equal(decimal.NewFromInt(400)))
+
+	// Only 100 of February's 400 was paid by March 15th: a month's interest at 2%.
+	assert.True(t, mar.Interest.Equal(decimal.NewFromInt(8)))
+	assert.True(t, mar.ClosingBalance.Equal(decimal.NewFromInt(308)))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDebtService_CloseStatements_ContinuesFromLastStatement(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	card := creditCard(uuid.New())
+	last := model.CreditCardStatement{
+		DebtID:         card.ID,
+		PeriodStart:    day(2026, time.January, 31),
+		PeriodEnd:      day(2026, time.February, 28),
+		DueDate:        day(2026, time.March, 15),
+		ClosingBalance: decimal.NewFromInt(400),
+	}
+
+	mockRepo.On("ListStatements", mock.Anything, card.ID).Return([]model.CreditCardStatement{last}, nil)
+	mockRepo.On("GetCardTransactions", mock.Anything, card.ID, last.PeriodEnd, (*time.Time)(nil)).Return([]model.Transaction{}, nil)
+	mockRepo.On("GetPayments", mock.Anything, card.ID).Return([]model.DebtPaymentRecord{
+		cardPayment(day(2026, time.March, 14), 400),
+	}, nil)
+	mockRepo.On("CloseStatement", mock.Anything, mock.MatchedBy(func(st *model.CreditCardStatement) bool {
+		return st.PeriodEnd.Equal(day(2026, time.March, 31)) &&
+			st.OpeningBalance.Equal(decimal.NewFromInt(400)) &&
+			st.Interest.IsZero() &&
+			st.ClosingBalance.IsZero() &&
+			st.MinimumDue.IsZero()
+	})).Return(nil).Once()
+
+	_, err := svc.closeStatements(context.Background(), card, day(2026, time.April, 5))
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDebtService_CloseStatements_BackfillIsCapped(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	card := creditCard(uuid.New())
+	card.StartDate = day(2023, time.January, 1)
+
+	mockRepo.On("ListStatements", mock.Anything, card.ID).Return([]model.CreditCardStatement{}, nil)
+	mockRepo.On("GetCardTransactions", mock.Anything, card.ID, day(2025, time.March, 31), (*time.Time)(nil)).Return([]model.Transaction{}, nil)
+	mockRepo.On("GetPayments", mock.Anything, card.ID).Return([]model.DebtPaymentRecord{}, nil)
+	mockRepo.On("CloseStatement", mock.Anything, mock.Anything).Return(nil).Times(maxBackfilledStatements)
+
+	_, err := svc.closeStatements(context.Background(), card, day(2026, time.April, 5))
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDebtService_CloseDueStatements(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	failing := creditCard(uuid.New())
+	card := creditCard(uuid.New())
+	last := model.CreditCardStatement{DebtID: card.ID, PeriodEnd: dateOf(time.Now()).AddDate(0, 0, -40)}
+
+	mockRepo.On("ListStatementCards", mock.Anything).Return([]model.Debt{*failing, *card}, nil)
+	mockRepo.On("ListStatements", mock.Anything, failing.ID).Return(nil, errors.New("db error"))
+	mockRepo.On("ListStatements", mock.Anything, card.ID).Return([]model.CreditCardStatement{last}, nil)
+	mockRepo.On("GetCardTransactions", mock.Anything, card.ID, last.PeriodEnd, (*time.Time)(nil)).Return([]model.Transaction{}, nil)
+	mockRepo.On("GetPayments", mock.Anything, card.ID).Return([]model.DebtPaymentRecord{}, nil)
+	mockRepo.On("CloseStatement", mock.Anything, mock.AnythingOfType("*model.CreditCardStatement")).Return(nil)
+
+	count, err := svc.CloseDueStatements(context.Background())
+
+	assert.Error(t, err)
+	assert.GreaterOrEqual(t, count, 1)
+	mockRepo.AssertCalled(t, "CloseStatement", mock.Anything, mock.AnythingOfType("*model.CreditCardStatement"))
+}
+
+func TestDebtService_ListStatements(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	card := creditCard(userID)
+	statements := []model.CreditCardStatement{{DebtID: card.ID, PeriodEnd: day(2026, time.March, 31)}}
+
+	mockRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+	mockRepo.On("ListStatements", mock.Anything, card.ID).Return(statements, nil)
+
+	got, err := svc.ListStatements(context.Background(), card.ID, userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, statements, got)
+	mockRepo.AssertNotCalled(t, "CloseStatement", mock.Anything, mock.Anything)
+}
+
+func TestDebtService_ListStatements_NotACard(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	loan := &model.Debt{ID: uuid.New(), UserID: userID, Type: model.DebtTypePersonalLoan}
+
+	mockRepo.On("GetByID", mock.Anything, loan.ID).Return(loan, nil)
+
+	_, err := svc.ListStatements(context.Background(), loan.ID, userID)
+
+	assert.ErrorIs(t, err, ErrNoStatementCycle)
+}
+
+func TestDebtService_GetStatement(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	card := creditCard(userID)
+	statement := &model.CreditCardStatement{
+		ID:             uuid.New(),
+		DebtID:         card.ID,
+		PeriodStart:    day(2026, time.January, 31),
+		PeriodEnd:      day(2026, time.February, 28),
+		DueDate:        day(2026, time.March, 15),
+		ClosingBalance: decimal.NewFromInt(400),
+		MinimumDue:     decimal.NewFromInt(50),
+	}
+
+	mockRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+	mockRepo.On("GetStatement", mock.Anything, card.ID, statement.ID).Return(statement, nil)
+	mockRepo.On("GetCardTransactions", mock.Anything, card.ID, statement.PeriodStart, &statement.PeriodEnd).Return([]model.Transaction{
+		{Amount: decimal.NewFromInt(400), Date: day(2026, time.February, 5)},
+	}, nil)
+	mockRepo.On("GetPayments", mock.Anything, card.ID).Return([]model.DebtPaymentRecord{
+		cardPayment(day(2026, time.March, 20), 300),
+		cardPayment(day(2026, time.March, 10), 100),
+		cardPayment(day(2026, time.February, 10), 600),
+	}, nil)
+
+	detail, err := svc.GetStatement(context.Background(), card.ID, statement.ID, userID)
+
+	require.NoError(t, err)
+	assert.Len(t, detail.Transactions, 1)
+	require.Len(t, detail.PaymentsInCycle, 1)
+	assert.True(t, detail.PaymentsInCycle[0].Amount.Equal(decimal.NewFromInt(600)))
+
+	// The payment after the due date does not count towards the statement.
+	assert.True(t, detail.AmountPaid.Equal(decimal.NewFromInt(100)))
+	assert.True(t, detail.RemainingDue.Equal(decimal.NewFromInt(300)))
+	assert.True(t, detail.MinimumRemaining.IsZero())
+	assert.False(t, detail.PaidInFull)
+	assert.False(t, detail.Overdue)
+}
+
+func TestDebtService_GetStatement_NotFound(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	card := creditCard(userID)
+	statementID := uuid.New()
+
+	mockRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+	mockRepo.On("GetStatement", mock.Anything, card.ID, statementID).Return(nil, repository.ErrStatementNotFound)
+
+	_, err := svc.GetStatement(context.Background(), card.ID, statementID, userID)
+
+	assert.ErrorIs(t, err, repository.ErrStatementNotFound)
+}
+
+func TestSplitDebtPayment_StatementCard(t *testing.T) {
+	t.Parallel()
+
+	card := creditCard(uuid.New())
+
+	principal, interest := splitDebtPayment(card, decimal.NewFromInt(1000), decimal.NewFromInt(200))
+
+	assert.True(t, principal.Equal(decimal.NewFromInt(200)))
+	assert.True(t, interest.IsZero())
+}
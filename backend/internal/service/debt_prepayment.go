@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/pkg/datetime"
+)
+
+// Service-level errors for payoff scenarios.
+var (
+	ErrInvalidPrepayment     = errors.New("prepayments need a positive amount and a month from 1, or a date that is not in the past")
+	ErrInvalidPrepaymentMode = errors.New("afterPrepayment must be shorten_term or reduce_payment")
+	ErrNoPrepayments         = errors.New("add at least one lump sum or extra payment")
+)
+
+// LumpSumInput is a one-off prepayment, such as a Tết bonus. It is paid with the regular
+// payment of Month, where 1 is the next payment, or with the first payment on or after Date.
+type LumpSumInput struct {
+	Amount decimal.Decimal `json:"amount"`
+	Month  int             `json:"month,omitempty"`
+	Date   *datetime.Date  `json:"date,omitempty"`
+}
+
+// ExtraPaymentInput is paid on top of every regular payment from StartMonth through
+// EndMonth, or until the debt is repaid when EndMonth is not set.
+type ExtraPaymentInput struct {
+	Amount     decimal.Decimal `json:"amount"`
+	StartMonth int             `json:"startMonth"`
+	EndMonth   *int            `json:"endMonth,omitempty"`
+}
+
+type PayoffScenarioInput struct {
+	MonthlyPayment  decimal.Decimal      `json:"monthlyPayment"` // defaults to the debt's minimum payment
+	LumpSums        []LumpSumInput       `json:"lumpSums"`
+	ExtraPayments   []ExtraPaymentInput  `json:"extraPayments"`
+	AfterPrepayment model.PrepaymentMode `json:"afterPrepayment"` // defaults to shorten_term
+}
+
+// prepaymentPlan holds the amounts paid on top of the regular payment, by month of a
+// payoff plan. With reducePayment, the regular payment after each prepayment is
+// recalculated to repay the balance by termMonths instead of finishing sooner.
+type prepaymentPlan struct {
+	lumpSums      map[int]decimal.Decimal
+	extras        []ExtraPaymentInput
+	reducePayment bool
+	termMonths    int
+}
+
+func (p *prepaymentPlan) extraFor(month int) decimal.Decimal {
+	total := p.lumpSums[month]
+	for _, e := range p.extras {
+		if month >= e.StartMonth && (e.EndMonth == nil || month <= *e.EndMonth) {
+			total = total.Add(e.Amount)
+		}
+	}
+	return total
+}
+
+// GetPayoffScenario calculates the payoff plan of a debt with lump sums and recurring
+// extra payments and compares it with the baseline plan at the same monthly payment.
+// Prepayments either shorten the term or reduce the payment so the debt is still repaid
+// in the baseline's month.
+func (s *DebtService) GetPayoffScenario(ctx context.Context, id, userID uuid.UUID, input PayoffScenarioInput) (*model.PayoffScenario, error) {
+	mode := input.AfterPrepayment
+	if mode == "" {
+		mode = model.PrepaymentShortenTerm
+	}
+	if mode != model.PrepaymentShortenTerm && mode != model.PrepaymentReducePayment {
+		return nil, ErrInvalidPrepaymentMode
+	}
+	if len(input.LumpSums) == 0 && len(input.ExtraPayments) == 0 {
+		return nil, ErrNoPrepayments
+	}
+
+	prepayments, err := newPrepaymentPlan(input, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	debt, err := s.getOwnedDebt(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	monthlyPayment := input.MonthlyPayment
+	if monthlyPayment.IsZero() {
+		monthlyPayment = debt.MinimumPayment
+	}
+
+	var schedule *rateSchedule
+	if debt.HasRateSchedule() {
+		schedule, err = s.buildRateSchedule(ctx, debt, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	baseline := calculatePayoffPlan(debt, monthlyPayment, schedule, nil)
+	prepayments.reducePayment = mode == model.PrepaymentReducePayment
+	prepayments.termMonths = baseline.MonthsToPayoff
+	plan := calculatePayoffPlan(debt, monthlyPayment, schedule, prepayments)
+
+	scenario := &model.PayoffScenario{
+		DebtID:              debt.ID,
+		AfterPrepayment:     mode,
+		Baseline:            *baseline,
+		Scenario:            *plan,
+		TotalExtraPayment:   decimal.Zero,
+		InterestSaved:       baseline.TotalInterest.Sub(plan.TotalInterest),
+		MonthsSaved:         baseline.MonthsToPayoff - plan.MonthsToPayoff,
+		FinalRegularPayment: decimal.Zero,
+	}
+	rows := plan.AmortizationPlan
+	for _, row := range rows {
+		scenario.TotalExtraPayment = scenario.TotalExtraPayment.Add(row.ExtraPayment)
+	}
+	if n := len(rows); n > 0 {
+		// The last instalment only repays what is left; the one before it shows the regular payment.
+		row := rows[max(n-2, 0)]
+		scenario.FinalRegularPayment = row.Payment.Sub(row.ExtraPayment)
+	}
+
+	return scenario, nil
+}
+
+// newPrepaymentPlan validates the prepayments and places dated lump sums in the month of
+// the first payment on or after their date.
+func newPrepaymentPlan(input PayoffScenarioInput, now time.Time) (*prepaymentPlan, error) {
+	plan := &prepaymentPlan{lumpSums: make(map[int]decimal.Decimal, len(input.LumpSums))}
+	today := dateOf(now)
+
+	for _, l := range input.LumpSums {
+		if !l.Amount.IsPositive() {
+			return nil, ErrInvalidPrepayment
+		}
+		month := l.Month
+		if l.Date != nil {
+			if l.Month != 0 || l.Date.Before(today) {
+				return nil, ErrInvalidPrepayment
+			}
+			month = max(monthsBetween(today, l.Date.Time), 1)
+			if today.AddDate(0, month, 0).Before(l.Date.Time) {
+				month++
+			}
+		}
+		if month < 1 {
+			return nil, ErrInvalidPrepayment
+		}
+		plan.lumpSums[month] = plan.lumpSums[month].Add(l.Amount)
+	}
+
+	for _, e := range input.ExtraPayments {
+		if !e.Amount.IsPositive() || e.StartMonth < 1 || (e.EndMonth != nil && *e.EndMonth < e.StartMonth) {
+			return nil, ErrInvalidPrepayment
+		}
+	}
+	plan.extras = input.ExtraPayments
+
+	return plan, nil
+}
+
+// reducedPayment recalculates the regular payment after a prepayment so the balance is
+// repaid over the months left. The payment is never raised. Flat-rate debts keep paying
+// interest on the original amount; equal-principal debts spread the balance evenly.
+func reducedPayment(debt *model.Debt, method model.InterestMethod, balance, rate decimal.Decimal, left int, payment, scheduledPrincipal decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	places := currencyPlaces(debt.Currency)
+	months := decimal.NewFromInt(int64(left))
+
+	switch method {
+	case model.InterestMethodFlat:
+		monthlyRate := rate.Div(decimal.NewFromInt(100)).Div(decimal.NewFromInt(12))
+		required := balance.Div(months).Add(debt.OriginalAmount.Mul(monthlyRate)).RoundCeil(places)
+		return decimal.Min(payment, required), scheduledPrincipal
+	case model.InterestMethodEqualPrincipal:
+		principal := balance.Div(months).RoundCeil(places)
+		if scheduledPrincipal.IsPositive() {
+			principal = decimal.Min(principal, scheduledPrincipal)
+		}
+		return decimal.Zero, principal
+	default:
+		return decimal.Min(payment, annuityPayment(balance, rate, left, places)), scheduledPrincipal
+	}
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+	"github.com/wealthpath/backend/pkg/datetime"
+)
+
+func personalLoan(userID uuid.UUID, method model.InterestMethod) *model.Debt {
+	balance := decimal.NewFromInt(120000000)
+	term := 24
+	return &model.Debt{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Type:           model.DebtTypePersonalLoan,
+		OriginalAmount: balance,
+		CurrentBalance: balance,
+		InterestRate:   decimal.NewFromInt(12),
+		MinimumPayment: loanSchedule(balance, decimal.NewFromInt(12), term, method, 0)[0].Payment,
+		Currency:       "VND",
+		StartDate:      time.Now(),
+		TermMonths:     &term,
+		InterestMethod: method,
+	}
+}
+
+func TestDebtService_GetPayoffScenario_ShortenTerm(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	debt := personalLoan(userID, model.InterestMethodDecliningBalance)
+
+	mockRepo.On("GetByID", mock.Anything, debt.ID).Return(debt, nil)
+
+	scenario, err := svc.GetPayoffScenario(context.Background(), debt.ID, userID, PayoffScenarioInput{
+		LumpSums:      []LumpSumInput{{Amount: decimal.NewFromInt(20000000), Month: 4}},
+		ExtraPayments: []ExtraPaymentInput{{Amount: decimal.NewFromInt(1000000), StartMonth: 1, EndMonth: intPtr(6)}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, model.PrepaymentShortenTerm, scenario.AfterPrepayment)
+	assert.Equal(t, 24, scenario.Baseline.MonthsToPayoff)
+	assert.Positive(t, scenario.MonthsSaved)
+	assert.Equal(t, scenario.Baseline.MonthsToPayoff-scenario.Scenario.MonthsToPayoff, scenario.MonthsSaved)
+	assert.True(t, scenario.InterestSaved.IsPositive())
+	assert.True(t, scenario.TotalExtraPayment.Equal(decimal.NewFromInt(26000000)))
+	assert.True(t, scenario.FinalRegularPayment.Equal(debt.MinimumPayment))
+
+	rows := scenario.Scenario.AmortizationPlan
+	assert.True(t, rows[3].ExtraPayment.Equal(decimal.NewFromInt(21000000)))
+	assert.True(t, rows[3].Payment.Equal(debt.MinimumPayment.Add(rows[3].ExtraPayment)))
+	assert.True(t, rows[6].ExtraPayment.IsZero())
+	assert.True(t, rows[len(rows)-1].RemainingBalance.IsZero())
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDebtService_GetPayoffScenario_ReducePayment(t *testing.T) {
+	t.Parallel()
+
+	methods := []model.InterestMethod{
+		model.InterestMethodDecliningBalance,
+		model.InterestMethodFlat,
+		model.InterestMethodEqualPrincipal,
+	}
+
+	for _, method := range methods {
+		method := method
+		t.Run(string(method), func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockDebtRepo)
+			svc := NewDebtService(mockRepo)
+			userID := uuid.New()
+			debt := personalLoan(userID, method)
+
+			mockRepo.On("GetByID", mock.Anything, debt.ID).Return(debt, nil)
+
+			scenario, err := svc.GetPayoffScenario(context.Background(), debt.ID, userID, PayoffScenarioInput{
+				LumpSums:        []LumpSumInput{{Amount: decimal.NewFromInt(30000000), Month: 6}},
+				AfterPrepayment: model.PrepaymentReducePayment,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, scenario.Baseline.MonthsToPayoff, scenario.Scenario.MonthsToPayoff)
+			assert.Zero(t, scenario.MonthsSaved)
+			if method == model.InterestMethodFlat {
+				// Flat-rate interest is charged on the original amount, so only a shorter term saves interest.
+				assert.True(t, scenario.InterestSaved.IsZero())
+			} else {
+				assert.True(t, scenario.InterestSaved.IsPositive())
+			}
+
+			rows := scenario.Scenario.AmortizationPlan
+			baseline := scenario.Baseline.AmortizationPlan
+			assert.True(t, rows[6].Payment.LessThan(baseline[6].Payment))
+			assert.True(t, scenario.FinalRegularPayment.LessThan(baseline[len(baseline)-2].Payment))
+			for i := 7; i < len(rows); i++ {
+				assert.True(t, rows[i].Payment.LessThanOrEqual(rows[i-1].Payment), "month %d", rows[i].Month)
+			}
+			assert.True(t, rows[len(rows)-1].RemainingBalance.IsZero())
+		})
+	}
+}
+
+func TestDebtService_GetPayoffScenario_CapsAtBalance(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	debt := personalLoan(userID, model.InterestMethodDecliningBalance)
+
+	mockRepo.On("GetByID", mock.Anything, debt.ID).Return(debt, nil)
+
+	scenario, err := svc.GetPayoffScenario(context.Background(), debt.ID, userID, PayoffScenarioInput{
+		LumpSums: []LumpSumInput{{Amount: decimal.NewFromInt(500000000), Month: 1}},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, scenario.Scenario.AmortizationPlan, 1)
+	row := scenario.Scenario.AmortizationPlan[0]
+	assert.True(t, row.Payment.Equal(debt.CurrentBalance.Add(row.Interest)))
+	assert.True(t, scenario.TotalExtraPayment.Equal(row.Payment.Sub(debt.MinimumPayment)))
+	assert.Equal(t, 23, scenario.MonthsSaved)
+}
+
+func TestDebtService_GetPayoffScenario_Errors(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	past := datetime.NewDate(2000, time.January, 1)
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		input   PayoffScenarioInput
+		wantErr error
+	}{
+		{
+			name:    "no prepayments",
+			userID:  userID,
+			input:   PayoffScenarioInput{},
+			wantErr: ErrNoPrepayments,
+		},
+		{
+			name:    "invalid mode",
+			userID:  userID,
+			input:   PayoffScenarioInput{LumpSums: []LumpSumInput{{Amount: decimal.NewFromInt(1), Month: 1}}, AfterPrepayment: "skip"},
+			wantErr: ErrInvalidPrepaymentMode,
+		},
+		{
+			name:    "non-positive lump sum",
+			userID:  userID,
+			input:   PayoffScenarioInput{LumpSums: []LumpSumInput{{Amount: decimal.Zero, Month: 1}}},
+			wantErr: ErrInvalidPrepayment,
+		},
+		{
+			name:    "lump sum without month",
+			userID:  userID,
+			input:   PayoffScenarioInput{LumpSums: []LumpSumInput{{Amount: decimal.NewFromInt(1)}}},
+			wantErr: ErrInvalidPrepayment,
+		},
+		{
+			name:    "past date",
+			userID:  userID,
+			input:   PayoffScenarioInput{LumpSums: []LumpSumInput{{Amount: decimal.NewFromInt(1), Date: &past}}},
+			wantErr: ErrInvalidPrepayment,
+		},
+		{
+			name:    "extra payment ends before it starts",
+			userID:  userID,
+			input:   PayoffScenarioInput{ExtraPayments: []ExtraPaymentInput{{Amount: decimal.NewFromInt(1), StartMonth: 5, EndMonth: intPtr(4)}}},
+			wantErr: ErrInvalidPrepayment,
+		},
+		{
+			name:    "other user's debt",
+			userID:  uuid.New(),
+			input:   PayoffScenarioInput{ExtraPayments: []ExtraPaymentInput{{Amount: decimal.NewFromInt(1), StartMonth: 1}}},
+			wantErr: repository.ErrDebtNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockDebtRepo)
+			svc := NewDebtService(mockRepo)
+			debt := personalLoan(userID, model.InterestMethodDecliningBalance)
+
+			mockRepo.On("GetByID", mock.Anything, debt.ID).Return(debt, nil).Maybe()
+
+			_, err := svc.GetPayoffScenario(context.Background(), debt.ID, tt.userID, tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNewPrepaymentPlan_Dates(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.October, 18, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		date      datetime.Date
+		wantMonth int
+	}{
+		{name: "today", date: datetime.NewDate(2026, time.October, 18), wantMonth: 1},
+		{name: "next payment date", date: datetime.NewDate(2026, time.November, 18), wantMonth: 1},
+		{name: "day after a payment", date: datetime.NewDate(2026, time.November, 19), wantMonth: 2},
+		{name: "Tết bonus", date: datetime.NewDate(2027, time.February, 10), wantMonth: 4},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan, err := newPrepaymentPlan(PayoffScenarioInput{
+				LumpSums: []LumpSumInput{{Amount: decimal.NewFromInt(100), Date: &tt.date}},
+			}, now)
+
+			require.NoError(t, err)
+			assert.True(t, plan.extraFor(tt.wantMonth).Equal(decimal.NewFromInt(100)))
+			assert.True(t, plan.extraFor(tt.wantMonth+1).IsZero())
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/wealthpath/backend/internal/model"
+)
+
+// Service-level errors for variable-rate debts.
+var (
+	ErrInvalidRateSchedule   = errors.New("variable rate requires termMonths, fixedRateMonths shorter than the term, referenceBankCode and referenceTermMonths")
+	ErrReferenceRateNotFound = errors.New("reference mortgage rate not found")
+)
+
+// defaultRateResetMonths is how often a floating rate is reset when the debt does not say;
+// Vietnamese banks usually review floating mortgage rates quarterly.
+const defaultRateResetMonths = 3
+
+// MortgageRateProvider looks up published bank rates used as floating-rate references.
+type MortgageRateProvider interface {
+	ListRates(ctx context.Context, productType string, termMonths *int, bankCode string) ([]model.InterestRate, error)
+}
+
+// SetRateProvider sets the provider of reference mortgage rates for variable-rate debts.
+func (s *DebtService) SetRateProvider(provider MortgageRateProvider) {
+	s.rates = provider
+}
+
+// rateSchedule describes how a variable rate evolves over a payoff plan,
+// with months counted from the first month of the plan.
+type rateSchedule struct {
+	fixedRate     decimal.Decimal
+	floatingRate  decimal.Decimal
+	firstFloating int // first month charged at the floating rate
+	resetMonths   int
+	remainingTerm int // months left on the loan at the start of the plan
+}
+
+// rateFor returns the annual rate charged in the given month.
+func (rs *rateSchedule) rateFor(month int) decimal.Decimal {
+	if month < rs.firstFloating {
+		return rs.fixedRate
+	}
+	return rs.floatingRate
+}
+
+// resetsAt reports whether the rate is reset, and the payment recalculated, in the given month.
+func (rs *rateSchedule) resetsAt(month int) bool {
+	return month >= rs.firstFloating && (month-rs.firstFloating)%rs.resetMonths == 0
+}
+
+// applyRateSchedule copies the variable-rate fields onto the debt, applying the
+// default reset interval, and validates them. Clearing fixedRateMonths clears the schedule.
+func applyRateSchedule(debt *model.Debt, termMonths, fixedRateMonths *int, margin *decimal.Decimal, bankCode *string, referenceTerm, resetMonths *int) error {
+	debt.TermMonths = termMonths
+	debt.FixedRateMonths = fixedRateMonths
+	debt.RateMargin = margin
+	debt.ReferenceBankCode = bankCode
+	debt.ReferenceTermMonths = referenceTerm
+	debt.RateResetMonths = resetMonths
+
+	if termMonths != nil && *termMonths <= 0 {
+		return ErrInvalidRateSchedule
+	}
+
+	anySet := fixedRateMonths != nil || margin != nil || bankCode != nil || referenceTerm != nil || resetMonths != nil
+	if !anySet {
+		return nil
+	}
+	if !debt.HasRateSchedule() || termMonths == nil || *bankCode == "" || *referenceTerm <= 0 {
+		return ErrInvalidRateSchedule
+	}
+	if *fixedRateMonths < 0 || *fixedRateMonths >= *termMonths {
+		return ErrInvalidRateSchedule
+	}
+	if resetMonths == nil {
+		reset := defaultRateResetMonths
+		debt.RateResetMonths = &reset
+	} else if *resetMonths <= 0 {
+		return ErrInvalidRateSchedule
+	}
+
+	return nil
+}
+
+// buildRateSchedule resolves the floating rate of a variable-rate debt from the
+// latest published mortgage rate of the reference product plus the debt's margin.
+func (s *DebtService) buildRateSchedule(ctx context.Context, debt *model.Debt, now time.Time) (*rateSchedule, error) {
+	if s.rates == nil {
+		return nil, ErrReferenceRateNotFound
+	}
+
+	rates, err := s.rates.ListRates(ctx, "mortgage", debt.ReferenceTermMonths, *debt.ReferenceBankCode)
+	if err != nil {
+		return nil, fmt.Errorf("fetching reference rate for debt %s: %w", debt.ID, err)
+	}
+
+	var reference *model.InterestRate
+	for i := range rates {
+		if reference == nil || rates[i].EffectiveDate.After(reference.EffectiveDate) {
+			reference = &rates[i]
+		}
+	}
+	if reference == nil {
+		return nil, ErrReferenceRateNotFound
+	}
+
+	floating := reference.Rate
+	if debt.RateMargin != nil {
+		floating = floating.Add(*debt.RateMargin)
+	}
+
+	elapsed := monthsBetween(debt.StartDate, now)
+
+	resetMonths := defaultRateResetMonths
+	if debt.RateResetMonths != nil {
+		resetMonths = *debt.RateResetMonths
+	}
+
+	remaining := 1
+	if debt.TermMonths != nil && *debt.TermMonths-elapsed > 1 {
+		remaining = *debt.TermMonths - elapsed
+	}
+
+	return &rateSchedule{
+		fixedRate:     debt.InterestRate,
+		floatingRate:  floating,
+		firstFloating: max(*debt.FixedRateMonths-elapsed, 0) + 1,
+		resetMonths:   resetMonths,
+		remainingTerm: remaining,
+	}, nil
+}
+
+// annuityPayment is the level monthly payment that repays balance over the given
+// number of months at an annual rate in percent, rounded up to the currency's minor unit.
+func annuityPayment(balance, annualRate decimal.Decimal, months int, places int32) decimal.Decimal {
+	if months < 1 {
+		months = 1
+	}
+
+	r := annualRate.Div(decimal.NewFromInt(100)).Div(decimal.NewFromInt(12))
+	if r.IsZero() {
+		return balance.Div(decimal.NewFromInt(int64(months))).RoundCeil(places)
+	}
+
+	// M = P * r(1+r)^n / ((1+r)^n - 1)
+	growth := decimalPow(decimal.NewFromInt(1).Add(r), months)
+	return balance.Mul(r).Mul(growth).Div(growth.Sub(decimal.NewFromInt(1))).RoundCeil(places)
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func variableRateMortgage(startDate time.Time) *model.Debt {
+	bank := "vcb"
+	margin := decimal.NewFromFloat(3.5)
+	balance := decimal.NewFromInt(1000000000)
+	return &model.Debt{
+		ID:                  uuid.New(),
+		Type:                model.DebtTypeMortgage,
+		CurrentBalance:      balance,
+		InterestRate:        decimal.NewFromInt(6),
+		MinimumPayment:      annuityPayment(balance, decimal.NewFromInt(6), 240, 0),
+		Currency:            "VND",
+		StartDate:           startDate,
+		TermMonths:          intPtr(240),
+		FixedRateMonths:     intPtr(12),
+		RateMargin:          &margin,
+		ReferenceBankCode:   &bank,
+		ReferenceTermMonths: intPtr(120),
+		RateResetMonths:     intPtr(3),
+	}
+}
+
+func TestDebtService_GetPayoffPlan_VariableRate(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	mockRates := new(MockDepositRateProvider)
+	svc := NewDebtService(mockRepo)
+	svc.SetRateProvider(mockRates)
+	debt := variableRateMortgage(time.Now())
+
+	mockRepo.On("GetByID", mock.Anything, debt.ID).Return(debt, nil)
+	mockRates.On("ListRates", mock.Anything, "mortgage", debt.ReferenceTermMonths, "vcb").Return([]model.InterestRate{
+		{BankCode: "vcb", ProductType: "mortgage", TermMonths: 120, Rate: decimal.NewFromFloat(8.5), EffectiveDate: time.Now().AddDate(0, -6, 0)},
+		{BankCode: "vcb", ProductType: "mortgage", TermMonths: 120, Rate: decimal.NewFromInt(7), EffectiveDate: time.Now()},
+	}, nil)
+
+	plan, err := svc.GetPayoffPlan(context.Background(), debt.ID, decimal.Zero)
+
+	require.NoError(t, err)
+
+	// The latest reference rate plus margin applies from month 13.
+	require.Len(t, plan.RateResets, 1)
+	reset := plan.RateResets[0]
+	assert.Equal(t, 13, reset.Month)
+	assert.True(t, reset.PreviousRate.Equal(decimal.NewFromInt(6)))
+	assert.True(t, reset.Rate.Equal(decimal.NewFromFloat(10.5)))
+	assert.True(t, reset.Payment.GreaterThan(reset.PreviousPayment), "expected payment shock")
+
+	assert.True(t, plan.AmortizationPlan[11].Rate.Equal(decimal.NewFromInt(6)))
+	assert.True(t, plan.AmortizationPlan[12].Rate.Equal(decimal.NewFromFloat(10.5)))
+	assert.True(t, plan.AmortizationPlan[12].Payment.Equal(reset.Payment))
+
+	// Re-amortizing at each reset keeps the loan on its 20-year term.
+	assert.LessOrEqual(t, plan.MonthsToPayoff, 240)
+	assert.GreaterOrEqual(t, plan.MonthsToPayoff, 238)
+}
+
+func TestDebtService_GetPayoffPlan_VariableRateAlreadyFloating(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	mockRates := new(MockDepositRateProvider)
+	svc := NewDebtService(mockRepo)
+	svc.SetRateProvider(mockRates)
+	debt := variableRateMortgage(time.Now().AddDate(-3, 0, 0))
+
+	mockRepo.On("GetByID", mock.Anything, debt.ID).Return(debt, nil)
+	mockRates.On("ListRates", mock.Anything, "mortgage", mock.Anything, "vcb").Return([]model.InterestRate{
+		{Rate: decimal.NewFromInt(7), EffectiveDate: time.Now()},
+	}, nil)
+
+	plan, err := svc.GetPayoffPlan(context.Background(), debt.ID, decimal.Zero)
+
+	require.NoError(t, err)
+	require.Len(t, plan.RateResets, 1)
+	assert.Equal(t, 1, plan.RateResets[0].Month)
+	assert.LessOrEqual(t, plan.MonthsToPayoff, 204)
+}
+
+func TestDebtService_GetPayoffPlan_ReferenceRateNotFound(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		provider bool
+	}{
+		{name: "no provider"},
+		{name: "no published rate", provider: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockDebtRepo)
+			svc := NewDebtService(mockRepo)
+			debt := variableRateMortgage(time.Now())
+			mockRepo.On("GetByID", mock.Anything, debt.ID).Return(debt, nil)
+			if tt.provider {
+				mockRates := new(MockDepositRateProvider)
+				mockRates.On("ListRates", mock.Anything, "mortgage", mock.Anything, "vcb").Return([]model.InterestRate{}, nil)
+				svc.SetRateProvider(mockRates)
+			}
+
+			plan, err := svc.GetPayoffPlan(context.Background(), debt.ID, decimal.Zero)
+
+			assert.ErrorIs(t, err, ErrReferenceRateNotFound)
+			assert.Nil(t, plan)
+		})
+	}
+}
+
+func TestDebtService_Create_RateSchedule(t *testing.T) {
+	t.Parallel()
+
+	bank := "vcb"
+	empty := ""
+
+	tests := []struct {
+		name      string
+		input     CreateDebtInput
+		wantReset *int
+		wantErr   error
+	}{
+		{
+			name:  "fixed rate debt",
+			input: CreateDebtInput{Name: "Card"},
+		},
+		{
+			name:      "defaults reset interval",
+			input:     CreateDebtInput{Name: "Home", TermMonths: intPtr(240), FixedRateMonths: intPtr(12), ReferenceBankCode: &bank, ReferenceTermMonths: intPtr(120)},
+			wantReset: intPtr(defaultRateResetMonths),
+		},
+		{
+			name:    "missing term",
+			input:   CreateDebtInput{Name: "Home", FixedRateMonths: intPtr(12), ReferenceBankCode: &bank, ReferenceTermMonths: intPtr(120)},
+			wantErr: ErrInvalidRateSchedule,
+		},
+		{
+			name:    "missing reference",
+			input:   CreateDebtInput{Name: "Home", TermMonths: intPtr(240), FixedRateMonths: intPtr(12)},
+			wantErr: ErrInvalidRateSchedule,
+		},
+		{
+			name:    "empty bank code",
+			input:   CreateDebtInput{Name: "Home", TermMonths: intPtr(240), FixedRateMonths: intPtr(12), ReferenceBankCode: &empty, ReferenceTermMonths: intPtr(120)},
+			wantErr: ErrInvalidRateSchedule,
+		},
+		{
+			name:    "fixed period longer than term",
+			input:   CreateDebtInput{Name: "Home", TermMonths: intPtr(12), FixedRateMonths: intPtr(24), ReferenceBankCode: &bank, ReferenceTermMonths: intPtr(120)},
+			wantErr: ErrInvalidRateSchedule,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockDebtRepo)
+			svc := NewDebtService(mockRepo)
+			mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Debt")).Return(nil).Maybe()
+
+			debt, err := svc.Create(context.Background(), uuid.New(), tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, debt)
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReset, debt.RateResetMonths)
+		})
+	}
+}
+
+func TestAnnuityPayment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		balance decimal.Decimal
+		rate    decimal.Decimal
+		months  int
+		places  int32
+		want    decimal.Decimal
+	}{
+		{name: "zero rate", balance: decimal.NewFromInt(1200), rate: decimal.Zero, months: 12, places: 2, want: decimal.NewFromInt(100)},
+		{name: "standard loan", balance: decimal.NewFromInt(10000), rate: decimal.NewFromInt(12), months: 12, places: 2, want: decimal.NewFromFloat(888.49)},
+		{name: "rounds up to whole dong", balance: decimal.NewFromInt(100000000), rate: decimal.NewFromInt(12), months: 12, places: 0, want: decimal.NewFromInt(8884879)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := annuityPayment(tt.balance, tt.rate, tt.months, tt.places)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/wealthpath/backend/internal/model"
+)
+
+// Service-level errors for refinance comparisons.
+var (
+	ErrInvalidRefinanceCosts = errors.New("fees, penalties and termMonths cannot be negative")
+	ErrNoRepaymentPlan       = errors.New("debt needs a minimum payment or a term to compare refinancing")
+	ErrNothingToRefinance    = errors.New("debt has no outstanding balance to refinance")
+	ErrNoRefinanceRates      = errors.New("no published rates for this debt's product and currency")
+)
+
+type RefinanceInput struct {
+	TermMonths               int             `json:"termMonths"` // defaults to the months left on the current plan
+	SwitchingFee             decimal.Decimal `json:"switchingFee"`
+	PrepaymentPenalty        decimal.Decimal `json:"prepaymentPenalty"`        // fixed amount charged by the current lender
+	PrepaymentPenaltyPercent decimal.Decimal `json:"prepaymentPenaltyPercent"` // percentage of the balance repaid early
+}
+
+// CompareRefinance compares keeping a debt on its current terms with moving the balance to
+// each bank's current loan or mortgage rate for a comparable term. Switching fees and
+// prepayment penalties are paid upfront; the break-even month is the first month in which
+// everything paid plus the balance still owed is no higher than when staying put.
+func (s *DebtService) CompareRefinance(ctx context.Context, id, userID uuid.UUID, input RefinanceInput) (*model.RefinanceComparison, error) {
+	if input.TermMonths < 0 || input.SwitchingFee.IsNegative() ||
+		input.PrepaymentPenalty.IsNegative() || input.PrepaymentPenaltyPercent.IsNegative() {
+		return nil, ErrInvalidRefinanceCosts
+	}
+
+	debt, err := s.getOwnedDebt(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !debt.CurrentBalance.IsPositive() {
+		return nil, ErrNothingToRefinance
+	}
+
+	now := time.Now()
+	places := currencyPlaces(debt.Currency)
+
+	payment := debt.MinimumPayment
+	if !payment.IsPositive() {
+		if debt.TermMonths == nil {
+			return nil, ErrNoRepaymentPlan
+		}
+		payment = annuityPayment(debt.CurrentBalance, debt.InterestRate, *debt.TermMonths-monthsBetween(debt.StartDate, now), places)
+	}
+
+	var schedule *rateSchedule
+	if debt.HasRateSchedule() {
+		schedule, err = s.buildRateSchedule(ctx, debt, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+	stay := calculatePayoffPlan(debt, payment, schedule, nil)
+
+	term := input.TermMonths
+	if term == 0 {
+		term = stay.MonthsToPayoff
+	}
+
+	productType := "loan"
+	if debt.Type == model.DebtTypeMortgage {
+		productType = "mortgage"
+	}
+
+	rates, err := s.refinanceRates(ctx, productType, debt.Currency, term)
+	if err != nil {
+		return nil, err
+	}
+
+	penalty := input.PrepaymentPenalty.Add(
+		debt.CurrentBalance.Mul(input.PrepaymentPenaltyPercent).Div(decimal.NewFromInt(100)).Round(places))
+	upfront := input.SwitchingFee.Add(penalty)
+
+	comparison := &model.RefinanceComparison{
+		DebtID:            debt.ID,
+		Currency:          debt.Currency,
+		ProductType:       productType,
+		CurrentBalance:    debt.CurrentBalance,
+		CurrentRate:       debt.InterestRate,
+		TermMonths:        term,
+		SwitchingFee:      input.SwitchingFee,
+		PrepaymentPenalty: penalty,
+		Stay: model.RefinanceOption{
+			Rate:           debt.InterestRate,
+			TermMonths:     stay.MonthsToPayoff,
+			MonthlyPayment: payment,
+			TotalInterest:  stay.TotalInterest,
+			TotalPayment:   stay.TotalPayment,
+		},
+		Offers: make([]model.RefinanceOffer, 0, len(rates)),
+	}
+
+	for _, rate := range rates {
+		rows := loanSchedule(debt.CurrentBalance, rate.Rate, term, model.InterestMethodDecliningBalance, places)
+
+		offer := model.RefinanceOffer{
+			RefinanceOption: model.RefinanceOption{
+				Rate:           rate.Rate,
+				TermMonths:     len(rows),
+				MonthlyPayment: rows[0].Payment,
+				TotalInterest:  decimal.Zero,
+				TotalPayment:   decimal.Zero,
+			},
+			BankCode:    rate.BankCode,
+			BankName:    rate.BankName,
+			UpfrontCost: upfront,
+		}
+		for _, row := range rows {
+			offer.TotalInterest = offer.TotalInterest.Add(row.Interest)
+			offer.TotalPayment = offer.TotalPayment.Add(row.Payment)
+		}
+		offer.TotalCost = offer.TotalPayment.Add(upfront)
+		offer.Savings = stay.TotalPayment.Sub(offer.TotalCost)
+
+		if month := breakEvenMonth(debt.CurrentBalance, stay.AmortizationPlan, rows, upfront); month > 0 {
+			date := now.AddDate(0, month, 0)
+			offer.BreakEvenMonth = &month
+			offer.BreakEvenDate = &date
+		}
+
+		comparison.Offers = append(comparison.Offers, offer)
+	}
+
+	sort.SliceStable(comparison.Offers, func(i, j int) bool {
+		return comparison.Offers[i].TotalCost.LessThan(comparison.Offers[j].TotalCost)
+	})
+
+	return comparison, nil
+}
+
+// refinanceRates returns, per bank, the latest published rate for the shortest term that
+// covers the requested one, or the bank's longest term when none does.
+func (s *DebtService) refinanceRates(ctx context.Context, productType, currency string, term int) ([]model.InterestRate, error) {
+	if s.rates == nil {
+		return nil, ErrNoRefinanceRates
+	}
+
+	rates, err := s.rates.ListRates(ctx, productType, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("listing %s rates: %w", productType, err)
+	}
+
+	best := make(map[string]model.InterestRate)
+	order := make([]string, 0)
+	for _, rate := range rates {
+		if rate.Currency != "" && rate.Currency != currency {
+			continue
+		}
+		current, ok := best[rate.BankCode]
+		if !ok {
+			best[rate.BankCode] = rate
+			order = append(order, rate.BankCode)
+			continue
+		}
+		if closerTerm(rate, current, term) {
+			best[rate.BankCode] = rate
+		}
+	}
+
+	if len(order) == 0 {
+		return nil, ErrNoRefinanceRates
+	}
+
+	result := make([]model.InterestRate, 0, len(order))
+	for _, code := range order {
+		result = append(result, best[code])
+	}
+	return result, nil
+}
+
+// closerTerm reports whether candidate matches the wanted term better than current.
+// Terms that cover the wanted term beat shorter ones, the shortest covering term wins,
+// and the most recent rate breaks ties.
+func closerTerm(candidate, current model.InterestRate, term int) bool {
+	candidateCovers := candidate.TermMonths >= term
+	currentCovers := current.TermMonths >= term
+
+	switch {
+	case candidateCovers != currentCovers:
+		return candidateCovers
+	case candidate.TermMonths != current.TermMonths:
+		if candidateCovers {
+			return candidate.TermMonths < current.TermMonths
+		}
+		return candidate.TermMonths > current.TermMonths
+	}
+	return candidate.EffectiveDate.After(current.EffectiveDate)
+}
+
+// breakEvenMonth returns the first month in which the refinanced loan's upfront cost,
+// payments so far and remaining balance add up to no more than staying put, or zero
+// if that never happens.
+func breakEvenMonth(balance decimal.Decimal, stay, refinanced []model.AmortizationRow, upfront decimal.Decimal) int {
+	months := max(len(stay), len(refinanced))
+
+	stayPaid, newPaid := decimal.Zero, upfront
+	stayBalance, newBalance := balance, balance
+	for m := 0; m < months; m++ {
+		if m < len(stay) {
+			stayPaid = stayPaid.Add(stay[m].Payment)
+			stayBalance = stay[m].RemainingBalance
+		}
+		if m < len(refinanced) {
+			newPaid = newPaid.Add(refinanced[m].Payment)
+			newBalance = refinanced[m].RemainingBalance
+		}
+
+		if newPaid.Add(newBalance).LessThanOrEqual(stayPaid.Add(stayBalance)) {
+			return m + 1
+		}
+	}
+	return 0
+}
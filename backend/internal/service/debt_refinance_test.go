@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+)
+
+func fixedRateMortgage(userID uuid.UUID) *model.Debt {
+	balance := decimal.NewFromInt(1000000000)
+	return &model.Debt{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Type:           model.DebtTypeMortgage,
+		CurrentBalance: balance,
+		InterestRate:   decimal.NewFromInt(11),
+		MinimumPayment: annuityPayment(balance, decimal.NewFromInt(11), 180, 0),
+		Currency:       "VND",
+		StartDate:      time.Now(),
+		InterestMethod: model.InterestMethodDecliningBalance,
+	}
+}
+
+func TestDebtService_CompareRefinance(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	mockRates := new(MockDepositRateProvider)
+	svc := NewDebtService(mockRepo)
+	svc.SetRateProvider(mockRates)
+	userID := uuid.New()
+	debt := fixedRateMortgage(userID)
+
+	mockRepo.On("GetByID", mock.Anything, debt.ID).Return(debt, nil)
+	mockRates.On("ListRates", mock.Anything, "mortgage", (*int)(nil), "").Return([]model.InterestRate{
+		{BankCode: "vcb", BankName: "Vietcombank", TermMonths: 120, Rate: decimal.NewFromInt(7), Currency: "VND", EffectiveDate: time.Now()},
+		{BankCode: "vcb", BankName: "Vietcombank", TermMonths: 240, Rate: decimal.NewFromFloat(8.5), Currency: "VND", EffectiveDate: time.Now().AddDate(0, -1, 0)},
+		{BankCode: "vcb", BankName: "Vietcombank", TermMonths: 180, Rate: decimal.NewFromInt(9), Currency: "VND", EffectiveDate: time.Now().AddDate(0, -6, 0)},
+		{BankCode: "vcb", BankName: "Vietcombank", TermMonths: 180, Rate: decimal.NewFromInt(8), Currency: "VND", EffectiveDate: time.Now()},
+		{BankCode: "acb", BankName: "ACB", TermMonths: 120, Rate: decimal.NewFromFloat(10.5), Currency: "VND", EffectiveDate: time.Now()},
+		{BankCode: "hsbc", BankName: "HSBC", TermMonths: 180, Rate: decimal.NewFromInt(4), Currency: "USD", EffectiveDate: time.Now()},
+	}, nil)
+
+	comparison, err := svc.CompareRefinance(context.Background(), debt.ID, userID, RefinanceInput{
+		SwitchingFee:             decimal.NewFromInt(5000000),
+		PrepaymentPenaltyPercent: decimal.NewFromInt(2),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "mortgage", comparison.ProductType)
+	assert.Equal(t, 180, comparison.TermMonths)
+	assert.True(t, comparison.PrepaymentPenalty.Equal(decimal.NewFromInt(20000000)))
+
+	// USD rates are ignored; the cheapest offer comes first.
+	require.Len(t, comparison.Offers, 2)
+	best, worst := comparison.Offers[0], comparison.Offers[1]
+
+	// The latest rate for the shortest term covering 180 months is used.
+	assert.Equal(t, "vcb", best.BankCode)
+	assert.True(t, best.Rate.Equal(decimal.NewFromInt(8)))
+	assert.Equal(t, 180, best.TermMonths)
+	assert.True(t, best.UpfrontCost.Equal(decimal.NewFromInt(25000000)))
+	assert.True(t, best.TotalCost.Equal(best.TotalPayment.Add(best.UpfrontCost)))
+	assert.True(t, best.Savings.IsPositive())
+	require.NotNil(t, best.BreakEvenMonth)
+	assert.Greater(t, *best.BreakEvenMonth, 1)
+	assert.Less(t, *best.BreakEvenMonth, 180)
+	assert.True(t, best.MonthlyPayment.LessThan(comparison.Stay.MonthlyPayment))
+
+	// Without a covering term the rate of the bank's longest term is used.
+	assert.Equal(t, "acb", worst.BankCode)
+	assert.True(t, worst.Rate.Equal(decimal.NewFromFloat(10.5)))
+	assert.Equal(t, 180, worst.TermMonths)
+
+	mockRepo.AssertExpectations(t)
+	mockRates.AssertExpectations(t)
+}
+
+func TestDebtService_CompareRefinance_NoBreakEven(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	mockRates := new(MockDepositRateProvider)
+	svc := NewDebtService(mockRepo)
+	svc.SetRateProvider(mockRates)
+	userID := uuid.New()
+	debt := fixedRateMortgage(userID)
+
+	mockRepo.On("GetByID", mock.Anything, debt.ID).Return(debt, nil)
+	mockRates.On("ListRates", mock.Anything, "mortgage", (*int)(nil), "").Return([]model.InterestRate{
+		{BankCode: "acb", BankName: "ACB", TermMonths: 180, Rate: decimal.NewFromFloat(10.9), Currency: "VND", EffectiveDate: time.Now()},
+	}, nil)
+
+	comparison, err := svc.CompareRefinance(context.Background(), debt.ID, userID, RefinanceInput{
+		SwitchingFee: decimal.NewFromInt(50000000),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, comparison.Offers, 1)
+	assert.Nil(t, comparison.Offers[0].BreakEvenMonth)
+	assert.True(t, comparison.Offers[0].Savings.IsNegative())
+}
+
+func TestDebtService_CompareRefinance_Errors(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		debt    func() *model.Debt
+		userID  uuid.UUID
+		input   RefinanceInput
+		rates   []model.InterestRate
+		wantErr error
+	}{
+		{
+			name:    "negative fee",
+			debt:    func() *model.Debt { return fixedRateMortgage(userID) },
+			userID:  userID,
+			input:   RefinanceInput{SwitchingFee: decimal.NewFromInt(-1)},
+			wantErr: ErrInvalidRefinanceCosts,
+		},
+		{
+			name:    "other user's debt",
+			debt:    func() *model.Debt { return fixedRateMortgage(userID) },
+			userID:  uuid.New(),
+			wantErr: repository.ErrDebtNotFound,
+		},
+		{
+			name: "paid off",
+			debt: func() *model.Debt {
+				d := fixedRateMortgage(userID)
+				d.CurrentBalance = decimal.Zero
+				return d
+			},
+			userID:  userID,
+			wantErr: ErrNothingToRefinance,
+		},
+		{
+			name: "no payment or term",
+			debt: func() *model.Debt {
+				d := fixedRateMortgage(userID)
+				d.MinimumPayment = decimal.Zero
+				return d
+			},
+			userID:  userID,
+			wantErr: ErrNoRepaymentPlan,
+		},
+		{
+			name:    "no rates in currency",
+			debt:    func() *model.Debt { return fixedRateMortgage(userID) },
+			userID:  userID,
+			rates:   []model.InterestRate{{BankCode: "hsbc", TermMonths: 180, Rate: decimal.NewFromInt(4), Currency: "USD"}},
+			wantErr: ErrNoRefinanceRates,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockDebtRepo)
+			mockRates := new(MockDepositRateProvider)
+			svc := NewDebtService(mockRepo)
+			svc.SetRateProvider(mockRates)
+			debt := tt.debt()
+
+			mockRepo.On("GetByID", mock.Anything, debt.ID).Return(debt, nil).Maybe()
+			mockRates.On("ListRates", mock.Anything, "mortgage", (*int)(nil), "").Return(tt.rates, nil).Maybe()
+
+			_, err := svc.CompareRefinance(context.Background(), debt.ID, tt.userID, tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBreakEvenMonth(t *testing.T) {
+	t.Parallel()
+
+	balance := decimal.NewFromInt(1200)
+	stay := loanSchedule(balance, decimal.NewFromInt(24), 12, model.InterestMethodDecliningBalance, 2)
+	cheaper := loanSchedule(balance, decimal.NewFromInt(12), 12, model.InterestMethodDecliningBalance, 2)
+
+	assert.Equal(t, 1, breakEvenMonth(balance, stay, cheaper, decimal.Zero))
+	assert.Equal(t, 0, breakEvenMonth(balance, stay, cheaper, decimal.NewFromInt(1000)))
+
+	month := breakEvenMonth(balance, stay, cheaper, decimal.NewFromInt(40))
+	assert.Greater(t, month, 1)
+	assert.LessOrEqual(t, month, 12)
+}
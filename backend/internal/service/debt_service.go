@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"fmt"
-	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,12 +20,22 @@ type DebtRepositoryInterface interface {
 	List(ctx context.Context, userID uuid.UUID) ([]model.Debt, error)
 	Update(ctx context.Context, debt *model.Debt) error
 	Delete(ctx context.Context, id, userID uuid.UUID) error
-	RecordPayment(ctx context.Context, payment *model.DebtPayment) error
+	RecordPayment(ctx context.Context, payment *model.DebtPayment, split model.DebtPaymentSplit) error
+	GetPayments(ctx context.Context, debtID uuid.UUID) ([]model.DebtPaymentRecord, error)
+	GetPayment(ctx context.Context, debtID, paymentID uuid.UUID) (*model.DebtPayment, error)
+	UpdatePayment(ctx context.Context, payment *model.DebtPayment, split model.DebtPaymentSplit) error
+	DeletePayment(ctx context.Context, debtID, paymentID uuid.UUID) error
+	ListStatementCards(ctx context.Context) ([]model.Debt, error)
+	ListStatements(ctx context.Context, debtID uuid.UUID) ([]model.CreditCardStatement, error)
+	GetStatement(ctx context.Context, debtID, statementID uuid.UUID) (*model.CreditCardStatement, error)
+	CloseStatement(ctx context.Context, statement *model.CreditCardStatement) error
+	GetCardTransactions(ctx context.Context, debtID uuid.UUID, from time.Time, to *time.Time) ([]model.Transaction, error)
 }
 
 // DebtService handles business logic for debt management and payoff calculations.
 type DebtService struct {
-	repo DebtRepositoryInterface
+	repo  DebtRepositoryInterface
+	rates MortgageRateProvider
 }
 
 // NewDebtService creates a new DebtService with the given repository.
@@ -35,50 +44,102 @@ func NewDebtService(repo DebtRepositoryInterface) *DebtService {
 }
 
 type CreateDebtInput struct {
-	Name           string          `json:"name"`
-	Type           model.DebtType  `json:"type"`
-	OriginalAmount decimal.Decimal `json:"originalAmount"`
-	CurrentBalance decimal.Decimal `json:"currentBalance"`
-	InterestRate   decimal.Decimal `json:"interestRate"` // APR as percentage (e.g., 5.5 for 5.5%)
-	MinimumPayment decimal.Decimal `json:"minimumPayment"`
-	Currency       string          `json:"currency"`
-	DueDay         int             `json:"dueDay"`
-	StartDate      time.Time       `json:"startDate"`
+	Name           string               `json:"name"`
+	Type           model.DebtType       `json:"type"`
+	OriginalAmount decimal.Decimal      `json:"originalAmount"`
+	CurrentBalance decimal.Decimal      `json:"currentBalance"`
+	InterestRate   decimal.Decimal      `json:"interestRate"` // APR as percentage (e.g., 5.5 for 5.5%)
+	MinimumPayment decimal.Decimal      `json:"minimumPayment"`
+	InterestMethod model.InterestMethod `json:"interestMethod"` // defaults to declining_balance
+	Currency       string               `json:"currency"`
+	DueDay         int                  `json:"dueDay"`
+	StartDate      time.Time            `json:"startDate"`
+
+	// Optional variable-rate schedule; see model.Debt.
+	TermMonths          *int             `json:"termMonths,omitempty"`
+	FixedRateMonths     *int             `json:"fixedRateMonths,omitempty"`
+	RateMargin          *decimal.Decimal `json:"rateMargin,omitempty"`
+	ReferenceBankCode   *string          `json:"referenceBankCode,omitempty"`
+	ReferenceTermMonths *int             `json:"referenceTermMonths,omitempty"`
+	RateResetMonths     *int             `json:"rateResetMonths,omitempty"`
+
+	// Optional credit card statement cycle; see model.Debt.
+	StatementDay          *int             `json:"statementDay,omitempty"`
+	GracePeriodDays       *int             `json:"gracePeriodDays,omitempty"`
+	MinimumPaymentPercent *decimal.Decimal `json:"minimumPaymentPercent,omitempty"`
+	CreditLimit           *decimal.Decimal `json:"creditLimit,omitempty"`
+
+	// Optional installment plan; termMonths is the number of instalments. See model.Debt.
+	Merchant       *string          `json:"merchant,omitempty"`
+	InstallmentFee *decimal.Decimal `json:"installmentFee,omitempty"`
+	CardID         *uuid.UUID       `json:"cardId,omitempty"`
 }
 
 type UpdateDebtInput struct {
-	Name           string          `json:"name"`
-	Type           model.DebtType  `json:"type"`
-	OriginalAmount decimal.Decimal `json:"originalAmount"`
-	CurrentBalance decimal.Decimal `json:"currentBalance"`
-	InterestRate   decimal.Decimal `json:"interestRate"`
-	MinimumPayment decimal.Decimal `json:"minimumPayment"`
-	Currency       string          `json:"currency"`
-	DueDay         int             `json:"dueDay"`
-	StartDate      time.Time       `json:"startDate"`
+	Name           string               `json:"name"`
+	Type           model.DebtType       `json:"type"`
+	OriginalAmount decimal.Decimal      `json:"originalAmount"`
+	CurrentBalance decimal.Decimal      `json:"currentBalance"`
+	InterestRate   decimal.Decimal      `json:"interestRate"`
+	MinimumPayment decimal.Decimal      `json:"minimumPayment"`
+	InterestMethod model.InterestMethod `json:"interestMethod"` // defaults to declining_balance
+	Currency       string               `json:"currency"`
+	DueDay         int                  `json:"dueDay"`
+	StartDate      time.Time            `json:"startDate"`
+
+	// Optional variable-rate schedule; see model.Debt.
+	TermMonths          *int             `json:"termMonths,omitempty"`
+	FixedRateMonths     *int             `json:"fixedRateMonths,omitempty"`
+	RateMargin          *decimal.Decimal `json:"rateMargin,omitempty"`
+	ReferenceBankCode   *string          `json:"referenceBankCode,omitempty"`
+	ReferenceTermMonths *int             `json:"referenceTermMonths,omitempty"`
+	RateResetMonths     *int             `json:"rateResetMonths,omitempty"`
+
+	// Optional credit card statement cycle; see model.Debt.
+	StatementDay          *int             `json:"statementDay,omitempty"`
+	GracePeriodDays       *int             `json:"gracePeriodDays,omitempty"`
+	MinimumPaymentPercent *decimal.Decimal `json:"minimumPaymentPercent,omitempty"`
+	CreditLimit           *decimal.Decimal `json:"creditLimit,omitempty"`
+
+	// Optional installment plan; termMonths is the number of instalments. See model.Debt.
+	Merchant       *string          `json:"merchant,omitempty"`
+	InstallmentFee *decimal.Decimal `json:"installmentFee,omitempty"`
+	CardID         *uuid.UUID       `json:"cardId,omitempty"`
 }
 
 type MakePaymentInput struct {
 	Amount decimal.Decimal `json:"amount"`
 	Date   time.Time       `json:"date"`
+
+	// Only used when recording a payment; corrections keep the payment's transactions in sync.
+	RecordTransaction bool `json:"recordTransaction"` // also record the payment as a Debt Payments expense, except for credit cards
+	SplitInterest     bool `json:"splitInterest"`     // record the interest as a separate Debt Interest expense
 }
 
 type InterestCalculatorInput struct {
-	Principal    decimal.Decimal `json:"principal"`
-	InterestRate decimal.Decimal `json:"interestRate"` // APR as percentage
-	TermMonths   int             `json:"termMonths"`
-	PaymentType  string          `json:"paymentType"` // "fixed" or "minimum"
+	Principal    decimal.Decimal      `json:"principal"`
+	InterestRate decimal.Decimal      `json:"interestRate"` // APR as percentage
+	TermMonths   int                  `json:"termMonths"`
+	PaymentType  string               `json:"paymentType"` // "fixed" or "minimum"
+	Method       model.InterestMethod `json:"method"`      // defaults to declining_balance
+	Currency     string               `json:"currency"`    // sets rounding, defaults to 2 decimal places
 }
 
 type InterestCalculatorResult struct {
-	MonthlyPayment decimal.Decimal `json:"monthlyPayment"`
-	TotalPayment   decimal.Decimal `json:"totalPayment"`
-	TotalInterest  decimal.Decimal `json:"totalInterest"`
-	PayoffDate     time.Time       `json:"payoffDate"`
+	Method         model.InterestMethod    `json:"method"`
+	MonthlyPayment decimal.Decimal         `json:"monthlyPayment"` // first instalment
+	LastPayment    decimal.Decimal         `json:"lastPayment"`
+	TotalPayment   decimal.Decimal         `json:"totalPayment"`
+	TotalInterest  decimal.Decimal         `json:"totalInterest"`
+	EffectiveAPR   decimal.Decimal         `json:"effectiveApr"` // declining-balance equivalent rate
+	FlatRate       decimal.Decimal         `json:"flatRate"`     // flat-rate equivalent rate
+	PayoffDate     time.Time               `json:"payoffDate"`
+	Schedule       []model.AmortizationRow `json:"schedule"`
 }
 
 // Create creates a new debt record for the given user.
-// Defaults currency to USD and sets current balance to original amount if not specified.
+// Defaults currency to USD and sets current balance to original amount if not specified,
+// or to the amount repaid in instalments for installment plans.
 func (s *DebtService) Create(ctx context.Context, userID uuid.UUID, input CreateDebtInput) (*model.Debt, error) {
 	debt := &model.Debt{
 		UserID:         userID,
@@ -96,8 +157,30 @@ func (s *DebtService) Create(ctx context.Context, userID uuid.UUID, input Create
 	if debt.Currency == "" {
 		debt.Currency = "USD"
 	}
+	if err := applyRateSchedule(debt, input.TermMonths, input.FixedRateMonths, input.RateMargin,
+		input.ReferenceBankCode, input.ReferenceTermMonths, input.RateResetMonths); err != nil {
+		return nil, err
+	}
+	if err := applyInterestMethod(debt, input.InterestMethod); err != nil {
+		return nil, err
+	}
+	if err := applyStatementCycle(debt, input.StatementDay, input.GracePeriodDays, input.MinimumPaymentPercent); err != nil {
+		return nil, err
+	}
+	if err := applyCreditLimit(debt, input.CreditLimit); err != nil {
+		return nil, err
+	}
+	if err := applyInstallmentPlan(debt, input.Merchant, input.InstallmentFee, input.CardID); err != nil {
+		return nil, err
+	}
+	if err := s.checkInstallmentCard(ctx, debt); err != nil {
+		return nil, err
+	}
 	if debt.CurrentBalance.IsZero() {
 		debt.CurrentBalance = debt.OriginalAmount
+		if debt.IsInstallmentPlan() {
+			debt.CurrentBalance = installmentTotal(debt)
+		}
 	}
 
 	if err := s.repo.Create(ctx, debt); err != nil {
@@ -107,21 +190,34 @@ func (s *DebtService) Create(ctx context.Context, userID uuid.UUID, input Create
 	return debt, nil
 }
 
-// Get retrieves a debt by its ID.
+// Get retrieves a debt by its ID, with the available credit of a credit card.
 func (s *DebtService) Get(ctx context.Context, id uuid.UUID) (*model.Debt, error) {
 	debt, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("getting debt %s: %w", id, err)
 	}
+	if debt.Type == model.DebtTypeCreditCard && debt.CreditLimit != nil {
+		debts, err := s.repo.List(ctx, debt.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("listing debts for user %s: %w", debt.UserID, err)
+		}
+		setAvailableCredit(debts)
+		for _, d := range debts {
+			if d.ID == debt.ID {
+				debt.AvailableCredit = d.AvailableCredit
+			}
+		}
+	}
 	return debt, nil
 }
 
-// List retrieves all debts for a user.
+// List retrieves all debts for a user, with the available credit of their credit cards.
 func (s *DebtService) List(ctx context.Context, userID uuid.UUID) ([]model.Debt, error) {
 	debts, err := s.repo.List(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("listing debts for user %s: %w", userID, err)
 	}
+	setAvailableCredit(debts)
 	return debts, nil
 }
 
@@ -146,6 +242,25 @@ func (s *DebtService) Update(ctx context.Context, id uuid.UUID, userID uuid.UUID
 	debt.Currency = input.Currency
 	debt.DueDay = input.DueDay
 	debt.StartDate = input.StartDate
+	if err := applyRateSchedule(debt, input.TermMonths, input.FixedRateMonths, input.RateMargin,
+		input.ReferenceBankCode, input.ReferenceTermMonths, input.RateResetMonths); err != nil {
+		return nil, err
+	}
+	if err := applyInterestMethod(debt, input.InterestMethod); err != nil {
+		return nil, err
+	}
+	if err := applyStatementCycle(debt, input.StatementDay, input.GracePeriodDays, input.MinimumPaymentPercent); err != nil {
+		return nil, err
+	}
+	if err := applyCreditLimit(debt, input.CreditLimit); err != nil {
+		return nil, err
+	}
+	if err := applyInstallmentPlan(debt, input.Merchant, input.InstallmentFee, input.CardID); err != nil {
+		return nil, err
+	}
+	if err := s.checkInstallmentCard(ctx, debt); err != nil {
+		return nil, err
+	}
 
 	if err := s.repo.Update(ctx, debt); err != nil {
 		return nil, fmt.Errorf("updating debt %s: %w", id, err)
@@ -163,44 +278,128 @@ func (s *DebtService) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID
 }
 
 // MakePayment records a payment against a debt, splitting it into principal and interest.
-// The interest portion is calculated using the monthly rate.
+// The interest portion is calculated using the monthly rate on the balance read under the
+// lock that records the payment. With RecordTransaction the payment is also recorded as an
+// expense in the same database transaction.
 func (s *DebtService) MakePayment(ctx context.Context, debtID uuid.UUID, userID uuid.UUID, input MakePaymentInput) (*model.Debt, error) {
-	debt, err := s.repo.GetByID(ctx, debtID)
+	if _, err := s.getOwnedDebt(ctx, debtID, userID); err != nil {
+		return nil, err
+	}
+
+	payment := &model.DebtPayment{
+		DebtID: debtID,
+		Amount: input.Amount,
+		Date:   input.Date,
+	}
+	if err := s.repo.RecordPayment(ctx, payment, paymentSplit(input)); err != nil {
+		return nil, fmt.Errorf("recording payment for debt %s: %w", debtID, err)
+	}
+
+	return s.repo.GetByID(ctx, debtID)
+}
+
+// paymentSplit splits a payment into principal and interest against the balance of the
+// debt it is recorded against, and with RecordTransaction adds the expenses recording it.
+// Credit card payments record no expense: the purchases they repay already were expenses,
+// and the payment only moves money between accounts.
+func paymentSplit(input MakePaymentInput) model.DebtPaymentSplit {
+	return func(debt *model.Debt, payment *model.DebtPayment) error {
+		payment.Principal, payment.Interest = splitDebtPayment(debt, debt.CurrentBalance, payment.Amount)
+		if input.RecordTransaction && debt.Type != model.DebtTypeCreditCard {
+			payment.SplitInterest = input.SplitInterest
+			payment.Transactions = paymentTransactions(debt, payment, payment.SplitInterest)
+		}
+		return nil
+	}
+}
+
+// ListPayments returns the payment history of a debt with principal and interest paid to date.
+// Returns ErrDebtNotFound if the debt does not exist or belongs to another user.
+func (s *DebtService) ListPayments(ctx context.Context, debtID uuid.UUID, userID uuid.UUID) (*model.DebtPaymentHistory, error) {
+	debt, err := s.getOwnedDebt(ctx, debtID, userID)
 	if err != nil {
-		return nil, fmt.Errorf("fetching debt %s for payment: %w", debtID, err)
+		return nil, err
 	}
 
-	if debt.UserID != userID {
-		return nil, repository.ErrDebtNotFound
+	payments, err := s.repo.GetPayments(ctx, debtID)
+	if err != nil {
+		return nil, fmt.Errorf("listing payments for debt %s: %w", debtID, err)
+	}
+
+	history := &model.DebtPaymentHistory{
+		DebtID:         debt.ID,
+		Currency:       debt.Currency,
+		CurrentBalance: debt.CurrentBalance,
+		TotalPaid:      decimal.Zero,
+		TotalPrincipal: decimal.Zero,
+		TotalInterest:  decimal.Zero,
+		Payments:       payments,
+	}
+	if history.Payments == nil {
+		history.Payments = []model.DebtPaymentRecord{}
+	}
+	for _, p := range payments {
+		history.TotalPaid = history.TotalPaid.Add(p.Amount)
+		history.TotalPrincipal = history.TotalPrincipal.Add(p.Principal)
+		history.TotalInterest = history.TotalInterest.Add(p.Interest)
 	}
 
-	// Calculate interest portion (monthly rate)
-	monthlyRate := debt.InterestRate.Div(decimal.NewFromInt(100)).Div(decimal.NewFromInt(12))
-	interestPortion := debt.CurrentBalance.Mul(monthlyRate)
-	principalPortion := input.Amount.Sub(interestPortion)
+	return history, nil
+}
 
-	if principalPortion.IsNegative() {
-		principalPortion = decimal.Zero
-		interestPortion = input.Amount
+// UpdatePayment corrects the amount or date of a debt's latest payment.
+// The split is recalculated against the balance as it was before the payment,
+// and the debt balance is adjusted by the change in principal. Expenses recording
+// the payment are updated to match. Older payments are left alone: correcting one
+// would change the balance every later payment was split against, so the repository
+// returns ErrDebtPaymentNotLatest for them.
+func (s *DebtService) UpdatePayment(ctx context.Context, debtID, paymentID, userID uuid.UUID, input MakePaymentInput) (*model.DebtPayment, error) {
+	if !input.Amount.IsPositive() {
+		return nil, ErrInvalidAmount
 	}
 
-	payment := &model.DebtPayment{
-		DebtID:    debtID,
-		Amount:    input.Amount,
-		Principal: principalPortion,
-		Interest:  interestPortion,
-		Date:      input.Date,
+	if _, err := s.getOwnedDebt(ctx, debtID, userID); err != nil {
+		return nil, err
 	}
 
-	if err := s.repo.RecordPayment(ctx, payment); err != nil {
-		return nil, fmt.Errorf("recording payment for debt %s: %w", debtID, err)
+	payment, err := s.repo.GetPayment(ctx, debtID, paymentID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching payment %s: %w", paymentID, err)
 	}
 
-	return s.repo.GetByID(ctx, debtID)
+	payment.Amount = input.Amount
+	if !input.Date.IsZero() {
+		payment.Date = input.Date
+	}
+	split := func(debt *model.Debt, payment *model.DebtPayment) error {
+		payment.Principal, payment.Interest = splitDebtPayment(debt, debt.CurrentBalance, payment.Amount)
+		syncPaymentTransactions(debt, payment)
+		return nil
+	}
+	if err := s.repo.UpdatePayment(ctx, payment, split); err != nil {
+		return nil, fmt.Errorf("updating payment %s: %w", paymentID, err)
+	}
+
+	return payment, nil
+}
+
+// DeletePayment reverses a payment and restores its principal to the debt balance.
+// Expenses recording the payment are removed with it.
+func (s *DebtService) DeletePayment(ctx context.Context, debtID, paymentID, userID uuid.UUID) error {
+	if _, err := s.getOwnedDebt(ctx, debtID, userID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeletePayment(ctx, debtID, paymentID); err != nil {
+		return fmt.Errorf("deleting payment %s: %w", paymentID, err)
+	}
+	return nil
 }
 
 // GetPayoffPlan calculates a debt payoff plan based on the monthly payment amount.
 // If monthlyPayment is zero, uses the minimum payment from the debt.
+// Variable-rate debts switch to the reference rate plus margin after the fixed period
+// and have their payment recalculated over the remaining term at every reset.
 func (s *DebtService) GetPayoffPlan(ctx context.Context, id uuid.UUID, monthlyPayment decimal.Decimal) (*model.PayoffPlan, error) {
 	debt, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -211,42 +410,133 @@ func (s *DebtService) GetPayoffPlan(ctx context.Context, id uuid.UUID, monthlyPa
 		monthlyPayment = debt.MinimumPayment
 	}
 
-	plan := calculatePayoffPlan(debt, monthlyPayment)
+	var schedule *rateSchedule
+	if debt.HasRateSchedule() {
+		schedule, err = s.buildRateSchedule(ctx, debt, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	plan := calculatePayoffPlan(debt, monthlyPayment, schedule, nil)
 	return plan, nil
 }
 
-// CalculateInterest computes loan amortization details using the standard formula.
-// Returns monthly payment, total payment, total interest, and payoff date.
+// CalculateInterest computes the instalment schedule of a loan under the requested interest
+// method, in decimal arithmetic rounded to the currency's minor unit. Returns the first and
+// last payment, totals, payoff date and the rate expressed both as a declining-balance APR
+// and as a flat rate, so quotes of either kind can be compared.
 func (s *DebtService) CalculateInterest(input InterestCalculatorInput) (*InterestCalculatorResult, error) {
-	monthlyRate := input.InterestRate.Div(decimal.NewFromInt(100)).Div(decimal.NewFromInt(12))
-
-	// Calculate fixed monthly payment using amortization formula
-	// M = P * [r(1+r)^n] / [(1+r)^n - 1]
-	r := monthlyRate.InexactFloat64()
-	p := input.Principal.InexactFloat64()
-	n := float64(input.TermMonths)
+	if !input.Principal.IsPositive() || input.TermMonths <= 0 {
+		return nil, ErrInvalidLoanTerm
+	}
 
-	var monthlyPayment float64
-	if r == 0 {
-		monthlyPayment = p / n
-	} else {
-		monthlyPayment = p * (r * math.Pow(1+r, n)) / (math.Pow(1+r, n) - 1)
+	method := input.Method
+	if method == "" {
+		method = model.InterestMethodDecliningBalance
 	}
+	if !isInterestMethod(method) {
+		return nil, ErrInvalidInterestMethod
+	}
+
+	places := currencyPlaces(input.Currency)
 
-	totalPayment := monthlyPayment * n
-	totalInterest := totalPayment - p
+	schedule := loanSchedule(input.Principal, input.InterestRate, input.TermMonths, method, places)
+
+	totalPayment := decimal.Zero
+	totalInterest := decimal.Zero
+	payments := make([]decimal.Decimal, len(schedule))
+	for i, row := range schedule {
+		totalPayment = totalPayment.Add(row.Payment)
+		totalInterest = totalInterest.Add(row.Interest)
+		payments[i] = row.Payment
+	}
 
 	return &InterestCalculatorResult{
-		MonthlyPayment: decimal.NewFromFloat(monthlyPayment).Round(2),
-		TotalPayment:   decimal.NewFromFloat(totalPayment).Round(2),
-		TotalInterest:  decimal.NewFromFloat(totalInterest).Round(2),
-		PayoffDate:     time.Now().AddDate(0, input.TermMonths, 0),
+		Method:         method,
+		MonthlyPayment: schedule[0].Payment,
+		LastPayment:    schedule[len(schedule)-1].Payment,
+		TotalPayment:   totalPayment,
+		TotalInterest:  totalInterest,
+		EffectiveAPR:   effectiveAPR(input.Principal, payments),
+		FlatRate:       flatRateEquivalent(input.Principal, totalInterest, len(schedule)),
+		PayoffDate:     time.Now().AddDate(0, len(schedule), 0),
+		Schedule:       schedule,
 	}, nil
 }
 
-func calculatePayoffPlan(debt *model.Debt, monthlyPayment decimal.Decimal) *model.PayoffPlan {
+// getOwnedDebt fetches a debt and hides it from users other than its owner.
+func (s *DebtService) getOwnedDebt(ctx context.Context, id, userID uuid.UUID) (*model.Debt, error) {
+	debt, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("fetching debt %s: %w", id, err)
+	}
+	if debt.UserID != userID {
+		return nil, repository.ErrDebtNotFound
+	}
+	return debt, nil
+}
+
+// splitDebtPayment divides a payment into principal and interest, where interest is one
+// month at the APR on the balance, or on the original amount for flat-rate debts.
+// Interest is rounded to the currency's minor unit; payments smaller than the interest
+// are all interest. Cards billed in statement cycles have interest added to the balance
+// when a statement closes, so payments are all principal.
+func splitDebtPayment(debt *model.Debt, balance, amount decimal.Decimal) (principal, interest decimal.Decimal) {
+	if debt.HasStatementCycle() {
+		return amount, decimal.Zero
+	}
+
+	interest = monthlyInterest(debt, balance, debt.InterestRate, currencyPlaces(debt.Currency))
+	principal = amount.Sub(interest)
+
+	if principal.IsNegative() {
+		principal = decimal.Zero
+		interest = amount
+	}
+	return principal, interest
+}
+
+// monthlyInterest returns a month of interest on a debt at an annual rate in percent:
+// on the balance, or on the original amount for flat-rate debts, rounded to the
+// currency's minor unit.
+func monthlyInterest(debt *model.Debt, balance, annualRate decimal.Decimal, places int32) decimal.Decimal {
+	monthlyRate := annualRate.Div(decimal.NewFromInt(100)).Div(decimal.NewFromInt(12))
+	if debt.InterestMethod == model.InterestMethodFlat {
+		return debt.OriginalAmount.Mul(monthlyRate).Round(places)
+	}
+	return balance.Mul(monthlyRate).Round(places)
+}
+
+// equalPrincipalInstalment returns the principal an equal-principal debt repays each
+// month, or zero for other debts and debts without a term.
+func equalPrincipalInstalment(debt *model.Debt, places int32) decimal.Decimal {
+	if debt.InterestMethod != model.InterestMethodEqualPrincipal || debt.TermMonths == nil || *debt.TermMonths <= 0 {
+		return decimal.Zero
+	}
+	return debt.OriginalAmount.Div(decimal.NewFromInt(int64(*debt.TermMonths))).RoundFloor(places)
+}
+
+// calculatePayoffPlan simulates monthly payments until the debt is repaid.
+// Flat-rate debts are charged interest on the original amount; equal-principal debts
+// pay at least the scheduled principal plus interest on the balance.
+// With a rate schedule, the payment of a declining-balance debt at each reset becomes the
+// larger of monthlyPayment and the annuity that repays the balance over the remaining term.
+// Prepayments are paid on top of the month's payment; see prepaymentPlan.
+// Interest is rounded to the currency's minor unit each month and the final instalment
+// repays exactly what is left, so the plan pays the balance plus its interest to the unit.
+func calculatePayoffPlan(debt *model.Debt, monthlyPayment decimal.Decimal, schedule *rateSchedule, prepayments *prepaymentPlan) *model.PayoffPlan {
 	balance := debt.CurrentBalance
-	monthlyRate := debt.InterestRate.Div(decimal.NewFromInt(100)).Div(decimal.NewFromInt(12))
+	rate := debt.InterestRate
+	payment := monthlyPayment
+	floor := monthlyPayment
+	places := currencyPlaces(debt.Currency)
+
+	method := debt.InterestMethod
+	if method == "" {
+		method = model.InterestMethodDecliningBalance
+	}
+	scheduledPrincipal := equalPrincipalInstalment(debt, places)
 
 	totalInterest := decimal.Zero
 	totalPayment := decimal.Zero
@@ -254,26 +544,68 @@ func calculatePayoffPlan(debt *model.Debt, monthlyPayment decimal.Decimal) *mode
 	maxMonths := 360 // 30 years cap
 
 	amortization := make([]model.AmortizationRow, 0)
+	var resets []model.RateReset
 
 	for balance.IsPositive() && months < maxMonths {
 		months++
 
-		interest := balance.Mul(monthlyRate).Round(2)
-		payment := monthlyPayment
+		if schedule != nil {
+			newRate := schedule.rateFor(months)
+			if schedule.resetsAt(months) {
+				newPayment := payment
+				if method == model.InterestMethodDecliningBalance {
+					required := annuityPayment(balance, newRate, schedule.remainingTerm-months+1, places)
+					newPayment = decimal.Max(floor, required)
+				}
+				if !newRate.Equal(rate) {
+					resets = append(resets, model.RateReset{
+						Month:           months,
+						Date:            time.Now().AddDate(0, months, 0),
+						PreviousRate:    rate,
+						Rate:            newRate,
+						PreviousPayment: payment,
+						Payment:         newPayment,
+					})
+				}
+				payment = newPayment
+			}
+			rate = newRate
+		}
+
+		interest := monthlyInterest(debt, balance, rate, places)
+		due := payment
+		if method == model.InterestMethodEqualPrincipal {
+			due = decimal.Max(payment, scheduledPrincipal.Add(interest))
+		}
 
-		if payment.GreaterThan(balance.Add(interest)) {
-			payment = balance.Add(interest)
+		if due.GreaterThan(balance.Add(interest)) {
+			due = balance.Add(interest)
 		}
 
-		principal := payment.Sub(interest)
+		extra := decimal.Zero
+		if prepayments != nil {
+			extra = decimal.Min(prepayments.extraFor(months), balance.Add(interest).Sub(due))
+			due = due.Add(extra)
+		}
+
+		principal := due.Sub(interest)
 		balance = balance.Sub(principal)
 
+		if extra.IsPositive() && balance.IsPositive() && prepayments.reducePayment {
+			if left := prepayments.termMonths - months; left > 0 {
+				payment, scheduledPrincipal = reducedPayment(debt, method, balance, rate, left, payment, scheduledPrincipal)
+				floor = payment
+			}
+		}
+
 		totalInterest = totalInterest.Add(interest)
-		totalPayment = totalPayment.Add(payment)
+		totalPayment = totalPayment.Add(due)
 
 		amortization = append(amortization, model.AmortizationRow{
 			Month:            months,
-			Payment:          payment,
+			Rate:             rate,
+			Payment:          due,
+			ExtraPayment:     extra,
 			Principal:        principal,
 			Interest:         interest,
 			RemainingBalance: balance,
@@ -284,6 +616,16 @@ func calculatePayoffPlan(debt *model.Debt, monthlyPayment decimal.Decimal) *mode
 		}
 	}
 
+	// The effective APR is only meaningful once the plan repays the whole balance.
+	effective := decimal.Zero
+	if !balance.IsPositive() {
+		payments := make([]decimal.Decimal, len(amortization))
+		for i, row := range amortization {
+			payments[i] = row.Payment
+		}
+		effective = effectiveAPR(debt.CurrentBalance, payments)
+	}
+
 	return &model.PayoffPlan{
 		DebtID:           debt.ID,
 		CurrentBalance:   debt.CurrentBalance,
@@ -292,6 +634,72 @@ func calculatePayoffPlan(debt *model.Debt, monthlyPayment decimal.Decimal) *mode
 		TotalPayment:     totalPayment,
 		PayoffDate:       time.Now().AddDate(0, months, 0),
 		MonthsToPayoff:   months,
+		InterestMethod:   method,
+		EffectiveAPR:     effective,
 		AmortizationPlan: amortization,
+		RateResets:       resets,
+	}
+}
+
+// paymentTransactions builds the expenses recording a payment: one Debt Payments expense
+// for the whole amount or, when the interest is split out, one for the principal and a
+// Debt Interest expense for the interest. Zero amounts are left out.
+func paymentTransactions(debt *model.Debt, payment *model.DebtPayment, splitInterest bool) []model.Transaction {
+	if !splitInterest {
+		return []model.Transaction{paymentTransaction(debt, payment, model.CategoryDebtPayments, payment.Amount)}
+	}
+
+	var transactions []model.Transaction
+	if payment.Principal.IsPositive() {
+		transactions = append(transactions, paymentTransaction(debt, payment, model.CategoryDebtPayments, payment.Principal))
+	}
+	if payment.Interest.IsPositive() {
+		transactions = append(transactions, paymentTransaction(debt, payment, model.CategoryDebtInterest, payment.Interest))
+	}
+	return transactions
+}
+
+func paymentTransaction(debt *model.Debt, payment *model.DebtPayment, category string, amount decimal.Decimal) model.Transaction {
+	description := debt.Name
+	if category == model.CategoryDebtInterest {
+		description += " interest"
+	}
+	return model.Transaction{
+		UserID:      debt.UserID,
+		Type:        model.TransactionTypeExpense,
+		Amount:      amount,
+		Currency:    debt.Currency,
+		Category:    category,
+		Description: description,
+		Date:        payment.Date,
+	}
+}
+
+// syncPaymentTransactions brings the expenses recording a corrected payment in line with
+// its new amount, split and date. A payment recorded with its interest split out keeps the
+// split, even while its interest is zero; a side that drops to zero is left with a zero
+// amount for the repository to remove, and a side that becomes due is added. A single
+// expense keeps its category, which a recurring payment may have chosen.
+func syncPaymentTransactions(debt *model.Debt, payment *model.DebtPayment) {
+	if len(payment.Transactions) == 0 {
+		return
+	}
+
+	wanted := paymentTransactions(debt, payment, payment.SplitInterest)
+	if !payment.SplitInterest && len(payment.Transactions) == 1 {
+		wanted[0].Category = payment.Transactions[0].Category
+	}
+	for i := range payment.Transactions {
+		t := &payment.Transactions[i]
+		t.Amount = decimal.Zero
+		t.Date = payment.Date
+		for j, w := range wanted {
+			if w.Category == t.Category {
+				t.Amount = w.Amount
+				wanted = append(wanted[:j], wanted[j+1:]...)
+				break
+			}
+		}
 	}
+	payment.Transactions = append(payment.Transactions, wanted...)
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/wealthpath/backend/internal/model"
 	"github.com/wealthpath/backend/internal/repository"
 )
@@ -53,11 +54,74 @@ func (m *MockDebtRepo) Delete(ctx context.Context, id, userID uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockDebtRepo) RecordPayment(ctx context.Context, payment *model.DebtPayment) error {
-	args := m.Called(ctx, payment)
+func (m *MockDebtRepo) RecordPayment(ctx context.Context, payment *model.DebtPayment, split model.DebtPaymentSplit) error {
+	args := m.Called(ctx, payment, split)
 	return args.Error(0)
 }
 
+func (m *MockDebtRepo) GetPayments(ctx context.Context, debtID uuid.UUID) ([]model.DebtPaymentRecord, error) {
+	args := m.Called(ctx, debtID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.DebtPaymentRecord), args.Error(1)
+}
+
+func (m *MockDebtRepo) GetPayment(ctx context.Context, debtID, paymentID uuid.UUID) (*model.DebtPayment, error) {
+	args := m.Called(ctx, debtID, paymentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DebtPayment), args.Error(1)
+}
+
+func (m *MockDebtRepo) UpdatePayment(ctx context.Context, payment *model.DebtPayment, split model.DebtPaymentSplit) error {
+	args := m.Called(ctx, payment, split)
+	return args.Error(0)
+}
+
+func (m *MockDebtRepo) DeletePayment(ctx context.Context, debtID, paymentID uuid.UUID) error {
+	args := m.Called(ctx, debtID, paymentID)
+	return args.Error(0)
+}
+
+func (m *MockDebtRepo) ListStatementCards(ctx context.Context) ([]model.Debt, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Debt), args.Error(1)
+}
+
+func (m *MockDebtRepo) ListStatements(ctx context.Context, debtID uuid.UUID) ([]model.CreditCardStatement, error) {
+	args := m.Called(ctx, debtID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.CreditCardStatement), args.Error(1)
+}
+
+func (m *MockDebtRepo) GetStatement(ctx context.Context, debtID, statementID uuid.UUID) (*model.CreditCardStatement, error) {
+	args := m.Called(ctx, debtID, statementID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CreditCardStatement), args.Error(1)
+}
+
+func (m *MockDebtRepo) CloseStatement(ctx context.Context, statement *model.CreditCardStatement) error {
+	args := m.Called(ctx, statement)
+	return args.Error(0)
+}
+
+func (m *MockDebtRepo) GetCardTransactions(ctx context.Context, debtID uuid.UUID, from time.Time, to *time.Time) ([]model.Transaction, error) {
+	args := m.Called(ctx, debtID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Transaction), args.Error(1)
+}
+
 // Table-driven tests with parallel execution (following Go rules)
 func TestDebtService_Create(t *testing.T) {
 	t.Parallel()
@@ -332,7 +396,7 @@ func TestDebtService_MakePayment(t *testing.T) {
 					CurrentBalance: decimal.NewFromFloat(10000),
 					InterestRate:   decimal.NewFromFloat(12),
 				}, nil).Once()
-				m.On("RecordPayment", mock.Anything, mock.AnythingOfType("*model.DebtPayment")).Return(nil)
+				m.On("RecordPayment", mock.Anything, mock.AnythingOfType("*model.DebtPayment"), mock.Anything).Return(nil)
 				m.On("GetByID", mock.Anything, debtID).Return(&model.Debt{
 					ID:             debtID,
 					UserID:         userID,
@@ -384,6 +448,121 @@ func TestDebtService_MakePayment(t *testing.T) {
 	}
 }
 
+func TestDebtService_MakePayment_RecordsTransactions(t *testing.T) {
+	t.Parallel()
+
+	// 10000 at 12% APR: 100 interest and 400 principal of a 500 payment.
+	tests := []struct {
+		name     string
+		debtType model.DebtType
+		input    MakePaymentInput
+		want     map[string]decimal.Decimal
+	}{
+		{
+			name:  "not recorded",
+			input: MakePaymentInput{},
+			want:  map[string]decimal.Decimal{},
+		},
+		{
+			// The card purchases the payment repays were already recorded as expenses.
+			name:     "credit card",
+			debtType: model.DebtTypeCreditCard,
+			input:    MakePaymentInput{RecordTransaction: true, SplitInterest: true},
+			want:     map[string]decimal.Decimal{},
+		},
+		{
+			name:  "whole payment",
+			input: MakePaymentInput{RecordTransaction: true},
+			want:  map[string]decimal.Decimal{model.CategoryDebtPayments: decimal.NewFromInt(500)},
+		},
+		{
+			name:  "interest split out",
+			input: MakePaymentInput{RecordTransaction: true, SplitInterest: true},
+			want: map[string]decimal.Decimal{
+				model.CategoryDebtPayments: decimal.NewFromInt(400),
+				model.CategoryDebtInterest: decimal.NewFromInt(100),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockDebtRepo)
+			svc := NewDebtService(mockRepo)
+			userID := uuid.New()
+			debt := &model.Debt{
+				ID:             uuid.New(),
+				UserID:         userID,
+				Name:           "Car loan",
+				Type:           tt.debtType,
+				CurrentBalance: decimal.NewFromInt(10000),
+				InterestRate:   decimal.NewFromInt(12),
+				Currency:       "VND",
+			}
+			date := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+
+			var recorded *model.DebtPayment
+			mockRepo.On("GetByID", mock.Anything, debt.ID).Return(debt, nil)
+			mockRepo.On("RecordPayment", mock.Anything, mock.AnythingOfType("*model.DebtPayment"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					recorded = args.Get(1).(*model.DebtPayment)
+					require.NoError(t, args.Get(2).(model.DebtPaymentSplit)(debt, recorded))
+				}).
+				Return(nil)
+
+			tt.input.Amount = decimal.NewFromInt(500)
+			tt.input.Date = date
+			_, err := svc.MakePayment(context.Background(), debt.ID, userID, tt.input)
+
+			require.NoError(t, err)
+			require.Len(t, recorded.Transactions, len(tt.want))
+			assert.Equal(t, tt.input.SplitInterest && len(tt.want) > 0, recorded.SplitInterest)
+			for _, tx := range recorded.Transactions {
+				assert.True(t, tx.Amount.Equal(tt.want[tx.Category]), "%s amount %s", tx.Category, tx.Amount)
+				assert.Equal(t, userID, tx.UserID)
+				assert.Equal(t, model.TransactionTypeExpense, tx.Type)
+				assert.Equal(t, "VND", tx.Currency)
+				assert.Equal(t, date, tx.Date)
+			}
+		})
+	}
+}
+
+func TestDebtService_MakePayment_SplitsAgainstLockedBalance(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	debt := &model.Debt{
+		ID:             uuid.New(),
+		UserID:         userID,
+		CurrentBalance: decimal.NewFromInt(10000),
+		InterestRate:   decimal.NewFromInt(12),
+	}
+	// Another payment brought the balance down to 5000 before this one took the lock.
+	locked := *debt
+	locked.CurrentBalance = decimal.NewFromInt(5000)
+
+	var recorded *model.DebtPayment
+	mockRepo.On("GetByID", mock.Anything, debt.ID).Return(debt, nil)
+	mockRepo.On("RecordPayment", mock.Anything, mock.AnythingOfType("*model.DebtPayment"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			recorded = args.Get(1).(*model.DebtPayment)
+			require.NoError(t, args.Get(2).(model.DebtPaymentSplit)(&locked, recorded))
+		}).
+		Return(nil)
+
+	_, err := svc.MakePayment(context.Background(), debt.ID, userID, MakePaymentInput{Amount: decimal.NewFromInt(500)})
+
+	require.NoError(t, err)
+	assert.True(t, recorded.Interest.Equal(decimal.NewFromInt(50)), "interest %s", recorded.Interest)
+	assert.True(t, recorded.Principal.Equal(decimal.NewFromInt(450)), "principal %s", recorded.Principal)
+}
+
 func TestDebtService_GetPayoffPlan(t *testing.T) {
 	t.Parallel()
 
@@ -485,10 +664,291 @@ func TestCalculatePayoffPlan(t *testing.T) {
 		InterestRate:   decimal.NewFromFloat(12),
 	}
 
-	plan := calculatePayoffPlan(debt, decimal.NewFromFloat(500))
+	plan := calculatePayoffPlan(debt, decimal.NewFromFloat(500), nil, nil)
 
 	assert.NotNil(t, plan)
 	assert.True(t, plan.MonthsToPayoff > 0)
 	assert.True(t, plan.TotalInterest.GreaterThan(decimal.Zero))
 	assert.NotEmpty(t, plan.AmortizationPlan)
 }
+
+func TestDebtService_ListPayments(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	debtID := uuid.New()
+
+	mockRepo.On("GetByID", mock.Anything, debtID).Return(&model.Debt{ID: debtID, UserID: userID, Currency: "USD", CurrentBalance: decimal.NewFromInt(8500)}, nil)
+	mockRepo.On("GetPayments", mock.Anything, debtID).Return([]model.DebtPaymentRecord{
+		{
+			DebtPayment:         model.DebtPayment{Amount: decimal.NewFromInt(800), Principal: decimal.NewFromInt(720), Interest: decimal.NewFromInt(80)},
+			CumulativePrincipal: decimal.NewFromInt(1500),
+			CumulativeInterest:  decimal.NewFromInt(100),
+		},
+		{
+			DebtPayment:         model.DebtPayment{Amount: decimal.NewFromInt(800), Principal: decimal.NewFromInt(780), Interest: decimal.NewFromInt(20)},
+			CumulativePrincipal: decimal.NewFromInt(780),
+			CumulativeInterest:  decimal.NewFromInt(20),
+		},
+	}, nil)
+
+	history, err := svc.ListPayments(context.Background(), debtID, userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, history.Payments, 2)
+	assert.True(t, history.TotalPaid.Equal(decimal.NewFromInt(1600)))
+	assert.True(t, history.TotalPrincipal.Equal(decimal.NewFromInt(1500)))
+	assert.True(t, history.TotalInterest.Equal(decimal.NewFromInt(100)))
+	assert.True(t, history.CurrentBalance.Equal(decimal.NewFromInt(8500)))
+}
+
+func TestDebtService_ListPayments_OtherUser(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	debtID := uuid.New()
+
+	mockRepo.On("GetByID", mock.Anything, debtID).Return(&model.Debt{ID: debtID, UserID: uuid.New()}, nil)
+
+	history, err := svc.ListPayments(context.Background(), debtID, uuid.New())
+
+	assert.ErrorIs(t, err, repository.ErrDebtNotFound)
+	assert.Nil(t, history)
+	mockRepo.AssertNotCalled(t, "GetPayments", mock.Anything, mock.Anything)
+}
+
+func TestDebtService_UpdatePayment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		amount        decimal.Decimal
+		getErr        error
+		updateErr     error
+		wantPrincipal decimal.Decimal
+		wantInterest  decimal.Decimal
+		wantErr       error
+	}{
+		{
+			// Balance before the payment, as read under the lock, was 9500 at 12% APR: 95 interest.
+			name:          "recalculates split",
+			amount:        decimal.NewFromInt(1000),
+			wantPrincipal: decimal.NewFromInt(905),
+			wantInterest:  decimal.NewFromInt(95),
+		},
+		{name: "invalid amount", amount: decimal.Zero, wantErr: ErrInvalidAmount},
+		{name: "payment not found", amount: decimal.NewFromInt(100), getErr: repository.ErrDebtPaymentNotFound, wantErr: repository.ErrDebtPaymentNotFound},
+		{
+			name:      "not the latest payment",
+			amount:    decimal.NewFromInt(1000),
+			updateErr: repository.ErrDebtPaymentNotLatest,
+			wantErr:   repository.ErrDebtPaymentNotLatest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockDebtRepo)
+			svc := NewDebtService(mockRepo)
+			userID := uuid.New()
+			debtID := uuid.New()
+			paymentID := uuid.New()
+			date := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+			debt := &model.Debt{
+				ID:             debtID,
+				UserID:         userID,
+				CurrentBalance: decimal.NewFromInt(9000),
+				InterestRate:   decimal.NewFromInt(12),
+			}
+			locked := *debt
+			locked.CurrentBalance = decimal.NewFromInt(9500)
+
+			mockRepo.On("GetByID", mock.Anything, debtID).Return(debt, nil).Maybe()
+			if tt.getErr != nil {
+				mockRepo.On("GetPayment", mock.Anything, debtID, paymentID).Return(nil, tt.getErr).Maybe()
+			} else {
+				mockRepo.On("GetPayment", mock.Anything, debtID, paymentID).Return(&model.DebtPayment{
+					ID:        paymentID,
+					DebtID:    debtID,
+					Amount:    decimal.NewFromInt(600),
+					Principal: decimal.NewFromInt(500),
+					Interest:  decimal.NewFromInt(100),
+					Date:      date,
+				}, nil).Maybe()
+			}
+			mockRepo.On("UpdatePayment", mock.Anything, mock.AnythingOfType("*model.DebtPayment"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					if tt.updateErr == nil {
+						require.NoError(t, args.Get(2).(model.DebtPaymentSplit)(&locked, args.Get(1).(*model.DebtPayment)))
+					}
+				}).
+				Return(tt.updateErr).Maybe()
+
+			payment, err := svc.UpdatePayment(context.Background(), debtID, paymentID, userID, MakePaymentInput{Amount: tt.amount})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, payment)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, payment.Principal.Equal(tt.wantPrincipal), "principal %s", payment.Principal)
+			assert.True(t, payment.Interest.Equal(tt.wantInterest), "interest %s", payment.Interest)
+			assert.Equal(t, date, payment.Date)
+		})
+	}
+}
+
+func TestSyncPaymentTransactions(t *testing.T) {
+	t.Parallel()
+
+	debt := &model.Debt{Name: "Card", UserID: uuid.New(), Currency: "VND"}
+	date := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	linked := func(category string, amount int64) model.Transaction {
+		return model.Transaction{ID: uuid.New(), Category: category, Amount: decimal.NewFromInt(amount)}
+	}
+
+	tests := []struct {
+		name      string
+		linked    []model.Transaction
+		split     bool
+		principal int64
+		interest  int64
+		want      map[string]int64
+		wantNew   int
+	}{
+		{
+			name:      "not recorded",
+			principal: 900,
+			interest:  100,
+			want:      map[string]int64{},
+		},
+		{
+			name:      "whole payment",
+			linked:    []model.Transaction{linked(model.CategoryDebtPayments, 500)},
+			principal: 900,
+			interest:  100,
+			want:      map[string]int64{model.CategoryDebtPayments: 1000},
+		},
+		{
+			name:      "recurring expense keeps its category",
+			linked:    []model.Transaction{linked("Housing", 500)},
+			principal: 900,
+			interest:  100,
+			want:      map[string]int64{"Housing": 1000},
+		},
+		{
+			name:      "split kept",
+			linked:    []model.Transaction{linked(model.CategoryDebtPayments, 400), linked(model.CategoryDebtInterest, 100)},
+			split:     true,
+			principal: 900,
+			interest:  80,
+			want:      map[string]int64{model.CategoryDebtPayments: 900, model.CategoryDebtInterest: 80},
+		},
+		{
+			name:      "interest dropped to zero",
+			linked:    []model.Transaction{linked(model.CategoryDebtPayments, 400), linked(model.CategoryDebtInterest, 100)},
+			split:     true,
+			principal: 500,
+			want:      map[string]int64{model.CategoryDebtPayments: 500, model.CategoryDebtInterest: 0},
+		},
+		{
+			name:      "principal becomes due",
+			linked:    []model.Transaction{linked(model.CategoryDebtInterest, 50)},
+			split:     true,
+			interest:  100,
+			principal: 200,
+			want:      map[string]int64{model.CategoryDebtPayments: 200, model.CategoryDebtInterest: 100},
+			wantNew:   1,
+		},
+		{
+			// Recorded split while its interest was zero, so only the principal expense exists.
+			name:      "interest becomes due on a split payment",
+			linked:    []model.Transaction{linked(model.CategoryDebtPayments, 500)},
+			split:     true,
+			interest:  100,
+			principal: 900,
+			want:      map[string]int64{model.CategoryDebtPayments: 900, model.CategoryDebtInterest: 100},
+			wantNew:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			payment := &model.DebtPayment{
+				Amount:        decimal.NewFromInt(tt.principal + tt.interest),
+				Principal:     decimal.NewFromInt(tt.principal),
+				Interest:      decimal.NewFromInt(tt.interest),
+				Date:          date,
+				SplitInterest: tt.split,
+				Transactions:  tt.linked,
+			}
+
+			syncPaymentTransactions(debt, payment)
+
+			require.Len(t, payment.Transactions, len(tt.want))
+			added := 0
+			for _, tx := range payment.Transactions {
+				assert.True(t, tx.Amount.Equal(decimal.NewFromInt(tt.want[tx.Category])), "%s amount %s", tx.Category, tx.Amount)
+				assert.Equal(t, date, tx.Date)
+				if tx.ID == uuid.Nil {
+					added++
+				}
+			}
+			assert.Equal(t, tt.wantNew, added)
+		})
+	}
+}
+
+func TestDebtService_DeletePayment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		owner     bool
+		deleteErr error
+		wantErr   error
+	}{
+		{name: "success", owner: true},
+		{name: "other user", owner: false, wantErr: repository.ErrDebtNotFound},
+		{name: "payment not found", owner: true, deleteErr: repository.ErrDebtPaymentNotFound, wantErr: repository.ErrDebtPaymentNotFound},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockDebtRepo)
+			svc := NewDebtService(mockRepo)
+			userID := uuid.New()
+			debtID := uuid.New()
+			paymentID := uuid.New()
+
+			ownerID := uuid.New()
+			if tt.owner {
+				ownerID = userID
+			}
+			mockRepo.On("GetByID", mock.Anything, debtID).Return(&model.Debt{ID: debtID, UserID: ownerID}, nil)
+			mockRepo.On("DeletePayment", mock.Anything, debtID, paymentID).Return(tt.deleteErr).Maybe()
+
+			err := svc.DeletePayment(context.Background(), debtID, paymentID, userID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				mockRepo.AssertCalled(t, "DeletePayment", mock.Anything, debtID, paymentID)
+			}
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/wealthpath/backend/internal/model"
+)
+
+// Service-level errors for multi-debt payoff strategies.
+var (
+	ErrInvalidDebtStrategy  = errors.New("strategy must be snowball, avalanche or custom")
+	ErrInvalidDebtOrder     = errors.New("customOrder must list the user's debts without duplicates")
+	ErrNoDebtsToPlan        = errors.New("no outstanding debts to plan")
+	ErrDebtCurrencyMismatch = errors.New("debts use different currencies; specify a currency")
+	ErrDebtBudgetTooLow     = errors.New("monthlyBudget must cover the minimum payments")
+)
+
+// maxStrategyMonths caps the simulation at the same 30 years used by single-debt payoff plans.
+const maxStrategyMonths = 360
+
+type DebtStrategyInput struct {
+	Strategy      model.DebtStrategy `json:"strategy"`      // defaults to avalanche
+	MonthlyBudget decimal.Decimal    `json:"monthlyBudget"` // defaults to the sum of minimum payments
+	Currency      string             `json:"currency,omitempty"`
+	CustomOrder   []uuid.UUID        `json:"customOrder,omitempty"` // debt IDs, highest priority first
+}
+
+// PlanPayoffStrategy simulates paying off all of a user's outstanding debts from a
+// single monthly budget. Every debt receives its minimum payment and the rest of the
+// budget goes to the debt with the highest priority under the chosen strategy, so the
+// minimum of a paid-off debt rolls over to the next one.
+// The plan is compared against paying only the minimums and against the other strategies.
+func (s *DebtService) PlanPayoffStrategy(ctx context.Context, userID uuid.UUID, input DebtStrategyInput) (*model.DebtStrategyPlan, error) {
+	strategy := input.Strategy
+	if strategy == "" {
+		strategy = model.DebtStrategyAvalanche
+	}
+	if !isDebtStrategy(strategy) {
+		return nil, ErrInvalidDebtStrategy
+	}
+	if strategy == model.DebtStrategyCustom && len(input.CustomOrder) == 0 {
+		return nil, ErrInvalidDebtOrder
+	}
+
+	all, err := s.repo.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing debts for user %s: %w", userID, err)
+	}
+
+	debts, code, err := outstandingDebts(all, input.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	minimums := decimal.Zero
+	for _, d := range debts {
+		minimums = minimums.Add(d.MinimumPayment)
+	}
+
+	budget := input.MonthlyBudget
+	if budget.IsZero() {
+		budget = minimums
+	}
+	if budget.LessThan(minimums) || !budget.IsPositive() {
+		return nil, ErrDebtBudgetTooLow
+	}
+
+	start := time.Now()
+	places := currencyPlaces(code)
+
+	schedules := make(map[uuid.UUID]*rateSchedule)
+	for i := range debts {
+		if !debts[i].HasRateSchedule() {
+			continue
+		}
+		schedule, err := s.buildRateSchedule(ctx, &debts[i], start)
+		if err != nil {
+			return nil, err
+		}
+		schedules[debts[i].ID] = schedule
+	}
+
+	ordered, err := orderDebts(debts, strategy, input.CustomOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := simulateDebtStrategy(ordered, schedules, budget, places, start, true)
+	plan.Strategy = strategy
+	plan.Currency = code
+	plan.MonthlyBudget = budget
+	plan.TotalMinimumPayment = minimums
+
+	baseline := simulateDebtStrategy(ordered, schedules, minimums, places, start, false)
+	plan.MinimumOnly = strategySummary(model.DebtStrategyMinimumOnly, baseline)
+	plan.InterestSaved = baseline.TotalInterest.Sub(plan.TotalInterest)
+	plan.MonthsSaved = baseline.MonthsToPayoff - plan.MonthsToPayoff
+
+	compared := []model.DebtStrategy{model.DebtStrategySnowball, model.DebtStrategyAvalanche}
+	if len(input.CustomOrder) > 0 {
+		compared = append(compared, model.DebtStrategyCustom)
+	}
+	for _, st := range compared {
+		if st == strategy {
+			plan.Comparison = append(plan.Comparison, strategySummary(st, plan))
+			continue
+		}
+		order, err := orderDebts(debts, st, input.CustomOrder)
+		if err != nil {
+			return nil, err
+		}
+		plan.Comparison = append(plan.Comparison, strategySummary(st, simulateDebtStrategy(order, schedules, budget, places, start, true)))
+	}
+
+	return plan, nil
+}
+
+func isDebtStrategy(strategy model.DebtStrategy) bool {
+	switch strategy {
+	case model.DebtStrategySnowball, model.DebtStrategyAvalanche, model.DebtStrategyCustom:
+		return true
+	}
+	return false
+}
+
+// outstandingDebts keeps debts with a positive balance in the requested currency.
+// Without a currency, all outstanding debts must share one.
+func outstandingDebts(debts []model.Debt, code string) ([]model.Debt, string, error) {
+	out := make([]model.Debt, 0, len(debts))
+	for _, d := range debts {
+		if !d.CurrentBalance.IsPositive() {
+			continue
+		}
+		if code != "" && d.Currency != code {
+			continue
+		}
+		out = append(out, d)
+	}
+
+	if len(out) == 0 {
+		return nil, "", ErrNoDebtsToPlan
+	}
+
+	if code == "" {
+		code = out[0].Currency
+		for _, d := range out[1:] {
+			if d.Currency != code {
+				return nil, "", ErrDebtCurrencyMismatch
+			}
+		}
+	}
+
+	return out, code, nil
+}
+
+// orderDebts returns the debts in payoff priority order.
+// Snowball targets the smallest balance, avalanche the highest rate; ties fall back
+// to the other criterion. Custom puts the listed debts first and the rest in
+// avalanche order.
+func orderDebts(debts []model.Debt, strategy model.DebtStrategy, customOrder []uuid.UUID) ([]model.Debt, error) {
+	ordered := make([]model.Debt, len(debts))
+	copy(ordered, debts)
+
+	byRate := func(a, b model.Debt) bool {
+		if !a.InterestRate.Equal(b.InterestRate) {
+			return a.InterestRate.GreaterThan(b.InterestRate)
+		}
+		return a.CurrentBalance.LessThan(b.CurrentBalance)
+	}
+
+	switch strategy {
+	case model.DebtStrategySnowball:
+		sort.SliceStable(ordered, func(i, j int) bool {
+			a, b := ordered[i], ordered[j]
+			if !a.CurrentBalance.Equal(b.CurrentBalance) {
+				return a.CurrentBalance.LessThan(b.CurrentBalance)
+			}
+			return a.InterestRate.GreaterThan(b.InterestRate)
+		})
+	case model.DebtStrategyAvalanche:
+		sort.SliceStable(ordered, func(i, j int) bool { return byRate(ordered[i], ordered[j]) })
+	case model.DebtStrategyCustom:
+		known := make(map[uuid.UUID]bool, len(debts))
+		for _, d := range debts {
+			known[d.ID] = true
+		}
+		rank := make(map[uuid.UUID]int, len(customOrder))
+		for i, id := range customOrder {
+			if _, dup := rank[id]; dup || !known[id] {
+				return nil, ErrInvalidDebtOrder
+			}
+			rank[id] = i
+		}
+		sort.SliceStable(ordered, func(i, j int) bool {
+			ri, iok := rank[ordered[i].ID]
+			rj, jok := rank[ordered[j].ID]
+			switch {
+			case iok && jok:
+				return ri < rj
+			case iok != jok:
+				return iok
+			}
+			return byRate(ordered[i], ordered[j])
+		})
+	default:
+		return nil, ErrInvalidDebtStrategy
+	}
+
+	return ordered, nil
+}
+
+// simulateDebtStrategy pays the debts month by month. Interest accrues monthly as it does
+// in a single debt's payoff plan: under the debt's interest method and, for variable-rate
+// debts, at the rate of their schedule. Every debt receives up to its minimum payment,
+// which for equal-principal debts covers the scheduled principal and for declining-balance
+// debts rises at a rate reset to the annuity repaying the rest of the term. When rollover
+// is set, what is left of the budget goes to the debts in the given order.
+// Without rollover only the minimums are paid, which is the baseline for comparison.
+func simulateDebtStrategy(debts []model.Debt, schedules map[uuid.UUID]*rateSchedule, budget decimal.Decimal, places int32, start time.Time, rollover bool) *model.DebtStrategyPlan {
+	balances := make([]decimal.Decimal, len(debts))
+	minimums := make([]decimal.Decimal, len(debts))
+	results := make([]model.DebtStrategyResult, len(debts))
+	remaining := 0
+	for i, d := range debts {
+		balances[i] = d.CurrentBalance
+		minimums[i] = d.MinimumPayment
+		results[i] = model.DebtStrategyResult{
+			DebtID:          d.ID,
+			Name:            d.Name,
+			Priority:        i + 1,
+			StartingBalance: d.CurrentBalance,
+			InterestRate:    d.InterestRate,
+			MinimumPayment:  d.MinimumPayment,
+			TotalInterest:   decimal.Zero,
+			TotalPayment:    decimal.Zero,
+		}
+		remaining++
+	}
+
+	plan := &model.DebtStrategyPlan{
+		TotalInterest: decimal.Zero,
+		TotalPayment:  decimal.Zero,
+		Schedule:      make([]model.DebtStrategyMonth, 0),
+	}
+
+	month := 0
+	for remaining > 0 && month < maxStrategyMonths {
+		month++
+		date := start.AddDate(0, month, 0)
+
+		interest := make([]decimal.Decimal, len(debts))
+		payments := make([]decimal.Decimal, len(debts))
+		available := budget
+		for i := range debts {
+			d := &debts[i]
+			if !balances[i].IsPositive() {
+				continue
+			}
+			rate := d.InterestRate
+			if schedule := schedules[d.ID]; schedule != nil {
+				rate = schedule.rateFor(month)
+				if schedule.resetsAt(month) && (d.InterestMethod == "" || d.InterestMethod == model.InterestMethodDecliningBalance) {
+					required := annuityPayment(balances[i], rate, schedule.remainingTerm-month+1, places)
+					minimums[i] = decimal.Max(d.MinimumPayment, required)
+				}
+			}
+			interest[i] = monthlyInterest(d, balances[i], rate, places)
+			due := minimums[i]
+			if d.InterestMethod == model.InterestMethodEqualPrincipal {
+				due = decimal.Max(due, equalPrincipalInstalment(d, places).Add(interest[i]))
+			}
+			payments[i] = decimal.Min(due, balances[i].Add(interest[i]))
+			available = available.Sub(payments[i])
+		}
+
+		if rollover {
+			for i := range debts {
+				if !available.IsPositive() {
+					break
+				}
+				owed := balances[i].Add(interest[i]).Sub(payments[i])
+				if !owed.IsPositive() {
+					continue
+				}
+				extra := decimal.Min(available, owed)
+				payments[i] = payments[i].Add(extra)
+				available = available.Sub(extra)
+			}
+		}
+
+		row := model.DebtStrategyMonth{
+			Month:            month,
+			Date:             date,
+			Payment:          decimal.Zero,
+			Interest:         decimal.Zero,
+			RemainingBalance: decimal.Zero,
+			Allocations:      make([]model.DebtAllocation, 0, remaining),
+		}
+
+		for i := range debts {
+			if !balances[i].IsPositive() {
+				continue
+			}
+
+			// Unpaid interest is capitalised, as a card or loan would.
+			interestPaid := decimal.Min(interest[i], payments[i])
+			balances[i] = balances[i].Add(interest[i]).Sub(payments[i])
+
+			results[i].TotalInterest = results[i].TotalInterest.Add(interest[i])
+			results[i].TotalPayment = results[i].TotalPayment.Add(payments[i])
+			if !balances[i].IsPositive() {
+				payoff := date
+				results[i].PayoffMonth = month
+				results[i].PayoffDate = &payoff
+				remaining--
+			}
+
+			row.Allocations = append(row.Allocations, model.DebtAllocation{
+				DebtID:           debts[i].ID,
+				Payment:          payments[i],
+				Principal:        payments[i].Sub(interestPaid),
+				Interest:         interest[i],
+				RemainingBalance: balances[i],
+			})
+			row.Payment = row.Payment.Add(payments[i])
+			row.Interest = row.Interest.Add(interest[i])
+			row.RemainingBalance = row.RemainingBalance.Add(balances[i])
+		}
+
+		plan.TotalInterest = plan.TotalInterest.Add(row.Interest)
+		plan.TotalPayment = plan.TotalPayment.Add(row.Payment)
+		plan.Schedule = append(plan.Schedule, row)
+	}
+
+	plan.Debts = results
+	plan.MonthsToPayoff = month
+	if remaining == 0 {
+		payoff := start.AddDate(0, month, 0)
+		plan.PayoffDate = &payoff
+	}
+
+	return plan
+}
+
+func strategySummary(strategy model.DebtStrategy, plan *model.DebtStrategyPlan) model.DebtStrategySummary {
+	return model.DebtStrategySummary{
+		Strategy:       strategy,
+		TotalInterest:  plan.TotalInterest,
+		TotalPayment:   plan.TotalPayment,
+		MonthsToPayoff: plan.MonthsToPayoff,
+		PayoffDate:     plan.PayoffDate,
+		PaidOff:        plan.PayoffDate != nil,
+	}
+}
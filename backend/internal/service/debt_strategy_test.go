@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+func strategyDebt(name string, balance, rate, minimum int64) model.Debt {
+	return model.Debt{
+		ID:             uuid.New(),
+		Name:           name,
+		CurrentBalance: decimal.NewFromInt(balance),
+		InterestRate:   decimal.NewFromInt(rate),
+		MinimumPayment: decimal.NewFromInt(minimum),
+		Currency:       "USD",
+	}
+}
+
+func TestDebtService_PlanPayoffStrategy_Rollover(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	small := strategyDebt("Card", 300, 0, 100)
+	large := strategyDebt("Car", 1000, 0, 100)
+
+	mockRepo.On("List", mock.Anything, userID).Return([]model.Debt{large, small}, nil)
+
+	plan, err := svc.PlanPayoffStrategy(context.Background(), userID, DebtStrategyInput{
+		Strategy:      model.DebtStrategySnowball,
+		MonthlyBudget: decimal.NewFromInt(300),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, plan.MonthsToPayoff)
+	assert.True(t, plan.TotalPayment.Equal(decimal.NewFromInt(1300)))
+	assert.True(t, plan.TotalMinimumPayment.Equal(decimal.NewFromInt(200)))
+
+	// The card is targeted first, then its minimum rolls over to the car.
+	assert.Equal(t, small.ID, plan.Debts[0].DebtID)
+	assert.Equal(t, 2, plan.Debts[0].PayoffMonth)
+	assert.Equal(t, 5, plan.Debts[1].PayoffMonth)
+	assert.True(t, plan.Schedule[0].Allocations[0].Payment.Equal(decimal.NewFromInt(200)))
+	assert.True(t, plan.Schedule[2].Allocations[0].Payment.Equal(decimal.NewFromInt(300)))
+
+	// Paying only the minimums takes ten months for the car loan.
+	assert.Equal(t, 10, plan.MinimumOnly.MonthsToPayoff)
+	assert.Equal(t, 5, plan.MonthsSaved)
+	assert.True(t, plan.InterestSaved.IsZero())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDebtService_PlanPayoffStrategy_AvalancheSavesInterest(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	card := strategyDebt("Card", 5000, 24, 150)
+	loan := strategyDebt("Loan", 2000, 6, 100)
+
+	mockRepo.On("List", mock.Anything, userID).Return([]model.Debt{loan, card}, nil)
+
+	plan, err := svc.PlanPayoffStrategy(context.Background(), userID, DebtStrategyInput{
+		MonthlyBudget: decimal.NewFromInt(600),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, model.DebtStrategyAvalanche, plan.Strategy)
+	assert.Equal(t, card.ID, plan.Debts[0].DebtID)
+	require.NotNil(t, plan.PayoffDate)
+	assert.True(t, plan.InterestSaved.IsPositive())
+	assert.Positive(t, plan.MonthsSaved)
+
+	require.Len(t, plan.Comparison, 2)
+	snowball, avalanche := plan.Comparison[0], plan.Comparison[1]
+	assert.Equal(t, model.DebtStrategySnowball, snowball.Strategy)
+	assert.True(t, avalanche.TotalInterest.LessThan(snowball.TotalInterest))
+	assert.True(t, avalanche.TotalInterest.Equal(plan.TotalInterest))
+
+	// Each month's allocation never exceeds the budget.
+	for _, month := range plan.Schedule {
+		assert.True(t, month.Payment.LessThanOrEqual(decimal.NewFromInt(600)))
+	}
+}
+
+func TestDebtService_PlanPayoffStrategy_CustomOrder(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	a := strategyDebt("A", 1000, 5, 50)
+	b := strategyDebt("B", 2000, 20, 50)
+	c := strategyDebt("C", 3000, 10, 50)
+
+	mockRepo.On("List", mock.Anything, userID).Return([]model.Debt{a, b, c}, nil)
+
+	plan, err := svc.PlanPayoffStrategy(context.Background(), userID, DebtStrategyInput{
+		Strategy:      model.DebtStrategyCustom,
+		MonthlyBudget: decimal.NewFromInt(500),
+		CustomOrder:   []uuid.UUID{a.ID},
+	})
+
+	require.NoError(t, err)
+	// Unlisted debts follow in avalanche order.
+	assert.Equal(t, []uuid.UUID{a.ID, b.ID, c.ID}, []uuid.UUID{plan.Debts[0].DebtID, plan.Debts[1].DebtID, plan.Debts[2].DebtID})
+	assert.Len(t, plan.Comparison, 3)
+}
+
+func TestDebtService_PlanPayoffStrategy_Errors(t *testing.T) {
+	t.Parallel()
+
+	usd := strategyDebt("Card", 1000, 20, 100)
+	vnd := strategyDebt("Vay", 50000000, 12, 2000000)
+	vnd.Currency = "VND"
+	paid := strategyDebt("Paid", 0, 10, 100)
+
+	tests := []struct {
+		name    string
+		debts   []model.Debt
+		listErr error
+		input   DebtStrategyInput
+		wantErr error
+	}{
+		{name: "unknown strategy", input: DebtStrategyInput{Strategy: "fastest"}, wantErr: ErrInvalidDebtStrategy},
+		{name: "custom without order", input: DebtStrategyInput{Strategy: model.DebtStrategyCustom}, wantErr: ErrInvalidDebtOrder},
+		{name: "repository error", listErr: errors.New("db error"), input: DebtStrategyInput{}},
+		{name: "no outstanding debts", debts: []model.Debt{paid}, input: DebtStrategyInput{}, wantErr: ErrNoDebtsToPlan},
+		{name: "mixed currencies", debts: []model.Debt{usd, vnd}, input: DebtStrategyInput{}, wantErr: ErrDebtCurrencyMismatch},
+		{name: "budget below minimums", debts: []model.Debt{usd}, input: DebtStrategyInput{MonthlyBudget: decimal.NewFromInt(50)}, wantErr: ErrDebtBudgetTooLow},
+		{name: "custom order with unknown debt", debts: []model.Debt{usd}, input: DebtStrategyInput{Strategy: model.DebtStrategyCustom, CustomOrder: []uuid.UUID{uuid.New()}}, wantErr: ErrInvalidDebtOrder},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockDebtRepo)
+			svc := NewDebtService(mockRepo)
+			userID := uuid.New()
+			mockRepo.On("List", mock.Anything, userID).Return(tt.debts, tt.listErr).Maybe()
+
+			plan, err := svc.PlanPayoffStrategy(context.Background(), userID, tt.input)
+
+			assert.Error(t, err)
+			assert.Nil(t, plan)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDebtService_PlanPayoffStrategy_CurrencyFilter(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	usd := strategyDebt("Card", 1000, 20, 100)
+	vnd := strategyDebt("Vay", 50000000, 12, 2000000)
+	vnd.Currency = "VND"
+
+	mockRepo.On("List", mock.Anything, userID).Return([]model.Debt{usd, vnd}, nil)
+
+	plan, err := svc.PlanPayoffStrategy(context.Background(), userID, DebtStrategyInput{Currency: "VND"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "VND", plan.Currency)
+	require.Len(t, plan.Debts, 1)
+	assert.Equal(t, vnd.ID, plan.Debts[0].DebtID)
+	// Without a budget only the minimums are paid, so nothing is saved.
+	assert.True(t, plan.MonthlyBudget.Equal(decimal.NewFromInt(2000000)))
+	assert.True(t, plan.InterestSaved.IsZero())
+	// VND has no minor unit, so interest is whole dong.
+	for _, month := range plan.Schedule {
+		assert.True(t, month.Interest.Equal(month.Interest.Round(0)))
+	}
+}
+
+func TestDebtService_PlanPayoffStrategy_FlatRate(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	loan := strategyDebt("Consumer loan", 12000, 12, 1120)
+	loan.OriginalAmount = decimal.NewFromInt(12000)
+	loan.InterestMethod = model.InterestMethodFlat
+
+	mockRepo.On("List", mock.Anything, userID).Return([]model.Debt{loan}, nil)
+
+	plan, err := svc.PlanPayoffStrategy(context.Background(), userID, DebtStrategyInput{})
+
+	require.NoError(t, err)
+	// 1% of the original amount every month, not of the falling balance.
+	assert.Equal(t, 12, plan.MonthsToPayoff)
+	assert.True(t, plan.TotalInterest.Equal(decimal.NewFromInt(1440)), plan.TotalInterest.String())
+	assert.True(t, plan.Schedule[11].Allocations[0].Interest.Equal(decimal.NewFromInt(120)))
+}
+
+func TestDebtService_PlanPayoffStrategy_VariableRate(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	mockRates := new(MockDepositRateProvider)
+	svc := NewDebtService(mockRepo)
+	svc.SetRateProvider(mockRates)
+	userID := uuid.New()
+	mortgage := variableRateMortgage(time.Now())
+	mortgage.UserID = userID
+
+	mockRepo.On("List", mock.Anything, userID).Return([]model.Debt{*mortgage}, nil)
+	mockRates.On("ListRates", mock.Anything, "mortgage", mortgage.ReferenceTermMonths, "vcb").Return([]model.InterestRate{
+		{BankCode: "vcb", ProductType: "mortgage", TermMonths: 120, Rate: decimal.NewFromInt(7), EffectiveDate: time.Now()},
+	}, nil)
+
+	plan, err := svc.PlanPayoffStrategy(context.Background(), userID, DebtStrategyInput{})
+	require.NoError(t, err)
+
+	// Paying the minimums matches the debt's own payoff plan through the rate resets.
+	schedule, err := svc.buildRateSchedule(context.Background(), mortgage, time.Now())
+	require.NoError(t, err)
+	payoff := calculatePayoffPlan(mortgage, mortgage.MinimumPayment, schedule, nil)
+	assert.Equal(t, payoff.MonthsToPayoff, plan.MonthsToPayoff)
+	assert.True(t, payoff.TotalInterest.Equal(plan.TotalInterest), "%s != %s", payoff.TotalInterest, plan.TotalInterest)
+	assert.True(t, plan.Schedule[12].Allocations[0].Payment.Equal(payoff.AmortizationPlan[12].Payment))
+}
+
+func TestDebtService_PlanPayoffStrategy_VariableRateWithoutReference(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	mortgage := variableRateMortgage(time.Now())
+
+	mockRepo.On("List", mock.Anything, userID).Return([]model.Debt{*mortgage}, nil)
+
+	_, err := svc.PlanPayoffStrategy(context.Background(), userID, DebtStrategyInput{})
+	assert.ErrorIs(t, err, ErrReferenceRateNotFound)
+}
@@ -0,0 +1,422 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+	"github.com/wealthpath/backend/pkg/currency"
+)
+
+// Service-level errors for deposit ladders.
+var (
+	ErrInvalidLadderHorizon   = errors.New("horizonMonths must be between 1 and 120")
+	ErrInvalidLadderRungs     = errors.New("rungs must be between 1 and 12")
+	ErrInvalidLiquidAmount    = errors.New("liquidAmount must be between 0 and amount")
+	ErrLadderCurrencyMismatch = errors.New("ladder currency does not match savings goal currency")
+)
+
+const (
+	maxLadderHorizonMonths = 120
+	maxLadderRungs         = 12
+	defaultLadderRungs     = 4
+)
+
+// DepositLadderRepositoryInterface defines the contract for deposit ladder data access.
+// Implementations must be safe for concurrent use.
+type DepositLadderRepositoryInterface interface {
+	Create(ctx context.Context, ladder *model.DepositLadder) error
+	GetByID(ctx context.Context, id uuid.UUID) (*model.DepositLadder, error)
+	List(ctx context.Context, userID uuid.UUID, goalID *uuid.UUID) ([]model.DepositLadder, error)
+	UpdateGoal(ctx context.Context, id, userID uuid.UUID, goalID *uuid.UUID) error
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+}
+
+// SavingsGoalGetter provides savings goal lookup for ownership checks.
+type SavingsGoalGetter interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*model.SavingsGoal, error)
+}
+
+// DepositLadderService plans term deposit ladders from bank deposit rates and stores them.
+type DepositLadderService struct {
+	repo     DepositLadderRepositoryInterface
+	goalRepo SavingsGoalGetter
+	rates    DepositRateProvider
+}
+
+// NewDepositLadderService creates a new DepositLadderService with the given dependencies.
+func NewDepositLadderService(repo DepositLadderRepositoryInterface, goalRepo SavingsGoalGetter, rates DepositRateProvider) *DepositLadderService {
+	return &DepositLadderService{
+		repo:     repo,
+		goalRepo: goalRepo,
+		rates:    rates,
+	}
+}
+
+type DepositLadderInput struct {
+	Amount          decimal.Decimal `json:"amount"`
+	Currency        string          `json:"currency"`        // defaults to VND
+	HorizonMonths   int             `json:"horizonMonths"`   // longest term the money can be locked up
+	Rungs           int             `json:"rungs"`           // number of staggered deposits, defaults to 4
+	LiquidAmount    decimal.Decimal `json:"liquidAmount"`    // portion that must stay accessible
+	LiquidityMonths int             `json:"liquidityMonths"` // longest term for the liquid portion, defaults to 1
+	BankCodes       []string        `json:"bankCodes"`       // restrict to these banks, empty means all
+	StartDate       *time.Time      `json:"startDate"`       // defaults to today
+}
+
+type SaveDepositLadderInput struct {
+	DepositLadderInput
+	GoalID *uuid.UUID `json:"goalId"`
+}
+
+type AttachLadderInput struct {
+	GoalID *uuid.UUID `json:"goalId"` // nil detaches the ladder
+}
+
+// Plan builds a deposit ladder without saving it.
+// Each rung uses the best available deposit rate for its term across the selected banks.
+// Returns ErrDepositRateNotFound if no rate fits the horizon or the liquidity requirement.
+func (s *DepositLadderService) Plan(ctx context.Context, input DepositLadderInput) (*model.DepositLadder, error) {
+	if err := normalizeLadderInput(&input); err != nil {
+		return nil, err
+	}
+
+	rates, err := s.rates.ListRates(ctx, "deposit", nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("listing deposit rates: %w", err)
+	}
+
+	best := bestRatesByTerm(rates, input.Currency, input.BankCodes, input.HorizonMonths)
+	if len(best) == 0 {
+		return nil, ErrDepositRateNotFound
+	}
+
+	ladder, err := buildDepositLadder(input, best)
+	if err != nil {
+		return nil, err
+	}
+	return ladder, nil
+}
+
+// Save plans a ladder and stores it for the user, optionally attached to one of their savings goals.
+func (s *DepositLadderService) Save(ctx context.Context, userID uuid.UUID, input SaveDepositLadderInput) (*model.DepositLadder, error) {
+	ladder, err := s.Plan(ctx, input.DepositLadderInput)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.GoalID != nil {
+		if err := s.checkGoal(ctx, userID, *input.GoalID, ladder.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	ladder.UserID = userID
+	ladder.GoalID = input.GoalID
+	if err := s.repo.Create(ctx, ladder); err != nil {
+		return nil, fmt.Errorf("creating deposit ladder: %w", err)
+	}
+
+	return ladder, nil
+}
+
+// Get retrieves a saved ladder with its maturity schedule.
+// Returns ErrDepositLadderNotFound if the ladder does not belong to the user.
+func (s *DepositLadderService) Get(ctx context.Context, id, userID uuid.UUID) (*model.DepositLadder, error) {
+	ladder, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting deposit ladder %s: %w", id, err)
+	}
+	if ladder.UserID != userID {
+		return nil, repository.ErrDepositLadderNotFound
+	}
+
+	ladder.Maturities = maturitySchedule(ladder)
+	return ladder, nil
+}
+
+// List retrieves the user's saved ladders, optionally only those attached to a goal.
+func (s *DepositLadderService) List(ctx context.Context, userID uuid.UUID, goalID *uuid.UUID) ([]model.DepositLadder, error) {
+	ladders, err := s.repo.List(ctx, userID, goalID)
+	if err != nil {
+		return nil, fmt.Errorf("listing deposit ladders for user %s: %w", userID, err)
+	}
+
+	for i := range ladders {
+		ladders[i].Maturities = maturitySchedule(&ladders[i])
+	}
+	return ladders, nil
+}
+
+// AttachToGoal links a saved ladder to a savings goal, or unlinks it when input.GoalID is nil.
+func (s *DepositLadderService) AttachToGoal(ctx context.Context, id, userID uuid.UUID, input AttachLadderInput) (*model.DepositLadder, error) {
+	ladder, err := s.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.GoalID != nil {
+		if err := s.checkGoal(ctx, userID, *input.GoalID, ladder.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.UpdateGoal(ctx, id, userID, input.GoalID); err != nil {
+		return nil, fmt.Errorf("attaching deposit ladder %s: %w", id, err)
+	}
+
+	ladder.GoalID = input.GoalID
+	return ladder, nil
+}
+
+// Delete removes a saved ladder.
+func (s *DepositLadderService) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	if err := s.repo.Delete(ctx, id, userID); err != nil {
+		return fmt.Errorf("deleting deposit ladder %s: %w", id, err)
+	}
+	return nil
+}
+
+// checkGoal ensures the goal belongs to the user and is held in the ladder's currency.
+func (s *DepositLadderService) checkGoal(ctx context.Context, userID, goalID uuid.UUID, curr string) error {
+	goal, err := s.goalRepo.GetByID(ctx, goalID)
+	if err != nil {
+		return fmt.Errorf("getting savings goal %s: %w", goalID, err)
+	}
+	if goal.UserID != userID {
+		return repository.ErrSavingsGoalNotFound
+	}
+	if goal.Currency != curr {
+		return ErrLadderCurrencyMismatch
+	}
+	return nil
+}
+
+// normalizeLadderInput validates the input and applies defaults.
+func normalizeLadderInput(input *DepositLadderInput) error {
+	if !input.Amount.IsPositive() {
+		return ErrInvalidAmount
+	}
+	if input.HorizonMonths < 1 || input.HorizonMonths > maxLadderHorizonMonths {
+		return ErrInvalidLadderHorizon
+	}
+	if input.Rungs == 0 {
+		input.Rungs = defaultLadderRungs
+	}
+	if input.Rungs < 1 || input.Rungs > maxLadderRungs {
+		return ErrInvalidLadderRungs
+	}
+	if input.LiquidAmount.IsNegative() || input.LiquidAmount.GreaterThan(input.Amount) {
+		return ErrInvalidLiquidAmount
+	}
+	if input.LiquidityMonths <= 0 {
+		input.LiquidityMonths = 1
+	}
+	if input.Currency == "" {
+		input.Currency = string(currency.VND)
+	}
+	if !currency.IsValid(input.Currency) {
+		return ErrUnsupportedCurrency
+	}
+	if input.StartDate == nil {
+		today := time.Now().Truncate(24 * time.Hour)
+		input.StartDate = &today
+	}
+	return nil
+}
+
+// bestRatesByTerm keeps the highest rate for each term up to the horizon, sorted by term.
+func bestRatesByTerm(rates []model.InterestRate, curr string, bankCodes []string, horizonMonths int) []model.InterestRate {
+	allowed := make(map[string]bool, len(bankCodes))
+	for _, code := range bankCodes {
+		allowed[strings.ToLower(code)] = true
+	}
+
+	byTerm := make(map[int]model.InterestRate)
+	for _, rate := range rates {
+		if rate.Currency != curr || rate.TermMonths <= 0 || rate.TermMonths > horizonMonths {
+			continue
+		}
+		if len(allowed) > 0 && !allowed[strings.ToLower(rate.BankCode)] {
+			continue
+		}
+		if current, ok := byTerm[rate.TermMonths]; !ok || rate.Rate.GreaterThan(current.Rate) {
+			byTerm[rate.TermMonths] = rate
+		}
+	}
+
+	best := make([]model.InterestRate, 0, len(byTerm))
+	for _, rate := range byTerm {
+		best = append(best, rate)
+	}
+	sort.Slice(best, func(i, j int) bool { return best[i].TermMonths < best[j].TermMonths })
+	return best
+}
+
+// buildDepositLadder splits the amount into a liquid rung and evenly staggered rungs.
+// Rung i of n targets a term of horizon*i/n months, using the longest available term
+// that does not exceed it. Rungs landing on the same term are merged.
+func buildDepositLadder(input DepositLadderInput, best []model.InterestRate) (*model.DepositLadder, error) {
+	places := currencyPlaces(input.Currency)
+	ladder := &model.DepositLadder{
+		Amount:        input.Amount,
+		Currency:      input.Currency,
+		HorizonMonths: input.HorizonMonths,
+		StartDate:     *input.StartDate,
+	}
+
+	var allocations []ladderAllocation
+	if input.LiquidAmount.IsPositive() {
+		var liquid *model.InterestRate
+		for i := range best {
+			if best[i].TermMonths <= input.LiquidityMonths && (liquid == nil || best[i].Rate.GreaterThan(liquid.Rate)) {
+				liquid = &best[i]
+			}
+		}
+		if liquid == nil {
+			return nil, ErrDepositRateNotFound
+		}
+		allocations = append(allocations, ladderAllocation{rate: *liquid, amount: input.LiquidAmount})
+	}
+
+	remaining := input.Amount.Sub(input.LiquidAmount)
+	if remaining.IsPositive() {
+		share := remaining.Div(decimal.NewFromInt(int64(input.Rungs))).RoundFloor(places)
+		allocated := decimal.Zero
+		for i := 1; i <= input.Rungs; i++ {
+			amount := share
+			if i == input.Rungs {
+				amount = remaining.Sub(allocated)
+			}
+			allocated = allocated.Add(amount)
+
+			target := (input.HorizonMonths*i + input.Rungs - 1) / input.Rungs
+			allocations = append(allocations, ladderAllocation{rate: termAtMost(best, target), amount: amount})
+		}
+	}
+
+	for _, alloc := range mergeAllocations(allocations) {
+		if !alloc.amount.IsPositive() {
+			continue
+		}
+		rung := ladderRung(alloc.rate, alloc.amount, input.HorizonMonths, ladder.StartDate, places)
+		rung.Position = len(ladder.Rungs) + 1
+		ladder.Rungs = append(ladder.Rungs, rung)
+		ladder.TotalInterestSimple = ladder.TotalInterestSimple.Add(rung.InterestSimple)
+		ladder.TotalInterestCompound = ladder.TotalInterestCompound.Add(rung.InterestCompound)
+	}
+
+	ladder.Maturities = maturitySchedule(ladder)
+	return ladder, nil
+}
+
+type ladderAllocation struct {
+	rate   model.InterestRate
+	amount decimal.Decimal
+}
+
+// mergeAllocations combines allocations that use the same bank and term, keeping first-seen order.
+func mergeAllocations(allocations []ladderAllocation) []ladderAllocation {
+	merged := make([]ladderAllocation, 0, len(allocations))
+	index := make(map[string]int)
+	for _, alloc := range allocations {
+		key := fmt.Sprintf("%s/%d", alloc.rate.BankCode, alloc.rate.TermMonths)
+		if i, ok := index[key]; ok {
+			merged[i].amount = merged[i].amount.Add(alloc.amount)
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, alloc)
+	}
+	return merged
+}
+
+// termAtMost returns the rate with the longest term not exceeding target, or the shortest term if none does.
+func termAtMost(best []model.InterestRate, target int) model.InterestRate {
+	chosen := best[0]
+	for _, rate := range best {
+		if rate.TermMonths <= target {
+			chosen = rate
+		}
+	}
+	return chosen
+}
+
+// ladderRung computes a rung that rolls over for as many full terms as fit in the horizon.
+func ladderRung(rate model.InterestRate, amount decimal.Decimal, horizonMonths int, start time.Time, places int32) model.DepositLadderRung {
+	rollovers := horizonMonths / rate.TermMonths
+	if rollovers < 1 {
+		rollovers = 1
+	}
+
+	periodRate := depositPeriodRate(rate.Rate, rate.TermMonths)
+	simplePerTerm := amount.Mul(periodRate).Round(places)
+
+	balance := amount
+	for i := 0; i < rollovers; i++ {
+		balance = balance.Add(balance.Mul(periodRate).Round(places))
+	}
+
+	return model.DepositLadderRung{
+		BankCode:          rate.BankCode,
+		BankName:          rate.BankName,
+		TermMonths:        rate.TermMonths,
+		Rate:              rate.Rate,
+		Amount:            amount,
+		Rollovers:         rollovers,
+		InterestSimple:    simplePerTerm.Mul(decimal.NewFromInt(int64(rollovers))),
+		InterestCompound:  balance.Sub(amount),
+		FinalMaturityDate: start.AddDate(0, rate.TermMonths*rollovers, 0),
+	}
+}
+
+// maturitySchedule lists every maturity of every rung in date order.
+func maturitySchedule(ladder *model.DepositLadder) []model.DepositMaturity {
+	places := currencyPlaces(ladder.Currency)
+	var schedule []model.DepositMaturity
+	for _, rung := range ladder.Rungs {
+		periodRate := depositPeriodRate(rung.Rate, rung.TermMonths)
+		simple := rung.Amount.Mul(periodRate).Round(places)
+		balance := rung.Amount
+		for i := 1; i <= rung.Rollovers; i++ {
+			interest := balance.Mul(periodRate).Round(places)
+			balance = balance.Add(interest)
+			schedule = append(schedule, model.DepositMaturity{
+				Date:             ladder.StartDate.AddDate(0, rung.TermMonths*i, 0),
+				Position:         rung.Position,
+				BankCode:         rung.BankCode,
+				TermMonths:       rung.TermMonths,
+				Principal:        rung.Amount,
+				InterestSimple:   simple,
+				InterestCompound: interest,
+				BalanceCompound:  balance,
+				Final:            i == rung.Rollovers,
+			})
+		}
+	}
+
+	sort.SliceStable(schedule, func(i, j int) bool { return schedule[i].Date.Before(schedule[j].Date) })
+	return schedule
+}
+
+// depositPeriodRate converts an annual percentage rate into the interest earned over one term.
+func depositPeriodRate(annualRate decimal.Decimal, termMonths int) decimal.Decimal {
+	return annualRate.Mul(decimal.NewFromInt(int64(termMonths))).Div(decimal.NewFromInt(1200))
+}
+
+// currencyPlaces returns the number of decimal places used by a currency, defaulting to 2.
+func currencyPlaces(code string) int32 {
+	info, ok := currency.GetInfo(currency.Currency(code))
+	if !ok {
+		return 2
+	}
+	return int32(info.DecimalPlaces)
+}
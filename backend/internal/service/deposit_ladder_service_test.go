@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+)
+
+// MockDepositLadderRepo implements DepositLadderRepositoryInterface for testing
+type MockDepositLadderRepo struct {
+	mock.Mock
+}
+
+func (m *MockDepositLadderRepo) Create(ctx context.Context, ladder *model.DepositLadder) error {
+	args := m.Called(ctx, ladder)
+	if ladder.ID == uuid.Nil {
+		ladder.ID = uuid.New()
+	}
+	return args.Error(0)
+}
+
+func (m *MockDepositLadderRepo) GetByID(ctx context.Context, id uuid.UUID) (*model.DepositLadder, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DepositLadder), args.Error(1)
+}
+
+func (m *MockDepositLadderRepo) List(ctx context.Context, userID uuid.UUID, goalID *uuid.UUID) ([]model.DepositLadder, error) {
+	args := m.Called(ctx, userID, goalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.DepositLadder), args.Error(1)
+}
+
+func (m *MockDepositLadderRepo) UpdateGoal(ctx context.Context, id, userID uuid.UUID, goalID *uuid.UUID) error {
+	args := m.Called(ctx, id, userID, goalID)
+	return args.Error(0)
+}
+
+func (m *MockDepositLadderRepo) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func sampleDepositRates() []model.InterestRate {
+	rate := func(bank string, term int, r float64) model.InterestRate {
+		return model.InterestRate{BankCode: bank, BankName: bank, ProductType: "deposit", TermMonths: term, Rate: decimal.NewFromFloat(r), Currency: "VND"}
+	}
+	return []model.InterestRate{
+		rate("vcb", 1, 1.7), rate("vcb", 3, 2.0), rate("vcb", 6, 3.0), rate("vcb", 12, 4.7),
+		rate("vpbank", 1, 3.4), rate("vpbank", 3, 3.5), rate("vpbank", 6, 4.3), rate("vpbank", 12, 5.3),
+		rate("mb", 6, 4.5), rate("mb", 24, 6.0),
+	}
+}
+
+func TestDepositLadderService_Plan(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		input       DepositLadderInput
+		wantErr     error
+		checkLadder func(*testing.T, *model.DepositLadder)
+	}{
+		{
+			name:  "four rungs staggered across a year",
+			input: DepositLadderInput{Amount: decimal.NewFromInt(100000000), HorizonMonths: 12, StartDate: &start},
+			checkLadder: func(t *testing.T, ladder *model.DepositLadder) {
+				// Targets 3, 6, 9, 12 months resolve to 3, 6, 6, 12; the two 6-month rungs merge
+				assert.Len(t, ladder.Rungs, 3)
+				assert.Equal(t, 3, ladder.Rungs[0].TermMonths)
+				assert.Equal(t, "vpbank", ladder.Rungs[0].BankCode)
+				assert.Equal(t, 6, ladder.Rungs[1].TermMonths)
+				assert.Equal(t, "mb", ladder.Rungs[1].BankCode)
+				assert.True(t, ladder.Rungs[1].Amount.Equal(decimal.NewFromInt(50000000)))
+				assert.Equal(t, 12, ladder.Rungs[2].TermMonths)
+				assert.Equal(t, "VND", ladder.Currency)
+				assert.True(t, ladder.TotalInterestCompound.GreaterThan(ladder.TotalInterestSimple))
+				assert.NotEmpty(t, ladder.Maturities)
+				assert.Equal(t, start.AddDate(0, 3, 0), ladder.Maturities[0].Date)
+			},
+		},
+		{
+			name: "liquid portion goes to best short term",
+			input: DepositLadderInput{
+				Amount:        decimal.NewFromInt(100000000),
+				LiquidAmount:  decimal.NewFromInt(10000000),
+				HorizonMonths: 12,
+				Rungs:         1,
+				StartDate:     &start,
+			},
+			checkLadder: func(t *testing.T, ladder *model.DepositLadder) {
+				assert.Len(t, ladder.Rungs, 2)
+				assert.Equal(t, 1, ladder.Rungs[0].TermMonths)
+				assert.Equal(t, 12, ladder.Rungs[0].Rollovers)
+				assert.True(t, ladder.Rungs[0].Amount.Equal(decimal.NewFromInt(10000000)))
+				assert.True(t, ladder.Rungs[1].Amount.Equal(decimal.NewFromInt(90000000)))
+			},
+		},
+		{
+			name:  "amounts split without losing residue",
+			input: DepositLadderInput{Amount: decimal.NewFromInt(100000001), HorizonMonths: 12, Rungs: 3, BankCodes: []string{"VCB"}, StartDate: &start},
+			checkLadder: func(t *testing.T, ladder *model.DepositLadder) {
+				total := decimal.Zero
+				for _, rung := range ladder.Rungs {
+					assert.Equal(t, "vcb", rung.BankCode)
+					total = total.Add(rung.Amount)
+				}
+				assert.True(t, total.Equal(decimal.NewFromInt(100000001)))
+			},
+		},
+		{
+			name:    "no rate for currency",
+			input:   DepositLadderInput{Amount: decimal.NewFromInt(1000), Currency: "USD", HorizonMonths: 12},
+			wantErr: ErrDepositRateNotFound,
+		},
+		{
+			name:    "invalid horizon",
+			input:   DepositLadderInput{Amount: decimal.NewFromInt(1000), HorizonMonths: 0},
+			wantErr: ErrInvalidLadderHorizon,
+		},
+		{
+			name:    "liquid amount exceeds total",
+			input:   DepositLadderInput{Amount: decimal.NewFromInt(1000), LiquidAmount: decimal.NewFromInt(2000), HorizonMonths: 12},
+			wantErr: ErrInvalidLiquidAmount,
+		},
+		{
+			name:    "zero amount",
+			input:   DepositLadderInput{Amount: decimal.Zero, HorizonMonths: 12},
+			wantErr: ErrInvalidAmount,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rates := new(MockDepositRateProvider)
+			rates.On("ListRates", mock.Anything, "deposit", (*int)(nil), "").Return(sampleDepositRates(), nil).Maybe()
+
+			svc := NewDepositLadderService(new(MockDepositLadderRepo), new(MockSavingsGoalRepo), rates)
+			ladder, err := svc.Plan(context.Background(), tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, ladder)
+				return
+			}
+			assert.NoError(t, err)
+			tt.checkLadder(t, ladder)
+		})
+	}
+}
+
+func TestLadderRung_SimpleVsCompound(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	rate := model.InterestRate{BankCode: "vpbank", TermMonths: 3, Rate: decimal.NewFromFloat(3.5)}
+
+	rung := ladderRung(rate, decimal.NewFromInt(25000000), 12, start, 0)
+
+	// 25,000,000 * 3.5% * 3/12 = 218,750 per term, four terms
+	assert.Equal(t, 4, rung.Rollovers)
+	assert.True(t, rung.InterestSimple.Equal(decimal.NewFromInt(875000)), "simple: %s", rung.InterestSimple)
+	assert.True(t, rung.InterestCompound.Equal(decimal.NewFromInt(886552)), "compound: %s", rung.InterestCompound)
+	assert.Equal(t, start.AddDate(0, 12, 0), rung.FinalMaturityDate)
+}
+
+func TestDepositLadderService_Save(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	goalID := uuid.New()
+
+	tests := []struct {
+		name    string
+		goal    *model.SavingsGoal
+		wantErr error
+	}{
+		{name: "attached to goal", goal: &model.SavingsGoal{ID: goalID, UserID: userID, Currency: "VND"}},
+		{name: "goal of another user", goal: &model.SavingsGoal{ID: goalID, UserID: uuid.New(), Currency: "VND"}, wantErr: repository.ErrSavingsGoalNotFound},
+		{name: "goal in another currency", goal: &model.SavingsGoal{ID: goalID, UserID: userID, Currency: "USD"}, wantErr: ErrLadderCurrencyMismatch},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := new(MockDepositLadderRepo)
+			goalRepo := new(MockSavingsGoalRepo)
+			rates := new(MockDepositRateProvider)
+			rates.On("ListRates", mock.Anything, "deposit", (*int)(nil), "").Return(sampleDepositRates(), nil)
+			goalRepo.On("GetByID", mock.Anything, goalID).Return(tt.goal, nil)
+			if tt.wantErr == nil {
+				repo.On("Create", mock.Anything, mock.MatchedBy(func(l *model.DepositLadder) bool {
+					return l.UserID == userID && *l.GoalID == goalID
+				})).Return(nil)
+			}
+
+			svc := NewDepositLadderService(repo, goalRepo, rates)
+			ladder, err := svc.Save(context.Background(), userID, SaveDepositLadderInput{
+				DepositLadderInput: DepositLadderInput{Amount: decimal.NewFromInt(50000000), HorizonMonths: 6},
+				GoalID:             &goalID,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, ladder)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, ladder.ID)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDepositLadderService_Get(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ladderID := uuid.New()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	stored := &model.DepositLadder{
+		ID:        ladderID,
+		UserID:    userID,
+		Currency:  "VND",
+		StartDate: start,
+		Rungs: []model.DepositLadderRung{
+			{Position: 1, BankCode: "mb", TermMonths: 6, Rate: decimal.NewFromFloat(4.5), Amount: decimal.NewFromInt(10000000), Rollovers: 2},
+		},
+	}
+
+	t.Run("builds maturity schedule", func(t *testing.T) {
+		t.Parallel()
+
+		repo := new(MockDepositLadderRepo)
+		repo.On("GetByID", mock.Anything, ladderID).Return(stored, nil)
+
+		svc := NewDepositLadderService(repo, new(MockSavingsGoalRepo), new(MockDepositRateProvider))
+		ladder, err := svc.Get(context.Background(), ladderID, userID)
+
+		assert.NoError(t, err)
+		assert.Len(t, ladder.Maturities, 2)
+		assert.False(t, ladder.Maturities[0].Final)
+		assert.True(t, ladder.Maturities[1].Final)
+		assert.True(t, ladder.Maturities[0].InterestSimple.Equal(decimal.NewFromInt(225000)))
+		assert.True(t, ladder.Maturities[1].BalanceCompound.Equal(decimal.NewFromInt(10455063)))
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		t.Parallel()
+
+		repo := new(MockDepositLadderRepo)
+		repo.On("GetByID", mock.Anything, ladderID).Return(stored, nil)
+
+		svc := NewDepositLadderService(repo, new(MockSavingsGoalRepo), new(MockDepositRateProvider))
+		ladder, err := svc.Get(context.Background(), ladderID, uuid.New())
+
+		assert.ErrorIs(t, err, repository.ErrDepositLadderNotFound)
+		assert.Nil(t, ladder)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		t.Parallel()
+
+		repo := new(MockDepositLadderRepo)
+		repo.On("GetByID", mock.Anything, ladderID).Return(nil, errors.New("db error"))
+
+		svc := NewDepositLadderService(repo, new(MockSavingsGoalRepo), new(MockDepositRateProvider))
+		_, err := svc.Get(context.Background(), ladderID, userID)
+
+		assert.Error(t, err)
+	})
+}
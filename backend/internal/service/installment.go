@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+)
+
+// Service-level errors for installment plans.
+var (
+	ErrInvalidInstallmentPlan = errors.New("installment plans need termMonths 1-60 instalments, no interest rate and a fee of zero or more; merchant, installmentFee and cardId are only for installment plans")
+	ErrInvalidInstallmentCard = errors.New("installment plans can only be charged to one of your credit cards in the same currency")
+	ErrInvalidCreditLimit     = errors.New("creditLimit is for credit cards and cannot be negative")
+	ErrNotInstallmentPlan     = errors.New("debt is not an installment plan")
+)
+
+// maxInstallmentCount is the longest plan Vietnamese card issuers and BNPL apps offer.
+const maxInstallmentCount = 60
+
+// applyInstallmentPlan copies the installment plan fields onto the debt and validates
+// them. Plans are interest-free and repaid in even instalments, so they are scheduled
+// like a flat-rate loan at 0% with the fee added to the amount repaid.
+func applyInstallmentPlan(debt *model.Debt, merchant *string, fee *decimal.Decimal, cardID *uuid.UUID) error {
+	debt.Merchant = merchant
+	debt.InstallmentFee = fee
+	debt.CardID = cardID
+
+	if debt.Type != model.DebtTypeInstallment {
+		if merchant != nil || fee != nil || cardID != nil {
+			return ErrInvalidInstallmentPlan
+		}
+		return nil
+	}
+
+	if debt.TermMonths == nil || *debt.TermMonths < 1 || *debt.TermMonths > maxInstallmentCount ||
+		!debt.InterestRate.IsZero() || (fee != nil && fee.IsNegative()) || debt.HasRateSchedule() {
+		return ErrInvalidInstallmentPlan
+	}
+	debt.InterestMethod = model.InterestMethodFlat
+	if debt.DueDay == 0 {
+		debt.DueDay = debt.StartDate.Day()
+	}
+	if debt.MinimumPayment.IsZero() {
+		debt.MinimumPayment = installmentSchedule(debt)[0].Payment
+	}
+	return nil
+}
+
+// applyCreditLimit sets the credit limit of a credit card.
+func applyCreditLimit(debt *model.Debt, limit *decimal.Decimal) error {
+	debt.CreditLimit = limit
+	if limit != nil && (debt.Type != model.DebtTypeCreditCard || limit.IsNegative()) {
+		return ErrInvalidCreditLimit
+	}
+	return nil
+}
+
+// checkInstallmentCard verifies that the card an installment plan is charged to is one
+// of the owner's credit cards in the plan's currency.
+func (s *DebtService) checkInstallmentCard(ctx context.Context, debt *model.Debt) error {
+	if debt.CardID == nil {
+		return nil
+	}
+	card, err := s.repo.GetByID(ctx, *debt.CardID)
+	if errors.Is(err, repository.ErrDebtNotFound) {
+		return ErrInvalidInstallmentCard
+	}
+	if err != nil {
+		return fmt.Errorf("fetching card %s: %w", *debt.CardID, err)
+	}
+	if card.UserID != debt.UserID || card.Type != model.DebtTypeCreditCard || card.Currency != debt.Currency {
+		return ErrInvalidInstallmentCard
+	}
+	return nil
+}
+
+// installmentTotal is the amount an installment plan repays: the purchase plus the fee.
+func installmentTotal(debt *model.Debt) decimal.Decimal {
+	if debt.InstallmentFee == nil {
+		return debt.OriginalAmount
+	}
+	return debt.OriginalAmount.Add(*debt.InstallmentFee)
+}
+
+// installmentSchedule splits the plan total into even instalments in the currency's
+// minor unit, with the rounding residue on the last one.
+func installmentSchedule(debt *model.Debt) []model.AmortizationRow {
+	return loanSchedule(installmentTotal(debt), decimal.Zero, *debt.TermMonths, model.InterestMethodFlat, currencyPlaces(debt.Currency))
+}
+
+// installmentDueDate returns the due date of instalment number n, n months after the
+// purchase on the plan's due day, clamped to the end of shorter months.
+func installmentDueDate(debt *model.Debt, n int) time.Time {
+	start := dateOf(debt.StartDate)
+	return statementDate(start.Year(), start.Month()+time.Month(n), debt.DueDay)
+}
+
+// GetInstallmentSchedule returns the instalments of an installment plan. What has been
+// repaid so far is applied to the earliest instalments, so prepaying marks later ones paid.
+func (s *DebtService) GetInstallmentSchedule(ctx context.Context, id, userID uuid.UUID) (*model.InstallmentSchedule, error) {
+	debt, err := s.getOwnedDebt(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !debt.IsInstallmentPlan() {
+		return nil, ErrNotInstallmentPlan
+	}
+	return buildInstallmentSchedule(debt, time.Now()), nil
+}
+
+func buildInstallmentSchedule(debt *model.Debt, now time.Time) *model.InstallmentSchedule {
+	total := installmentTotal(debt)
+	rows := installmentSchedule(debt)
+	today := dateOf(now)
+
+	schedule := &model.InstallmentSchedule{
+		DebtID:           debt.ID,
+		Currency:         debt.Currency,
+		PurchaseAmount:   debt.OriginalAmount,
+		Fee:              total.Sub(debt.OriginalAmount),
+		TotalRepayable:   total,
+		InstallmentCount: len(rows),
+		RemainingAmount:  decimal.Max(debt.CurrentBalance, decimal.Zero),
+		CardID:           debt.CardID,
+		Installments:     make([]model.Installment, 0, len(rows)),
+	}
+	if debt.Merchant != nil {
+		schedule.Merchant = *debt.Merchant
+	}
+
+	payments := make([]decimal.Decimal, 0, len(rows))
+	repaid := decimal.Max(total.Sub(debt.CurrentBalance), decimal.Zero)
+	dueFound := false
+	for _, row := range rows {
+		payments = append(payments, row.Payment)
+
+		paid := decimal.Min(row.Payment, repaid)
+		repaid = repaid.Sub(paid)
+		installment := model.Installment{
+			Number:     row.Month,
+			DueDate:    installmentDueDate(debt, row.Month),
+			Amount:     row.Payment,
+			AmountPaid: paid,
+		}
+		switch {
+		case paid.Equal(row.Payment):
+			installment.Status = model.InstallmentPaid
+			schedule.PaidCount++
+		case installment.DueDate.Before(today):
+			installment.Status = model.InstallmentOverdue
+		case !dueFound:
+			installment.Status = model.InstallmentDue
+			dueFound = true
+		default:
+			installment.Status = model.InstallmentUpcoming
+		}
+		if installment.Status != model.InstallmentPaid && schedule.NextDueDate == nil {
+			due := installment.DueDate
+			schedule.NextDueDate = &due
+		}
+		schedule.Installments = append(schedule.Installments, installment)
+	}
+	schedule.RemainingCount = schedule.InstallmentCount - schedule.PaidCount
+	schedule.EffectiveAPR = effectiveAPR(debt.OriginalAmount, payments)
+
+	return schedule
+}
+
+// UpcomingInstallments returns the unpaid instalments of the user's installment plans,
+// overdue ones included, soonest first, as bills for the upcoming bills reminder.
+func (s *DebtService) UpcomingInstallments(ctx context.Context, userID uuid.UUID, limit int) ([]model.UpcomingBill, error) {
+	debts, err := s.repo.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing debts for user %s: %w", userID, err)
+	}
+	return upcomingInstallments(debts, time.Now(), limit), nil
+}
+
+func upcomingInstallments(debts []model.Debt, now time.Time, limit int) []model.UpcomingBill {
+	var bills []model.UpcomingBill
+	for i := range debts {
+		debt := &debts[i]
+		if !debt.IsInstallmentPlan() || !debt.CurrentBalance.IsPositive() {
+			continue
+		}
+		name := debt.Name
+		if debt.Merchant != nil && *debt.Merchant != "" {
+			name = *debt.Merchant
+		}
+		schedule := buildInstallmentSchedule(debt, now)
+		for _, installment := range schedule.Installments {
+			if installment.Status == model.InstallmentPaid {
+				continue
+			}
+			bills = append(bills, model.UpcomingBill{
+				ID:          debt.ID,
+				Description: fmt.Sprintf("%s instalment %d/%d", name, installment.Number, schedule.InstallmentCount),
+				Amount:      installment.Amount.Sub(installment.AmountPaid),
+				Currency:    debt.Currency,
+				Category:    model.CategoryDebtPayments,
+				DueDate:     installment.DueDate,
+				Type:        model.TransactionTypeExpense,
+			})
+		}
+	}
+
+	sort.SliceStable(bills, func(i, j int) bool { return bills[i].DueDate.Before(bills[j].DueDate) })
+	if limit > 0 && len(bills) > limit {
+		bills = bills[:limit]
+	}
+	return bills
+}
+
+// setAvailableCredit sets the available credit of each credit card with a limit in
+// debts: the limit less the card balance and the balances of installment plans charged
+// to it. debts must hold all of the owner's debts.
+func setAvailableCredit(debts []model.Debt) {
+	for i := range debts {
+		card := &debts[i]
+		if card.Type != model.DebtTypeCreditCard || card.CreditLimit == nil {
+			continue
+		}
+		available := card.CreditLimit.Sub(card.CurrentBalance)
+		for _, plan := range debts {
+			if plan.IsInstallmentPlan() && plan.CardID != nil && *plan.CardID == card.ID && plan.CurrentBalance.IsPositive() {
+				available = available.Sub(plan.CurrentBalance)
+			}
+		}
+		available = decimal.Max(available, decimal.Zero)
+		card.AvailableCredit = &available
+	}
+}
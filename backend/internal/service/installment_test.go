@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+)
+
+func installmentPlan(userID uuid.UUID, cardID *uuid.UUID) *model.Debt {
+	fee := decimal.NewFromInt(519800)
+	total := decimal.NewFromInt(26509800)
+	return &model.Debt{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Name:           "iPhone",
+		Type:           model.DebtTypeInstallment,
+		OriginalAmount: decimal.NewFromInt(25990000),
+		CurrentBalance: total,
+		MinimumPayment: decimal.NewFromInt(2209150),
+		InterestMethod: model.InterestMethodFlat,
+		Currency:       "VND",
+		DueDay:         15,
+		StartDate:      day(2026, time.July, 15),
+		TermMonths:     intPtr(12),
+		Merchant:       strPtr("Thế Giới Di Động"),
+		InstallmentFee: &fee,
+		CardID:         cardID,
+	}
+}
+
+func TestDebtService_Create_InstallmentPlan(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	card := creditCard(userID)
+	card.Currency = "VND"
+
+	mockRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Debt")).Return(nil)
+
+	debt, err := svc.Create(context.Background(), userID, CreateDebtInput{
+		Name:           "Laptop",
+		Type:           model.DebtTypeInstallment,
+		OriginalAmount: decimal.NewFromInt(25990000),
+		Currency:       "VND",
+		StartDate:      day(2026, time.January, 31),
+		TermMonths:     intPtr(12),
+		Merchant:       strPtr("FPT Shop"),
+		CardID:         &card.ID,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, debt.CurrentBalance.Equal(decimal.NewFromInt(25990000)))
+	assert.True(t, debt.MinimumPayment.Equal(decimal.NewFromInt(2165833)))
+	assert.Equal(t, model.InterestMethodFlat, debt.InterestMethod)
+	assert.Equal(t, 31, debt.DueDay)
+	assert.Equal(t, &card.ID, debt.CardID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDebtService_Create_InstallmentPlanWithFee(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	fee := decimal.NewFromInt(519800)
+
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Debt")).Return(nil)
+
+	debt, err := svc.Create(context.Background(), uuid.New(), CreateDebtInput{
+		Name:           "iPhone",
+		Type:           model.DebtTypeInstallment,
+		OriginalAmount: decimal.NewFromInt(25990000),
+		Currency:       "VND",
+		DueDay:         5,
+		StartDate:      day(2026, time.July, 15),
+		TermMonths:     intPtr(12),
+		InstallmentFee: &fee,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, debt.CurrentBalance.Equal(decimal.NewFromInt(26509800)))
+	assert.True(t, debt.MinimumPayment.Equal(decimal.NewFromInt(2209150)))
+	assert.Equal(t, 5, debt.DueDay)
+}
+
+func TestDebtService_Create_InstallmentPlanErrors(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	negative := decimal.NewFromInt(-1)
+	limit := decimal.NewFromInt(50000000)
+
+	otherUsersCard := creditCard(uuid.New())
+	otherUsersCard.Currency = "VND"
+	usdCard := creditCard(userID)
+	loan := personalLoan(userID, model.InterestMethodDecliningBalance)
+
+	plan := func() CreateDebtInput {
+		return CreateDebtInput{
+			Name:           "Tủ lạnh",
+			Type:           model.DebtTypeInstallment,
+			OriginalAmount: decimal.NewFromInt(12000000),
+			Currency:       "VND",
+			StartDate:      day(2026, time.October, 1),
+			TermMonths:     intPtr(6),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		input   func() CreateDebtInput
+		wantErr error
+	}{
+		{
+			name:    "no instalments",
+			input:   func() CreateDebtInput { in := plan(); in.TermMonths = nil; return in },
+			wantErr: ErrInvalidInstallmentPlan,
+		},
+		{
+			name:    "too many instalments",
+			input:   func() CreateDebtInput { in := plan(); in.TermMonths = intPtr(61); return in },
+			wantErr: ErrInvalidInstallmentPlan,
+		},
+		{
+			name:    "interest rate",
+			input:   func() CreateDebtInput { in := plan(); in.InterestRate = decimal.NewFromInt(18); return in },
+			wantErr: ErrInvalidInstallmentPlan,
+		},
+		{
+			name:    "negative fee",
+			input:   func() CreateDebtInput { in := plan(); in.InstallmentFee = &negative; return in },
+			wantErr: ErrInvalidInstallmentPlan,
+		},
+		{
+			name: "merchant on a loan",
+			input: func() CreateDebtInput {
+				in := plan()
+				in.Type = model.DebtTypePersonalLoan
+				in.Merchant = strPtr("FPT Shop")
+				return in
+			},
+			wantErr: ErrInvalidInstallmentPlan,
+		},
+		{
+			name:    "other user's card",
+			input:   func() CreateDebtInput { in := plan(); in.CardID = &otherUsersCard.ID; return in },
+			wantErr: ErrInvalidInstallmentCard,
+		},
+		{
+			name:    "card in another currency",
+			input:   func() CreateDebtInput { in := plan(); in.CardID = &usdCard.ID; return in },
+			wantErr: ErrInvalidInstallmentCard,
+		},
+		{
+			name:    "not a card",
+			input:   func() CreateDebtInput { in := plan(); in.CardID = &loan.ID; return in },
+			wantErr: ErrInvalidInstallmentCard,
+		},
+		{
+			name:    "missing card",
+			input:   func() CreateDebtInput { in := plan(); id := uuid.New(); in.CardID = &id; return in },
+			wantErr: ErrInvalidInstallmentCard,
+		},
+		{
+			name:    "credit limit on a plan",
+			input:   func() CreateDebtInput { in := plan(); in.CreditLimit = &limit; return in },
+			wantErr: ErrInvalidCreditLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockDebtRepo)
+			svc := NewDebtService(mockRepo)
+
+			mockRepo.On("GetByID", mock.Anything, otherUsersCard.ID).Return(otherUsersCard, nil).Maybe()
+			mockRepo.On("GetByID", mock.Anything, usdCard.ID).Return(usdCard, nil).Maybe()
+			mockRepo.On("GetByID", mock.Anything, loan.ID).Return(loan, nil).Maybe()
+			mockRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, repository.ErrDebtNotFound).Maybe()
+
+			_, err := svc.Create(context.Background(), userID, tt.input())
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestBuildInstallmentSchedule(t *testing.T) {
+	t.Parallel()
+
+	debt := installmentPlan(uuid.New(), nil)
+	// One instalment and part of the second repaid.
+	debt.CurrentBalance = decimal.NewFromInt(26509800 - 2209150 - 1000000)
+
+	schedule := buildInstallmentSchedule(debt, time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, "Thế Giới Di Động", schedule.Merchant)
+	assert.True(t, schedule.TotalRepayable.Equal(decimal.NewFromInt(26509800)))
+	assert.Equal(t, 12, schedule.InstallmentCount)
+	assert.Equal(t, 1, schedule.PaidCount)
+	assert.Equal(t, 11, schedule.RemainingCount)
+	assert.True(t, schedule.EffectiveAPR.IsPositive())
+	require.NotNil(t, schedule.NextDueDate)
+	assert.Equal(t, day(2026, time.September, 15), *schedule.NextDueDate)
+
+	want := []struct {
+		due    time.Time
+		paid   int64
+		status model.InstallmentStatus
+	}{
+		{due: day(2026, time.August, 15), paid: 2209150, status: model.InstallmentPaid},
+		{due: day(2026, time.September, 15), paid: 1000000, status: model.InstallmentOverdue},
+		{due: day(2026, time.October, 15), paid: 0, status: model.InstallmentOverdue},
+		{due: day(2026, time.November, 15), paid: 0, status: model.InstallmentDue},
+		{due: day(2026, time.December, 15), paid: 0, status: model.InstallmentUpcoming},
+	}
+	for i, w := range want {
+		installment := schedule.Installments[i]
+		assert.Equal(t, i+1, installment.Number)
+		assert.Equal(t, w.due, installment.DueDate, "instalment %d", i+1)
+		assert.True(t, installment.AmountPaid.Equal(decimal.NewFromInt(w.paid)), "instalment %d", i+1)
+		assert.Equal(t, w.status, installment.Status, "instalment %d", i+1)
+	}
+}
+
+func TestBuildInstallmentSchedule_Rounding(t *testing.T) {
+	t.Parallel()
+
+	debt := installmentPlan(uuid.New(), nil)
+	debt.InstallmentFee = nil
+	debt.CurrentBalance = debt.OriginalAmount
+
+	schedule := buildInstallmentSchedule(debt, day(2026, time.July, 15))
+
+	total := decimal.Zero
+	for _, installment := range schedule.Installments[:11] {
+		assert.True(t, installment.Amount.Equal(decimal.NewFromInt(2165833)))
+		total = total.Add(installment.Amount)
+	}
+	last := schedule.Installments[11]
+	assert.True(t, last.Amount.Equal(decimal.NewFromInt(2165837)))
+	assert.True(t, total.Add(last.Amount).Equal(debt.OriginalAmount))
+	assert.True(t, schedule.EffectiveAPR.IsZero())
+}
+
+func TestInstallmentDueDate_MonthEnd(t *testing.T) {
+	t.Parallel()
+
+	debt := installmentPlan(uuid.New(), nil)
+	debt.StartDate = day(2027, time.January, 31)
+	debt.DueDay = 31
+
+	assert.Equal(t, day(2027, time.February, 28), installmentDueDate(debt, 1))
+	assert.Equal(t, day(2027, time.March, 31), installmentDueDate(debt, 2))
+	assert.Equal(t, day(2028, time.January, 31), installmentDueDate(debt, 12))
+}
+
+func TestUpcomingInstallments(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	now := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+
+	phone := installmentPlan(userID, nil)
+	phone.StartDate = day(2026, time.September, 25)
+	phone.DueDay = 25
+	phone.CurrentBalance = phone.CurrentBalance.Sub(phone.MinimumPayment)
+
+	fridge := installmentPlan(userID, nil)
+	fridge.Merchant = nil
+	fridge.Name = "Tủ lạnh"
+	fridge.StartDate = day(2026, time.September, 20)
+	fridge.DueDay = 20
+
+	repaid := installmentPlan(userID, nil)
+	repaid.CurrentBalance = decimal.Zero
+
+	debts := []model.Debt{*phone, *fridge, *repaid, *personalLoan(userID, model.InterestMethodFlat)}
+	bills := upcomingInstallments(debts, now, 3)
+
+	require.Len(t, bills, 3)
+	assert.Equal(t, "Tủ lạnh instalment 1/12", bills[0].Description)
+	assert.Equal(t, day(2026, time.October, 20), bills[0].DueDate)
+	assert.Equal(t, fridge.ID, bills[0].ID)
+	assert.Equal(t, "Tủ lạnh instalment 2/12", bills[1].Description)
+	assert.Equal(t, day(2026, time.November, 20), bills[1].DueDate)
+	assert.Equal(t, "Thế Giới Di Động instalment 2/12", bills[2].Description)
+	assert.Equal(t, day(2026, time.November, 25), bills[2].DueDate)
+	for _, bill := range bills {
+		assert.True(t, bill.Amount.Equal(decimal.NewFromInt(2209150)))
+		assert.Equal(t, "VND", bill.Currency)
+		assert.Equal(t, model.CategoryDebtPayments, bill.Category)
+		assert.Equal(t, model.TransactionTypeExpense, bill.Type)
+	}
+}
+
+func TestDebtService_UpcomingInstallments(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+
+	mockRepo.On("List", mock.Anything, userID).Return([]model.Debt{*installmentPlan(userID, nil)}, nil)
+
+	bills, err := svc.UpcomingInstallments(context.Background(), userID, 50)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, bills)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSetAvailableCredit(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	limit := decimal.NewFromInt(50000000)
+	card := creditCard(userID)
+	card.Currency = "VND"
+	card.CurrentBalance = decimal.NewFromInt(4000000)
+	card.CreditLimit = &limit
+
+	noLimit := creditCard(userID)
+
+	charged := installmentPlan(userID, &card.ID)
+	charged.CurrentBalance = decimal.NewFromInt(20000000)
+	repaid := installmentPlan(userID, &card.ID)
+	repaid.CurrentBalance = decimal.Zero
+	elsewhere := installmentPlan(userID, &noLimit.ID)
+
+	debts := []model.Debt{*card, *noLimit, *charged, *repaid, *elsewhere}
+	setAvailableCredit(debts)
+
+	require.NotNil(t, debts[0].AvailableCredit)
+	assert.True(t, debts[0].AvailableCredit.Equal(decimal.NewFromInt(26000000)))
+	assert.Nil(t, debts[1].AvailableCredit)
+	assert.Nil(t, debts[2].AvailableCredit)
+
+	overLimit := decimal.NewFromInt(10000000)
+	debts[0].CreditLimit = &overLimit
+	setAvailableCredit(debts)
+	assert.True(t, debts[0].AvailableCredit.IsZero())
+}
+
+func TestDebtService_Get_AvailableCredit(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockDebtRepo)
+	svc := NewDebtService(mockRepo)
+	userID := uuid.New()
+	limit := decimal.NewFromInt(30000000)
+	card := creditCard(userID)
+	card.CurrentBalance = decimal.NewFromInt(1000000)
+	card.CreditLimit = &limit
+	plan := installmentPlan(userID, &card.ID)
+
+	mockRepo.On("GetByID", mock.Anything, card.ID).Return(card, nil)
+	mockRepo.On("List", mock.Anything, userID).Return([]model.Debt{*card, *plan}, nil)
+
+	debt, err := svc.Get(context.Background(), card.ID)
+
+	require.NoError(t, err)
+	require.NotNil(t, debt.AvailableCredit)
+	assert.True(t, debt.AvailableCredit.Equal(decimal.NewFromInt(30000000-1000000-26509800)))
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/wealthpath/backend/internal/model"
+)
+
+// Service-level errors for loan interest methods.
+var (
+	ErrInvalidInterestMethod = errors.New("interestMethod must be declining_balance, flat or equal_principal")
+	ErrEqualPrincipalTerm    = errors.New("equal_principal debts require termMonths")
+	ErrInvalidLoanTerm       = errors.New("principal and termMonths must be greater than zero")
+)
+
+func isInterestMethod(method model.InterestMethod) bool {
+	switch method {
+	case model.InterestMethodDecliningBalance, model.InterestMethodFlat, model.InterestMethodEqualPrincipal:
+		return true
+	}
+	return false
+}
+
+// applyInterestMethod sets the debt's interest method, defaulting to declining balance.
+// Equal principal instalments need a term to size the principal portion.
+func applyInterestMethod(debt *model.Debt, method model.InterestMethod) error {
+	if method == "" {
+		method = model.InterestMethodDecliningBalance
+	}
+	if !isInterestMethod(method) {
+		return ErrInvalidInterestMethod
+	}
+	if method == model.InterestMethodEqualPrincipal && debt.TermMonths == nil {
+		return ErrEqualPrincipalTerm
+	}
+	debt.InterestMethod = method
+	return nil
+}
+
+// loanSchedule builds the full instalment schedule of a new loan.
+// Amounts are rounded to the given number of decimal places, the currency's minor
+// unit. Even principal and flat interest portions are rounded down and the last
+// instalment absorbs the rounding residue, so the schedule repays the principal
+// exactly and flat-rate interest totals the rate on the principal over the term.
+func loanSchedule(principal, annualRate decimal.Decimal, months int, method model.InterestMethod, places int32) []model.AmortizationRow {
+	n := decimal.NewFromInt(int64(months))
+	monthlyRate := annualRate.Div(decimal.NewFromInt(100)).Div(decimal.NewFromInt(12))
+	evenPrincipal := principal.Div(n).RoundFloor(places)
+	flatInterest := principal.Mul(monthlyRate).RoundFloor(places)
+	flatResidue := principal.Mul(monthlyRate).Mul(n).Round(places).Sub(flatInterest.Mul(n))
+	level := annuityPayment(principal, annualRate, months, places)
+
+	rows := make([]model.AmortizationRow, 0, months)
+	balance := principal
+	for month := 1; month <= months; month++ {
+		var interest, principalPart decimal.Decimal
+		switch method {
+		case model.InterestMethodFlat:
+			interest = flatInterest
+			if month == months {
+				interest = interest.Add(flatResidue)
+			}
+			principalPart = evenPrincipal
+		case model.InterestMethodEqualPrincipal:
+			interest = balance.Mul(monthlyRate).Round(places)
+			principalPart = evenPrincipal
+		default:
+			interest = balance.Mul(monthlyRate).Round(places)
+			principalPart = level.Sub(interest)
+		}
+
+		if month == months || principalPart.GreaterThan(balance) {
+			principalPart = balance
+		}
+		balance = balance.Sub(principalPart)
+
+		rows = append(rows, model.AmortizationRow{
+			Month:            month,
+			Rate:             annualRate,
+			Payment:          principalPart.Add(interest),
+			Principal:        principalPart,
+			Interest:         interest,
+			RemainingBalance: balance,
+		})
+
+		if !balance.IsPositive() {
+			break
+		}
+	}
+
+	return rows
+}
+
+// aprBisections narrows the monthly rate to within 2^-40 of the internal rate of
+// return, well below the 0.01 percentage points effectiveAPR is rounded to.
+const aprBisections = 40
+
+// effectiveAPR returns the annual rate, as a percentage, at which the payments repay
+// principal on a declining balance: the loan's internal rate of return times twelve.
+// This converts a flat-rate quote into the rate a bank would quote for the same loan.
+func effectiveAPR(principal decimal.Decimal, payments []decimal.Decimal) decimal.Decimal {
+	if !principal.IsPositive() || len(payments) == 0 {
+		return decimal.Zero
+	}
+
+	total := decimal.Zero
+	for _, payment := range payments {
+		total = total.Add(payment)
+	}
+	if total.LessThanOrEqual(principal) {
+		return decimal.Zero
+	}
+
+	one := decimal.NewFromInt(1)
+	presentValue := func(r decimal.Decimal) decimal.Decimal {
+		discount := one.Div(one.Add(r))
+		factor := one
+		pv := decimal.Zero
+		for _, payment := range payments {
+			factor = factor.Mul(discount).Round(16)
+			pv = pv.Add(payment.Mul(factor))
+		}
+		return pv
+	}
+
+	// Present value falls as the rate rises, so bisect until it matches the principal.
+	two := decimal.NewFromInt(2)
+	lo, hi := decimal.Zero, one
+	for i := 0; i < aprBisections; i++ {
+		mid := lo.Add(hi).Div(two)
+		if presentValue(mid).GreaterThan(principal) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return lo.Add(hi).Div(two).Mul(decimal.NewFromInt(1200)).Round(2)
+}
+
+// flatRateEquivalent returns the flat annual rate, as a percentage, that charges the
+// same total interest on the original principal over the term.
+func flatRateEquivalent(principal, totalInterest decimal.Decimal, months int) decimal.Decimal {
+	if !principal.IsPositive() || months <= 0 {
+		return decimal.Zero
+	}
+	years := decimal.NewFromInt(int64(months)).Div(decimal.NewFromInt(12))
+	return totalInterest.Div(principal).Div(years).Mul(decimal.NewFromInt(100)).Round(2)
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+func TestDebtService_CalculateInterest_Methods(t *testing.T) {
+	t.Parallel()
+
+	principal := decimal.NewFromInt(12000000)
+
+	tests := []struct {
+		name          string
+		method        model.InterestMethod
+		wantFirst     decimal.Decimal
+		wantLast      decimal.Decimal
+		wantInterest  decimal.Decimal
+		wantAPRMin    float64
+		wantAPRMax    float64
+		wantFlatRate  decimal.Decimal
+		checkFlatRate bool
+	}{
+		{
+			// Lãi phẳng: 1% a month on the original 12 million for the whole year.
+			name:          "flat",
+			method:        model.InterestMethodFlat,
+			wantFirst:     decimal.NewFromInt(1120000),
+			wantLast:      decimal.NewFromInt(1120000),
+			wantInterest:  decimal.NewFromInt(1440000),
+			wantAPRMin:    21.4,
+			wantAPRMax:    21.5,
+			wantFlatRate:  decimal.NewFromInt(12),
+			checkFlatRate: true,
+		},
+		{
+			// Dư nợ giảm dần: 1 million principal plus interest on what is left.
+			name:          "equal principal",
+			method:        model.InterestMethodEqualPrincipal,
+			wantFirst:     decimal.NewFromInt(1120000),
+			wantLast:      decimal.NewFromInt(1010000),
+			wantInterest:  decimal.NewFromInt(780000),
+			wantAPRMin:    11.99,
+			wantAPRMax:    12.01,
+			wantFlatRate:  decimal.NewFromFloat(6.5),
+			checkFlatRate: true,
+		},
+		{
+			name:       "declining balance",
+			method:     model.InterestMethodDecliningBalance,
+			wantFirst:  decimal.NewFromInt(1066186),
+			wantAPRMin: 11.99,
+			wantAPRMax: 12.01,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := NewDebtService(nil)
+			result, err := svc.CalculateInterest(InterestCalculatorInput{
+				Principal:    principal,
+				InterestRate: decimal.NewFromInt(12),
+				TermMonths:   12,
+				Method:       tt.method,
+				Currency:     "VND",
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.method, result.Method)
+			assert.True(t, result.MonthlyPayment.Equal(tt.wantFirst), "first payment %s", result.MonthlyPayment)
+			if !tt.wantLast.IsZero() {
+				assert.True(t, result.LastPayment.Equal(tt.wantLast), "last payment %s", result.LastPayment)
+			}
+			if !tt.wantInterest.IsZero() {
+				assert.True(t, result.TotalInterest.Equal(tt.wantInterest), "total interest %s", result.TotalInterest)
+			}
+			apr := result.EffectiveAPR.InexactFloat64()
+			assert.GreaterOrEqual(t, apr, tt.wantAPRMin)
+			assert.LessOrEqual(t, apr, tt.wantAPRMax)
+			if tt.checkFlatRate {
+				assert.True(t, result.FlatRate.Equal(tt.wantFlatRate), "flat rate %s", result.FlatRate)
+			}
+
+			// Every schedule repays the principal exactly.
+			require.Len(t, result.Schedule, 12)
+			repaid := decimal.Zero
+			for _, row := range result.Schedule {
+				repaid = repaid.Add(row.Principal)
+			}
+			assert.True(t, repaid.Equal(principal), "repaid %s", repaid)
+			assert.True(t, result.Schedule[11].RemainingBalance.IsZero())
+		})
+	}
+}
+
+func TestDebtService_CalculateInterest_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   InterestCalculatorInput
+		wantErr error
+	}{
+		{name: "zero term", input: InterestCalculatorInput{Principal: decimal.NewFromInt(1000)}, wantErr: ErrInvalidLoanTerm},
+		{name: "zero principal", input: InterestCalculatorInput{TermMonths: 12}, wantErr: ErrInvalidLoanTerm},
+		{name: "unknown method", input: InterestCalculatorInput{Principal: decimal.NewFromInt(1000), TermMonths: 12, Method: "balloon"}, wantErr: ErrInvalidInterestMethod},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := NewDebtService(nil).CalculateInterest(tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestCalculatePayoffPlan_InterestMethods(t *testing.T) {
+	t.Parallel()
+
+	term := 12
+	flat := &model.Debt{
+		ID:             uuid.New(),
+		OriginalAmount: decimal.NewFromInt(12000),
+		CurrentBalance: decimal.NewFromInt(6000),
+		InterestRate:   decimal.NewFromInt(12),
+		InterestMethod: model.InterestMethodFlat,
+	}
+	declining := *flat
+	declining.InterestMethod = model.InterestMethodDecliningBalance
+	equalPrincipal := *flat
+	equalPrincipal.InterestMethod = model.InterestMethodEqualPrincipal
+	equalPrincipal.TermMonths = &term
+
+	flatPlan := calculatePayoffPlan(flat, decimal.NewFromInt(1120), nil, nil)
+	decliningPlan := calculatePayoffPlan(&declining, decimal.NewFromInt(1120), nil, nil)
+	equalPlan := calculatePayoffPlan(&equalPrincipal, decimal.Zero, nil, nil)
+
+	// Flat interest stays at 1% of the original amount as the balance falls.
+	assert.Equal(t, model.InterestMethodFlat, flatPlan.InterestMethod)
+	assert.True(t, flatPlan.AmortizationPlan[0].Interest.Equal(decimal.NewFromInt(120)))
+	assert.True(t, flatPlan.AmortizationPlan[3].Interest.Equal(decimal.NewFromInt(120)))
+	assert.True(t, flatPlan.TotalInterest.GreaterThan(decliningPlan.TotalInterest))
+	assert.True(t, flatPlan.EffectiveAPR.GreaterThan(decimal.NewFromInt(12)))
+
+	// Equal principal repays 1000 a month plus interest on the balance.
+	assert.Equal(t, 6, equalPlan.MonthsToPayoff)
+	assert.True(t, equalPlan.AmortizationPlan[0].Payment.Equal(decimal.NewFromInt(1060)))
+	assert.True(t, equalPlan.AmortizationPlan[5].Payment.Equal(decimal.NewFromInt(1010)))
+}
+
+// loanCase is a random loan for property-based tests of the amortization math.
+type loanCase struct {
+	Currency  string
+	Principal decimal.Decimal
+	Rate      decimal.Decimal
+	Months    int
+	Method    model.InterestMethod
+}
+
+var (
+	propertyCurrencies = []string{"VND", "JPY", "USD"}
+	propertyMethods    = []model.InterestMethod{
+		model.InterestMethodDecliningBalance,
+		model.InterestMethodFlat,
+		model.InterestMethodEqualPrincipal,
+	}
+)
+
+// newLoanCase maps arbitrary numbers onto a loan of up to about 43 million minor units
+// at 0-36% APR over 1-360 months.
+func newLoanCase(units uint32, rateBasisPoints uint16, months uint16, method, curr uint8) loanCase {
+	code := propertyCurrencies[int(curr)%len(propertyCurrencies)]
+	return loanCase{
+		Currency:  code,
+		Principal: decimal.New(int64(units%43000000)+1, -currencyPlaces(code)),
+		Rate:      decimal.New(int64(rateBasisPoints%3601), -2),
+		Months:    int(months%360) + 1,
+		Method:    propertyMethods[int(method)%len(propertyMethods)],
+	}
+}
+
+func propertyConfig() *quick.Config {
+	return &quick.Config{MaxCount: 300, Rand: rand.New(rand.NewSource(38))}
+}
+
+// inMinorUnits reports whether an amount is non-negative and has no more decimal places
+// than the currency allows.
+func inMinorUnits(amount decimal.Decimal, places int32) bool {
+	return !amount.IsNegative() && amount.Equal(amount.Round(places))
+}
+
+func TestLoanSchedule_Properties(t *testing.T) {
+	t.Parallel()
+
+	property := func(units uint32, rateBasisPoints uint16, months uint16, method, curr uint8) bool {
+		c := newLoanCase(units, rateBasisPoints, months, method, curr)
+		places := currencyPlaces(c.Currency)
+		rows := loanSchedule(c.Principal, c.Rate, c.Months, c.Method, places)
+
+		principal, interest, payment := decimal.Zero, decimal.Zero, decimal.Zero
+		for _, row := range rows {
+			if !inMinorUnits(row.Payment, places) || !inMinorUnits(row.Principal, places) ||
+				!inMinorUnits(row.Interest, places) || !inMinorUnits(row.RemainingBalance, places) {
+				t.Logf("%+v: month %d is not in minor units: %+v", c, row.Month, row)
+				return false
+			}
+			principal = principal.Add(row.Principal)
+			interest = interest.Add(row.Interest)
+			payment = payment.Add(row.Payment)
+		}
+
+		last := rows[len(rows)-1]
+		switch {
+		case !principal.Equal(c.Principal) || !last.RemainingBalance.IsZero():
+			t.Logf("%+v: repaid %s, %s left", c, principal, last.RemainingBalance)
+			return false
+		case !payment.Equal(c.Principal.Add(interest)):
+			t.Logf("%+v: paid %s for %s interest", c, payment, interest)
+			return false
+		case c.Method != model.InterestMethodDecliningBalance && len(rows) != c.Months:
+			t.Logf("%+v: %d instalments", c, len(rows))
+			return false
+		}
+
+		switch c.Method {
+		case model.InterestMethodFlat:
+			// The residue of rounding each month's interest is charged on the last instalment.
+			monthlyRate := c.Rate.Div(decimal.NewFromInt(1200))
+			want := c.Principal.Mul(monthlyRate).Mul(decimal.NewFromInt(int64(c.Months))).Round(places)
+			if !interest.Equal(want) {
+				t.Logf("%+v: flat interest %s, want %s", c, interest, want)
+				return false
+			}
+		case model.InterestMethodDecliningBalance:
+			// Level instalments; the last one repays what is left. The level is rounded up
+			// by less than a minor unit and each month's interest by at most half of one,
+			// and those differences compound until the last instalment.
+			for _, row := range rows[:len(rows)-1] {
+				if !row.Payment.Equal(rows[0].Payment) {
+					t.Logf("%+v: month %d pays %s, not %s", c, row.Month, row.Payment, rows[0].Payment)
+					return false
+				}
+			}
+			compounded := decimal.NewFromInt(int64(len(rows)))
+			if monthlyRate := c.Rate.Div(decimal.NewFromInt(1200)); monthlyRate.IsPositive() {
+				growth := decimalPow(decimal.NewFromInt(1).Add(monthlyRate), len(rows))
+				compounded = growth.Sub(decimal.NewFromInt(1)).Div(monthlyRate)
+			}
+			residue := last.Payment.Sub(rows[0].Payment).Abs()
+			if residue.GreaterThan(compounded.Mul(decimal.NewFromFloat(1.5)).Mul(decimal.New(1, -places))) {
+				t.Logf("%+v: last instalment %s, level %s", c, last.Payment, rows[0].Payment)
+				return false
+			}
+		}
+		return true
+	}
+
+	require.NoError(t, quick.Check(property, propertyConfig()))
+}
+
+func TestCalculatePayoffPlan_Properties(t *testing.T) {
+	t.Parallel()
+
+	property := func(units uint32, rateBasisPoints uint16, months uint16, method, curr uint8) bool {
+		c := newLoanCase(units, rateBasisPoints, months, method, curr)
+		places := currencyPlaces(c.Currency)
+		debt := &model.Debt{
+			ID:             uuid.New(),
+			OriginalAmount: c.Principal,
+			CurrentBalance: c.Principal,
+			InterestRate:   c.Rate,
+			Currency:       c.Currency,
+			TermMonths:     &c.Months,
+			InterestMethod: c.Method,
+		}
+		// The first instalment of a new loan, plus a minor unit for each month's rounding,
+		// repays it within its term under every method.
+		payment := loanSchedule(c.Principal, c.Rate, c.Months, c.Method, places)[0].Payment.
+			Add(decimal.New(int64(c.Months), -places))
+
+		plan := calculatePayoffPlan(debt, payment, nil, nil)
+
+		principal, interest, paid := decimal.Zero, decimal.Zero, decimal.Zero
+		for _, row := range plan.AmortizationPlan {
+			if !inMinorUnits(row.Payment, places) || !inMinorUnits(row.Principal, places) ||
+				!inMinorUnits(row.Interest, places) || !inMinorUnits(row.RemainingBalance, places) {
+				t.Logf("%+v: month %d is not in minor units: %+v", c, row.Month, row)
+				return false
+			}
+			principal = principal.Add(row.Principal)
+			interest = interest.Add(row.Interest)
+			paid = paid.Add(row.Payment)
+		}
+
+		switch {
+		case plan.MonthsToPayoff > c.Months:
+			t.Logf("%+v: paid off in %d months", c, plan.MonthsToPayoff)
+			return false
+		case !principal.Equal(c.Principal) || !plan.AmortizationPlan[len(plan.AmortizationPlan)-1].RemainingBalance.IsZero():
+			t.Logf("%+v: repaid %s", c, principal)
+			return false
+		case !interest.Equal(plan.TotalInterest) || !paid.Equal(plan.TotalPayment):
+			t.Logf("%+v: rows total %s and %s, plan %s and %s", c, interest, paid, plan.TotalInterest, plan.TotalPayment)
+			return false
+		case !plan.TotalPayment.Equal(c.Principal.Add(plan.TotalInterest)):
+			t.Logf("%+v: paid %s for %s interest", c, plan.TotalPayment, plan.TotalInterest)
+			return false
+		}
+		return true
+	}
+
+	require.NoError(t, quick.Check(property, propertyConfig()))
+}
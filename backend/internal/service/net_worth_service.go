@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/wealthpath/backend/internal/model"
+)
+
+// Service-level errors for net worth.
+var (
+	ErrInvalidNetWorthRange = errors.New("start must not be after end")
+)
+
+// NetWorthRepositoryInterface defines the contract for net worth data access.
+// Implementations must be safe for concurrent use.
+type NetWorthRepositoryInterface interface {
+	Items(ctx context.Context, userID uuid.UUID) ([]model.NetWorthItem, error)
+	SaveSnapshots(ctx context.Context, date time.Time) (int, error)
+	ListSnapshots(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]model.NetWorthSnapshot, error)
+}
+
+// NetWorthService tracks net worth: savings goals and term deposits less debts, per
+// currency, as it is today and as daily snapshots over time.
+type NetWorthService struct {
+	repo NetWorthRepositoryInterface
+}
+
+// NewNetWorthService creates a new NetWorthService with the given repository.
+func NewNetWorthService(repo NetWorthRepositoryInterface) *NetWorthService {
+	return &NetWorthService{repo: repo}
+}
+
+// GetBreakdown returns the user's current net worth in each currency with the assets and
+// liabilities it is made of. Amounts in different currencies are never added up.
+func (s *NetWorthService) GetBreakdown(ctx context.Context, userID uuid.UUID) (*model.NetWorthBreakdown, error) {
+	items, err := s.repo.Items(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("getting net worth items for user %s: %w", userID, err)
+	}
+
+	today := dateOf(time.Now())
+	breakdown := &model.NetWorthBreakdown{
+		Date:        today,
+		Totals:      []model.NetWorthSnapshot{},
+		Assets:      []model.NetWorthItem{},
+		Liabilities: []model.NetWorthItem{},
+	}
+	totals := make(map[string]*model.NetWorthSnapshot)
+	for _, item := range items {
+		total, ok := totals[item.Currency]
+		if !ok {
+			total = &model.NetWorthSnapshot{UserID: userID, Date: today, Currency: item.Currency}
+			totals[item.Currency] = total
+		}
+		if item.IsLiability() {
+			total.Liabilities = total.Liabilities.Add(item.Amount)
+			breakdown.Liabilities = append(breakdown.Liabilities, item)
+		} else {
+			total.Assets = total.Assets.Add(item.Amount)
+			breakdown.Assets = append(breakdown.Assets, item)
+		}
+	}
+	for _, total := range totals {
+		total.NetWorth = total.Assets.Sub(total.Liabilities)
+		breakdown.Totals = append(breakdown.Totals, *total)
+	}
+	sort.Slice(breakdown.Totals, func(i, j int) bool {
+		return breakdown.Totals[i].Currency < breakdown.Totals[j].Currency
+	})
+
+	return breakdown, nil
+}
+
+// GetHistory returns the user's daily net worth snapshots between two days, one series
+// per currency. end defaults to today and start to a year before end.
+func (s *NetWorthService) GetHistory(ctx context.Context, userID uuid.UUID, start, end time.Time) (*model.NetWorthHistory, error) {
+	if end.IsZero() {
+		end = time.Now()
+	}
+	end = dateOf(end)
+	if start.IsZero() {
+		start = end.AddDate(-1, 0, 0)
+	}
+	start = dateOf(start)
+	if start.After(end) {
+		return nil, ErrInvalidNetWorthRange
+	}
+
+	snapshots, err := s.repo.ListSnapshots(ctx, userID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("listing net worth snapshots for user %s: %w", userID, err)
+	}
+
+	history := &model.NetWorthHistory{Start: start, End: end, Series: []model.NetWorthSeries{}}
+	for _, snapshot := range snapshots {
+		n := len(history.Series)
+		if n == 0 || history.Series[n-1].Currency != snapshot.Currency {
+			history.Series = append(history.Series, model.NetWorthSeries{Currency: snapshot.Currency})
+			n++
+		}
+		series := &history.Series[n-1]
+		series.Points = append(series.Points, snapshot)
+		series.Change = snapshot.NetWorth.Sub(series.Points[0].NetWorth)
+	}
+	return history, nil
+}
+
+// TakeSnapshots records today's net worth of every user, replacing the snapshots already
+// taken today, and returns how many it recorded. Run often enough, each day keeps the net
+// worth at its end; days no run covered have no snapshot, as past balances are not kept.
+func (s *NetWorthService) TakeSnapshots(ctx context.Context) (int, error) {
+	saved, err := s.repo.SaveSnapshots(ctx, dateOf(time.Now()))
+	if err != nil {
+		return 0, fmt.Errorf("saving net worth snapshots: %w", err)
+	}
+	return saved, nil
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+// MockNetWorthRepo implements NetWorthRepositoryInterface for testing
+type MockNetWorthRepo struct {
+	mock.Mock
+}
+
+func (m *MockNetWorthRepo) Items(ctx context.Context, userID uuid.UUID) ([]model.NetWorthItem, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.NetWorthItem), args.Error(1)
+}
+
+func (m *MockNetWorthRepo) SaveSnapshots(ctx context.Context, date time.Time) (int, error) {
+	args := m.Called(ctx, date)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockNetWorthRepo) ListSnapshots(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]model.NetWorthSnapshot, error) {
+	args := m.Called(ctx, userID, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.NetWorthSnapshot), args.Error(1)
+}
+
+func netWorthSnapshot(date time.Time, currency string, assets, liabilities int64) model.NetWorthSnapshot {
+	return model.NetWorthSnapshot{
+		Date:        date,
+		Currency:    currency,
+		Assets:      decimal.NewFromInt(assets),
+		Liabilities: decimal.NewFromInt(liabilities),
+		NetWorth:    decimal.NewFromInt(assets - liabilities),
+	}
+}
+
+func TestNetWorthService_GetBreakdown(t *testing.T) {
+	t.Parallel()
+
+	repo := new(MockNetWorthRepo)
+	service := NewNetWorthService(repo)
+	userID := uuid.New()
+
+	repo.On("Items", mock.Anything, userID).Return([]model.NetWorthItem{
+		{Kind: model.NetWorthDebt, ID: uuid.New(), Name: "Mortgage", Type: string(model.DebtTypeMortgage), Currency: "VND", Amount: decimal.NewFromInt(1500000000)},
+		{Kind: model.NetWorthSavingsGoal, ID: uuid.New(), Name: "Emergency fund", Currency: "VND", Amount: decimal.NewFromInt(200000000)},
+		{Kind: model.NetWorthSavingsGoal, ID: uuid.New(), Name: "Travel", Currency: "USD", Amount: decimal.NewFromInt(1200)},
+		{Kind: model.NetWorthTermDeposit, ID: uuid.New(), Name: "Term deposits from 2026-01-15", Currency: "VND", Amount: decimal.NewFromInt(500000000)},
+	}, nil)
+
+	breakdown, err := service.GetBreakdown(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, dateOf(time.Now()), breakdown.Date)
+	require.Len(t, breakdown.Assets, 3)
+	require.Len(t, breakdown.Liabilities, 1)
+	assert.Equal(t, "Mortgage", breakdown.Liabilities[0].Name)
+
+	// Totals are per currency, never converted.
+	require.Len(t, breakdown.Totals, 2)
+	usd, vnd := breakdown.Totals[0], breakdown.Totals[1]
+	assert.Equal(t, "USD", usd.Currency)
+	assert.True(t, decimal.NewFromInt(1200).Equal(usd.NetWorth))
+	assert.True(t, usd.Liabilities.IsZero())
+	assert.Equal(t, "VND", vnd.Currency)
+	assert.True(t, decimal.NewFromInt(700000000).Equal(vnd.Assets))
+	assert.True(t, decimal.NewFromInt(1500000000).Equal(vnd.Liabilities))
+	assert.True(t, decimal.NewFromInt(-800000000).Equal(vnd.NetWorth))
+}
+
+func TestNetWorthService_GetBreakdown_Empty(t *testing.T) {
+	t.Parallel()
+
+	repo := new(MockNetWorthRepo)
+	service := NewNetWorthService(repo)
+	userID := uuid.New()
+	repo.On("Items", mock.Anything, userID).Return(nil, nil)
+
+	breakdown, err := service.GetBreakdown(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.NotNil(t, breakdown.Totals)
+	assert.NotNil(t, breakdown.Assets)
+	assert.NotNil(t, breakdown.Liabilities)
+}
+
+func TestNetWorthService_GetHistory(t *testing.T) {
+	t.Parallel()
+
+	repo := new(MockNetWorthRepo)
+	service := NewNetWorthService(repo)
+	userID := uuid.New()
+	start, end := day(2026, 10, 1), day(2026, 10, 3)
+
+	repo.On("ListSnapshots", mock.Anything, userID, start, end).Return([]model.NetWorthSnapshot{
+		netWorthSnapshot(day(2026, 10, 1), "USD", 1000, 0),
+		netWorthSnapshot(day(2026, 10, 1), "VND", 700000000, 1500000000),
+		netWorthSnapshot(day(2026, 10, 2), "VND", 702000000, 1495000000),
+		netWorthSnapshot(day(2026, 10, 3), "VND", 702000000, 1490000000),
+	}, nil)
+
+	history, err := service.GetHistory(context.Background(), userID, start, end)
+
+	require.NoError(t, err)
+	require.Len(t, history.Series, 2)
+	assert.Equal(t, "USD", history.Series[0].Currency)
+	assert.Len(t, history.Series[0].Points, 1)
+	assert.True(t, history.Series[0].Change.IsZero())
+	vnd := history.Series[1]
+	require.Len(t, vnd.Points, 3)
+	assert.True(t, decimal.NewFromInt(12000000).Equal(vnd.Change))
+}
+
+func TestNetWorthService_GetHistory_DefaultRange(t *testing.T) {
+	t.Parallel()
+
+	repo := new(MockNetWorthRepo)
+	service := NewNetWorthService(repo)
+	userID := uuid.New()
+	today := dateOf(time.Now())
+	repo.On("ListSnapshots", mock.Anything, userID, today.AddDate(-1, 0, 0), today).Return([]model.NetWorthSnapshot{}, nil)
+
+	history, err := service.GetHistory(context.Background(), userID, time.Time{}, time.Time{})
+
+	require.NoError(t, err)
+	assert.Equal(t, today, history.End)
+	assert.NotNil(t, history.Series)
+	repo.AssertExpectations(t)
+}
+
+func TestNetWorthService_GetHistory_InvalidRange(t *testing.T) {
+	t.Parallel()
+
+	repo := new(MockNetWorthRepo)
+	service := NewNetWorthService(repo)
+
+	_, err := service.GetHistory(context.Background(), uuid.New(), day(2026, 10, 2), day(2026, 10, 1))
+
+	assert.ErrorIs(t, err, ErrInvalidNetWorthRange)
+	repo.AssertNotCalled(t, "ListSnapshots", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestNetWorthService_TakeSnapshots(t *testing.T) {
+	t.Parallel()
+
+	repo := new(MockNetWorthRepo)
+	service := NewNetWorthService(repo)
+	repo.On("SaveSnapshots", mock.Anything, dateOf(time.Now())).Return(42, nil).Once()
+	repo.On("SaveSnapshots", mock.Anything, mock.Anything).Return(0, errors.New("connection reset")).Once()
+
+	saved, err := service.TakeSnapshots(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 42, saved)
+
+	_, err = service.TakeSnapshots(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+	"github.com/wealthpath/backend/pkg/rrule"
+)
+
+// Service-level errors for recurring suggestions.
+var (
+	ErrSuggestionNotFound = errors.New("recurring suggestion not found")
+)
+
+const (
+	// suggestionHistoryDays is how far back the analyser looks: a little over a year,
+	// so yearly bills are seen twice.
+	suggestionHistoryDays  = 400
+	suggestionHistoryLimit = 5000
+	// suggestionAmountTolerance is how far from the typical amount a transaction may
+	// be and still belong to the series, like a phone bill with extra data one month.
+	suggestionAmountTolerance = 0.25
+	minSuggestionConfidence   = 0.6
+)
+
+// TransactionHistory provides past transactions to find recurring series in.
+// Implementations must be safe for concurrent use.
+type TransactionHistory interface {
+	List(ctx context.Context, userID uuid.UUID, filters repository.TransactionFilters) ([]model.Transaction, error)
+}
+
+// RecurringSuggestion proposes a recurring transaction for a series of similar
+// transactions at regular intervals found in the user's history.
+type RecurringSuggestion struct {
+	ID             string               `json:"id"` // stable while the series lasts
+	Input          CreateRecurringInput `json:"input"`
+	Frequency      string               `json:"frequency"`  // weekly, biweekly, monthly, quarterly or yearly
+	Confidence     float64              `json:"confidence"` // 0 to 1
+	Occurrences    int                  `json:"occurrences"`
+	LastDate       time.Time            `json:"lastDate"`
+	TransactionIDs []uuid.UUID          `json:"transactionIds"`
+}
+
+// seriesPeriod is a detectable interval between transactions of a series.
+type seriesPeriod struct {
+	name           string
+	frequency      model.RecurringFrequency
+	minDays        int
+	maxDays        int
+	nominalDays    int
+	minOccurrences int
+}
+
+var seriesPeriods = []seriesPeriod{
+	{"weekly", model.FrequencyWeekly, 5, 9, 7, 3},
+	{"biweekly", model.FrequencyBiweekly, 12, 16, 14, 3},
+	{"monthly", model.FrequencyMonthly, 26, 35, 30, 3},
+	{"quarterly", model.FrequencyMonthly, 85, 97, 91, 3},
+	{"yearly", model.FrequencyYearly, 355, 375, 365, 2},
+}
+
+// SetTransactionHistory lets the service suggest recurring transactions from history.
+func (s *RecurringService) SetTransactionHistory(history TransactionHistory) {
+	s.history = history
+}
+
+// Suggestions finds series of transactions with a similar description and amount at
+// regular intervals that no recurring transaction covers yet, most confident first.
+func (s *RecurringService) Suggestions(ctx context.Context, userID uuid.UUID) ([]RecurringSuggestion, error) {
+	if s.history == nil {
+		return []RecurringSuggestion{}, nil
+	}
+	now := time.Now()
+	since := now.AddDate(0, 0, -suggestionHistoryDays)
+	history, err := s.history.List(ctx, userID, repository.TransactionFilters{StartDate: &since, Limit: suggestionHistoryLimit})
+	if err != nil {
+		return nil, fmt.Errorf("listing transaction history for user %s: %w", userID, err)
+	}
+	existing, err := s.recurringRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing recurring transactions for user %s: %w", userID, err)
+	}
+	return detectRecurring(history, existing, now), nil
+}
+
+// AcceptSuggestion creates the recurring transaction a suggestion proposes.
+// Returns ErrSuggestionNotFound when the suggestion no longer applies.
+func (s *RecurringService) AcceptSuggestion(ctx context.Context, userID uuid.UUID, id string) (*model.RecurringTransaction, error) {
+	suggestions, err := s.Suggestions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, suggestion := range suggestions {
+		if suggestion.ID == id {
+			return s.Create(ctx, userID, suggestion.Input)
+		}
+	}
+	return nil, ErrSuggestionNotFound
+}
+
+// detectRecurring groups transactions by type, currency and normalized description and
+// proposes a recurring transaction for each group that repeats regularly.
+func detectRecurring(history []model.Transaction, existing []model.RecurringTransaction, now time.Time) []RecurringSuggestion {
+	covered := make(map[string]bool, len(existing))
+	for _, rt := range existing {
+		covered[seriesKey(rt.Type, rt.Currency, rt.Description)] = true
+	}
+
+	groups := make(map[string][]model.Transaction)
+	for _, tx := range history {
+		// Generated and debt payment transactions are already scheduled elsewhere.
+		if tx.RecurringID != nil || tx.DebtPaymentID != nil {
+			continue
+		}
+		key := seriesKey(tx.Type, tx.Currency, tx.Description)
+		if key == "" || covered[key] {
+			continue
+		}
+		groups[key] = append(groups[key], tx)
+	}
+
+	suggestions := []RecurringSuggestion{}
+	for key, txs := range groups {
+		if suggestion, ok := detectSeries(key, txs, now); ok {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Confidence != suggestions[j].Confidence {
+			return suggestions[i].Confidence > suggestions[j].Confidence
+		}
+		return suggestions[i].ID < suggestions[j].ID
+	})
+	return suggestions
+}
+
+// detectSeries checks whether a group of similar transactions repeats at a regular
+// interval and scores how confident the match is, from how regular the intervals are,
+// how stable the amount is and how many times it repeated.
+func detectSeries(key string, txs []model.Transaction, now time.Time) (RecurringSuggestion, bool) {
+	txs = withTypicalAmount(txs)
+	if len(txs) < 2 {
+		return RecurringSuggestion{}, false
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].Date.Before(txs[j].Date) })
+
+	var intervals []int
+	for i := 1; i < len(txs); i++ {
+		if days := daysBetween(txs[i-1].Date, txs[i].Date); days > 0 {
+			intervals = append(intervals, days)
+		}
+	}
+	if len(intervals) == 0 {
+		return RecurringSuggestion{}, false
+	}
+	sorted := append([]int(nil), intervals...)
+	sort.Ints(sorted)
+	median := sorted[len(sorted)/2]
+
+	var period *seriesPeriod
+	for i := range seriesPeriods {
+		if median >= seriesPeriods[i].minDays && median <= seriesPeriods[i].maxDays {
+			period = &seriesPeriods[i]
+		}
+	}
+	if period == nil || len(txs) < period.minOccurrences {
+		return RecurringSuggestion{}, false
+	}
+
+	last := txs[len(txs)-1]
+	// A series that has not repeated for one and a half periods has stopped.
+	if daysBetween(last.Date, now) > period.nominalDays*3/2 {
+		return RecurringSuggestion{}, false
+	}
+
+	regular := 0
+	for _, days := range intervals {
+		if days >= period.minDays && days <= period.maxDays {
+			regular++
+		}
+	}
+	regularity := float64(regular) / float64(len(intervals))
+	amountScore := math.Max(0, 1-4*amountVariation(txs))
+	countScore := math.Min(1, float64(len(intervals))/5)
+	if period.minOccurrences == 2 {
+		countScore = math.Min(1, float64(len(intervals))/2)
+	}
+	confidence := math.Round((0.5*regularity+0.3*amountScore+0.2*countScore)*100) / 100
+	if confidence < minSuggestionConfidence {
+		return RecurringSuggestion{}, false
+	}
+
+	rule, anchor, needsRule := seriesRule(*period, txs)
+	start := dateOf(last.Date)
+	from, inclusive := dateOf(now), true
+	if !from.After(start) {
+		from, inclusive = start, false
+	}
+	next, ok := rule.After(start, from, inclusive)
+	if !ok {
+		return RecurringSuggestion{}, false
+	}
+
+	input := CreateRecurringInput{
+		Type:        last.Type,
+		Amount:      last.Amount,
+		Currency:    last.Currency,
+		Category:    mostCommonCategory(txs),
+		Description: strings.TrimSpace(last.Description),
+		Frequency:   period.frequency,
+		StartDate:   next,
+	}
+	if needsRule {
+		source := rule.String()
+		input.RRule = &source
+	} else if anchor > 28 {
+		input.AnchorDay = &anchor
+	}
+
+	ids := make([]uuid.UUID, len(txs))
+	for i, tx := range txs {
+		ids[i] = tx.ID
+	}
+	sum := sha1.Sum([]byte(key))
+	return RecurringSuggestion{
+		ID:             hex.EncodeToString(sum[:8]),
+		Input:          input,
+		Frequency:      period.name,
+		Confidence:     confidence,
+		Occurrences:    len(txs),
+		LastDate:       last.Date,
+		TransactionIDs: ids,
+	}, true
+}
+
+// seriesRule is the schedule of a detected series. Monthly and quarterly series fall on
+// the anchor day, the day of the month they most often fell on, or the month end in
+// shorter months. needsRule reports whether the schedule needs an RRULE rather than the
+// frequency shorthand anchored to that day.
+func seriesRule(period seriesPeriod, txs []model.Transaction) (rule *rrule.Rule, anchor int, needsRule bool) {
+	switch period.name {
+	case "monthly", "quarterly":
+		counts := make(map[int]int)
+		anchor = txs[len(txs)-1].Date.Day()
+		for _, tx := range txs {
+			counts[tx.Date.Day()]++
+			if counts[tx.Date.Day()] > counts[anchor] {
+				anchor = tx.Date.Day()
+			}
+		}
+		rule = dueDayRule(anchor)
+		if period.name == "quarterly" {
+			rule.Interval = 3
+			needsRule = true
+		}
+		return rule, anchor, needsRule
+	default:
+		return frequencyRule(period.frequency), 0, false
+	}
+}
+
+// withTypicalAmount keeps the transactions whose amount is close to the median.
+func withTypicalAmount(txs []model.Transaction) []model.Transaction {
+	amounts := make([]decimal.Decimal, len(txs))
+	for i, tx := range txs {
+		amounts[i] = tx.Amount
+	}
+	sort.Slice(amounts, func(i, j int) bool { return amounts[i].LessThan(amounts[j]) })
+	median := amounts[len(amounts)/2]
+	tolerance := median.Mul(decimal.NewFromFloat(suggestionAmountTolerance))
+
+	kept := make([]model.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		if tx.Amount.Sub(median).Abs().LessThanOrEqual(tolerance) {
+			kept = append(kept, tx)
+		}
+	}
+	return kept
+}
+
+// amountVariation is the coefficient of variation of the amounts.
+func amountVariation(txs []model.Transaction) float64 {
+	var sum float64
+	for _, tx := range txs {
+		sum += tx.Amount.InexactFloat64()
+	}
+	mean := sum / float64(len(txs))
+	if mean == 0 {
+		return 0
+	}
+	var squares float64
+	for _, tx := range txs {
+		d := tx.Amount.InexactFloat64() - mean
+		squares += d * d
+	}
+	return math.Sqrt(squares/float64(len(txs))) / mean
+}
+
+func mostCommonCategory(txs []model.Transaction) string {
+	counts := make(map[string]int)
+	best := txs[len(txs)-1].Category
+	for _, tx := range txs {
+		counts[tx.Category]++
+		if counts[tx.Category] > counts[best] {
+			best = tx.Category
+		}
+	}
+	return best
+}
+
+// seriesKey identifies a series by type, currency and description with case, digits
+// and punctuation ignored, so "Netflix 09/2026" and "NETFLIX 10/2026" match.
+// It is empty when the description has no letters.
+func seriesKey(txType model.TransactionType, currency, description string) string {
+	description = strings.TrimSuffix(strings.TrimSpace(description), "(recurring)")
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool { return !unicode.IsLetter(r) })
+	if len(words) == 0 {
+		return ""
+	}
+	return string(txType) + "|" + currency + "|" + strings.Join(words, " ")
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(dateOf(to).Sub(dateOf(from)).Hours() / 24)
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wealthpath/backend/internal/model"
+)
+
+func expense(description string, amount int64, date time.Time) model.Transaction {
+	return model.Transaction{
+		ID:          uuid.New(),
+		Type:        model.TransactionTypeExpense,
+		Amount:      decimal.NewFromInt(amount),
+		Currency:    "VND",
+		Category:    "Bills & Utilities",
+		Description: description,
+		Date:        date,
+	}
+}
+
+func monthlySeries(description string, amount int64, dayOfMonth, months int, through time.Time) []model.Transaction {
+	var txs []model.Transaction
+	for i := months - 1; i >= 0; i-- {
+		month := time.Date(through.Year(), through.Month()-time.Month(i), 1, 0, 0, 0, 0, time.UTC)
+		txs = append(txs, expense(description, amount, statementDate(month.Year(), month.Month(), dayOfMonth)))
+	}
+	return txs
+}
+
+func TestDetectRecurring(t *testing.T) {
+	t.Parallel()
+
+	now := day(2026, time.October, 18)
+
+	var history []model.Transaction
+	// Monthly subscription with the month in the description.
+	for _, date := range []time.Time{
+		day(2026, time.May, 10), day(2026, time.June, 10), day(2026, time.July, 10),
+		day(2026, time.August, 10), day(2026, time.September, 10), day(2026, time.October, 10),
+	} {
+		history = append(history, expense("NETFLIX.COM "+date.Format("01/2006"), 260000, date))
+	}
+	// Rent on the last day of the month.
+	history = append(history, monthlySeries("Tiền nhà", 8000000, 31, 5, day(2026, time.September, 30))...)
+	// Phone plan with one month of extra data, which is left out of the series.
+	phone := monthlySeries("Viettel", 150000, 5, 6, now)
+	phone[2].Amount = decimal.NewFromInt(450000)
+	history = append(history, phone...)
+	// Weekly groceries vary too much to be a bill.
+	for i, amount := range []int64{350000, 1200000, 90000, 640000, 2100000, 410000} {
+		history = append(history, expense("Co.opmart", amount, now.AddDate(0, 0, -7*i)))
+	}
+	// A gym membership that was cancelled in spring.
+	history = append(history, monthlySeries("California Fitness", 900000, 1, 4, day(2026, time.April, 1))...)
+	// Already set up as a recurring transaction.
+	history = append(history, monthlySeries("Spotify", 59000, 20, 4, day(2026, time.September, 20))...)
+	// Generated by a recurring transaction.
+	for _, tx := range monthlySeries("Internet (recurring)", 220000, 3, 4, now) {
+		recurringID := uuid.New()
+		tx.RecurringID = &recurringID
+		history = append(history, tx)
+	}
+	// Yearly domain renewal.
+	history = append(history, expense("Domain renewal", 450000, day(2025, time.November, 2)), expense("Domain renewal", 450000, day(2024, time.November, 1)))
+	// Quarterly insurance premium.
+	for _, date := range []time.Time{day(2026, time.January, 15), day(2026, time.April, 15), day(2026, time.July, 15), day(2026, time.October, 15)} {
+		history = append(history, expense("Bảo Việt premium", 1800000, date))
+	}
+
+	existing := []model.RecurringTransaction{{Type: model.TransactionTypeExpense, Currency: "VND", Description: "spotify"}}
+
+	suggestions := detectRecurring(history, existing, now)
+
+	byDescription := make(map[string]RecurringSuggestion)
+	for _, s := range suggestions {
+		byDescription[s.Input.Description] = s
+	}
+	require.Len(t, byDescription, 5, "suggested: %v", byDescription)
+
+	netflix := byDescription["NETFLIX.COM 10/2026"]
+	assert.Equal(t, "monthly", netflix.Frequency)
+	assert.Equal(t, 1.0, netflix.Confidence)
+	assert.Equal(t, 6, netflix.Occurrences)
+	assert.Equal(t, model.FrequencyMonthly, netflix.Input.Frequency)
+	assert.Nil(t, netflix.Input.RRule)
+	assert.Equal(t, day(2026, time.November, 10), netflix.Input.StartDate)
+	assert.True(t, netflix.Input.Amount.Equal(decimal.NewFromInt(260000)))
+
+	rent := byDescription["Tiền nhà"]
+	assert.Nil(t, rent.Input.RRule)
+	require.NotNil(t, rent.Input.AnchorDay)
+	assert.Equal(t, 31, *rent.Input.AnchorDay)
+	assert.Equal(t, day(2026, time.October, 31), rent.Input.StartDate)
+
+	viettel := byDescription["Viettel"]
+	assert.Equal(t, 5, viettel.Occurrences)
+	assert.NotContains(t, viettel.TransactionIDs, phone[2].ID)
+	assert.Equal(t, day(2026, time.November, 5), viettel.Input.StartDate)
+
+	domain := byDescription["Domain renewal"]
+	assert.Equal(t, "yearly", domain.Frequency)
+	assert.Equal(t, model.FrequencyYearly, domain.Input.Frequency)
+
+	insurance := byDescription["Bảo Việt premium"]
+	assert.Equal(t, "quarterly", insurance.Frequency)
+	require.NotNil(t, insurance.Input.RRule)
+	assert.Equal(t, "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=15", *insurance.Input.RRule)
+	assert.Equal(t, day(2027, time.January, 15), insurance.Input.StartDate)
+
+	for i := 1; i < len(suggestions); i++ {
+		assert.GreaterOrEqual(t, suggestions[i-1].Confidence, suggestions[i].Confidence)
+	}
+	// IDs are stable across runs so a suggestion can be accepted later.
+	again := detectRecurring(history, existing, now)
+	assert.Equal(t, suggestions, again)
+}
+
+func TestSeriesKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		seriesKey(model.TransactionTypeExpense, "VND", "NETFLIX.COM 09/2026"),
+		seriesKey(model.TransactionTypeExpense, "VND", "Netflix com 10/2026"))
+	assert.Equal(t, "expense|VND|tiền điện evn", seriesKey(model.TransactionTypeExpense, "VND", "Tiền điện EVN #8812"))
+	assert.NotEqual(t,
+		seriesKey(model.TransactionTypeExpense, "VND", "Netflix"),
+		seriesKey(model.TransactionTypeIncome, "VND", "Netflix"))
+	assert.Empty(t, seriesKey(model.TransactionTypeExpense, "VND", "12/10"))
+}
+
+func TestRecurringService_AcceptSuggestion(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	history := new(MockTransactionRepo)
+	service := NewRecurringService(mockRepo, new(MockTransactionCreator))
+	service.SetTransactionHistory(history)
+	userID := uuid.New()
+
+	today := dateOf(time.Now())
+	txs := monthlySeries("Netflix", 260000, today.Day(), 6, today)
+	if today.Day() > 28 {
+		txs = monthlySeries("Netflix", 260000, 28, 6, today.AddDate(0, 0, -3))
+	}
+	history.On("List", mock.Anything, userID, mock.Anything).Return(txs, nil)
+	mockRepo.On("GetByUserID", mock.Anything, userID).Return([]model.RecurringTransaction{}, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(rt *model.RecurringTransaction) bool {
+		return rt.UserID == userID && rt.Description == "Netflix" && rt.Frequency == model.FrequencyMonthly &&
+			rt.NextOccurrence.After(today)
+	})).Return(nil)
+
+	suggestions, err := service.Suggestions(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+
+	rt, err := service.AcceptSuggestion(context.Background(), userID, suggestions[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Netflix", rt.Description)
+
+	_, err = service.AcceptSuggestion(context.Background(), userID, "unknown")
+	assert.ErrorIs(t, err, ErrSuggestionNotFound)
+	mockRepo.AssertExpectations(t)
+}
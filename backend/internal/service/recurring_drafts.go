@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+)
+
+// Service-level errors for recurring drafts.
+var (
+	ErrInvalidAmountType = errors.New("amountType must be 'fixed', 'estimated' or 'average'")
+	ErrDraftNotFound     = errors.New("recurring draft not found")
+)
+
+// averagedOccurrences is how many recent confirmed amounts an average-amount recurring
+// transaction drafts its next occurrence at.
+const averagedOccurrences = 3
+
+// RecurringDraftStore stores the drafts generated by variable-amount recurring transactions.
+// Implementations must be safe for concurrent use.
+type RecurringDraftStore interface {
+	Create(ctx context.Context, draft *model.RecurringDraft, lastGenerated, nextOccurrence time.Time) (bool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*model.RecurringDraft, error)
+	ListPending(ctx context.Context, userID uuid.UUID) ([]model.RecurringDraft, error)
+	Confirm(ctx context.Context, draft *model.RecurringDraft, tx *model.Transaction, transfer *model.RecurringTransfer) error
+	Dismiss(ctx context.Context, id uuid.UUID) error
+}
+
+// SetDraftStore makes variable-amount recurring transactions generate drafts awaiting
+// confirmation. Without one, they generate transactions at the estimated amount.
+func (s *RecurringService) SetDraftStore(drafts RecurringDraftStore) {
+	s.drafts = drafts
+}
+
+// ConfirmDraftInput adjusts a draft as it is confirmed; omitted fields keep the draft's.
+type ConfirmDraftInput struct {
+	Amount *decimal.Decimal `json:"amount"`
+	Date   *time.Time       `json:"date"`
+}
+
+// ListDrafts returns the user's drafts awaiting confirmation, oldest occurrence first.
+func (s *RecurringService) ListDrafts(ctx context.Context, userID uuid.UUID) ([]model.RecurringDraft, error) {
+	if s.drafts == nil {
+		return []model.RecurringDraft{}, nil
+	}
+	drafts, err := s.drafts.ListPending(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing recurring drafts for user %s: %w", userID, err)
+	}
+	return drafts, nil
+}
+
+// ConfirmDraft turns a pending draft into a transaction at the actual amount, linked to
+// its recurring transaction like a generated one, pays it into the savings goal or debt
+// the recurring transaction targets in the same database transaction, and runs the
+// created hooks.
+// Returns ErrDraftNotFound if the draft is not pending or belongs to another user.
+func (s *RecurringService) ConfirmDraft(ctx context.Context, userID, id uuid.UUID, input ConfirmDraftInput) (*model.Transaction, error) {
+	draft, err := s.pendingDraft(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := draft.Amount
+	if input.Amount != nil {
+		amount = *input.Amount
+	}
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrInvalidAmount
+	}
+	date := draft.DueDate
+	if input.Date != nil {
+		date = *input.Date
+	}
+
+	occurrenceDate := draft.OccurrenceDate
+	tx := &model.Transaction{
+		UserID:         draft.UserID,
+		Type:           draft.Type,
+		Amount:         amount,
+		Currency:       draft.Currency,
+		Category:       draft.Category,
+		Description:    draft.Description + " (recurring)",
+		Date:           date,
+		RecurringID:    &draft.RecurringID,
+		OccurrenceDate: &occurrenceDate,
+	}
+	var transfer *model.RecurringTransfer
+	rt, err := s.targetOf(ctx, draft)
+	if err != nil {
+		return nil, err
+	}
+	if rt != nil {
+		if transfer, err = s.transferOf(ctx, rt, amount, date); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.drafts.Confirm(ctx, draft, tx, transfer); err != nil {
+		if errors.Is(err, repository.ErrRecurringDraftNotFound) {
+			return nil, ErrDraftNotFound
+		}
+		return nil, fmt.Errorf("confirming recurring draft %s: %w", id, err)
+	}
+	s.runCreatedHooks(ctx, tx)
+
+	return tx, nil
+}
+
+// DismissDraft discards a pending draft for an occurrence that did not happen.
+// Returns ErrDraftNotFound if the draft is not pending or belongs to another user.
+func (s *RecurringService) DismissDraft(ctx context.Context, userID, id uuid.UUID) error {
+	if _, err := s.pendingDraft(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := s.drafts.Dismiss(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrRecurringDraftNotFound) {
+			return ErrDraftNotFound
+		}
+		return fmt.Errorf("dismissing recurring draft %s: %w", id, err)
+	}
+	return nil
+}
+
+// pendingDraft returns a pending draft of the user.
+func (s *RecurringService) pendingDraft(ctx context.Context, userID, id uuid.UUID) (*model.RecurringDraft, error) {
+	if s.drafts == nil {
+		return nil, ErrDraftNotFound
+	}
+	draft, err := s.drafts.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecurringDraftNotFound) {
+			return nil, ErrDraftNotFound
+		}
+		return nil, fmt.Errorf("getting recurring draft %s: %w", id, err)
+	}
+	if draft.UserID != userID || draft.Status != model.DraftPending {
+		return nil, ErrDraftNotFound
+	}
+	return draft, nil
+}
+
+// estimatedAmount returns the amount a variable-amount recurring transaction drafts its
+// occurrences at: the average of its last confirmed amounts for average-amount ones, and
+// the template amount otherwise or before any amount was confirmed.
+func (s *RecurringService) estimatedAmount(ctx context.Context, rt *model.RecurringTransaction) (decimal.Decimal, error) {
+	if rt.AmountType != model.AmountAverage {
+		return rt.Amount, nil
+	}
+	transactions, err := s.transactionRepo.ListByRecurringID(ctx, rt.ID)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("listing transactions of recurring transaction %s: %w", rt.ID, err)
+	}
+
+	total := decimal.Zero
+	n := 0
+	for _, tx := range transactions {
+		if tx.Currency != rt.Currency {
+			continue
+		}
+		total = total.Add(tx.Amount)
+		n++
+		if n == averagedOccurrences {
+			break
+		}
+	}
+	if n == 0 {
+		return rt.Amount, nil
+	}
+	return total.Div(decimal.NewFromInt(int64(n))).Round(currencyPlaces(rt.Currency)), nil
+}
+
+// isValidAmountType checks if the given amount type is a supported value.
+func isValidAmountType(t model.RecurringAmountType) bool {
+	switch t {
+	case model.AmountFixed, model.AmountEstimated, model.AmountAverage:
+		return true
+	}
+	return false
+}
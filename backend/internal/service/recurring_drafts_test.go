@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+)
+
+// MockDraftStore implements RecurringDraftStore for testing
+type MockDraftStore struct {
+	mock.Mock
+}
+
+func (m *MockDraftStore) Create(ctx context.Context, draft *model.RecurringDraft, lastGenerated, nextOccurrence time.Time) (bool, error) {
+	args := m.Called(ctx, draft, lastGenerated, nextOccurrence)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockDraftStore) GetByID(ctx context.Context, id uuid.UUID) (*model.RecurringDraft, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RecurringDraft), args.Error(1)
+}
+
+func (m *MockDraftStore) ListPending(ctx context.Context, userID uuid.UUID) ([]model.RecurringDraft, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.RecurringDraft), args.Error(1)
+}
+
+func (m *MockDraftStore) Confirm(ctx context.Context, draft *model.RecurringDraft, tx *model.Transaction, transfer *model.RecurringTransfer) error {
+	args := m.Called(ctx, draft, tx, transfer)
+	return args.Error(0)
+}
+
+func (m *MockDraftStore) Dismiss(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// electricityBill returns a monthly variable-amount bill due today.
+func electricityBill(amountType model.RecurringAmountType) model.RecurringTransaction {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return model.RecurringTransaction{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		Type:           model.TransactionTypeExpense,
+		Amount:         decimal.NewFromInt(800000),
+		Currency:       "VND",
+		Category:       "Utilities",
+		Description:    "Electricity",
+		Frequency:      model.FrequencyMonthly,
+		AmountType:     amountType,
+		StartDate:      today,
+		NextOccurrence: today,
+		IsActive:       true,
+	}
+}
+
+func TestRecurringService_ProcessDueTransactions_Drafts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		amountType model.RecurringAmountType
+		confirmed  []model.Transaction
+		wantAmount decimal.Decimal
+	}{
+		{
+			name:       "estimated drafts at the template amount",
+			amountType: model.AmountEstimated,
+			wantAmount: decimal.NewFromInt(800000),
+		},
+		{
+			name:       "average drafts at the last three confirmed amounts",
+			amountType: model.AmountAverage,
+			confirmed: []model.Transaction{
+				{Amount: decimal.NewFromInt(910000), Currency: "VND"},
+				{Amount: decimal.NewFromInt(1020000), Currency: "VND"},
+				{Amount: decimal.NewFromInt(755000), Currency: "VND"},
+				{Amount: decimal.NewFromInt(300000), Currency: "VND"},
+			},
+			wantAmount: decimal.NewFromInt(895000),
+		},
+		{
+			name:       "average before any confirmed amount",
+			amountType: model.AmountAverage,
+			confirmed:  []model.Transaction{},
+			wantAmount: decimal.NewFromInt(800000),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockRecurringRepo)
+			mockTxRepo := new(MockTransactionCreator)
+			drafts := new(MockDraftStore)
+			service := NewRecurringService(mockRepo, mockTxRepo)
+			service.SetDraftStore(drafts)
+			hook := new(MockCreatedHook)
+			service.AddCreatedHook(hook)
+
+			rt := electricityBill(tt.amountType)
+			mockRepo.On("GetDueTransactions", mock.Anything, mock.Anything).Return([]model.RecurringTransaction{rt}, nil)
+			mockRepo.On("ListExceptions", mock.Anything, mock.Anything).Return([]model.RecurringException{}, nil)
+			if tt.confirmed != nil {
+				mockTxRepo.On("ListByRecurringID", mock.Anything, rt.ID).Return(tt.confirmed, nil)
+			}
+			drafts.On("Create", mock.Anything, mock.MatchedBy(func(d *model.RecurringDraft) bool {
+				return d.RecurringID == rt.ID && d.UserID == rt.UserID && d.Amount.Equal(tt.wantAmount) &&
+					d.OccurrenceDate.Equal(rt.NextOccurrence) && d.Description == "Electricity"
+			}), mock.Anything, rt.NextOccurrence.AddDate(0, 1, 0)).Return(true, nil)
+
+			count, err := service.ProcessDueTransactions(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+			drafts.AssertExpectations(t)
+			mockTxRepo.AssertExpectations(t)
+			mockTxRepo.AssertNotCalled(t, "CreateRecurring", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			hook.AssertNotCalled(t, "OnTransactionCreated", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRecurringService_ProcessDueTransactions_EstimatedWithoutDrafts(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	mockTxRepo := new(MockTransactionCreator)
+	service := NewRecurringService(mockRepo, mockTxRepo)
+
+	rt := electricityBill(model.AmountEstimated)
+	mockRepo.On("GetDueTransactions", mock.Anything, mock.Anything).Return([]model.RecurringTransaction{rt}, nil)
+	mockRepo.On("ListExceptions", mock.Anything, mock.Anything).Return([]model.RecurringException{}, nil)
+	mockTxRepo.On("CreateRecurring", mock.Anything, mock.MatchedBy(func(tx *model.Transaction) bool {
+		return tx.Amount.Equal(rt.Amount)
+	}), mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+
+	count, err := service.ProcessDueTransactions(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestRecurringService_ConfirmDraft(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	occurrence := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	pending := func() *model.RecurringDraft {
+		return &model.RecurringDraft{
+			ID:             uuid.New(),
+			RecurringID:    uuid.New(),
+			UserID:         userID,
+			Type:           model.TransactionTypeExpense,
+			Amount:         decimal.NewFromInt(800000),
+			Currency:       "VND",
+			Category:       "Utilities",
+			Description:    "Electricity",
+			OccurrenceDate: occurrence,
+			DueDate:        occurrence,
+			Status:         model.DraftPending,
+		}
+	}
+	actual := decimal.NewFromInt(912000)
+	paid := time.Date(2026, 10, 8, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		draft      func() *model.RecurringDraft
+		userID     uuid.UUID
+		input      ConfirmDraftInput
+		confirmErr error
+		wantAmount decimal.Decimal
+		wantDate   time.Time
+		wantErr    error
+	}{
+		{
+			name:       "as drafted",
+			draft:      pending,
+			userID:     userID,
+			wantAmount: decimal.NewFromInt(800000),
+			wantDate:   occurrence,
+		},
+		{
+			name:       "adjusted",
+			draft:      pending,
+			userID:     userID,
+			input:      ConfirmDraftInput{Amount: &actual, Date: &paid},
+			wantAmount: actual,
+			wantDate:   paid,
+		},
+		{
+			name:    "invalid amount",
+			draft:   pending,
+			userID:  userID,
+			input:   ConfirmDraftInput{Amount: decimalPtr(decimal.Zero)},
+			wantErr: ErrInvalidAmount,
+		},
+		{
+			name:    "another user's draft",
+			draft:   pending,
+			userID:  uuid.New(),
+			wantErr: ErrDraftNotFound,
+		},
+		{
+			name: "already confirmed",
+			draft: func() *model.RecurringDraft {
+				d := pending()
+				d.Status = model.DraftConfirmed
+				return d
+			},
+			userID:  userID,
+			wantErr: ErrDraftNotFound,
+		},
+		{
+			name:       "confirmed concurrently",
+			draft:      pending,
+			userID:     userID,
+			confirmErr: repository.ErrRecurringDraftNotFound,
+			wantErr:    ErrDraftNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			drafts := new(MockDraftStore)
+			service := NewRecurringService(new(MockRecurringRepo), new(MockTransactionCreator))
+			service.SetDraftStore(drafts)
+			hook := new(MockCreatedHook)
+			service.AddCreatedHook(hook)
+
+			draft := tt.draft()
+			drafts.On("GetByID", mock.Anything, draft.ID).Return(draft, nil)
+			drafts.On("Confirm", mock.Anything, draft, mock.AnythingOfType("*model.Transaction"), mock.Anything).Return(tt.confirmErr).Maybe()
+			hook.On("OnTransactionCreated", mock.Anything, mock.AnythingOfType("*model.Transaction")).Return(nil).Maybe()
+
+			tx, err := service.ConfirmDraft(context.Background(), tt.userID, draft.ID, tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				hook.AssertNotCalled(t, "OnTransactionCreated", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tx.Amount.Equal(tt.wantAmount))
+			assert.Equal(t, tt.wantDate, tx.Date)
+			assert.Equal(t, "Electricity (recurring)", tx.Description)
+			assert.Equal(t, draft.RecurringID, *tx.RecurringID)
+			assert.Equal(t, occurrence, *tx.OccurrenceDate)
+			hook.AssertNumberOfCalls(t, "OnTransactionCreated", 1)
+		})
+	}
+}
+
+func TestRecurringService_DismissDraft(t *testing.T) {
+	t.Parallel()
+
+	drafts := new(MockDraftStore)
+	service := NewRecurringService(new(MockRecurringRepo), new(MockTransactionCreator))
+	service.SetDraftStore(drafts)
+
+	userID := uuid.New()
+	draft := &model.RecurringDraft{ID: uuid.New(), UserID: userID, Status: model.DraftPending}
+	drafts.On("GetByID", mock.Anything, draft.ID).Return(draft, nil)
+	drafts.On("Dismiss", mock.Anything, draft.ID).Return(nil)
+
+	require.NoError(t, service.DismissDraft(context.Background(), userID, draft.ID))
+	assert.ErrorIs(t, service.DismissDraft(context.Background(), uuid.New(), draft.ID), ErrDraftNotFound)
+	drafts.AssertNumberOfCalls(t, "Dismiss", 1)
+}
+
+func TestRecurringService_ListDrafts_WithoutStore(t *testing.T) {
+	t.Parallel()
+
+	service := NewRecurringService(new(MockRecurringRepo), new(MockTransactionCreator))
+
+	drafts, err := service.ListDrafts(context.Background(), uuid.New())
+
+	require.NoError(t, err)
+	assert.Empty(t, drafts)
+}
+
+func TestRecurringService_Create_AmountType(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	service := NewRecurringService(mockRepo, new(MockTransactionCreator))
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(rt *model.RecurringTransaction) bool {
+		return rt.AmountType == model.AmountFixed
+	})).Return(nil)
+
+	input := CreateRecurringInput{
+		Type:      model.TransactionTypeExpense,
+		Amount:    decimal.NewFromInt(800000),
+		Category:  "Utilities",
+		Frequency: model.FrequencyMonthly,
+		StartDate: time.Date(2026, 11, 5, 0, 0, 0, 0, time.UTC),
+	}
+	_, err := service.Create(context.Background(), uuid.New(), input)
+	require.NoError(t, err)
+
+	input.AmountType = "guessed"
+	_, err = service.Create(context.Background(), uuid.New(), input)
+	assert.ErrorIs(t, err, ErrInvalidAmountType)
+	mockRepo.AssertNumberOfCalls(t, "Create", 1)
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+)
+
+// Service-level errors for occurrence exceptions.
+var (
+	ErrNotAnOccurrence   = errors.New("date is not an occurrence of the recurring transaction still to be generated")
+	ErrEmptyException    = errors.New("an exception must skip the occurrence, move it or override its amount")
+	ErrInvalidMove       = errors.New("an occurrence can only move to a day between the occurrences before and after it")
+	ErrExceptionNotFound = errors.New("occurrence exception not found")
+	ErrNegativeOverride  = errors.New("amount must not be negative")
+	ErrSkipWithOverrides = errors.New("a skipped occurrence cannot also be moved or re-priced")
+)
+
+// maxOccurrenceSteps bounds the occurrences walked to find one, about three years of a
+// daily schedule.
+const maxOccurrenceSteps = 1100
+
+// OccurrenceExceptionInput changes a single occurrence of a recurring transaction.
+type OccurrenceExceptionInput struct {
+	Skip   bool             `json:"skip"`
+	MoveTo *time.Time       `json:"moveTo"`
+	Amount *decimal.Decimal `json:"amount"` // zero for an occurrence that costs nothing this time
+}
+
+// SetException skips, moves or overrides the amount of an occurrence that is still to
+// be generated, replacing any exception it already has. A moved occurrence stays between
+// the occurrences before and after it.
+// Returns ErrRecurringNotFound if the transaction does not exist or belongs to another user.
+func (s *RecurringService) SetException(ctx context.Context, userID, id uuid.UUID, occurrence time.Time, input OccurrenceExceptionInput) (*model.RecurringException, error) {
+	if !input.Skip && input.MoveTo == nil && input.Amount == nil {
+		return nil, ErrEmptyException
+	}
+	if input.Skip && (input.MoveTo != nil || input.Amount != nil) {
+		return nil, ErrSkipWithOverrides
+	}
+	if input.Amount != nil && input.Amount.IsNegative() {
+		return nil, ErrNegativeOverride
+	}
+
+	rt, err := s.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	previous, next, err := occurrenceWindow(rt, occurrence)
+	if err != nil {
+		return nil, err
+	}
+	if input.MoveTo != nil {
+		moveTo := dateOf(*input.MoveTo)
+		if (previous != nil && !moveTo.After(dateOf(*previous))) || (next != nil && !moveTo.Before(dateOf(*next))) {
+			return nil, ErrInvalidMove
+		}
+		input.MoveTo = &moveTo
+	}
+
+	exception := &model.RecurringException{
+		RecurringID:    rt.ID,
+		OccurrenceDate: dateOf(occurrence),
+		Skip:           input.Skip,
+		MovedTo:        input.MoveTo,
+		Amount:         input.Amount,
+	}
+	if err := s.recurringRepo.SaveException(ctx, exception); err != nil {
+		return nil, fmt.Errorf("saving exception of recurring transaction %s: %w", id, err)
+	}
+	return exception, nil
+}
+
+// DeleteException restores an occurrence to its schedule.
+// Returns ErrExceptionNotFound if the occurrence has no exception.
+func (s *RecurringService) DeleteException(ctx context.Context, userID, id uuid.UUID, occurrence time.Time) error {
+	if _, err := s.GetByID(ctx, userID, id); err != nil {
+		return err
+	}
+	if err := s.recurringRepo.DeleteException(ctx, id, dateOf(occurrence)); err != nil {
+		if errors.Is(err, repository.ErrRecurringExceptionNotFound) {
+			return ErrExceptionNotFound
+		}
+		return fmt.Errorf("deleting exception of recurring transaction %s: %w", id, err)
+	}
+	return nil
+}
+
+// ListExceptions returns the exceptions of a recurring transaction, by occurrence.
+func (s *RecurringService) ListExceptions(ctx context.Context, userID, id uuid.UUID) ([]model.RecurringException, error) {
+	if _, err := s.GetByID(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	exceptions, err := s.recurringRepo.ListExceptions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("listing exceptions of recurring transaction %s: %w", id, err)
+	}
+	if exceptions == nil {
+		exceptions = []model.RecurringException{}
+	}
+	return exceptions, nil
+}
+
+// occurrenceWindow checks that a date is an occurrence still to be generated and returns
+// the occurrences before and after it, nil when there is none still to be generated
+// before it or none after it.
+func occurrenceWindow(rt *model.RecurringTransaction, date time.Time) (previous, next *time.Time, err error) {
+	date = dateOf(date)
+	occurrence := rt.NextOccurrence
+	for i := 0; dateOf(occurrence).Before(date); i++ {
+		if i == maxOccurrenceSteps {
+			return nil, nil, ErrNotAnOccurrence
+		}
+		prev := occurrence
+		following, ok := nextOccurrence(rt, occurrence, false)
+		if !ok {
+			return nil, nil, ErrNotAnOccurrence
+		}
+		previous, occurrence = &prev, following
+	}
+	if !dateOf(occurrence).Equal(date) || (rt.EndDate != nil && occurrence.After(*rt.EndDate)) {
+		return nil, nil, ErrNotAnOccurrence
+	}
+	if following, ok := nextOccurrence(rt, occurrence, false); ok {
+		next = &following
+	}
+	return previous, next, nil
+}
+
+// plannedOccurrence is an occurrence of a recurring transaction once its exception applies.
+type plannedOccurrence struct {
+	date    time.Time
+	amount  decimal.Decimal
+	skipped bool
+}
+
+// planOccurrence applies an occurrence's exception, if any. An amount overridden to zero
+// skips the occurrence, as there is nothing to record.
+func planOccurrence(occurrence time.Time, amount decimal.Decimal, exception *model.RecurringException) plannedOccurrence {
+	plan := plannedOccurrence{date: occurrence, amount: amount}
+	if exception == nil {
+		return plan
+	}
+	if exception.MovedTo != nil {
+		plan.date = *exception.MovedTo
+	}
+	if exception.Amount != nil {
+		plan.amount = *exception.Amount
+	}
+	plan.skipped = exception.Skip || plan.amount.IsZero()
+	return plan
+}
+
+// exceptionsByOccurrence indexes exceptions by recurring transaction and occurrence date.
+func exceptionsByOccurrence(exceptions []model.RecurringException) map[uuid.UUID]map[time.Time]*model.RecurringException {
+	indexed := make(map[uuid.UUID]map[time.Time]*model.RecurringException)
+	for i := range exceptions {
+		e := &exceptions[i]
+		if indexed[e.RecurringID] == nil {
+			indexed[e.RecurringID] = make(map[time.Time]*model.RecurringException)
+		}
+		indexed[e.RecurringID][dateOf(e.OccurrenceDate)] = e
+	}
+	return indexed
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+)
+
+func TestRecurringService_SetException(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	rt := &model.RecurringTransaction{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Amount:         decimal.NewFromInt(6000000),
+		Description:    "Rent",
+		Frequency:      model.FrequencyMonthly,
+		StartDate:      day(2026, 1, 15),
+		NextOccurrence: day(2026, 11, 15),
+		IsActive:       true,
+	}
+	moveTo := func(d time.Time) *time.Time { return &d }
+	zero := decimal.Zero
+	negative := decimal.NewFromInt(-1)
+
+	tests := []struct {
+		name       string
+		occurrence time.Time
+		input      OccurrenceExceptionInput
+		wantErr    error
+	}{
+		{"skip", day(2026, 12, 15), OccurrenceExceptionInput{Skip: true}, nil},
+		{"move within its month", day(2026, 11, 15), OccurrenceExceptionInput{MoveTo: moveTo(day(2026, 11, 20))}, nil},
+		{"move earlier", day(2026, 12, 15), OccurrenceExceptionInput{MoveTo: moveTo(day(2026, 11, 30))}, nil},
+		{"zero amount", day(2027, 1, 15), OccurrenceExceptionInput{Amount: &zero}, nil},
+		{"empty", day(2026, 11, 15), OccurrenceExceptionInput{}, ErrEmptyException},
+		{"skip and re-price", day(2026, 11, 15), OccurrenceExceptionInput{Skip: true, Amount: &zero}, ErrSkipWithOverrides},
+		{"negative amount", day(2026, 11, 15), OccurrenceExceptionInput{Amount: &negative}, ErrNegativeOverride},
+		{"not on the schedule", day(2026, 11, 16), OccurrenceExceptionInput{Skip: true}, ErrNotAnOccurrence},
+		{"already generated", day(2026, 10, 15), OccurrenceExceptionInput{Skip: true}, ErrNotAnOccurrence},
+		{"move onto the next occurrence", day(2026, 11, 15), OccurrenceExceptionInput{MoveTo: moveTo(day(2026, 12, 15))}, ErrInvalidMove},
+		{"move past the previous occurrence", day(2026, 12, 15), OccurrenceExceptionInput{MoveTo: moveTo(day(2026, 11, 10))}, ErrInvalidMove},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockRecurringRepo)
+			service := NewRecurringService(mockRepo, new(MockTransactionCreator))
+			mockRepo.On("GetByID", mock.Anything, rt.ID).Return(rt, nil).Maybe()
+			mockRepo.On("SaveException", mock.Anything, mock.AnythingOfType("*model.RecurringException")).Return(nil).Maybe()
+
+			exception, err := service.SetException(context.Background(), userID, rt.ID, tt.occurrence, tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockRepo.AssertNotCalled(t, "SaveException", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, rt.ID, exception.RecurringID)
+			assert.Equal(t, tt.occurrence, exception.OccurrenceDate)
+		})
+	}
+}
+
+func TestRecurringService_SetException_OtherUser(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	service := NewRecurringService(mockRepo, new(MockTransactionCreator))
+	rt := &model.RecurringTransaction{ID: uuid.New(), UserID: uuid.New(), Frequency: model.FrequencyMonthly,
+		StartDate: day(2026, 1, 15), NextOccurrence: day(2026, 11, 15)}
+	mockRepo.On("GetByID", mock.Anything, rt.ID).Return(rt, nil)
+
+	_, err := service.SetException(context.Background(), uuid.New(), rt.ID, day(2026, 11, 15), OccurrenceExceptionInput{Skip: true})
+
+	assert.ErrorIs(t, err, ErrRecurringNotFound)
+	mockRepo.AssertNotCalled(t, "SaveException", mock.Anything, mock.Anything)
+}
+
+func TestRecurringService_ProcessDueTransactions_Exceptions(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	mockTxRepo := new(MockTransactionCreator)
+	service := NewRecurringService(mockRepo, mockTxRepo)
+
+	// Four weekly occurrences are due: the first is skipped, the second moved two days
+	// later at a lower amount, the third free this time and the fourth as scheduled.
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	start := today.AddDate(0, 0, -21)
+	rt := model.RecurringTransaction{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		Type:           model.TransactionTypeExpense,
+		Amount:         decimal.NewFromInt(50),
+		Description:    "Gym",
+		Frequency:      model.FrequencyWeekly,
+		StartDate:      start,
+		NextOccurrence: start,
+		IsActive:       true,
+	}
+	moved := start.AddDate(0, 0, 9)
+	reduced := decimal.NewFromInt(40)
+	free := decimal.Zero
+
+	mockRepo.On("GetDueTransactions", mock.Anything, mock.Anything).Return([]model.RecurringTransaction{rt}, nil)
+	mockRepo.On("ListExceptions", mock.Anything, rt.ID).Return([]model.RecurringException{
+		{RecurringID: rt.ID, OccurrenceDate: start, Skip: true},
+		{RecurringID: rt.ID, OccurrenceDate: start.AddDate(0, 0, 7), MovedTo: &moved, Amount: &reduced},
+		{RecurringID: rt.ID, OccurrenceDate: start.AddDate(0, 0, 14), Amount: &free},
+	}, nil)
+	mockRepo.On("Advance", mock.Anything, rt.ID, mock.Anything, start.AddDate(0, 0, 7)).Return(nil).Once()
+	mockRepo.On("Advance", mock.Anything, rt.ID, mock.Anything, today).Return(nil).Once()
+	var generated []*model.Transaction
+	mockTxRepo.On("CreateRecurring", mock.Anything, mock.AnythingOfType("*model.Transaction"), mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		generated = append(generated, args.Get(1).(*model.Transaction))
+	}).Return(true, nil)
+
+	count, err := service.ProcessDueTransactions(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	require.Len(t, generated, 2)
+	assert.Equal(t, moved, generated[0].Date)
+	assert.Equal(t, start.AddDate(0, 0, 7), *generated[0].OccurrenceDate)
+	assert.True(t, reduced.Equal(generated[0].Amount))
+	assert.Equal(t, today, generated[1].Date)
+	assert.True(t, rt.Amount.Equal(generated[1].Amount))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRecurringService_ProcessDueTransactions_MovedLater(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	mockTxRepo := new(MockTransactionCreator)
+	service := NewRecurringService(mockRepo, mockTxRepo)
+
+	// Today's occurrence is moved to tomorrow, so nothing is generated yet.
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	moved := today.AddDate(0, 0, 1)
+	rt := model.RecurringTransaction{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		Amount:         decimal.NewFromInt(50),
+		Frequency:      model.FrequencyWeekly,
+		StartDate:      today,
+		NextOccurrence: today,
+		IsActive:       true,
+	}
+	mockRepo.On("GetDueTransactions", mock.Anything, mock.Anything).Return([]model.RecurringTransaction{rt}, nil)
+	mockRepo.On("ListExceptions", mock.Anything, rt.ID).Return([]model.RecurringException{
+		{RecurringID: rt.ID, OccurrenceDate: today, MovedTo: &moved},
+	}, nil)
+
+	count, err := service.ProcessDueTransactions(context.Background())
+
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	mockTxRepo.AssertNotCalled(t, "CreateRecurring", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Advance", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRecurringService_GetUpcoming_Exceptions(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	service := NewRecurringService(mockRepo, new(MockTransactionCreator))
+	userID := uuid.New()
+	today := dateOf(time.Now())
+
+	// The next occurrence is skipped and the one after moved and re-priced.
+	rt := model.RecurringTransaction{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Amount:         decimal.NewFromInt(50),
+		Description:    "Gym",
+		Frequency:      model.FrequencyWeekly,
+		StartDate:      today.AddDate(0, 0, 1),
+		NextOccurrence: today.AddDate(0, 0, 1),
+		IsActive:       true,
+	}
+	moved := today.AddDate(0, 0, 10)
+	reduced := decimal.NewFromInt(40)
+	mockRepo.On("GetActiveByUserID", mock.Anything, userID).Return([]model.RecurringTransaction{rt}, nil)
+	mockRepo.On("ListExceptionsByUser", mock.Anything, userID).Return([]model.RecurringException{
+		{RecurringID: rt.ID, OccurrenceDate: today.AddDate(0, 0, 1), Skip: true},
+		{RecurringID: rt.ID, OccurrenceDate: today.AddDate(0, 0, 8), MovedTo: &moved, Amount: &reduced},
+	}, nil)
+
+	bills, err := service.GetUpcoming(context.Background(), userID, 5)
+
+	require.NoError(t, err)
+	require.Len(t, bills, 2)
+	assert.True(t, bills[0].Skipped)
+	assert.Equal(t, today.AddDate(0, 0, 1), bills[0].DueDate)
+	assert.False(t, bills[1].Skipped)
+	assert.Equal(t, moved, bills[1].DueDate)
+	require.NotNil(t, bills[1].ScheduledDate)
+	assert.Equal(t, today.AddDate(0, 0, 8), *bills[1].ScheduledDate)
+	assert.True(t, reduced.Equal(bills[1].Amount))
+	require.NotNil(t, bills[1].ScheduledAmount)
+	assert.True(t, rt.Amount.Equal(*bills[1].ScheduledAmount))
+}
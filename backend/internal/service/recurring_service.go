@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/pkg/holiday"
+	"github.com/wealthpath/backend/pkg/rrule"
 )
 
 // Service-level errors for recurring transactions.
@@ -16,9 +20,17 @@ var (
 	ErrInvalidAmount     = errors.New("amount must be greater than zero")
 	ErrInvalidType       = errors.New("type must be 'income' or 'expense'")
 	ErrInvalidFrequency  = errors.New("invalid frequency")
+	ErrInvalidRRule      = errors.New("rrule must be an RFC 5545 rule with FREQ DAILY, WEEKLY, MONTHLY or YEARLY")
+	ErrNoOccurrences     = errors.New("the schedule has no occurrences from the start date")
 	ErrRecurringNotFound = errors.New("recurring transaction not found")
+	ErrInvalidAnchorDay  = errors.New("anchorDay must be from 1 to 31")
+	ErrInvalidPolicy     = errors.New("businessDayPolicy must be 'keep', 'previous' or 'next'")
 )
 
+// businessCalendar is the public holiday calendar business-day policies move
+// occurrences by.
+var businessCalendar holiday.Calendar = holiday.Vietnam
+
 // RecurringRepositoryInterface defines the contract for recurring transaction data access.
 // Implementations must be safe for concurrent use.
 type RecurringRepositoryInterface interface {
@@ -27,20 +39,39 @@ type RecurringRepositoryInterface interface {
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]model.RecurringTransaction, error)
 	Update(ctx context.Context, rt *model.RecurringTransaction) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	GetUpcoming(ctx context.Context, userID uuid.UUID, limit int) ([]model.UpcomingBill, error)
+	GetActiveByUserID(ctx context.Context, userID uuid.UUID) ([]model.RecurringTransaction, error)
 	GetDueTransactions(ctx context.Context, now time.Time) ([]model.RecurringTransaction, error)
-	UpdateLastGenerated(ctx context.Context, id uuid.UUID, lastGenerated, nextOccurrence time.Time) error
+	Advance(ctx context.Context, id uuid.UUID, lastGenerated, nextOccurrence time.Time) error
+	Deactivate(ctx context.Context, id uuid.UUID, nextOccurrence time.Time) error
+	SaveException(ctx context.Context, e *model.RecurringException) error
+	DeleteException(ctx context.Context, recurringID uuid.UUID, occurrence time.Time) error
+	ListExceptions(ctx context.Context, recurringID uuid.UUID) ([]model.RecurringException, error)
+	ListExceptionsByUser(ctx context.Context, userID uuid.UUID) ([]model.RecurringException, error)
 }
 
-// TransactionCreator provides transaction creation capability for recurring processing.
+// TransactionCreator stores the transactions generated by recurring transactions.
+// Implementations must be safe for concurrent use.
 type TransactionCreator interface {
-	Create(ctx context.Context, tx *model.Transaction) error
+	CreateRecurring(ctx context.Context, tx *model.Transaction, transfer *model.RecurringTransfer, lastGenerated, nextOccurrence time.Time) (bool, error)
+	ListByRecurringID(ctx context.Context, recurringID uuid.UUID) ([]model.Transaction, error)
+}
+
+// InstallmentReminder lists the instalments of installment plans that are still to be paid.
+// Implementations must be safe for concurrent use.
+type InstallmentReminder interface {
+	UpcomingInstallments(ctx context.Context, userID uuid.UUID, limit int) ([]model.UpcomingBill, error)
 }
 
 // RecurringService handles business logic for recurring transactions and bill scheduling.
 type RecurringService struct {
 	recurringRepo   RecurringRepositoryInterface
 	transactionRepo TransactionCreator
+	createdHooks    []TransactionCreatedHook
+	installments    InstallmentReminder
+	history         TransactionHistory
+	drafts          RecurringDraftStore
+	goals           GoalContributor
+	debts           DebtPayer
 }
 
 // NewRecurringService creates a new RecurringService with the given repositories.
@@ -51,31 +82,56 @@ func NewRecurringService(recurringRepo RecurringRepositoryInterface, transaction
 	}
 }
 
+// AddCreatedHook registers a hook that runs after each generated transaction.
+func (s *RecurringService) AddCreatedHook(hook TransactionCreatedHook) {
+	s.createdHooks = append(s.createdHooks, hook)
+}
+
+// SetInstallmentReminder adds the instalments of installment plans to upcoming bills.
+func (s *RecurringService) SetInstallmentReminder(installments InstallmentReminder) {
+	s.installments = installments
+}
+
 type CreateRecurringInput struct {
-	Type        model.TransactionType    `json:"type"`
-	Amount      decimal.Decimal          `json:"amount"`
-	Currency    string                   `json:"currency"`
-	Category    string                   `json:"category"`
-	Description string                   `json:"description"`
-	Frequency   model.RecurringFrequency `json:"frequency"`
-	StartDate   time.Time                `json:"startDate"`
-	EndDate     *time.Time               `json:"endDate"`
+	Type              model.TransactionType     `json:"type"`
+	Amount            decimal.Decimal           `json:"amount"`
+	Currency          string                    `json:"currency"`
+	Category          string                    `json:"category"`
+	Description       string                    `json:"description"`
+	Frequency         model.RecurringFrequency  `json:"frequency"`
+	RRule             *string                   `json:"rrule,omitempty"` // e.g. FREQ=MONTHLY;BYMONTHDAY=15,-1; overrides frequency
+	StartDate         time.Time                 `json:"startDate"`
+	EndDate           *time.Time                `json:"endDate"`
+	AmountType        model.RecurringAmountType `json:"amountType"`        // estimated or average for bills that vary; defaults to fixed
+	AnchorDay         *int                      `json:"anchorDay"`         // day of month of monthly and yearly frequencies, e.g. 31 for month ends; defaults to the start date's
+	BusinessDayPolicy model.BusinessDayPolicy   `json:"businessDayPolicy"` // previous or next moves weekend and Vietnamese holiday occurrences to a working day; defaults to keep
+	GoalID            *uuid.UUID                `json:"goalId"`            // savings goal each occurrence contributes to
+	DebtID            *uuid.UUID                `json:"debtId"`            // debt each occurrence is a payment of
 }
 
 type UpdateRecurringInput struct {
-	Type        *model.TransactionType    `json:"type"`
-	Amount      *decimal.Decimal          `json:"amount"`
-	Currency    *string                   `json:"currency"`
-	Category    *string                   `json:"category"`
-	Description *string                   `json:"description"`
-	Frequency   *model.RecurringFrequency `json:"frequency"`
-	StartDate   *time.Time                `json:"startDate"`
-	EndDate     *time.Time                `json:"endDate"`
-	IsActive    *bool                     `json:"isActive"`
+	Type              *model.TransactionType     `json:"type"`
+	Amount            *decimal.Decimal           `json:"amount"`
+	Currency          *string                    `json:"currency"`
+	Category          *string                    `json:"category"`
+	Description       *string                    `json:"description"`
+	Frequency         *model.RecurringFrequency  `json:"frequency"`
+	RRule             *string                    `json:"rrule"` // an empty rule goes back to frequency
+	StartDate         *time.Time                 `json:"startDate"`
+	EndDate           *time.Time                 `json:"endDate"`
+	IsActive          *bool                      `json:"isActive"`
+	AmountType        *model.RecurringAmountType `json:"amountType"`
+	AnchorDay         *int                       `json:"anchorDay"` // zero goes back to the start date's day
+	BusinessDayPolicy *model.BusinessDayPolicy   `json:"businessDayPolicy"`
+	GoalID            *uuid.UUID                 `json:"goalId"` // the nil UUID removes the target
+	DebtID            *uuid.UUID                 `json:"debtId"` // the nil UUID removes the target
 }
 
 // Create creates a new recurring transaction for the given user.
-// Validates amount, type, and frequency before creation.
+// Validates amount, type, and frequency or rrule before creation. The first occurrence
+// is the first date on or after the start date that the schedule matches, moved by the
+// business-day policy. An expense can target a savings goal or a debt in its currency,
+// so each occurrence also contributes to the goal or pays the debt.
 func (s *RecurringService) Create(ctx context.Context, userID uuid.UUID, input CreateRecurringInput) (*model.RecurringTransaction, error) {
 	if input.Amount.LessThanOrEqual(decimal.Zero) {
 		return nil, ErrInvalidAmount
@@ -85,24 +141,51 @@ func (s *RecurringService) Create(ctx context.Context, userID uuid.UUID, input C
 		return nil, ErrInvalidType
 	}
 
-	if !isValidFrequency(input.Frequency) {
-		return nil, ErrInvalidFrequency
+	if input.AmountType == "" {
+		input.AmountType = model.AmountFixed
+	}
+	if !isValidAmountType(input.AmountType) {
+		return nil, ErrInvalidAmountType
+	}
+	if input.BusinessDayPolicy == "" {
+		input.BusinessDayPolicy = model.BusinessDayKeep
+	}
+	if !isValidBusinessDayPolicy(input.BusinessDayPolicy) {
+		return nil, ErrInvalidPolicy
+	}
+	if input.AnchorDay != nil && (*input.AnchorDay < 1 || *input.AnchorDay > 31) {
+		return nil, ErrInvalidAnchorDay
 	}
 
 	rt := &model.RecurringTransaction{
-		UserID:         userID,
-		Type:           input.Type,
-		Amount:         input.Amount,
-		Currency:       input.Currency,
-		Category:       input.Category,
-		Description:    input.Description,
-		Frequency:      input.Frequency,
-		StartDate:      input.StartDate,
-		EndDate:        input.EndDate,
-		NextOccurrence: input.StartDate,
-		IsActive:       true,
+		UserID:            userID,
+		Type:              input.Type,
+		Amount:            input.Amount,
+		Currency:          input.Currency,
+		Category:          input.Category,
+		Description:       input.Description,
+		Frequency:         input.Frequency,
+		StartDate:         input.StartDate,
+		EndDate:           input.EndDate,
+		AmountType:        input.AmountType,
+		AnchorDay:         input.AnchorDay,
+		BusinessDayPolicy: input.BusinessDayPolicy,
+		GoalID:            input.GoalID,
+		DebtID:            input.DebtID,
+		IsActive:          true,
+	}
+	if err := applyRecurrence(rt, input.RRule); err != nil {
+		return nil, err
+	}
+	first, ok := firstOccurrence(rt)
+	if !ok {
+		return nil, ErrNoOccurrences
 	}
+	rt.NextOccurrence = first
 
+	if err := s.validateTarget(ctx, rt); err != nil {
+		return nil, err
+	}
 	if rt.Currency == "" {
 		rt.Currency = "USD"
 	}
@@ -135,7 +218,10 @@ func (s *RecurringService) GetByID(ctx context.Context, userID, id uuid.UUID) (*
 	return rt, nil
 }
 
-// Update modifies an existing recurring transaction.
+// Update modifies an existing recurring transaction. Changes apply to the transactions it
+// generates from now on; those already generated are not changed. A changed schedule,
+// or a resumed one, continues from today rather than from its start date, so missed
+// occurrences are not generated.
 // Returns ErrRecurringNotFound if the transaction does not exist or belongs to another user.
 func (s *RecurringService) Update(ctx context.Context, userID, id uuid.UUID, input UpdateRecurringInput) (*model.RecurringTransaction, error) {
 	rt, err := s.recurringRepo.GetByID(ctx, id)
@@ -147,6 +233,9 @@ func (s *RecurringService) Update(ctx context.Context, userID, id uuid.UUID, inp
 	}
 
 	if input.Type != nil {
+		if *input.Type != model.TransactionTypeIncome && *input.Type != model.TransactionTypeExpense {
+			return nil, ErrInvalidType
+		}
 		rt.Type = *input.Type
 	}
 	if input.Amount != nil {
@@ -164,23 +253,65 @@ func (s *RecurringService) Update(ctx context.Context, userID, id uuid.UUID, inp
 	if input.Description != nil {
 		rt.Description = *input.Description
 	}
-	if input.Frequency != nil {
-		if !isValidFrequency(*input.Frequency) {
-			return nil, ErrInvalidFrequency
+	if input.Frequency != nil || input.RRule != nil {
+		rule := rt.RRule
+		if input.Frequency != nil {
+			rt.Frequency = *input.Frequency
+			rule = nil
+		}
+		if input.RRule != nil {
+			rule = input.RRule
+		}
+		if err := applyRecurrence(rt, rule); err != nil {
+			return nil, err
 		}
-		rt.Frequency = *input.Frequency
-		rt.NextOccurrence = calculateNextOccurrence(rt.StartDate, *input.Frequency)
 	}
 	if input.StartDate != nil {
 		rt.StartDate = *input.StartDate
-		rt.NextOccurrence = calculateNextOccurrence(*input.StartDate, rt.Frequency)
+	}
+	if input.AnchorDay != nil {
+		switch {
+		case *input.AnchorDay == 0:
+			rt.AnchorDay = nil
+		case *input.AnchorDay < 1 || *input.AnchorDay > 31:
+			return nil, ErrInvalidAnchorDay
+		default:
+			rt.AnchorDay = input.AnchorDay
+		}
+	}
+	if input.BusinessDayPolicy != nil {
+		if !isValidBusinessDayPolicy(*input.BusinessDayPolicy) {
+			return nil, ErrInvalidPolicy
+		}
+		rt.BusinessDayPolicy = *input.BusinessDayPolicy
 	}
 	if input.EndDate != nil {
 		rt.EndDate = input.EndDate
 	}
+	resumed := input.IsActive != nil && *input.IsActive && !rt.IsActive
 	if input.IsActive != nil {
 		rt.IsActive = *input.IsActive
 	}
+	if input.Frequency != nil || input.RRule != nil || input.StartDate != nil ||
+		input.AnchorDay != nil || input.BusinessDayPolicy != nil || resumed {
+		next, ok := nextOccurrence(rt, rescheduleFrom(rt, time.Now()), true)
+		if !ok {
+			return nil, ErrNoOccurrences
+		}
+		rt.NextOccurrence = next
+	}
+	if input.AmountType != nil {
+		if !isValidAmountType(*input.AmountType) {
+			return nil, ErrInvalidAmountType
+		}
+		rt.AmountType = *input.AmountType
+	}
+	if input.GoalID != nil || input.DebtID != nil || input.Type != nil || input.Currency != nil {
+		applyTargetInput(rt, input.GoalID, input.DebtID)
+		if err := s.validateTarget(ctx, rt); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := s.recurringRepo.Update(ctx, rt); err != nil {
 		return nil, fmt.Errorf("updating recurring transaction %s: %w", id, err)
@@ -189,6 +320,21 @@ func (s *RecurringService) Update(ctx context.Context, userID, id uuid.UUID, inp
 	return rt, nil
 }
 
+// rescheduleFrom returns the date a changed schedule resumes from: its start date, or
+// today once it has started, so that edits apply to future occurrences only and the
+// transactions already generated are left as they are. It is the day after the last
+// generation when that was today, so no day is generated twice.
+func rescheduleFrom(rt *model.RecurringTransaction, now time.Time) time.Time {
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, rt.StartDate.Location())
+	if rt.LastGenerated != nil && !dateOf(*rt.LastGenerated).Before(dateOf(from)) {
+		from = from.AddDate(0, 0, 1)
+	}
+	if rt.StartDate.After(from) {
+		return rt.StartDate
+	}
+	return from
+}
+
 // Delete removes a recurring transaction by ID for the given user.
 func (s *RecurringService) Delete(ctx context.Context, userID, id uuid.UUID) error {
 	rt, err := s.recurringRepo.GetByID(ctx, id)
@@ -216,20 +362,99 @@ func (s *RecurringService) Resume(ctx context.Context, userID, id uuid.UUID) (*m
 	return s.Update(ctx, userID, id, UpdateRecurringInput{IsActive: &isActive})
 }
 
-// GetUpcoming retrieves upcoming bills for a user, limited to the specified count.
+// GetUpcoming retrieves upcoming bills for a user, soonest first, limited to the specified
+// count. Each active recurring transaction gives its next occurrence from today, moved or
+// re-priced by its exception, preceded by the occurrences skipped before it so the skips
+// are visible. Unpaid instalments of installment plans are included when a reminder is set.
 func (s *RecurringService) GetUpcoming(ctx context.Context, userID uuid.UUID, limit int) ([]model.UpcomingBill, error) {
 	if limit <= 0 {
 		limit = 5
 	}
-	bills, err := s.recurringRepo.GetUpcoming(ctx, userID, limit)
+	templates, err := s.recurringRepo.GetActiveByUserID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("getting upcoming bills for user %s: %w", userID, err)
 	}
+	exceptions, err := s.recurringRepo.ListExceptionsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("getting occurrence exceptions for user %s: %w", userID, err)
+	}
+	byOccurrence := exceptionsByOccurrence(exceptions)
+	today := dateOf(time.Now())
+
+	var bills []model.UpcomingBill
+	for i := range templates {
+		bills = append(bills, upcomingBills(&templates[i], byOccurrence[templates[i].ID], today)...)
+	}
+	if s.installments != nil {
+		installments, err := s.installments.UpcomingInstallments(ctx, userID, limit)
+		if err != nil {
+			return nil, fmt.Errorf("getting upcoming instalments for user %s: %w", userID, err)
+		}
+		bills = append(bills, installments...)
+	}
+
+	sort.SliceStable(bills, func(i, j int) bool { return bills[i].DueDate.Before(bills[j].DueDate) })
+	if len(bills) > limit {
+		bills = bills[:limit]
+	}
+	if bills == nil {
+		bills = []model.UpcomingBill{}
+	}
 	return bills, nil
 }
 
-// ProcessDueTransactions generates transactions for all due recurring items.
-// This should be called by a cron job. Returns the count of processed items.
+// upcomingBills returns the next bill of a recurring transaction due from today, preceded
+// by the occurrences its exceptions skip before it.
+func upcomingBills(rt *model.RecurringTransaction, exceptions map[time.Time]*model.RecurringException, today time.Time) []model.UpcomingBill {
+	var bills []model.UpcomingBill
+	occurrence := rt.NextOccurrence
+	for i := 0; i < maxOccurrenceSteps; i++ {
+		if rt.EndDate != nil && occurrence.After(*rt.EndDate) {
+			break
+		}
+		plan := planOccurrence(occurrence, rt.Amount, exceptions[dateOf(occurrence)])
+		if !dateOf(plan.date).Before(today) {
+			bill := model.UpcomingBill{
+				ID:          rt.ID,
+				Description: rt.Description,
+				Amount:      plan.amount,
+				Currency:    rt.Currency,
+				Category:    rt.Category,
+				DueDate:     plan.date,
+				Type:        rt.Type,
+				Skipped:     plan.skipped,
+			}
+			if !plan.date.Equal(occurrence) {
+				scheduled := occurrence
+				bill.ScheduledDate = &scheduled
+			}
+			if !plan.amount.Equal(rt.Amount) {
+				scheduled := rt.Amount
+				bill.ScheduledAmount = &scheduled
+			}
+			bills = append(bills, bill)
+			if !plan.skipped {
+				break
+			}
+		}
+
+		next, ok := nextOccurrence(rt, occurrence, false)
+		if !ok {
+			break
+		}
+		occurrence = next
+	}
+	return bills
+}
+
+// ProcessDueTransactions generates the transactions of all due recurring items,
+// catching up on every occurrence missed since the last run up to now and stopping at
+// the end date. Variable-amount items generate drafts awaiting confirmation instead.
+// Occurrence exceptions apply: skipped occurrences are passed over, and moved or
+// re-priced ones are generated on their new day at their new amount.
+// Each occurrence is generated at most once, so a retried run does not duplicate
+// transactions. It is run by the scheduler. Returns the count of generated transactions
+// and drafts, and the errors of the items that failed; the other items are still processed.
 func (s *RecurringService) ProcessDueTransactions(ctx context.Context) (int, error) {
 	now := time.Now()
 	dueItems, err := s.recurringRepo.GetDueTransactions(ctx, now)
@@ -238,31 +463,148 @@ func (s *RecurringService) ProcessDueTransactions(ctx context.Context) (int, err
 	}
 
 	count := 0
+	var errs []error
 	for _, rt := range dueItems {
-		tx := &model.Transaction{
-			UserID:      rt.UserID,
-			Type:        rt.Type,
-			Amount:      rt.Amount,
-			Currency:    rt.Currency,
-			Category:    rt.Category,
-			Description: rt.Description + " (recurring)",
-			Date:        rt.NextOccurrence,
+		amount := rt.Amount
+		if rt.IsVariable() {
+			if amount, err = s.estimatedAmount(ctx, &rt); err != nil {
+				log.Printf("Estimating recurring transaction %s failed: %v", rt.ID, err)
+				errs = append(errs, fmt.Errorf("estimating recurring transaction %s: %w", rt.ID, err))
+				continue
+			}
 		}
 
-		if err := s.transactionRepo.Create(ctx, tx); err != nil {
-			continue // Log error but continue processing
+		exceptions, err := s.recurringRepo.ListExceptions(ctx, rt.ID)
+		if err != nil {
+			log.Printf("Listing exceptions of recurring transaction %s failed: %v", rt.ID, err)
+			errs = append(errs, fmt.Errorf("listing exceptions of recurring transaction %s: %w", rt.ID, err))
+			continue
+		}
+		byOccurrence := exceptionsByOccurrence(exceptions)[rt.ID]
+
+		occurrence := rt.NextOccurrence
+		ended := false
+		for {
+			if rt.EndDate != nil && occurrence.After(*rt.EndDate) {
+				ended = true
+				break
+			}
+			plan := planOccurrence(occurrence, amount, byOccurrence[dateOf(occurrence)])
+			if plan.date.After(now) {
+				break
+			}
+
+			next, ok := nextOccurrence(&rt, occurrence, false)
+			if !ok {
+				// The series has ended: keep the last occurrence and stop generating.
+				next = occurrence
+			}
+
+			created := false
+			if plan.skipped {
+				err = s.recurringRepo.Advance(ctx, rt.ID, now, next)
+			} else {
+				created, err = s.generateOccurrence(ctx, &rt, plan.amount, occurrence, plan.date, now, next)
+			}
+			if errors.Is(err, ErrDebtPaidOff) {
+				// Nothing is left to pay: end the series at this occurrence.
+				log.Printf("Recurring transaction %s ends: debt %s is paid off", rt.ID, *rt.DebtID)
+				ended = true
+				break
+			}
+			if err != nil {
+				// Retry from this occurrence on the next run.
+				log.Printf("Generating recurring transaction %s for %s failed: %v", rt.ID, occurrence.Format("2006-01-02"), err)
+				errs = append(errs, fmt.Errorf("generating recurring transaction %s for %s: %w", rt.ID, occurrence.Format("2006-01-02"), err))
+				break
+			}
+			if created {
+				count++
+			}
+
+			if !ok {
+				ended = true
+				break
+			}
+			occurrence = next
 		}
 
-		nextOccurrence := calculateNextOccurrence(rt.NextOccurrence, rt.Frequency)
-
-		if err := s.recurringRepo.UpdateLastGenerated(ctx, rt.ID, now, nextOccurrence); err != nil {
-			continue
+		if ended {
+			// rt was read before generating, so only the fields ending the series are
+			// written back, leaving edits made since alone.
+			if err := s.recurringRepo.Deactivate(ctx, rt.ID, occurrence); err != nil {
+				log.Printf("Deactivating ended recurring transaction %s failed: %v", rt.ID, err)
+				errs = append(errs, fmt.Errorf("deactivating ended recurring transaction %s: %w", rt.ID, err))
+			}
 		}
+	}
 
-		count++
+	return count, errors.Join(errs...)
+}
+
+// generateOccurrence generates an occurrence of a recurring transaction on a day at an
+// amount and moves it on to the next occurrence: a draft for variable-amount items when
+// drafts are stored, and otherwise a transaction recorded with the payment into its
+// savings goal or debt, followed by the created hooks.
+func (s *RecurringService) generateOccurrence(ctx context.Context, rt *model.RecurringTransaction, amount decimal.Decimal, occurrence, date, now, next time.Time) (bool, error) {
+	if rt.IsVariable() && s.drafts != nil {
+		return s.drafts.Create(ctx, &model.RecurringDraft{
+			RecurringID:    rt.ID,
+			UserID:         rt.UserID,
+			Type:           rt.Type,
+			Amount:         amount,
+			Currency:       rt.Currency,
+			Category:       rt.Category,
+			Description:    rt.Description,
+			OccurrenceDate: occurrence,
+			DueDate:        date,
+		}, now, next)
 	}
 
-	return count, nil
+	occurrenceDate := occurrence
+	tx := &model.Transaction{
+		UserID:         rt.UserID,
+		Type:           rt.Type,
+		Amount:         amount,
+		Currency:       rt.Currency,
+		Category:       rt.Category,
+		Description:    rt.Description + " (recurring)",
+		Date:           date,
+		RecurringID:    &rt.ID,
+		OccurrenceDate: &occurrenceDate,
+	}
+	transfer, err := s.transferOf(ctx, rt, amount, date)
+	if err != nil {
+		return false, err
+	}
+	created, err := s.transactionRepo.CreateRecurring(ctx, tx, transfer, now, next)
+	if err != nil || !created {
+		return false, err
+	}
+	s.runCreatedHooks(ctx, tx)
+	return true, nil
+}
+
+// runCreatedHooks runs the created hooks on a transaction, logging their failures.
+func (s *RecurringService) runCreatedHooks(ctx context.Context, tx *model.Transaction) {
+	for _, hook := range s.createdHooks {
+		if err := hook.OnTransactionCreated(ctx, tx); err != nil {
+			log.Printf("Transaction %s created hook failed: %v", tx.ID, err)
+		}
+	}
+}
+
+// ListTransactions returns the transactions generated by a recurring transaction,
+// newest first. Returns ErrRecurringNotFound if it belongs to another user.
+func (s *RecurringService) ListTransactions(ctx context.Context, userID, id uuid.UUID) ([]model.Transaction, error) {
+	if _, err := s.GetByID(ctx, userID, id); err != nil {
+		return nil, err
+	}
+	transactions, err := s.transactionRepo.ListByRecurringID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("listing transactions of recurring transaction %s: %w", id, err)
+	}
+	return transactions, nil
 }
 
 // isValidFrequency checks if the given frequency is a supported value.
@@ -275,20 +617,157 @@ func isValidFrequency(f model.RecurringFrequency) bool {
 	return false
 }
 
-// calculateNextOccurrence computes the next occurrence date based on frequency.
-func calculateNextOccurrence(from time.Time, frequency model.RecurringFrequency) time.Time {
-	switch frequency {
-	case model.FrequencyDaily:
-		return from.AddDate(0, 0, 1)
-	case model.FrequencyWeekly:
-		return from.AddDate(0, 0, 7)
-	case model.FrequencyBiweekly:
-		return from.AddDate(0, 0, 14)
-	case model.FrequencyMonthly:
-		return from.AddDate(0, 1, 0)
-	case model.FrequencyYearly:
-		return from.AddDate(1, 0, 0)
+// shorthandRules are the recurrence rules of the frequency shorthands.
+var shorthandRules = map[model.RecurringFrequency]string{
+	model.FrequencyDaily:    "FREQ=DAILY",
+	model.FrequencyWeekly:   "FREQ=WEEKLY",
+	model.FrequencyBiweekly: "FREQ=WEEKLY;INTERVAL=2",
+	model.FrequencyMonthly:  "FREQ=MONTHLY",
+	model.FrequencyYearly:   "FREQ=YEARLY",
+}
+
+// applyRecurrence sets the schedule of a recurring transaction from an RRULE, stored in
+// canonical form with Frequency set to its closest shorthand and an UNTIL date as the
+// end date. Without a rule, Frequency must be a valid shorthand.
+func applyRecurrence(rt *model.RecurringTransaction, rule *string) error {
+	if rule == nil || *rule == "" {
+		if !isValidFrequency(rt.Frequency) {
+			return ErrInvalidFrequency
+		}
+		rt.RRule = nil
+		return nil
+	}
+
+	parsed, err := rrule.Parse(*rule)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRRule, err)
+	}
+	canonical := parsed.String()
+	rt.RRule = &canonical
+	rt.Frequency = shorthandFrequency(parsed)
+	if parsed.Until != nil && rt.EndDate == nil {
+		until := *parsed.Until
+		rt.EndDate = &until
+	}
+	return nil
+}
+
+// shorthandFrequency returns the frequency shorthand closest to a rule.
+func shorthandFrequency(rule *rrule.Rule) model.RecurringFrequency {
+	switch rule.Freq {
+	case rrule.Daily:
+		return model.FrequencyDaily
+	case rrule.Weekly:
+		if rule.Interval == 2 {
+			return model.FrequencyBiweekly
+		}
+		return model.FrequencyWeekly
+	case rrule.Yearly:
+		return model.FrequencyYearly
 	default:
-		return from.AddDate(0, 1, 0) // Default to monthly
+		return model.FrequencyMonthly
+	}
+}
+
+// recurrenceRule returns the rule a recurring transaction repeats by: its RRULE, or the
+// rule of its frequency shorthand. Monthly and yearly shorthands fall on the anchor day,
+// or on the last day of months that are shorter, so a series started on the 31st keeps
+// to month ends.
+func recurrenceRule(rt *model.RecurringTransaction) *rrule.Rule {
+	if rt.RRule != nil {
+		if rule, err := rrule.Parse(*rt.RRule); err == nil {
+			return rule
+		}
 	}
+	rule := frequencyRule(rt.Frequency)
+	switch rule.Freq {
+	case rrule.Monthly:
+		anchorMonthDay(rule, anchorDay(rt))
+	case rrule.Yearly:
+		rule.ByMonth = []time.Month{rt.StartDate.Month()}
+		anchorMonthDay(rule, anchorDay(rt))
+	}
+	return rule
+}
+
+// anchorDay returns the day of month a recurring transaction is anchored to.
+func anchorDay(rt *model.RecurringTransaction) int {
+	if rt.AnchorDay != nil {
+		return *rt.AnchorDay
+	}
+	return rt.StartDate.Day()
+}
+
+// anchorMonthDay makes a rule fall on a day of the month, or on the last day of months
+// that are shorter.
+func anchorMonthDay(rule *rrule.Rule, day int) {
+	if day <= 28 {
+		rule.ByMonthDay = []int{day}
+		return
+	}
+	rule.ByMonthDay = make([]int, 0, day-27)
+	for d := 28; d <= day; d++ {
+		rule.ByMonthDay = append(rule.ByMonthDay, d)
+	}
+	rule.BySetPos = []int{-1}
+}
+
+// frequencyRule returns the rule of a frequency shorthand, monthly when unknown.
+func frequencyRule(frequency model.RecurringFrequency) *rrule.Rule {
+	source, ok := shorthandRules[frequency]
+	if !ok {
+		source = shorthandRules[model.FrequencyMonthly]
+	}
+	rule, _ := rrule.Parse(source)
+	return rule
+}
+
+// nextOccurrence returns the first occurrence of the series after a date, or on it when
+// inclusive, once moved off weekends and public holidays by its business-day policy.
+// It reports false when the series has ended.
+func nextOccurrence(rt *model.RecurringTransaction, after time.Time, inclusive bool) (time.Time, bool) {
+	rule := recurrenceRule(rt)
+	if rt.BusinessDayPolicy != model.BusinessDayPrevious && rt.BusinessDayPolicy != model.BusinessDayNext {
+		return rule.After(rt.StartDate, after, inclusive)
+	}
+
+	// A scheduled day up to holiday.MaxShift days away can move past the date.
+	from := after.AddDate(0, 0, -holiday.MaxShift)
+	for {
+		scheduled, ok := rule.After(rt.StartDate, from, true)
+		if !ok {
+			return time.Time{}, false
+		}
+		day := businessDay(rt.BusinessDayPolicy, scheduled)
+		if day.After(after) || (inclusive && day.Equal(after)) {
+			return day, true
+		}
+		from = scheduled.AddDate(0, 0, 1)
+	}
+}
+
+// firstOccurrence returns the first occurrence of the series, which the business-day
+// policy can move before its start date.
+func firstOccurrence(rt *model.RecurringTransaction) (time.Time, bool) {
+	return nextOccurrence(rt, rt.StartDate.AddDate(0, 0, -holiday.MaxShift), true)
+}
+
+// businessDay moves a scheduled day on a weekend or public holiday by a policy.
+func businessDay(policy model.BusinessDayPolicy, day time.Time) time.Time {
+	switch policy {
+	case model.BusinessDayPrevious:
+		return holiday.Previous(businessCalendar, day)
+	case model.BusinessDayNext:
+		return holiday.Next(businessCalendar, day)
+	}
+	return day
+}
+
+// isValidBusinessDayPolicy checks if the given business-day policy is a supported value.
+func isValidBusinessDayPolicy(p model.BusinessDayPolicy) bool {
+	switch p {
+	case model.BusinessDayKeep, model.BusinessDayPrevious, model.BusinessDayNext:
+		return true
+	}
+	return false
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/wealthpath/backend/internal/model"
 )
 
@@ -52,12 +53,12 @@ func (m *MockRecurringRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockRecurringRepo) GetUpcoming(ctx context.Context, userID uuid.UUID, limit int) ([]model.UpcomingBill, error) {
-	args := m.Called(ctx, userID, limit)
+func (m *MockRecurringRepo) GetActiveByUserID(ctx context.Context, userID uuid.UUID) ([]model.RecurringTransaction, error) {
+	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]model.UpcomingBill), args.Error(1)
+	return args.Get(0).([]model.RecurringTransaction), args.Error(1)
 }
 
 func (m *MockRecurringRepo) GetDueTransactions(ctx context.Context, now time.Time) ([]model.RecurringTransaction, error) {
@@ -68,19 +69,71 @@ func (m *MockRecurringRepo) GetDueTransactions(ctx context.Context, now time.Tim
 	return args.Get(0).([]model.RecurringTransaction), args.Error(1)
 }
 
-func (m *MockRecurringRepo) UpdateLastGenerated(ctx context.Context, id uuid.UUID, lastGenerated, nextOccurrence time.Time) error {
+func (m *MockRecurringRepo) Advance(ctx context.Context, id uuid.UUID, lastGenerated, nextOccurrence time.Time) error {
 	args := m.Called(ctx, id, lastGenerated, nextOccurrence)
 	return args.Error(0)
 }
 
+func (m *MockRecurringRepo) Deactivate(ctx context.Context, id uuid.UUID, nextOccurrence time.Time) error {
+	args := m.Called(ctx, id, nextOccurrence)
+	return args.Error(0)
+}
+
+func (m *MockRecurringRepo) SaveException(ctx context.Context, e *model.RecurringException) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+func (m *MockRecurringRepo) DeleteException(ctx context.Context, recurringID uuid.UUID, occurrence time.Time) error {
+	args := m.Called(ctx, recurringID, occurrence)
+	return args.Error(0)
+}
+
+func (m *MockRecurringRepo) ListExceptions(ctx context.Context, recurringID uuid.UUID) ([]model.RecurringException, error) {
+	args := m.Called(ctx, recurringID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.RecurringException), args.Error(1)
+}
+
+func (m *MockRecurringRepo) ListExceptionsByUser(ctx context.Context, userID uuid.UUID) ([]model.RecurringException, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.RecurringException), args.Error(1)
+}
+
+// MockInstallmentReminder implements InstallmentReminder for testing
+type MockInstallmentReminder struct {
+	mock.Mock
+}
+
+func (m *MockInstallmentReminder) UpcomingInstallments(ctx context.Context, userID uuid.UUID, limit int) ([]model.UpcomingBill, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.UpcomingBill), args.Error(1)
+}
+
 // MockTransactionCreator implements TransactionCreator for testing
 type MockTransactionCreator struct {
 	mock.Mock
 }
 
-func (m *MockTransactionCreator) Create(ctx context.Context, tx *model.Transaction) error {
-	args := m.Called(ctx, tx)
-	return args.Error(0)
+func (m *MockTransactionCreator) CreateRecurring(ctx context.Context, tx *model.Transaction, transfer *model.RecurringTransfer, lastGenerated, nextOccurrence time.Time) (bool, error) {
+	args := m.Called(ctx, tx, transfer, lastGenerated, nextOccurrence)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTransactionCreator) ListByRecurringID(ctx context.Context, recurringID uuid.UUID) ([]model.Transaction, error) {
+	args := m.Called(ctx, recurringID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Transaction), args.Error(1)
 }
 
 // Table-driven tests with parallel execution (following Go rules)
@@ -170,6 +223,52 @@ func TestRecurringService_Create(t *testing.T) {
 			wantErr:   true,
 			check:     nil,
 		},
+		{
+			name: "rrule",
+			input: CreateRecurringInput{
+				Type:      model.TransactionTypeIncome,
+				Amount:    decimal.NewFromFloat(100),
+				Category:  "Salary",
+				Frequency: model.FrequencyWeekly,
+				RRule:     strPtr("RRULE:FREQ=MONTHLY;BYMONTHDAY=15,-1;UNTIL=20271231"),
+				StartDate: time.Date(2027, 1, 20, 0, 0, 0, 0, time.UTC),
+			},
+			setupMock: func(m *MockRecurringRepo) {
+				m.On("Create", mock.Anything, mock.AnythingOfType("*model.RecurringTransaction")).Return(nil)
+			},
+			check: func(t *testing.T, rt *model.RecurringTransaction) {
+				require.NotNil(t, rt.RRule)
+				assert.Equal(t, "FREQ=MONTHLY;UNTIL=20271231;BYMONTHDAY=15,-1", *rt.RRule)
+				assert.Equal(t, model.FrequencyMonthly, rt.Frequency)
+				assert.Equal(t, time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC), rt.NextOccurrence)
+				require.NotNil(t, rt.EndDate)
+				assert.Equal(t, time.Date(2027, 12, 31, 0, 0, 0, 0, time.UTC), *rt.EndDate)
+			},
+		},
+		{
+			name: "invalid rrule",
+			input: CreateRecurringInput{
+				Type:      model.TransactionTypeExpense,
+				Amount:    decimal.NewFromFloat(100),
+				Category:  "Utilities",
+				RRule:     strPtr("FREQ=HOURLY"),
+				StartDate: time.Now(),
+			},
+			setupMock: func(m *MockRecurringRepo) {},
+			wantErr:   true,
+		},
+		{
+			name: "rrule without occurrences",
+			input: CreateRecurringInput{
+				Type:      model.TransactionTypeExpense,
+				Amount:    decimal.NewFromFloat(100),
+				Category:  "Utilities",
+				RRule:     strPtr("FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30"),
+				StartDate: time.Now(),
+			},
+			setupMock: func(m *MockRecurringRepo) {},
+			wantErr:   true,
+		},
 		{
 			name: "repository error",
 			input: CreateRecurringInput{
@@ -475,6 +574,22 @@ func TestRecurringService_Update(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid type",
+			input: func() UpdateRecurringInput {
+				txType := model.TransactionType("transfer")
+				return UpdateRecurringInput{Type: &txType}
+			}(),
+			setupMock: func(m *MockRecurringRepo, rtID, userID uuid.UUID) {
+				m.On("GetByID", mock.Anything, rtID).Return(&model.RecurringTransaction{
+					ID:        rtID,
+					UserID:    userID,
+					Type:      model.TransactionTypeExpense,
+					Frequency: model.FrequencyMonthly,
+				}, nil)
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid frequency",
 			input: func() UpdateRecurringInput {
@@ -667,18 +782,25 @@ func TestRecurringService_GetUpcoming(t *testing.T) {
 	mockTxRepo := new(MockTransactionCreator)
 	service := NewRecurringService(mockRepo, mockTxRepo)
 	userID := uuid.New()
+	today := dateOf(time.Now())
 
-	expected := []model.UpcomingBill{
-		{ID: uuid.New(), Description: "Electricity"},
-		{ID: uuid.New(), Description: "Internet"},
+	templates := []model.RecurringTransaction{
+		{ID: uuid.New(), Description: "Internet", Amount: decimal.NewFromInt(300000), Frequency: model.FrequencyMonthly,
+			StartDate: today.AddDate(0, 0, 9), NextOccurrence: today.AddDate(0, 0, 9)},
+		{ID: uuid.New(), Description: "Electricity", Amount: decimal.NewFromInt(800000), Frequency: model.FrequencyMonthly,
+			StartDate: today.AddDate(0, 0, 2), NextOccurrence: today.AddDate(0, 0, 2)},
 	}
 
-	mockRepo.On("GetUpcoming", mock.Anything, userID, 5).Return(expected, nil)
+	mockRepo.On("GetActiveByUserID", mock.Anything, userID).Return(templates, nil)
+	mockRepo.On("ListExceptionsByUser", mock.Anything, userID).Return([]model.RecurringException{}, nil)
 
 	bills, err := service.GetUpcoming(context.Background(), userID, 5)
 
 	assert.NoError(t, err)
 	assert.Len(t, bills, 2)
+	assert.Equal(t, "Electricity", bills[0].Description)
+	assert.Equal(t, today.AddDate(0, 0, 2), bills[0].DueDate)
+	assert.Equal(t, "Internet", bills[1].Description)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -689,13 +811,55 @@ func TestRecurringService_GetUpcoming_DefaultLimit(t *testing.T) {
 	mockTxRepo := new(MockTransactionCreator)
 	service := NewRecurringService(mockRepo, mockTxRepo)
 	userID := uuid.New()
+	today := dateOf(time.Now())
+
+	var templates []model.RecurringTransaction
+	for i := 0; i < 7; i++ {
+		templates = append(templates, model.RecurringTransaction{ID: uuid.New(), Frequency: model.FrequencyMonthly,
+			StartDate: today.AddDate(0, 0, i), NextOccurrence: today.AddDate(0, 0, i)})
+	}
+	mockRepo.On("GetActiveByUserID", mock.Anything, userID).Return(templates, nil)
+	mockRepo.On("ListExceptionsByUser", mock.Anything, userID).Return([]model.RecurringException{}, nil)
+
+	bills, err := service.GetUpcoming(context.Background(), userID, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, bills, 5)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRecurringService_GetUpcoming_Installments(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	mockTxRepo := new(MockTransactionCreator)
+	reminder := new(MockInstallmentReminder)
+	service := NewRecurringService(mockRepo, mockTxRepo)
+	service.SetInstallmentReminder(reminder)
+	userID := uuid.New()
+	today := dateOf(time.Now())
 
-	mockRepo.On("GetUpcoming", mock.Anything, userID, 5).Return([]model.UpcomingBill{}, nil)
+	mockRepo.On("GetActiveByUserID", mock.Anything, userID).Return([]model.RecurringTransaction{
+		{ID: uuid.New(), Description: "Electricity", Frequency: model.FrequencyMonthly,
+			StartDate: today.AddDate(0, 0, 2), NextOccurrence: today.AddDate(0, 0, 2)},
+		{ID: uuid.New(), Description: "Internet", Frequency: model.FrequencyMonthly,
+			StartDate: today.AddDate(0, 0, 9), NextOccurrence: today.AddDate(0, 0, 9)},
+	}, nil)
+	mockRepo.On("ListExceptionsByUser", mock.Anything, userID).Return([]model.RecurringException{}, nil)
+	reminder.On("UpcomingInstallments", mock.Anything, userID, 3).Return([]model.UpcomingBill{
+		{Description: "FPT Shop instalment 3/6", DueDate: today.AddDate(0, 0, 5)},
+		{Description: "FPT Shop instalment 4/6", DueDate: today.AddDate(0, 1, 5)},
+	}, nil)
 
-	_, err := service.GetUpcoming(context.Background(), userID, 0)
+	bills, err := service.GetUpcoming(context.Background(), userID, 3)
 
 	assert.NoError(t, err)
+	assert.Len(t, bills, 3)
+	assert.Equal(t, "Electricity", bills[0].Description)
+	assert.Equal(t, "FPT Shop instalment 3/6", bills[1].Description)
+	assert.Equal(t, "Internet", bills[2].Description)
 	mockRepo.AssertExpectations(t)
+	reminder.AssertExpectations(t)
 }
 
 func TestRecurringService_ProcessDueTransactions(t *testing.T) {
@@ -720,8 +884,10 @@ func TestRecurringService_ProcessDueTransactions(t *testing.T) {
 	}
 
 	mockRepo.On("GetDueTransactions", mock.Anything, mock.Anything).Return(dueItems, nil)
-	mockTxRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Transaction")).Return(nil)
-	mockRepo.On("UpdateLastGenerated", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("ListExceptions", mock.Anything, mock.Anything).Return([]model.RecurringException{}, nil)
+	mockTxRepo.On("CreateRecurring", mock.Anything, mock.MatchedBy(func(tx *model.Transaction) bool {
+		return *tx.RecurringID == dueItems[0].ID && tx.OccurrenceDate.Equal(dueItems[0].NextOccurrence)
+	}), mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
 
 	count, err := service.ProcessDueTransactions(context.Background())
 
@@ -774,7 +940,7 @@ func TestIsValidFrequency(t *testing.T) {
 	}
 }
 
-func TestCalculateNextOccurrence(t *testing.T) {
+func TestNextOccurrence_Shorthands(t *testing.T) {
 	t.Parallel()
 
 	baseDate := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
@@ -794,18 +960,544 @@ func TestCalculateNextOccurrence(t *testing.T) {
 		tt := tt
 		t.Run(string(tt.frequency), func(t *testing.T) {
 			t.Parallel()
-			result := calculateNextOccurrence(baseDate, tt.frequency)
+			rt := &model.RecurringTransaction{Frequency: tt.frequency, StartDate: baseDate}
+			result, ok := nextOccurrence(rt, baseDate, false)
+			assert.True(t, ok)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
 
-func TestCalculateNextOccurrence_UnknownFrequency(t *testing.T) {
+func TestNextOccurrence_UnknownFrequency(t *testing.T) {
 	t.Parallel()
 
 	baseDate := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
-	result := calculateNextOccurrence(baseDate, model.RecurringFrequency("unknown"))
+	rt := &model.RecurringTransaction{Frequency: model.RecurringFrequency("unknown"), StartDate: baseDate}
+	result, ok := nextOccurrence(rt, baseDate, false)
 
 	// Unknown frequency defaults to monthly
-	assert.True(t, result.After(baseDate))
+	assert.True(t, ok)
+	assert.Equal(t, baseDate.AddDate(0, 1, 0), result)
+}
+
+func TestNextOccurrence_RRule(t *testing.T) {
+	t.Parallel()
+
+	rt := &model.RecurringTransaction{
+		Frequency: model.FrequencyMonthly,
+		RRule:     strPtr("FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1"),
+		StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	result, ok := nextOccurrence(rt, time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC), false)
+
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, 11, 30, 0, 0, 0, 0, time.UTC), result)
+}
+
+func TestRecurringService_Update_RRule(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2027, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		existingRule  *string
+		input         UpdateRecurringInput
+		wantRule      *string
+		wantFrequency model.RecurringFrequency
+		wantNext      time.Time
+		wantErr       error
+	}{
+		{
+			name:          "set rule",
+			input:         UpdateRecurringInput{RRule: strPtr("FREQ=MONTHLY;BYMONTHDAY=15,-1")},
+			wantRule:      strPtr("FREQ=MONTHLY;BYMONTHDAY=15,-1"),
+			wantFrequency: model.FrequencyMonthly,
+			wantNext:      time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "every other week",
+			input:         UpdateRecurringInput{RRule: strPtr("FREQ=WEEKLY;INTERVAL=2")},
+			wantRule:      strPtr("FREQ=WEEKLY;INTERVAL=2"),
+			wantFrequency: model.FrequencyBiweekly,
+			wantNext:      start,
+		},
+		{
+			name:          "frequency replaces rule",
+			existingRule:  strPtr("FREQ=MONTHLY;INTERVAL=3"),
+			input:         UpdateRecurringInput{Frequency: func() *model.RecurringFrequency { f := model.FrequencyWeekly; return &f }()},
+			wantFrequency: model.FrequencyWeekly,
+			wantNext:      start,
+		},
+		{
+			name:          "empty rule clears it",
+			existingRule:  strPtr("FREQ=MONTHLY;INTERVAL=3"),
+			input:         UpdateRecurringInput{RRule: strPtr("")},
+			wantFrequency: model.FrequencyMonthly,
+			wantNext:      start,
+		},
+		{
+			name:    "invalid rule",
+			input:   UpdateRecurringInput{RRule: strPtr("FREQ=MONTHLY;BYDAY=9MO")},
+			wantErr: ErrInvalidRRule,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockRecurringRepo)
+			service := NewRecurringService(mockRepo, new(MockTransactionCreator))
+			rtID := uuid.New()
+			userID := uuid.New()
+
+			mockRepo.On("GetByID", mock.Anything, rtID).Return(&model.RecurringTransaction{
+				ID:        rtID,
+				UserID:    userID,
+				Frequency: model.FrequencyMonthly,
+				RRule:     tt.existingRule,
+				StartDate: start,
+			}, nil)
+			mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*model.RecurringTransaction")).Return(nil).Maybe()
+
+			rt, err := service.Update(context.Background(), userID, rtID, tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRule, rt.RRule)
+			assert.Equal(t, tt.wantFrequency, rt.Frequency)
+			assert.Equal(t, tt.wantNext, rt.NextOccurrence)
+		})
+	}
+}
+
+func TestRecurringService_ProcessDueTransactions_SeriesEnded(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	mockTxRepo := new(MockTransactionCreator)
+	service := NewRecurringService(mockRepo, mockTxRepo)
+
+	last := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	rt := model.RecurringTransaction{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		Type:           model.TransactionTypeExpense,
+		Amount:         decimal.NewFromFloat(100),
+		Frequency:      model.FrequencyMonthly,
+		RRule:          strPtr("FREQ=MONTHLY;COUNT=3"),
+		StartDate:      time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC),
+		NextOccurrence: last,
+		IsActive:       true,
+	}
+
+	mockRepo.On("GetDueTransactions", mock.Anything, mock.Anything).Return([]model.RecurringTransaction{rt}, nil)
+	mockRepo.On("ListExceptions", mock.Anything, mock.Anything).Return([]model.RecurringException{}, nil)
+	mockTxRepo.On("CreateRecurring", mock.Anything, mock.AnythingOfType("*model.Transaction"), mock.Anything, mock.Anything, last).Return(true, nil)
+	mockRepo.On("Deactivate", mock.Anything, rt.ID, last).Return(nil)
+
+	count, err := service.ProcessDueTransactions(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRecurringService_ProcessDueTransactions_CatchUp(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	mockTxRepo := new(MockTransactionCreator)
+	service := NewRecurringService(mockRepo, mockTxRepo)
+
+	// Missed for three weeks: every missed week is generated and the next one is scheduled.
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	start := today.AddDate(0, 0, -21)
+	rt := model.RecurringTransaction{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		Type:           model.TransactionTypeExpense,
+		Amount:         decimal.NewFromFloat(50),
+		Description:    "Gym",
+		Frequency:      model.FrequencyWeekly,
+		StartDate:      start,
+		NextOccurrence: start,
+		IsActive:       true,
+	}
+
+	var dates []time.Time
+	mockRepo.On("GetDueTransactions", mock.Anything, mock.Anything).Return([]model.RecurringTransaction{rt}, nil)
+	mockRepo.On("ListExceptions", mock.Anything, mock.Anything).Return([]model.RecurringException{}, nil)
+	var nexts []time.Time
+	mockTxRepo.On("CreateRecurring", mock.Anything, mock.AnythingOfType("*model.Transaction"), mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		dates = append(dates, args.Get(1).(*model.Transaction).Date)
+		nexts = append(nexts, args.Get(4).(time.Time))
+	}).Return(true, nil)
+
+	count, err := service.ProcessDueTransactions(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+	assert.Equal(t, []time.Time{start, start.AddDate(0, 0, 7), start.AddDate(0, 0, 14), today}, dates)
+	assert.Equal(t, []time.Time{start.AddDate(0, 0, 7), start.AddDate(0, 0, 14), today, today.AddDate(0, 0, 7)}, nexts)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRecurringService_ProcessDueTransactions_CatchUpUntilEndDate(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	mockTxRepo := new(MockTransactionCreator)
+	service := NewRecurringService(mockRepo, mockTxRepo)
+
+	// The end date passed while missed: generate up to it, then deactivate.
+	start := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+	rt := model.RecurringTransaction{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		Type:           model.TransactionTypeExpense,
+		Amount:         decimal.NewFromFloat(100),
+		Frequency:      model.FrequencyMonthly,
+		StartDate:      start,
+		EndDate:        &end,
+		NextOccurrence: start,
+		IsActive:       true,
+	}
+	after := time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC)
+
+	mockRepo.On("GetDueTransactions", mock.Anything, mock.Anything).Return([]model.RecurringTransaction{rt}, nil)
+	mockRepo.On("ListExceptions", mock.Anything, mock.Anything).Return([]model.RecurringException{}, nil)
+	mockTxRepo.On("CreateRecurring", mock.Anything, mock.AnythingOfType("*model.Transaction"), mock.Anything, mock.Anything, mock.Anything).Return(true, nil).Times(3)
+	mockRepo.On("Deactivate", mock.Anything, rt.ID, after).Return(nil)
+
+	count, err := service.ProcessDueTransactions(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	mockRepo.AssertExpectations(t)
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestRecurringService_ProcessDueTransactions_CreateFailsMidway(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	mockTxRepo := new(MockTransactionCreator)
+	service := NewRecurringService(mockRepo, mockTxRepo)
+
+	start := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	rt := model.RecurringTransaction{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		Type:           model.TransactionTypeExpense,
+		Amount:         decimal.NewFromFloat(100),
+		Frequency:      model.FrequencyMonthly,
+		StartDate:      start,
+		NextOccurrence: start,
+		IsActive:       true,
+	}
+
+	// The second occurrence fails, so the next run resumes from it.
+	mockRepo.On("GetDueTransactions", mock.Anything, mock.Anything).Return([]model.RecurringTransaction{rt}, nil)
+	mockRepo.On("ListExceptions", mock.Anything, mock.Anything).Return([]model.RecurringException{}, nil)
+	mockTxRepo.On("CreateRecurring", mock.Anything, mock.AnythingOfType("*model.Transaction"), mock.Anything, mock.Anything, start.AddDate(0, 1, 0)).Return(true, nil).Once()
+	mockTxRepo.On("CreateRecurring", mock.Anything, mock.AnythingOfType("*model.Transaction"), mock.Anything, mock.Anything, start.AddDate(0, 2, 0)).Return(false, errors.New("db error")).Once()
+
+	count, err := service.ProcessDueTransactions(context.Background())
+
+	assert.ErrorContains(t, err, "db error")
+	assert.Equal(t, 1, count)
+	mockRepo.AssertExpectations(t)
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestRecurringService_ProcessDueTransactions_AlreadyGenerated(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	mockTxRepo := new(MockTransactionCreator)
+	hook := new(MockCreatedHook)
+	service := NewRecurringService(mockRepo, mockTxRepo)
+	service.AddCreatedHook(hook)
+
+	// A previous run generated the occurrence but was cut off: it is not generated again.
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	rt := model.RecurringTransaction{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		Type:           model.TransactionTypeExpense,
+		Amount:         decimal.NewFromFloat(100),
+		Frequency:      model.FrequencyMonthly,
+		StartDate:      today,
+		NextOccurrence: today,
+		IsActive:       true,
+	}
+
+	mockRepo.On("GetDueTransactions", mock.Anything, mock.Anything).Return([]model.RecurringTransaction{rt}, nil)
+	mockRepo.On("ListExceptions", mock.Anything, mock.Anything).Return([]model.RecurringException{}, nil)
+	mockTxRepo.On("CreateRecurring", mock.Anything, mock.AnythingOfType("*model.Transaction"), mock.Anything, mock.Anything, today.AddDate(0, 1, 0)).Return(false, nil)
+
+	count, err := service.ProcessDueTransactions(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	mockTxRepo.AssertExpectations(t)
+	hook.AssertNotCalled(t, "OnTransactionCreated", mock.Anything, mock.Anything)
+}
+
+func TestRecurringService_ListTransactions(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	mockTxRepo := new(MockTransactionCreator)
+	service := NewRecurringService(mockRepo, mockTxRepo)
+
+	userID := uuid.New()
+	rt := &model.RecurringTransaction{ID: uuid.New(), UserID: userID}
+	generated := []model.Transaction{{ID: uuid.New(), UserID: userID, RecurringID: &rt.ID}}
+	mockRepo.On("GetByID", mock.Anything, rt.ID).Return(rt, nil)
+	mockTxRepo.On("ListByRecurringID", mock.Anything, rt.ID).Return(generated, nil)
+
+	transactions, err := service.ListTransactions(context.Background(), userID, rt.ID)
+	require.NoError(t, err)
+	assert.Equal(t, generated, transactions)
+
+	_, err = service.ListTransactions(context.Background(), uuid.New(), rt.ID)
+	assert.ErrorIs(t, err, ErrRecurringNotFound)
+	mockTxRepo.AssertNumberOfCalls(t, "ListByRecurringID", 1)
+}
+
+func TestRecurringService_Update_FutureOnly(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	service := NewRecurringService(mockRepo, new(MockTransactionCreator))
+
+	// Started long ago: the new schedule resumes from today, not from the start date.
+	userID := uuid.New()
+	rt := &model.RecurringTransaction{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Frequency: model.FrequencyWeekly,
+		StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	mockRepo.On("GetByID", mock.Anything, rt.ID).Return(rt, nil)
+	mockRepo.On("Update", mock.Anything, rt).Return(nil)
+
+	monthly := model.FrequencyMonthly
+	updated, err := service.Update(context.Background(), userID, rt.ID, UpdateRecurringInput{Frequency: &monthly})
+	require.NoError(t, err)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	want := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if want.Before(today) {
+		want = want.AddDate(0, 1, 0)
+	}
+	assert.Equal(t, want, updated.NextOccurrence)
+}
+
+func TestNextOccurrence_MonthEndAnchor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		rt    *model.RecurringTransaction
+		after time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "started on the 31st keeps to month ends",
+			rt:    &model.RecurringTransaction{Frequency: model.FrequencyMonthly, StartDate: day(2027, time.January, 31)},
+			after: day(2027, time.January, 31),
+			want:  []time.Time{day(2027, time.February, 28), day(2027, time.March, 31), day(2027, time.April, 30)},
+		},
+		{
+			name: "anchor day later than the start date",
+			rt: &model.RecurringTransaction{
+				Frequency: model.FrequencyMonthly, StartDate: day(2027, time.February, 28), AnchorDay: intPtr(30),
+			},
+			after: day(2027, time.February, 28),
+			want:  []time.Time{day(2027, time.March, 30), day(2027, time.April, 30), day(2027, time.May, 30)},
+		},
+		{
+			name:  "yearly on a leap day",
+			rt:    &model.RecurringTransaction{Frequency: model.FrequencyYearly, StartDate: day(2028, time.February, 29)},
+			after: day(2028, time.February, 29),
+			want:  []time.Time{day(2029, time.February, 28), day(2030, time.February, 28), day(2031, time.February, 28)},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []time.Time
+			after := tt.after
+			for range tt.want {
+				next, ok := nextOccurrence(tt.rt, after, false)
+				require.True(t, ok)
+				got = append(got, next)
+				after = next
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNextOccurrence_BusinessDayPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy model.BusinessDayPolicy
+		start  time.Time
+		after  time.Time
+		want   []time.Time
+	}{
+		{
+			name:   "keep",
+			policy: model.BusinessDayKeep,
+			start:  day(2026, time.January, 31),
+			after:  day(2026, time.September, 30),
+			want:   []time.Time{day(2026, time.October, 31), day(2026, time.November, 30)},
+		},
+		{
+			name:   "previous working day",
+			policy: model.BusinessDayPrevious,
+			start:  day(2026, time.January, 31),
+			after:  day(2026, time.September, 30),
+			want:   []time.Time{day(2026, time.October, 30), day(2026, time.November, 30), day(2026, time.December, 31)},
+		},
+		{
+			name:   "next working day",
+			policy: model.BusinessDayNext,
+			start:  day(2026, time.January, 31),
+			after:  day(2026, time.September, 30),
+			want:   []time.Time{day(2026, time.November, 2), day(2026, time.November, 30)},
+		},
+		{
+			// Tet 2027 with the weekend days off moved after it runs from 5 to 11 February.
+			name:   "next working day after Tet",
+			policy: model.BusinessDayNext,
+			start:  day(2026, time.November, 6),
+			after:  day(2027, time.January, 6),
+			want:   []time.Time{day(2027, time.February, 12), day(2027, time.March, 8)},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rt := &model.RecurringTransaction{Frequency: model.FrequencyMonthly, StartDate: tt.start, BusinessDayPolicy: tt.policy}
+			var got []time.Time
+			after := tt.after
+			for range tt.want {
+				next, ok := nextOccurrence(rt, after, false)
+				require.True(t, ok)
+				got = append(got, next)
+				after = next
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRecurringService_Create_BusinessDayPolicy(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	service := NewRecurringService(mockRepo, new(MockTransactionCreator))
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.RecurringTransaction")).Return(nil)
+
+	// Starts on a Saturday: paid on the Friday before.
+	input := CreateRecurringInput{
+		Type:              model.TransactionTypeExpense,
+		Amount:            decimal.NewFromInt(8000000),
+		Category:          "Housing",
+		Frequency:         model.FrequencyMonthly,
+		StartDate:         day(2026, time.October, 31),
+		BusinessDayPolicy: model.BusinessDayPrevious,
+	}
+	rt, err := service.Create(context.Background(), uuid.New(), input)
+	require.NoError(t, err)
+	assert.Equal(t, day(2026, time.October, 30), rt.NextOccurrence)
+
+	input.BusinessDayPolicy = "nearest"
+	_, err = service.Create(context.Background(), uuid.New(), input)
+	assert.ErrorIs(t, err, ErrInvalidPolicy)
+
+	input.BusinessDayPolicy = ""
+	input.AnchorDay = intPtr(32)
+	_, err = service.Create(context.Background(), uuid.New(), input)
+	assert.ErrorIs(t, err, ErrInvalidAnchorDay)
+	mockRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestRecurringService_Update_Reschedules(t *testing.T) {
+	t.Parallel()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	stale := today.AddDate(0, -4, 0)
+	active := true
+	daily := model.FrequencyDaily
+
+	tests := []struct {
+		name  string
+		rt    model.RecurringTransaction
+		input UpdateRecurringInput
+		want  time.Time
+	}{
+		{
+			name:  "resuming skips the paused occurrences",
+			rt:    model.RecurringTransaction{Frequency: model.FrequencyDaily, StartDate: stale, NextOccurrence: stale},
+			input: UpdateRecurringInput{IsActive: &active},
+			want:  today,
+		},
+		{
+			name: "not twice on the day already generated",
+			rt: model.RecurringTransaction{
+				Frequency: model.FrequencyMonthly, StartDate: stale, NextOccurrence: today.AddDate(0, 1, 0),
+				LastGenerated: &today, IsActive: true,
+			},
+			input: UpdateRecurringInput{Frequency: &daily},
+			want:  today.AddDate(0, 0, 1),
+		},
+		{
+			name: "description only keeps the schedule",
+			rt: model.RecurringTransaction{
+				Frequency: model.FrequencyMonthly, StartDate: stale, NextOccurrence: stale, IsActive: true,
+			},
+			input: UpdateRecurringInput{Description: strPtr("Rent")},
+			want:  stale,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockRecurringRepo)
+			service := NewRecurringService(mockRepo, new(MockTransactionCreator))
+			rt := tt.rt
+			rt.ID, rt.UserID = uuid.New(), uuid.New()
+			mockRepo.On("GetByID", mock.Anything, rt.ID).Return(&rt, nil)
+			mockRepo.On("Update", mock.Anything, &rt).Return(nil)
+
+			updated, err := service.Update(context.Background(), rt.UserID, rt.ID, tt.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, updated.NextOccurrence)
+		})
+	}
 }
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+)
+
+// Service-level errors for recurring transfers into savings goals and debts.
+var (
+	ErrMultipleTargets    = errors.New("a recurring transaction can target a savings goal or a debt, not both")
+	ErrTargetNotExpense   = errors.New("only expenses can target a savings goal or a debt")
+	ErrTargetCurrency     = errors.New("currency must match the currency of the savings goal or debt")
+	ErrTargetsUnavailable = errors.New("savings goal and debt targets are not available")
+	ErrDebtPaidOff        = errors.New("the debt is paid off")
+)
+
+// GoalContributor looks up the savings goals recurring transactions contribute to.
+// Implementations must be safe for concurrent use.
+type GoalContributor interface {
+	Get(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*model.SavingsGoal, error)
+}
+
+// DebtPayer looks up the debts recurring transactions pay.
+// Implementations must be safe for concurrent use.
+type DebtPayer interface {
+	Get(ctx context.Context, id uuid.UUID) (*model.Debt, error)
+}
+
+// SetTransferTargets lets recurring expenses contribute to a savings goal or pay a debt.
+func (s *RecurringService) SetTransferTargets(goals GoalContributor, debts DebtPayer) {
+	s.goals = goals
+	s.debts = debts
+}
+
+// validateTarget checks that a recurring transaction targeting a savings goal or a debt
+// is an expense in its currency, and that the user can contribute to the goal or owns the
+// debt. An empty currency defaults to the target's, and an empty category of a debt
+// payment to Debt Payments.
+func (s *RecurringService) validateTarget(ctx context.Context, rt *model.RecurringTransaction) error {
+	if rt.GoalID == nil && rt.DebtID == nil {
+		return nil
+	}
+	if rt.GoalID != nil && rt.DebtID != nil {
+		return ErrMultipleTargets
+	}
+	if rt.Type != model.TransactionTypeExpense {
+		return ErrTargetNotExpense
+	}
+
+	var currency string
+	if rt.GoalID != nil {
+		if s.goals == nil {
+			return ErrTargetsUnavailable
+		}
+		goal, err := s.goals.Get(ctx, *rt.GoalID, rt.UserID)
+		if err != nil {
+			return err
+		}
+		if permissionRank(goal.Permission) < permissionRank(model.GoalPermissionContribute) {
+			return ErrGoalPermissionDenied
+		}
+		currency = goal.Currency
+	} else {
+		if s.debts == nil {
+			return ErrTargetsUnavailable
+		}
+		debt, err := s.debts.Get(ctx, *rt.DebtID)
+		if err != nil {
+			return err
+		}
+		if debt.UserID != rt.UserID {
+			return repository.ErrDebtNotFound
+		}
+		currency = debt.Currency
+		if rt.Category == "" {
+			rt.Category = model.CategoryDebtPayments
+		}
+	}
+
+	if rt.Currency == "" {
+		rt.Currency = currency
+	}
+	if rt.Currency != currency {
+		return ErrTargetCurrency
+	}
+	return nil
+}
+
+// transferOf prepares the contribution to the savings goal or the payment of the debt a
+// recurring transaction targets, to be recorded with the transaction it generates. The
+// transaction records the expense, so the debt payment does not record another. A goal
+// or debt the user can no longer pay into is logged and left out, and the transaction is
+// still generated. Returns ErrDebtPaidOff once the debt is paid off, so the series ends;
+// other failures are returned so the occurrence is retried.
+func (s *RecurringService) transferOf(ctx context.Context, rt *model.RecurringTransaction, amount decimal.Decimal, date time.Time) (*model.RecurringTransfer, error) {
+	switch {
+	case rt.GoalID != nil && s.goals != nil:
+		goal, err := s.goals.Get(ctx, *rt.GoalID, rt.UserID)
+		if err == nil && permissionRank(goal.Permission) < permissionRank(model.GoalPermissionContribute) {
+			err = ErrGoalPermissionDenied
+		}
+		if errors.Is(err, repository.ErrSavingsGoalNotFound) || errors.Is(err, ErrGoalPermissionDenied) {
+			log.Printf("Recurring transaction %s cannot contribute to savings goal %s: %v", rt.ID, *rt.GoalID, err)
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("fetching savings goal %s: %w", *rt.GoalID, err)
+		}
+		return &model.RecurringTransfer{Contribution: &model.SavingsContribution{
+			GoalID: goal.ID,
+			UserID: rt.UserID,
+			Amount: amount,
+			Date:   date,
+		}}, nil
+	case rt.DebtID != nil && s.debts != nil:
+		debt, err := s.debts.Get(ctx, *rt.DebtID)
+		if err == nil && debt.UserID != rt.UserID {
+			err = repository.ErrDebtNotFound
+		}
+		if errors.Is(err, repository.ErrDebtNotFound) {
+			log.Printf("Recurring transaction %s cannot pay debt %s: %v", rt.ID, *rt.DebtID, err)
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("fetching debt %s: %w", *rt.DebtID, err)
+		}
+		if !debt.CurrentBalance.IsPositive() {
+			return nil, ErrDebtPaidOff
+		}
+		return &model.RecurringTransfer{
+			Payment:      &model.DebtPayment{DebtID: debt.ID, Amount: amount, Date: date},
+			PaymentSplit: recurringPaymentSplit,
+		}, nil
+	}
+	return nil, nil
+}
+
+// recurringPaymentSplit splits a recurring payment against the locked debt like a payment
+// made by hand, but pays no more principal than the balance: the payment that pays off
+// the debt is cut down to the balance and its interest. Returns ErrDebtPaidOff once
+// nothing is owed.
+func recurringPaymentSplit(debt *model.Debt, payment *model.DebtPayment) error {
+	if !debt.CurrentBalance.IsPositive() {
+		return ErrDebtPaidOff
+	}
+	payment.Principal, payment.Interest = splitDebtPayment(debt, debt.CurrentBalance, payment.Amount)
+	if payment.Principal.GreaterThan(debt.CurrentBalance) {
+		payment.Principal = debt.CurrentBalance
+		payment.Amount = payment.Principal.Add(payment.Interest)
+	}
+	return nil
+}
+
+// targetOf returns the recurring transaction a confirmed draft pays into a savings goal or
+// debt, or nil when it has no target.
+func (s *RecurringService) targetOf(ctx context.Context, draft *model.RecurringDraft) (*model.RecurringTransaction, error) {
+	if s.goals == nil && s.debts == nil {
+		return nil, nil
+	}
+	rt, err := s.recurringRepo.GetByID(ctx, draft.RecurringID)
+	if err != nil {
+		return nil, fmt.Errorf("getting recurring transaction %s of draft %s: %w", draft.RecurringID, draft.ID, err)
+	}
+	if rt.GoalID == nil && rt.DebtID == nil {
+		return nil, nil
+	}
+	return rt, nil
+}
+
+// applyTargetInput sets the savings goal or debt an update targets; the nil UUID clears it.
+func applyTargetInput(rt *model.RecurringTransaction, goalID, debtID *uuid.UUID) {
+	if goalID != nil {
+		rt.GoalID = goalID
+		if *goalID == uuid.Nil {
+			rt.GoalID = nil
+		}
+	}
+	if debtID != nil {
+		rt.DebtID = debtID
+		if *debtID == uuid.Nil {
+			rt.DebtID = nil
+		}
+	}
+}
@@ -0,0 +1,403 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+)
+
+// MockGoalContributor implements GoalContributor for testing
+type MockGoalContributor struct {
+	mock.Mock
+}
+
+func (m *MockGoalContributor) Get(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*model.SavingsGoal, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SavingsGoal), args.Error(1)
+}
+
+// MockDebtPayer implements DebtPayer for testing
+type MockDebtPayer struct {
+	mock.Mock
+}
+
+func (m *MockDebtPayer) Get(ctx context.Context, id uuid.UUID) (*model.Debt, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Debt), args.Error(1)
+}
+
+func TestRecurringService_Create_Targets(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	goal := &model.SavingsGoal{ID: uuid.New(), UserID: userID, Currency: "VND", Permission: model.GoalPermissionOwner}
+	sharedGoal := &model.SavingsGoal{ID: uuid.New(), UserID: uuid.New(), Currency: "VND", Permission: model.GoalPermissionView}
+	mortgage := &model.Debt{ID: uuid.New(), UserID: userID, Currency: "VND"}
+	othersDebt := &model.Debt{ID: uuid.New(), UserID: uuid.New(), Currency: "VND"}
+
+	tests := []struct {
+		name         string
+		input        CreateRecurringInput
+		wantErr      error
+		wantCurrency string
+		wantCategory string
+	}{
+		{
+			name:         "savings goal",
+			input:        CreateRecurringInput{Type: model.TransactionTypeExpense, Category: "Investments", GoalID: &goal.ID},
+			wantCurrency: "VND",
+			wantCategory: "Investments",
+		},
+		{
+			name:         "debt payment",
+			input:        CreateRecurringInput{Type: model.TransactionTypeExpense, DebtID: &mortgage.ID},
+			wantCurrency: "VND",
+			wantCategory: model.CategoryDebtPayments,
+		},
+		{
+			name:    "both targets",
+			input:   CreateRecurringInput{Type: model.TransactionTypeExpense, GoalID: &goal.ID, DebtID: &mortgage.ID},
+			wantErr: ErrMultipleTargets,
+		},
+		{
+			name:    "income",
+			input:   CreateRecurringInput{Type: model.TransactionTypeIncome, GoalID: &goal.ID},
+			wantErr: ErrTargetNotExpense,
+		},
+		{
+			name:    "other currency",
+			input:   CreateRecurringInput{Type: model.TransactionTypeExpense, Currency: "USD", DebtID: &mortgage.ID},
+			wantErr: ErrTargetCurrency,
+		},
+		{
+			name:    "goal shared for viewing",
+			input:   CreateRecurringInput{Type: model.TransactionTypeExpense, GoalID: &sharedGoal.ID},
+			wantErr: ErrGoalPermissionDenied,
+		},
+		{
+			name:    "another user's debt",
+			input:   CreateRecurringInput{Type: model.TransactionTypeExpense, DebtID: &othersDebt.ID},
+			wantErr: repository.ErrDebtNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockRecurringRepo)
+			goals := new(MockGoalContributor)
+			debts := new(MockDebtPayer)
+			service := NewRecurringService(mockRepo, new(MockTransactionCreator))
+			service.SetTransferTargets(goals, debts)
+			goals.On("Get", mock.Anything, goal.ID, userID).Return(goal, nil).Maybe()
+			goals.On("Get", mock.Anything, sharedGoal.ID, userID).Return(sharedGoal, nil).Maybe()
+			debts.On("Get", mock.Anything, mortgage.ID).Return(mortgage, nil).Maybe()
+			debts.On("Get", mock.Anything, othersDebt.ID).Return(othersDebt, nil).Maybe()
+			mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.RecurringTransaction")).Return(nil).Maybe()
+
+			input := tt.input
+			input.Amount = decimal.NewFromInt(2000000)
+			input.Frequency = model.FrequencyMonthly
+			input.StartDate = day(2026, 11, 1)
+			rt, err := service.Create(context.Background(), userID, input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCurrency, rt.Currency)
+			assert.Equal(t, tt.wantCategory, rt.Category)
+			assert.Equal(t, input.GoalID, rt.GoalID)
+			assert.Equal(t, input.DebtID, rt.DebtID)
+		})
+	}
+}
+
+func TestRecurringService_Update_ClearsTarget(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockRecurringRepo)
+	service := NewRecurringService(mockRepo, new(MockTransactionCreator))
+	userID, goalID := uuid.New(), uuid.New()
+	rt := &model.RecurringTransaction{ID: uuid.New(), UserID: userID, Type: model.TransactionTypeExpense,
+		Currency: "VND", GoalID: &goalID}
+	mockRepo.On("GetByID", mock.Anything, rt.ID).Return(rt, nil)
+	mockRepo.On("Update", mock.Anything, rt).Return(nil)
+
+	none := uuid.Nil
+	updated, err := service.Update(context.Background(), userID, rt.ID, UpdateRecurringInput{GoalID: &none})
+
+	require.NoError(t, err)
+	assert.Nil(t, updated.GoalID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRecurringService_ProcessDueTransactions_DebtPayment(t *testing.T) {
+	t.Parallel()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	debtID := uuid.New()
+	rt := model.RecurringTransaction{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		Type:           model.TransactionTypeExpense,
+		Amount:         decimal.NewFromInt(5000000),
+		Currency:       "VND",
+		Category:       model.CategoryDebtPayments,
+		Description:    "Mortgage",
+		Frequency:      model.FrequencyMonthly,
+		StartDate:      today,
+		NextOccurrence: today,
+		DebtID:         &debtID,
+		IsActive:       true,
+	}
+	debt := &model.Debt{ID: debtID, UserID: rt.UserID, CurrentBalance: decimal.NewFromInt(900000000), InterestRate: decimal.NewFromInt(12)}
+
+	mockRepo := new(MockRecurringRepo)
+	mockTxRepo := new(MockTransactionCreator)
+	debts := new(MockDebtPayer)
+	service := NewRecurringService(mockRepo, mockTxRepo)
+	service.SetTransferTargets(new(MockGoalContributor), debts)
+
+	// The payment is recorded by the repository with the transaction, not by the debt service.
+	mockRepo.On("GetDueTransactions", mock.Anything, mock.Anything).Return([]model.RecurringTransaction{rt}, nil)
+	mockRepo.On("ListExceptions", mock.Anything, rt.ID).Return([]model.RecurringException{}, nil)
+	debts.On("Get", mock.Anything, debtID).Return(debt, nil)
+	var payment *model.DebtPayment
+	mockTxRepo.On("CreateRecurring", mock.Anything, mock.AnythingOfType("*model.Transaction"),
+		mock.AnythingOfType("*model.RecurringTransfer"), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			transfer := args.Get(2).(*model.RecurringTransfer)
+			payment = transfer.Payment
+			// The repository splits the payment against the balance it locks: 100,000,000 here.
+			locked := *debt
+			locked.CurrentBalance = decimal.NewFromInt(100000000)
+			require.NoError(t, transfer.PaymentSplit(&locked, payment))
+		}).
+		Return(true, nil)
+
+	count, err := service.ProcessDueTransactions(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.NotNil(t, payment)
+	assert.Equal(t, debtID, payment.DebtID)
+	assert.Equal(t, today, payment.Date)
+	assert.True(t, payment.Interest.Equal(decimal.NewFromInt(1000000)), "interest %s", payment.Interest)
+	assert.True(t, payment.Principal.Equal(decimal.NewFromInt(4000000)), "principal %s", payment.Principal)
+	assert.Empty(t, payment.Transactions)
+	debts.AssertExpectations(t)
+	mockTxRepo.AssertExpectations(t)
+}
+
+func TestRecurringService_ProcessDueTransactions_DebtPaidOff(t *testing.T) {
+	t.Parallel()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	debtID := uuid.New()
+	rt := model.RecurringTransaction{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		Type:           model.TransactionTypeExpense,
+		Amount:         decimal.NewFromInt(5000000),
+		Currency:       "VND",
+		Category:       model.CategoryDebtPayments,
+		Frequency:      model.FrequencyMonthly,
+		StartDate:      today.AddDate(0, -6, 0),
+		NextOccurrence: today,
+		DebtID:         &debtID,
+		IsActive:       true,
+	}
+
+	tests := []struct {
+		name      string
+		balance   decimal.Decimal
+		createErr error
+	}{
+		{name: "paid off before the occurrence", balance: decimal.Zero},
+		// The last payment took the lock first; the repository's split refuses this one.
+		{name: "paid off while generating", balance: decimal.NewFromInt(1000000), createErr: ErrDebtPaidOff},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockRecurringRepo)
+			mockTxRepo := new(MockTransactionCreator)
+			debts := new(MockDebtPayer)
+			service := NewRecurringService(mockRepo, mockTxRepo)
+			service.SetTransferTargets(new(MockGoalContributor), debts)
+
+			mockRepo.On("GetDueTransactions", mock.Anything, mock.Anything).Return([]model.RecurringTransaction{rt}, nil)
+			mockRepo.On("ListExceptions", mock.Anything, rt.ID).Return([]model.RecurringException{}, nil)
+			debts.On("Get", mock.Anything, debtID).Return(&model.Debt{ID: debtID, UserID: rt.UserID, CurrentBalance: tt.balance}, nil)
+			mockTxRepo.On("CreateRecurring", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(false, tt.createErr).Maybe()
+			mockRepo.On("Deactivate", mock.Anything, rt.ID, today).Return(nil)
+
+			count, err := service.ProcessDueTransactions(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, 0, count)
+			if tt.createErr == nil {
+				mockTxRepo.AssertNotCalled(t, "CreateRecurring", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRecurringPaymentSplit(t *testing.T) {
+	t.Parallel()
+
+	// 12% APR: one percent of the balance a month.
+	tests := []struct {
+		name          string
+		balance       int64
+		wantAmount    int64
+		wantPrincipal int64
+		wantInterest  int64
+		wantErr       error
+	}{
+		{name: "within the balance", balance: 100000000, wantAmount: 5000000, wantPrincipal: 4000000, wantInterest: 1000000},
+		{name: "pays off the balance", balance: 3000000, wantAmount: 3030000, wantPrincipal: 3000000, wantInterest: 30000},
+		{name: "already paid off", balance: 0, wantErr: ErrDebtPaidOff},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			debt := &model.Debt{CurrentBalance: decimal.NewFromInt(tt.balance), InterestRate: decimal.NewFromInt(12), Currency: "VND"}
+			payment := &model.DebtPayment{Amount: decimal.NewFromInt(5000000)}
+
+			err := recurringPaymentSplit(debt, payment)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, payment.Amount.Equal(decimal.NewFromInt(tt.wantAmount)), "amount %s", payment.Amount)
+			assert.True(t, payment.Principal.Equal(decimal.NewFromInt(tt.wantPrincipal)), "principal %s", payment.Principal)
+			assert.True(t, payment.Interest.Equal(decimal.NewFromInt(tt.wantInterest)), "interest %s", payment.Interest)
+		})
+	}
+}
+
+func TestRecurringService_ProcessDueTransactions_TargetUnavailable(t *testing.T) {
+	t.Parallel()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	goalID := uuid.New()
+	rt := model.RecurringTransaction{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		Type:           model.TransactionTypeExpense,
+		Amount:         decimal.NewFromInt(2000000),
+		Currency:       "VND",
+		Category:       "Savings",
+		Frequency:      model.FrequencyMonthly,
+		StartDate:      today,
+		NextOccurrence: today,
+		GoalID:         &goalID,
+		IsActive:       true,
+	}
+
+	tests := []struct {
+		name        string
+		goal        *model.SavingsGoal
+		goalErr     error
+		wantCreated bool
+	}{
+		{name: "goal no longer shared", goalErr: repository.ErrSavingsGoalNotFound, wantCreated: true},
+		{name: "contribute permission revoked", goal: &model.SavingsGoal{ID: goalID, Permission: model.GoalPermissionView}, wantCreated: true},
+		{name: "lookup fails", goalErr: errors.New("db error")},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockRecurringRepo)
+			mockTxRepo := new(MockTransactionCreator)
+			goals := new(MockGoalContributor)
+			service := NewRecurringService(mockRepo, mockTxRepo)
+			service.SetTransferTargets(goals, new(MockDebtPayer))
+
+			mockRepo.On("GetDueTransactions", mock.Anything, mock.Anything).Return([]model.RecurringTransaction{rt}, nil)
+			mockRepo.On("ListExceptions", mock.Anything, rt.ID).Return([]model.RecurringException{}, nil)
+			goals.On("Get", mock.Anything, goalID, rt.UserID).Return(tt.goal, tt.goalErr)
+			mockTxRepo.On("CreateRecurring", mock.Anything, mock.AnythingOfType("*model.Transaction"),
+				(*model.RecurringTransfer)(nil), mock.Anything, mock.Anything).Return(true, nil).Maybe()
+
+			count, err := service.ProcessDueTransactions(context.Background())
+
+			if tt.wantCreated {
+				// The expense still happened; only the contribution is left out.
+				require.NoError(t, err)
+				assert.Equal(t, 1, count)
+				return
+			}
+			// A failure that may pass is retried from this occurrence on the next run.
+			assert.ErrorContains(t, err, "db error")
+			assert.Zero(t, count)
+			mockTxRepo.AssertNotCalled(t, "CreateRecurring", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRecurringService_ConfirmDraft_GoalContribution(t *testing.T) {
+	t.Parallel()
+
+	drafts := new(MockDraftStore)
+	mockRepo := new(MockRecurringRepo)
+	goals := new(MockGoalContributor)
+	service := NewRecurringService(mockRepo, new(MockTransactionCreator))
+	service.SetDraftStore(drafts)
+	service.SetTransferTargets(goals, new(MockDebtPayer))
+
+	userID, goalID := uuid.New(), uuid.New()
+	rt := &model.RecurringTransaction{ID: uuid.New(), UserID: userID, GoalID: &goalID}
+	draft := &model.RecurringDraft{ID: uuid.New(), RecurringID: rt.ID, UserID: userID, Type: model.TransactionTypeExpense,
+		Amount: decimal.NewFromInt(2000000), Currency: "VND", Status: model.DraftPending, DueDate: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)}
+	actual := decimal.NewFromInt(2500000)
+
+	drafts.On("GetByID", mock.Anything, draft.ID).Return(draft, nil)
+	mockRepo.On("GetByID", mock.Anything, rt.ID).Return(rt, nil)
+	goals.On("Get", mock.Anything, goalID, userID).Return(&model.SavingsGoal{ID: goalID, UserID: userID, Permission: model.GoalPermissionOwner}, nil)
+	drafts.On("Confirm", mock.Anything, draft, mock.AnythingOfType("*model.Transaction"), mock.MatchedBy(func(transfer *model.RecurringTransfer) bool {
+		c := transfer.Contribution
+		return c != nil && transfer.Payment == nil && c.GoalID == goalID && c.UserID == userID &&
+			c.Amount.Equal(actual) && c.Date.Equal(draft.DueDate)
+	})).Return(nil)
+
+	_, err := service.ConfirmDraft(context.Background(), userID, draft.ID, ConfirmDraftInput{Amount: &actual})
+
+	require.NoError(t, err)
+	goals.AssertExpectations(t)
+	drafts.AssertExpectations(t)
+}
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+)
+
+// Service-level errors for reports.
+var (
+	ErrInvalidReportRange  = errors.New("start must not be after end")
+	ErrInvalidGroupBy      = errors.New("groupBy must be day, week, month, quarter, year, category, tag, account or currency")
+	ErrConflictingGroupBy  = errors.New("groupBy can combine one period with one of category, tag, account or currency")
+	ErrTagsUnsupported     = errors.New("transactions have no tags to group by")
+	ErrInvalidMetric       = errors.New("metrics must be sum, count or average")
+	ErrInvalidComparison   = errors.New("compare must be 'yoy' or 'pop'")
+	ErrTooManyReportPoints = errors.New("the range has too many periods for the grouping, group by a longer period")
+)
+
+// maxReportPeriods bounds the periods of a report, about three years of days.
+const maxReportPeriods = 1100
+
+// ReportRepositoryInterface defines the contract for report aggregation.
+// Implementations must be safe for concurrent use.
+type ReportRepositoryInterface interface {
+	Aggregate(ctx context.Context, q repository.ReportQuery) ([]model.ReportRow, error)
+}
+
+// ReportService builds reports of transactions over arbitrary date ranges.
+type ReportService struct {
+	repo ReportRepositoryInterface
+}
+
+// NewReportService creates a new ReportService with the given repository.
+func NewReportService(repo ReportRepositoryInterface) *ReportService {
+	return &ReportService{repo: repo}
+}
+
+type ReportInput struct {
+	Type     model.TransactionType  `json:"type"`    // defaults to expense
+	Start    time.Time              `json:"start"`   // defaults to the start of the month a year before end
+	End      time.Time              `json:"end"`     // defaults to today
+	GroupBy  []model.ReportGroupBy  `json:"groupBy"` // at most one period and one other grouping
+	Metrics  []model.ReportMetric   `json:"metrics"` // defaults to sum
+	Compare  model.ReportComparison `json:"compare"`
+	Currency string                 `json:"currency"` // only transactions in this currency
+}
+
+// Generate aggregates the user's income or expenses over a date range into one series
+// per group and currency, so amounts in different currencies are never added up. With a
+// period grouping, each series has a point for every period of the range, zero when
+// empty, ready to chart. A comparison aggregates the same range a year earlier or the
+// range of the same length just before, matching each point to its counterpart.
+func (s *ReportService) Generate(ctx context.Context, userID uuid.UUID, input ReportInput) (*model.Report, error) {
+	if input.Type == "" {
+		input.Type = model.TransactionTypeExpense
+	}
+	if input.Type != model.TransactionTypeIncome && input.Type != model.TransactionTypeExpense {
+		return nil, ErrInvalidType
+	}
+	if input.End.IsZero() {
+		input.End = time.Now()
+	}
+	input.End = dateOf(input.End)
+	if input.Start.IsZero() {
+		input.Start = time.Date(input.End.Year()-1, input.End.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	input.Start = dateOf(input.Start)
+	if input.Start.After(input.End) {
+		return nil, ErrInvalidReportRange
+	}
+
+	period, dimension, err := reportGrouping(input.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+	if len(input.Metrics) == 0 {
+		input.Metrics = []model.ReportMetric{model.ReportSum}
+	}
+	for _, m := range input.Metrics {
+		if m != model.ReportSum && m != model.ReportCount && m != model.ReportAverage {
+			return nil, ErrInvalidMetric
+		}
+	}
+	if period != "" && len(reportPeriodStarts(period, input.Start, input.End)) > maxReportPeriods {
+		return nil, ErrTooManyReportPoints
+	}
+	var compareStart, compareEnd *time.Time
+	if input.Compare != "" {
+		start, end, err := comparisonRange(input.Compare, input.Start, input.End)
+		if err != nil {
+			return nil, err
+		}
+		compareStart, compareEnd = &start, &end
+	}
+
+	query := repository.ReportQuery{
+		UserID:    userID,
+		Type:      input.Type,
+		Start:     input.Start,
+		End:       input.End,
+		Period:    period,
+		Dimension: dimension,
+	}
+	if input.Currency != "" {
+		query.Currency = &input.Currency
+	}
+	rows, err := s.repo.Aggregate(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("aggregating report for user %s: %w", userID, err)
+	}
+
+	report := &model.Report{
+		Type:         input.Type,
+		Start:        input.Start,
+		End:          input.End,
+		GroupBy:      input.GroupBy,
+		Metrics:      input.Metrics,
+		Compare:      input.Compare,
+		CompareStart: compareStart,
+		CompareEnd:   compareEnd,
+	}
+	if report.GroupBy == nil {
+		report.GroupBy = []model.ReportGroupBy{}
+	}
+
+	var previous []model.ReportRow
+	if compareStart != nil {
+		query.Start, query.End = *compareStart, *compareEnd
+		if previous, err = s.repo.Aggregate(ctx, query); err != nil {
+			return nil, fmt.Errorf("aggregating comparison report for user %s: %w", userID, err)
+		}
+	}
+
+	report.Series = buildReportSeries(rows, previous, period, input.Start, input.End, compareStart, input.Metrics)
+	return report, nil
+}
+
+// reportGrouping splits a report's groupings into its period and its other grouping.
+func reportGrouping(groupBy []model.ReportGroupBy) (period, dimension model.ReportGroupBy, err error) {
+	for _, g := range groupBy {
+		switch {
+		case g.IsPeriod():
+			if period != "" {
+				return "", "", ErrConflictingGroupBy
+			}
+			period = g
+		case g == model.ReportByTag:
+			return "", "", ErrTagsUnsupported
+		case g == model.ReportByCategory || g == model.ReportByAccount || g == model.ReportByCurrency:
+			if dimension != "" {
+				return "", "", ErrConflictingGroupBy
+			}
+			dimension = g
+		default:
+			return "", "", ErrInvalidGroupBy
+		}
+	}
+	return period, dimension, nil
+}
+
+// comparisonRange returns the range a report is compared with: the same days a year
+// earlier, or the range of the same length just before it. A range of whole months is
+// compared with as many whole months before it.
+func comparisonRange(compare model.ReportComparison, start, end time.Time) (time.Time, time.Time, error) {
+	switch compare {
+	case model.CompareYearOverYear:
+		return start.AddDate(-1, 0, 0), end.AddDate(-1, 0, 0), nil
+	case model.ComparePreviousPeriod:
+		previousEnd := start.AddDate(0, 0, -1)
+		if start.Day() == 1 && end.AddDate(0, 0, 1).Day() == 1 {
+			months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month()) + 1
+			return start.AddDate(0, -months, 0), previousEnd, nil
+		}
+		days := int(end.Sub(start).Hours() / 24)
+		return previousEnd.AddDate(0, 0, -days), previousEnd, nil
+	}
+	return time.Time{}, time.Time{}, ErrInvalidComparison
+}
+
+// reportSeriesKey identifies a series: a group in a currency.
+type reportSeriesKey struct {
+	key      string
+	currency string
+}
+
+// buildReportSeries turns the aggregated rows into series with a point for every period,
+// matched to the points of the comparison rows, which start on compareStart, when
+// comparing. Series are ordered by currency and then by largest total first.
+func buildReportSeries(rows, previous []model.ReportRow, period model.ReportGroupBy, start, end time.Time, compareStart *time.Time, metrics []model.ReportMetric) []model.ReportSeries {
+	var periods []time.Time
+	if period != "" {
+		periods = reportPeriodStarts(period, start, end)
+	}
+
+	type seriesRows struct {
+		label   string
+		current map[int]model.ReportRow
+		prior   map[int]model.ReportRow
+	}
+	var order []reportSeriesKey
+	series := make(map[reportSeriesKey]*seriesRows)
+	add := func(row model.ReportRow, rangeStart time.Time, isPrior bool) {
+		k := reportSeriesKey{key: row.Key, currency: row.Currency}
+		sr, ok := series[k]
+		if !ok {
+			sr = &seriesRows{current: map[int]model.ReportRow{}, prior: map[int]model.ReportRow{}}
+			series[k] = sr
+			order = append(order, k)
+		}
+		if row.Label != "" {
+			sr.label = row.Label
+		}
+		index := 0
+		if row.Period != nil {
+			index = reportPeriodIndex(period, rangeStart, *row.Period)
+		}
+		if isPrior {
+			sr.prior[index] = row
+		} else {
+			sr.current[index] = row
+		}
+	}
+	for _, row := range rows {
+		add(row, start, false)
+	}
+	if compareStart != nil {
+		for _, row := range previous {
+			add(row, *compareStart, true)
+		}
+	}
+
+	result := make([]model.ReportSeries, 0, len(order))
+	for _, k := range order {
+		sr := series[k]
+		places := currencyPlaces(k.currency)
+		rs := model.ReportSeries{Key: k.key, Label: sr.label, Currency: k.currency}
+
+		point := func(index int, at *time.Time) model.ReportPoint {
+			p := model.ReportPoint{Period: at, ReportValues: reportValues(sr.current[index], metrics, places)}
+			if compareStart != nil {
+				p.Previous, p.Change = comparedValues(p.ReportValues, sr.prior[index], metrics, places)
+			}
+			return p
+		}
+		if period == "" {
+			rs.Points = []model.ReportPoint{point(0, nil)}
+		} else {
+			rs.Points = make([]model.ReportPoint, len(periods))
+			for i := range periods {
+				rs.Points[i] = point(i, &periods[i])
+			}
+		}
+
+		rs.Total = model.ReportPoint{ReportValues: reportValues(totalOf(sr.current, places), metrics, places)}
+		if compareStart != nil {
+			rs.Total.Previous, rs.Total.Change = comparedValues(rs.Total.ReportValues, totalOf(sr.prior, places), metrics, places)
+		}
+		result = append(result, rs)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Currency != result[j].Currency {
+			return result[i].Currency < result[j].Currency
+		}
+		return seriesTotal(result[i]).GreaterThan(seriesTotal(result[j]))
+	})
+	return result
+}
+
+// reportValues picks the requested metrics of an aggregate.
+func reportValues(row model.ReportRow, metrics []model.ReportMetric, places int32) model.ReportValues {
+	var values model.ReportValues
+	for _, m := range metrics {
+		switch m {
+		case model.ReportSum:
+			sum := row.Sum
+			values.Sum = &sum
+		case model.ReportCount:
+			count := row.Count
+			values.Count = &count
+		case model.ReportAverage:
+			average := row.Average.Round(places)
+			values.Average = &average
+		}
+	}
+	return values
+}
+
+// comparedValues returns the requested metrics of the comparison aggregate and how much
+// the current values changed from them.
+func comparedValues(current model.ReportValues, prior model.ReportRow, metrics []model.ReportMetric, places int32) (*model.ReportValues, *model.ReportValues) {
+	previous := reportValues(prior, metrics, places)
+	var change model.ReportValues
+	if current.Sum != nil {
+		diff := current.Sum.Sub(*previous.Sum)
+		change.Sum = &diff
+	}
+	if current.Count != nil {
+		diff := *current.Count - *previous.Count
+		change.Count = &diff
+	}
+	if current.Average != nil {
+		diff := current.Average.Sub(*previous.Average)
+		change.Average = &diff
+	}
+	return &previous, &change
+}
+
+// totalOf adds up the aggregates of the periods of a series.
+func totalOf(rows map[int]model.ReportRow, places int32) model.ReportRow {
+	var total model.ReportRow
+	for _, row := range rows {
+		total.Sum = total.Sum.Add(row.Sum)
+		total.Count += row.Count
+	}
+	if total.Count > 0 {
+		total.Average = total.Sum.Div(decimal.NewFromInt(int64(total.Count))).Round(places)
+	}
+	return total
+}
+
+// seriesTotal returns the total amount of a series, for ordering.
+func seriesTotal(rs model.ReportSeries) decimal.Decimal {
+	if rs.Total.Sum != nil {
+		return *rs.Total.Sum
+	}
+	if rs.Total.Count != nil {
+		return decimal.NewFromInt(int64(*rs.Total.Count))
+	}
+	if rs.Total.Average != nil {
+		return *rs.Total.Average
+	}
+	return decimal.Zero
+}
+
+// reportPeriodStart returns the start of the period a day falls in, as PostgreSQL's
+// date_trunc does: weeks start on Monday.
+func reportPeriodStart(period model.ReportGroupBy, day time.Time) time.Time {
+	day = dateOf(day)
+	switch period {
+	case model.ReportByWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case model.ReportByMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case model.ReportByQuarter:
+		return time.Date(day.Year(), day.Month()-(day.Month()-1)%3, 1, 0, 0, 0, 0, time.UTC)
+	case model.ReportByYear:
+		return time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+// reportPeriodStarts returns the start of every period a range overlaps.
+func reportPeriodStarts(period model.ReportGroupBy, start, end time.Time) []time.Time {
+	var starts []time.Time
+	for p := reportPeriodStart(period, start); !p.After(end); p = nextReportPeriod(period, p) {
+		starts = append(starts, p)
+		if len(starts) > maxReportPeriods {
+			break
+		}
+	}
+	return starts
+}
+
+// nextReportPeriod returns the start of the period after the one starting on a day.
+func nextReportPeriod(period model.ReportGroupBy, start time.Time) time.Time {
+	switch period {
+	case model.ReportByWeek:
+		return start.AddDate(0, 0, 7)
+	case model.ReportByMonth:
+		return start.AddDate(0, 1, 0)
+	case model.ReportByQuarter:
+		return start.AddDate(0, 3, 0)
+	case model.ReportByYear:
+		return start.AddDate(1, 0, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// reportPeriodIndex returns the position of a period among the periods of a range.
+func reportPeriodIndex(period model.ReportGroupBy, rangeStart, periodStart time.Time) int {
+	first, p := reportPeriodStart(period, rangeStart), dateOf(periodStart)
+	months := (p.Year()-first.Year())*12 + int(p.Month()-first.Month())
+	switch period {
+	case model.ReportByWeek:
+		return int(p.Sub(first).Hours()/24) / 7
+	case model.ReportByMonth:
+		return months
+	case model.ReportByQuarter:
+		return months / 3
+	case model.ReportByYear:
+		return p.Year() - first.Year()
+	}
+	return int(p.Sub(first).Hours() / 24)
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+)
+
+// MockReportRepo implements ReportRepositoryInterface for testing
+type MockReportRepo struct {
+	mock.Mock
+}
+
+func (m *MockReportRepo) Aggregate(ctx context.Context, q repository.ReportQuery) ([]model.ReportRow, error) {
+	args := m.Called(ctx, q)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.ReportRow), args.Error(1)
+}
+
+func reportRow(period time.Time, key string, sum int64, count int) model.ReportRow {
+	return model.ReportRow{
+		Period:   &period,
+		Key:      key,
+		Currency: "VND",
+		Sum:      decimal.NewFromInt(sum),
+		Count:    count,
+		Average:  decimal.NewFromInt(sum).Div(decimal.NewFromInt(int64(count))),
+	}
+}
+
+func TestReportService_Generate_YearOverYear(t *testing.T) {
+	t.Parallel()
+
+	repo := new(MockReportRepo)
+	service := NewReportService(repo)
+	userID := uuid.New()
+	start, end := day(2026, 1, 1), day(2026, 3, 31)
+
+	repo.On("Aggregate", mock.Anything, mock.MatchedBy(func(q repository.ReportQuery) bool {
+		return q.Start.Equal(start) && q.End.Equal(end)
+	})).Return([]model.ReportRow{
+		reportRow(day(2026, 1, 1), "Transportation", 300000, 3),
+		reportRow(day(2026, 1, 1), "Food & Dining", 1000000, 4),
+		reportRow(day(2026, 3, 1), "Food & Dining", 500000, 2),
+	}, nil)
+	repo.On("Aggregate", mock.Anything, mock.MatchedBy(func(q repository.ReportQuery) bool {
+		return q.Start.Equal(day(2025, 1, 1)) && q.End.Equal(day(2025, 3, 31))
+	})).Return([]model.ReportRow{
+		reportRow(day(2025, 1, 1), "Food & Dining", 800000, 4),
+		reportRow(day(2025, 2, 1), "Healthcare", 200000, 1),
+	}, nil)
+
+	report, err := service.Generate(context.Background(), userID, ReportInput{
+		Start:   start,
+		End:     end,
+		GroupBy: []model.ReportGroupBy{model.ReportByMonth, model.ReportByCategory},
+		Metrics: []model.ReportMetric{model.ReportSum, model.ReportCount},
+		Compare: model.CompareYearOverYear,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, model.TransactionTypeExpense, report.Type)
+	assert.Equal(t, day(2025, 1, 1), *report.CompareStart)
+	require.Len(t, report.Series, 3)
+
+	// Largest total first; a category only spent on last year still shows.
+	food := report.Series[0]
+	assert.Equal(t, "Food & Dining", food.Key)
+	require.Len(t, food.Points, 3)
+	assert.Equal(t, day(2026, 2, 1), *food.Points[1].Period)
+	assert.True(t, food.Points[1].Sum.IsZero())
+	assert.True(t, decimal.NewFromInt(800000).Equal(*food.Points[0].Previous.Sum))
+	assert.True(t, decimal.NewFromInt(200000).Equal(*food.Points[0].Change.Sum))
+	assert.Equal(t, 0, *food.Points[0].Change.Count)
+	assert.True(t, decimal.NewFromInt(1500000).Equal(*food.Total.Sum))
+	assert.Equal(t, 6, *food.Total.Count)
+	assert.Nil(t, food.Total.Average)
+	assert.True(t, decimal.NewFromInt(700000).Equal(*food.Total.Change.Sum))
+
+	assert.Equal(t, "Transportation", report.Series[1].Key)
+	healthcare := report.Series[2]
+	assert.Equal(t, "Healthcare", healthcare.Key)
+	assert.True(t, healthcare.Total.Sum.IsZero())
+	assert.True(t, decimal.NewFromInt(200000).Equal(*healthcare.Points[1].Previous.Sum))
+	repo.AssertExpectations(t)
+}
+
+func TestReportService_Generate_Totals(t *testing.T) {
+	t.Parallel()
+
+	repo := new(MockReportRepo)
+	service := NewReportService(repo)
+
+	// Without groupings, each currency is a single series with a single point.
+	repo.On("Aggregate", mock.Anything, mock.MatchedBy(func(q repository.ReportQuery) bool {
+		return q.Period == "" && q.Dimension == "" && q.Type == model.TransactionTypeIncome
+	})).Return([]model.ReportRow{
+		{Currency: "USD", Sum: decimal.NewFromInt(100), Count: 3, Average: decimal.RequireFromString("33.333333")},
+		{Currency: "VND", Sum: decimal.NewFromInt(30000000), Count: 1, Average: decimal.NewFromInt(30000000)},
+	}, nil)
+
+	report, err := service.Generate(context.Background(), uuid.New(), ReportInput{
+		Type:    model.TransactionTypeIncome,
+		Start:   day(2026, 1, 1),
+		End:     day(2026, 12, 31),
+		Metrics: []model.ReportMetric{model.ReportAverage},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, report.Series, 2)
+	usd := report.Series[0]
+	assert.Equal(t, "USD", usd.Currency)
+	require.Len(t, usd.Points, 1)
+	assert.Nil(t, usd.Points[0].Period)
+	assert.Nil(t, usd.Points[0].Sum)
+	assert.Equal(t, "33.33", usd.Points[0].Average.String())
+	assert.Equal(t, "33.33", usd.Total.Average.String())
+	assert.Nil(t, usd.Total.Previous)
+}
+
+func TestReportService_Generate_DefaultRange(t *testing.T) {
+	t.Parallel()
+
+	repo := new(MockReportRepo)
+	service := NewReportService(repo)
+	today := dateOf(time.Now())
+
+	var query repository.ReportQuery
+	repo.On("Aggregate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		query = args.Get(1).(repository.ReportQuery)
+	}).Return([]model.ReportRow{}, nil)
+
+	report, err := service.Generate(context.Background(), uuid.New(), ReportInput{})
+
+	require.NoError(t, err)
+	assert.Equal(t, today, query.End)
+	assert.Equal(t, 1, query.Start.Day())
+	assert.Equal(t, today.AddDate(0, -11, 1-today.Day()), query.Start)
+	assert.Equal(t, model.TransactionTypeExpense, query.Type)
+	assert.Nil(t, query.Currency)
+	assert.NotNil(t, report.Series)
+	assert.Equal(t, []model.ReportMetric{model.ReportSum}, report.Metrics)
+}
+
+func TestReportService_Generate_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   ReportInput
+		wantErr error
+	}{
+		{"invalid type", ReportInput{Type: "transfer"}, ErrInvalidType},
+		{"start after end", ReportInput{Start: day(2026, 2, 1), End: day(2026, 1, 1)}, ErrInvalidReportRange},
+		{"unknown grouping", ReportInput{GroupBy: []model.ReportGroupBy{"merchant"}}, ErrInvalidGroupBy},
+		{"two periods", ReportInput{GroupBy: []model.ReportGroupBy{model.ReportByMonth, model.ReportByWeek}}, ErrConflictingGroupBy},
+		{"two dimensions", ReportInput{GroupBy: []model.ReportGroupBy{model.ReportByCategory, model.ReportByCurrency}}, ErrConflictingGroupBy},
+		{"tags", ReportInput{GroupBy: []model.ReportGroupBy{model.ReportByTag}}, ErrTagsUnsupported},
+		{"unknown metric", ReportInput{Metrics: []model.ReportMetric{"median"}}, ErrInvalidMetric},
+		{"unknown comparison", ReportInput{Compare: "mom"}, ErrInvalidComparison},
+		{"too many days", ReportInput{Start: day(2020, 1, 1), End: day(2026, 1, 1), GroupBy: []model.ReportGroupBy{model.ReportByDay}}, ErrTooManyReportPoints},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := new(MockReportRepo)
+			service := NewReportService(repo)
+
+			_, err := service.Generate(context.Background(), uuid.New(), tt.input)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			repo.AssertNotCalled(t, "Aggregate", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestComparisonRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		compare    model.ReportComparison
+		start, end time.Time
+		wantStart  time.Time
+		wantEnd    time.Time
+	}{
+		{"year over year", model.CompareYearOverYear, day(2026, 3, 1), day(2026, 3, 31), day(2025, 3, 1), day(2025, 3, 31)},
+		{"previous quarter", model.ComparePreviousPeriod, day(2026, 4, 1), day(2026, 6, 30), day(2026, 1, 1), day(2026, 3, 31)},
+		{"previous month", model.ComparePreviousPeriod, day(2026, 3, 1), day(2026, 3, 31), day(2026, 2, 1), day(2026, 2, 28)},
+		{"previous days", model.ComparePreviousPeriod, day(2026, 10, 10), day(2026, 10, 19), day(2026, 9, 30), day(2026, 10, 9)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			start, end, err := comparisonRange(tt.compare, tt.start, tt.end)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
+
+func TestReportPeriods(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, day(2026, 10, 12), reportPeriodStart(model.ReportByWeek, day(2026, 10, 18)))
+	assert.Equal(t, day(2026, 10, 12), reportPeriodStart(model.ReportByWeek, day(2026, 10, 12)))
+	assert.Equal(t, day(2026, 7, 1), reportPeriodStart(model.ReportByQuarter, day(2026, 8, 15)))
+	assert.Equal(t, day(2026, 1, 1), reportPeriodStart(model.ReportByYear, day(2026, 8, 15)))
+
+	weeks := reportPeriodStarts(model.ReportByWeek, day(2026, 10, 1), day(2026, 10, 31))
+	require.Len(t, weeks, 5)
+	assert.Equal(t, day(2026, 9, 28), weeks[0])
+	assert.Equal(t, 4, reportPeriodIndex(model.ReportByWeek, day(2026, 10, 1), day(2026, 10, 26)))
+	assert.Equal(t, 2, reportPeriodIndex(model.ReportByQuarter, day(2026, 2, 10), day(2026, 7, 1)))
+	assert.Equal(t, 14, reportPeriodIndex(model.ReportByMonth, day(2025, 11, 5), day(2027, 1, 1)))
+}
@@ -0,0 +1,386 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+	"github.com/wealthpath/backend/pkg/currency"
+	"github.com/wealthpath/backend/pkg/rrule"
+)
+
+// Service-level errors for savings rules.
+var (
+	ErrInvalidRuleType       = errors.New("type must be 'round_up', 'percent_of_income' or 'fixed_schedule'")
+	ErrInvalidRulePercentage = errors.New("percentage must be greater than 0 and at most 100")
+	ErrInvalidRuleRoundTo    = errors.New("roundTo must be greater than zero")
+)
+
+// SavingsRuleRepositoryInterface defines the contract for savings rule data access.
+// Implementations must be safe for concurrent use.
+type SavingsRuleRepositoryInterface interface {
+	Create(ctx context.Context, rule *model.SavingsRule) error
+	GetByID(ctx context.Context, id uuid.UUID) (*model.SavingsRule, error)
+	ListByGoal(ctx context.Context, goalID uuid.UUID) ([]model.SavingsRule, error)
+	ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]model.SavingsRule, error)
+	Update(ctx context.Context, rule *model.SavingsRule) error
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+	GetDueScheduled(ctx context.Context, before time.Time) ([]model.SavingsRule, error)
+	RecordScheduledRun(ctx context.Context, contribution *model.SavingsContribution, nextRun time.Time) error
+}
+
+// SavingsContributionStore provides goal lookup and contribution recording for savings rules.
+type SavingsContributionStore interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*model.SavingsGoal, error)
+	RecordContribution(ctx context.Context, contribution *model.SavingsContribution) error
+}
+
+// SavingsRuleService manages automatic savings rules and applies them to new transactions.
+type SavingsRuleService struct {
+	repo     SavingsRuleRepositoryInterface
+	goalRepo SavingsContributionStore
+}
+
+// NewSavingsRuleService creates a new SavingsRuleService with the given repositories.
+func NewSavingsRuleService(repo SavingsRuleRepositoryInterface, goalRepo SavingsContributionStore) *SavingsRuleService {
+	return &SavingsRuleService{
+		repo:     repo,
+		goalRepo: goalRepo,
+	}
+}
+
+type CreateSavingsRuleInput struct {
+	Type       model.SavingsRuleType     `json:"type"`
+	RoundTo    *decimal.Decimal          `json:"roundTo"`    // round_up, defaults by goal currency
+	Percentage *decimal.Decimal          `json:"percentage"` // percent_of_income
+	Category   *string                   `json:"category"`   // percent_of_income, empty matches any income
+	Amount     *decimal.Decimal          `json:"amount"`     // fixed_schedule
+	Frequency  *model.RecurringFrequency `json:"frequency"`  // fixed_schedule
+	StartDate  *time.Time                `json:"startDate"`  // fixed_schedule first run, defaults to today
+}
+
+type UpdateSavingsRuleInput struct {
+	RoundTo    *decimal.Decimal          `json:"roundTo"`
+	Percentage *decimal.Decimal          `json:"percentage"`
+	Category   *string                   `json:"category"`
+	Amount     *decimal.Decimal          `json:"amount"`
+	Frequency  *model.RecurringFrequency `json:"frequency"`
+	NextRun    *time.Time                `json:"nextRun"`
+	IsActive   *bool                     `json:"isActive"`
+}
+
+// Create adds an automatic savings rule to a goal owned by the user.
+// Round-up rules default to 10,000 for VND goals and 1 for other currencies.
+func (s *SavingsRuleService) Create(ctx context.Context, userID, goalID uuid.UUID, input CreateSavingsRuleInput) (*model.SavingsRule, error) {
+	goal, err := s.getOwnedGoal(ctx, userID, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &model.SavingsRule{
+		GoalID:   goalID,
+		UserID:   userID,
+		Type:     input.Type,
+		IsActive: true,
+	}
+
+	switch input.Type {
+	case model.SavingsRuleRoundUp:
+		roundTo := defaultRoundUpIncrement(goal.Currency)
+		if input.RoundTo != nil {
+			roundTo = *input.RoundTo
+		}
+		rule.RoundTo = &roundTo
+	case model.SavingsRulePercentOfIncome:
+		rule.Percentage = input.Percentage
+		if input.Category != nil && *input.Category != "" {
+			rule.Category = input.Category
+		}
+	case model.SavingsRuleFixedSchedule:
+		rule.Amount = input.Amount
+		rule.Frequency = input.Frequency
+		nextRun := time.Now().Truncate(24 * time.Hour)
+		if input.StartDate != nil {
+			nextRun = *input.StartDate
+		}
+		rule.NextRun = &nextRun
+		anchor := nextRun.Day()
+		rule.AnchorDay = &anchor
+	default:
+		return nil, ErrInvalidRuleType
+	}
+
+	if err := validateSavingsRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, rule); err != nil {
+		return nil, fmt.Errorf("creating savings rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// List retrieves all rules attached to a goal owned by the user.
+func (s *SavingsRuleService) List(ctx context.Context, userID, goalID uuid.UUID) ([]model.SavingsRule, error) {
+	if _, err := s.getOwnedGoal(ctx, userID, goalID); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.repo.ListByGoal(ctx, goalID)
+	if err != nil {
+		return nil, fmt.Errorf("listing savings rules for goal %s: %w", goalID, err)
+	}
+	return rules, nil
+}
+
+// Update modifies an existing savings rule. The rule type cannot be changed.
+// Returns ErrSavingsRuleNotFound if the rule does not belong to the user's goal.
+func (s *SavingsRuleService) Update(ctx context.Context, userID, goalID, ruleID uuid.UUID, input UpdateSavingsRuleInput) (*model.SavingsRule, error) {
+	rule, err := s.getOwnedRule(ctx, userID, goalID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.RoundTo != nil {
+		rule.RoundTo = input.RoundTo
+	}
+	if input.Percentage != nil {
+		rule.Percentage = input.Percentage
+	}
+	if input.Category != nil {
+		rule.Category = input.Category
+		if *input.Category == "" {
+			rule.Category = nil
+		}
+	}
+	if input.Amount != nil {
+		rule.Amount = input.Amount
+	}
+	if input.Frequency != nil {
+		rule.Frequency = input.Frequency
+	}
+	if input.NextRun != nil {
+		rule.NextRun = input.NextRun
+		anchor := input.NextRun.Day()
+		rule.AnchorDay = &anchor
+	}
+	if input.IsActive != nil {
+		rule.IsActive = *input.IsActive
+	}
+
+	if err := validateSavingsRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, rule); err != nil {
+		return nil, fmt.Errorf("updating savings rule %s: %w", ruleID, err)
+	}
+
+	return rule, nil
+}
+
+// Delete removes a savings rule from a goal owned by the user.
+func (s *SavingsRuleService) Delete(ctx context.Context, userID, goalID, ruleID uuid.UUID) error {
+	if _, err := s.getOwnedRule(ctx, userID, goalID, ruleID); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, ruleID, userID); err != nil {
+		return fmt.Errorf("deleting savings rule %s: %w", ruleID, err)
+	}
+	return nil
+}
+
+// OnTransactionCreated applies the user's round-up and percent-of-income rules to a new transaction.
+// Each resulting contribution is linked back to the rule and the source transaction.
+// Rules whose goal currency differs from the transaction currency are skipped.
+func (s *SavingsRuleService) OnTransactionCreated(ctx context.Context, tx *model.Transaction) error {
+	rules, err := s.repo.ListActiveByUser(ctx, tx.UserID)
+	if err != nil {
+		return fmt.Errorf("listing savings rules for user %s: %w", tx.UserID, err)
+	}
+
+	var errs []error
+	for _, rule := range rules {
+		amount := ruleContribution(rule, tx)
+		if !amount.IsPositive() {
+			continue
+		}
+
+		goal, err := s.goalRepo.GetByID(ctx, rule.GoalID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fetching savings goal %s for rule %s: %w", rule.GoalID, rule.ID, err))
+			continue
+		}
+		if goal.Currency != tx.Currency {
+			continue
+		}
+
+		ruleID := rule.ID
+		txID := tx.ID
+		contribution := &model.SavingsContribution{
+			GoalID:              rule.GoalID,
+			UserID:              rule.UserID,
+			Amount:              amount,
+			Date:                tx.Date,
+			RuleID:              &ruleID,
+			SourceTransactionID: &txID,
+		}
+		if err := s.goalRepo.RecordContribution(ctx, contribution); err != nil {
+			errs = append(errs, fmt.Errorf("recording contribution for rule %s: %w", rule.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ProcessScheduledRules records contributions for all due fixed-schedule rules,
+// catching up on every missed run. Each run is recorded together with the move to the
+// next one, so a failed run is retried from where it stopped and never recorded twice.
+// Returns the count of contributions recorded and the errors of the rules that failed.
+func (s *SavingsRuleService) ProcessScheduledRules(ctx context.Context) (int, error) {
+	now := time.Now()
+	dueRules, err := s.repo.GetDueScheduled(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("fetching due savings rules: %w", err)
+	}
+
+	count := 0
+	var errs []error
+	for _, rule := range dueRules {
+		if rule.NextRun == nil || rule.Amount == nil || rule.Frequency == nil {
+			continue
+		}
+
+		next := *rule.NextRun
+		schedule := savingsRuleSchedule(&rule, next)
+		for !next.After(now) {
+			following, _ := schedule.After(next, next, false)
+			ruleID := rule.ID
+			contribution := &model.SavingsContribution{
+				GoalID: rule.GoalID,
+				UserID: rule.UserID,
+				Amount: *rule.Amount,
+				Date:   next,
+				RuleID: &ruleID,
+			}
+			if err := s.repo.RecordScheduledRun(ctx, contribution, following); err != nil {
+				log.Printf("Savings rule %s run on %s failed: %v", rule.ID, next.Format("2006-01-02"), err)
+				errs = append(errs, fmt.Errorf("recording run of savings rule %s on %s: %w", rule.ID, next.Format("2006-01-02"), err))
+				break // Retry from this run next time
+			}
+			count++
+			next = following
+		}
+	}
+
+	return count, errors.Join(errs...)
+}
+
+// savingsRuleSchedule returns the schedule of a fixed-schedule rule from its next run.
+// Monthly and yearly runs fall on the rule's anchor day, or on the last day of months
+// that are shorter.
+func savingsRuleSchedule(rule *model.SavingsRule, next time.Time) *rrule.Rule {
+	schedule := frequencyRule(*rule.Frequency)
+	day := next.Day()
+	if rule.AnchorDay != nil {
+		day = *rule.AnchorDay
+	}
+	switch schedule.Freq {
+	case rrule.Monthly:
+		anchorMonthDay(schedule, day)
+	case rrule.Yearly:
+		schedule.ByMonth = []time.Month{next.Month()}
+		anchorMonthDay(schedule, day)
+	}
+	return schedule
+}
+
+// getOwnedGoal fetches a goal and ensures it belongs to the user.
+func (s *SavingsRuleService) getOwnedGoal(ctx context.Context, userID, goalID uuid.UUID) (*model.SavingsGoal, error) {
+	goal, err := s.goalRepo.GetByID(ctx, goalID)
+	if err != nil {
+		return nil, fmt.Errorf("getting savings goal %s: %w", goalID, err)
+	}
+	if goal.UserID != userID {
+		return nil, repository.ErrSavingsGoalNotFound
+	}
+	return goal, nil
+}
+
+// getOwnedRule fetches a rule and ensures it belongs to the given goal and user.
+func (s *SavingsRuleService) getOwnedRule(ctx context.Context, userID, goalID, ruleID uuid.UUID) (*model.SavingsRule, error) {
+	rule, err := s.repo.GetByID(ctx, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("getting savings rule %s: %w", ruleID, err)
+	}
+	if rule.UserID != userID || rule.GoalID != goalID {
+		return nil, repository.ErrSavingsRuleNotFound
+	}
+	return rule, nil
+}
+
+// validateSavingsRule checks that the fields required by the rule type are present and sensible.
+func validateSavingsRule(rule *model.SavingsRule) error {
+	switch rule.Type {
+	case model.SavingsRuleRoundUp:
+		if rule.RoundTo == nil || !rule.RoundTo.IsPositive() {
+			return ErrInvalidRuleRoundTo
+		}
+	case model.SavingsRulePercentOfIncome:
+		if rule.Percentage == nil || !rule.Percentage.IsPositive() || rule.Percentage.GreaterThan(decimal.NewFromInt(100)) {
+			return ErrInvalidRulePercentage
+		}
+	case model.SavingsRuleFixedSchedule:
+		if rule.Amount == nil || !rule.Amount.IsPositive() {
+			return ErrInvalidAmount
+		}
+		if rule.Frequency == nil || !isValidFrequency(*rule.Frequency) {
+			return ErrInvalidFrequency
+		}
+	default:
+		return ErrInvalidRuleType
+	}
+	return nil
+}
+
+// ruleContribution returns the amount a rule sweeps from a transaction, or zero if it does not apply.
+func ruleContribution(rule model.SavingsRule, tx *model.Transaction) decimal.Decimal {
+	switch rule.Type {
+	case model.SavingsRuleRoundUp:
+		if tx.Type != model.TransactionTypeExpense || rule.RoundTo == nil || !rule.RoundTo.IsPositive() {
+			return decimal.Zero
+		}
+		remainder := tx.Amount.Mod(*rule.RoundTo)
+		if remainder.IsZero() {
+			return decimal.Zero
+		}
+		return rule.RoundTo.Sub(remainder)
+	case model.SavingsRulePercentOfIncome:
+		if tx.Type != model.TransactionTypeIncome || rule.Percentage == nil {
+			return decimal.Zero
+		}
+		if rule.Category != nil && !strings.EqualFold(*rule.Category, tx.Category) {
+			return decimal.Zero
+		}
+		amount := tx.Amount.Mul(*rule.Percentage).Div(decimal.NewFromInt(100))
+		return currency.NewMoney(amount, currency.Currency(tx.Currency)).Round().Amount
+	}
+	return decimal.Zero
+}
+
+// defaultRoundUpIncrement returns the round-up step for a currency: 10,000 VND or one whole unit otherwise.
+func defaultRoundUpIncrement(code string) decimal.Decimal {
+	if currency.Currency(code) == currency.VND {
+		return decimal.NewFromInt(10000)
+	}
+	return decimal.NewFromInt(1)
+}
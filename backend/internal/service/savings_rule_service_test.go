@@ -0,0 +1,452 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+)
+
+// MockSavingsRuleRepo implements SavingsRuleRepositoryInterface for testing
+type MockSavingsRuleRepo struct {
+	mock.Mock
+}
+
+func (m *MockSavingsRuleRepo) Create(ctx context.Context, rule *model.SavingsRule) error {
+	args := m.Called(ctx, rule)
+	if rule.ID == uuid.Nil {
+		rule.ID = uuid.New()
+	}
+	return args.Error(0)
+}
+
+func (m *MockSavingsRuleRepo) GetByID(ctx context.Context, id uuid.UUID) (*model.SavingsRule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SavingsRule), args.Error(1)
+}
+
+func (m *MockSavingsRuleRepo) ListByGoal(ctx context.Context, goalID uuid.UUID) ([]model.SavingsRule, error) {
+	args := m.Called(ctx, goalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SavingsRule), args.Error(1)
+}
+
+func (m *MockSavingsRuleRepo) ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]model.SavingsRule, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SavingsRule), args.Error(1)
+}
+
+func (m *MockSavingsRuleRepo) Update(ctx context.Context, rule *model.SavingsRule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockSavingsRuleRepo) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockSavingsRuleRepo) GetDueScheduled(ctx context.Context, before time.Time) ([]model.SavingsRule, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SavingsRule), args.Error(1)
+}
+
+func (m *MockSavingsRuleRepo) RecordScheduledRun(ctx context.Context, contribution *model.SavingsContribution, nextRun time.Time) error {
+	args := m.Called(ctx, contribution, nextRun)
+	return args.Error(0)
+}
+
+func decimalPtr(d decimal.Decimal) *decimal.Decimal {
+	return &d
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestSavingsRuleService_Create(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	goalID := uuid.New()
+	monthly := model.FrequencyMonthly
+	invalidFreq := model.RecurringFrequency("hourly")
+
+	tests := []struct {
+		name      string
+		goal      *model.SavingsGoal
+		input     CreateSavingsRuleInput
+		wantErr   error
+		checkRule func(*testing.T, *model.SavingsRule)
+	}{
+		{
+			name:  "round up defaults to 10k for VND goal",
+			goal:  &model.SavingsGoal{ID: goalID, UserID: userID, Currency: "VND"},
+			input: CreateSavingsRuleInput{Type: model.SavingsRuleRoundUp},
+			checkRule: func(t *testing.T, rule *model.SavingsRule) {
+				assert.True(t, rule.RoundTo.Equal(decimal.NewFromInt(10000)))
+				assert.True(t, rule.IsActive)
+			},
+		},
+		{
+			name:  "round up defaults to 1 for USD goal",
+			goal:  &model.SavingsGoal{ID: goalID, UserID: userID, Currency: "USD"},
+			input: CreateSavingsRuleInput{Type: model.SavingsRuleRoundUp},
+			checkRule: func(t *testing.T, rule *model.SavingsRule) {
+				assert.True(t, rule.RoundTo.Equal(decimal.NewFromInt(1)))
+			},
+		},
+		{
+			name:  "percent of income with category",
+			goal:  &model.SavingsGoal{ID: goalID, UserID: userID, Currency: "VND"},
+			input: CreateSavingsRuleInput{Type: model.SavingsRulePercentOfIncome, Percentage: decimalPtr(decimal.NewFromInt(10)), Category: strPtr("Salary")},
+			checkRule: func(t *testing.T, rule *model.SavingsRule) {
+				assert.Equal(t, "Salary", *rule.Category)
+			},
+		},
+		{
+			name:    "percent over 100",
+			goal:    &model.SavingsGoal{ID: goalID, UserID: userID, Currency: "VND"},
+			input:   CreateSavingsRuleInput{Type: model.SavingsRulePercentOfIncome, Percentage: decimalPtr(decimal.NewFromInt(150))},
+			wantErr: ErrInvalidRulePercentage,
+		},
+		{
+			name:  "fixed schedule sets next run",
+			goal:  &model.SavingsGoal{ID: goalID, UserID: userID, Currency: "VND"},
+			input: CreateSavingsRuleInput{Type: model.SavingsRuleFixedSchedule, Amount: decimalPtr(decimal.NewFromInt(500000)), Frequency: &monthly},
+			checkRule: func(t *testing.T, rule *model.SavingsRule) {
+				require.NotNil(t, rule.NextRun)
+				require.NotNil(t, rule.AnchorDay)
+				assert.Equal(t, rule.NextRun.Day(), *rule.AnchorDay)
+			},
+		},
+		{
+			name:    "fixed schedule invalid frequency",
+			goal:    &model.SavingsGoal{ID: goalID, UserID: userID, Currency: "VND"},
+			input:   CreateSavingsRuleInput{Type: model.SavingsRuleFixedSchedule, Amount: decimalPtr(decimal.NewFromInt(500000)), Frequency: &invalidFreq},
+			wantErr: ErrInvalidFrequency,
+		},
+		{
+			name:    "invalid type",
+			goal:    &model.SavingsGoal{ID: goalID, UserID: userID, Currency: "VND"},
+			input:   CreateSavingsRuleInput{Type: "lottery"},
+			wantErr: ErrInvalidRuleType,
+		},
+		{
+			name:    "goal owned by another user",
+			goal:    &model.SavingsGoal{ID: goalID, UserID: uuid.New(), Currency: "VND"},
+			input:   CreateSavingsRuleInput{Type: model.SavingsRuleRoundUp},
+			wantErr: repository.ErrSavingsGoalNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ruleRepo := new(MockSavingsRuleRepo)
+			goalRepo := new(MockSavingsGoalRepo)
+			goalRepo.On("GetByID", mock.Anything, goalID).Return(tt.goal, nil)
+			if tt.wantErr == nil {
+				ruleRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.SavingsRule")).Return(nil)
+			}
+
+			svc := NewSavingsRuleService(ruleRepo, goalRepo)
+			rule, err := svc.Create(context.Background(), userID, goalID, tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, rule)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, goalID, rule.GoalID)
+				tt.checkRule(t, rule)
+			}
+			ruleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSavingsRuleService_Update_WrongGoal(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ruleID := uuid.New()
+	ruleRepo := new(MockSavingsRuleRepo)
+	ruleRepo.On("GetByID", mock.Anything, ruleID).Return(&model.SavingsRule{ID: ruleID, GoalID: uuid.New(), UserID: userID}, nil)
+
+	svc := NewSavingsRuleService(ruleRepo, new(MockSavingsGoalRepo))
+	rule, err := svc.Update(context.Background(), userID, uuid.New(), ruleID, UpdateSavingsRuleInput{})
+
+	assert.ErrorIs(t, err, repository.ErrSavingsRuleNotFound)
+	assert.Nil(t, rule)
+	ruleRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestRuleContribution(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rule model.SavingsRule
+		tx   model.Transaction
+		want string
+	}{
+		{
+			name: "round up VND expense to 10k",
+			rule: model.SavingsRule{Type: model.SavingsRuleRoundUp, RoundTo: decimalPtr(decimal.NewFromInt(10000))},
+			tx:   model.Transaction{Type: model.TransactionTypeExpense, Amount: decimal.NewFromInt(45000), Currency: "VND"},
+			want: "5000",
+		},
+		{
+			name: "round up USD expense to 1",
+			rule: model.SavingsRule{Type: model.SavingsRuleRoundUp, RoundTo: decimalPtr(decimal.NewFromInt(1))},
+			tx:   model.Transaction{Type: model.TransactionTypeExpense, Amount: decimal.RequireFromString("3.25"), Currency: "USD"},
+			want: "0.75",
+		},
+		{
+			name: "round up exact amount sweeps nothing",
+			rule: model.SavingsRule{Type: model.SavingsRuleRoundUp, RoundTo: decimalPtr(decimal.NewFromInt(10000))},
+			tx:   model.Transaction{Type: model.TransactionTypeExpense, Amount: decimal.NewFromInt(50000), Currency: "VND"},
+			want: "0",
+		},
+		{
+			name: "round up ignores income",
+			rule: model.SavingsRule{Type: model.SavingsRuleRoundUp, RoundTo: decimalPtr(decimal.NewFromInt(10000))},
+			tx:   model.Transaction{Type: model.TransactionTypeIncome, Amount: decimal.NewFromInt(45000), Currency: "VND"},
+			want: "0",
+		},
+		{
+			name: "percent of matching income category",
+			rule: model.SavingsRule{Type: model.SavingsRulePercentOfIncome, Percentage: decimalPtr(decimal.NewFromInt(10)), Category: strPtr("Salary")},
+			tx:   model.Transaction{Type: model.TransactionTypeIncome, Amount: decimal.NewFromInt(25000005), Currency: "VND", Category: "salary"},
+			want: "2500001",
+		},
+		{
+			name: "percent skips other category",
+			rule: model.SavingsRule{Type: model.SavingsRulePercentOfIncome, Percentage: decimalPtr(decimal.NewFromInt(10)), Category: strPtr("Salary")},
+			tx:   model.Transaction{Type: model.TransactionTypeIncome, Amount: decimal.NewFromInt(1000000), Currency: "VND", Category: "Freelance"},
+			want: "0",
+		},
+		{
+			name: "percent without category matches any income",
+			rule: model.SavingsRule{Type: model.SavingsRulePercentOfIncome, Percentage: decimalPtr(decimal.RequireFromString("12.5"))},
+			tx:   model.Transaction{Type: model.TransactionTypeIncome, Amount: decimal.RequireFromString("1000.10"), Currency: "USD", Category: "Bonus"},
+			want: "125.01",
+		},
+		{
+			name: "fixed schedule ignores transactions",
+			rule: model.SavingsRule{Type: model.SavingsRuleFixedSchedule, Amount: decimalPtr(decimal.NewFromInt(100))},
+			tx:   model.Transaction{Type: model.TransactionTypeIncome, Amount: decimal.NewFromInt(1000), Currency: "USD"},
+			want: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := ruleContribution(tt.rule, &tt.tx)
+			assert.True(t, got.Equal(decimal.RequireFromString(tt.want)), "got %s, want %s", got, tt.want)
+		})
+	}
+}
+
+func TestSavingsRuleService_OnTransactionCreated(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	vndGoal := &model.SavingsGoal{ID: uuid.New(), UserID: userID, Currency: "VND"}
+	usdGoal := &model.SavingsGoal{ID: uuid.New(), UserID: userID, Currency: "USD"}
+	roundUp := model.SavingsRule{ID: uuid.New(), GoalID: vndGoal.ID, UserID: userID, Type: model.SavingsRuleRoundUp, RoundTo: decimalPtr(decimal.NewFromInt(10000)), IsActive: true}
+	usdRoundUp := model.SavingsRule{ID: uuid.New(), GoalID: usdGoal.ID, UserID: userID, Type: model.SavingsRuleRoundUp, RoundTo: decimalPtr(decimal.NewFromInt(5000)), IsActive: true}
+
+	tx := &model.Transaction{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Type:     model.TransactionTypeExpense,
+		Amount:   decimal.NewFromInt(32000),
+		Currency: "VND",
+		Date:     time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC),
+	}
+
+	ruleRepo := new(MockSavingsRuleRepo)
+	goalRepo := new(MockSavingsGoalRepo)
+	// The USD goal rule would sweep 3000 but is skipped on a VND transaction
+	ruleRepo.On("ListActiveByUser", mock.Anything, userID).Return([]model.SavingsRule{roundUp, usdRoundUp}, nil)
+	goalRepo.On("GetByID", mock.Anything, vndGoal.ID).Return(vndGoal, nil)
+	goalRepo.On("GetByID", mock.Anything, usdGoal.ID).Return(usdGoal, nil)
+	goalRepo.On("RecordContribution", mock.Anything, mock.MatchedBy(func(c *model.SavingsContribution) bool {
+		return c.GoalID == vndGoal.ID &&
+			c.Amount.Equal(decimal.NewFromInt(8000)) &&
+			c.Date.Equal(tx.Date) &&
+			*c.RuleID == roundUp.ID &&
+			*c.SourceTransactionID == tx.ID
+	})).Return(nil).Once()
+
+	svc := NewSavingsRuleService(ruleRepo, goalRepo)
+	err := svc.OnTransactionCreated(context.Background(), tx)
+
+	assert.NoError(t, err)
+	goalRepo.AssertExpectations(t)
+	goalRepo.AssertNumberOfCalls(t, "RecordContribution", 1)
+}
+
+func TestSavingsRuleService_OnTransactionCreated_ReportsErrors(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	goal := &model.SavingsGoal{ID: uuid.New(), UserID: userID, Currency: "USD"}
+	rule := model.SavingsRule{ID: uuid.New(), GoalID: goal.ID, UserID: userID, Type: model.SavingsRuleRoundUp, RoundTo: decimalPtr(decimal.NewFromInt(1)), IsActive: true}
+
+	ruleRepo := new(MockSavingsRuleRepo)
+	goalRepo := new(MockSavingsGoalRepo)
+	ruleRepo.On("ListActiveByUser", mock.Anything, userID).Return([]model.SavingsRule{rule}, nil)
+	goalRepo.On("GetByID", mock.Anything, goal.ID).Return(goal, nil)
+	goalRepo.On("RecordContribution", mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+	svc := NewSavingsRuleService(ruleRepo, goalRepo)
+	err := svc.OnTransactionCreated(context.Background(), &model.Transaction{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Type:     model.TransactionTypeExpense,
+		Amount:   decimal.RequireFromString("4.20"),
+		Currency: "USD",
+	})
+
+	assert.Error(t, err)
+}
+
+func TestSavingsRuleService_ProcessScheduledRules(t *testing.T) {
+	t.Parallel()
+
+	weekly := model.FrequencyWeekly
+	start := time.Now().AddDate(0, 0, -15).Truncate(24 * time.Hour)
+	rule := model.SavingsRule{
+		ID:        uuid.New(),
+		GoalID:    uuid.New(),
+		UserID:    uuid.New(),
+		Type:      model.SavingsRuleFixedSchedule,
+		Amount:    decimalPtr(decimal.NewFromInt(200000)),
+		Frequency: &weekly,
+		NextRun:   &start,
+		IsActive:  true,
+	}
+
+	ruleRepo := new(MockSavingsRuleRepo)
+	goalRepo := new(MockSavingsGoalRepo)
+	ruleRepo.On("GetDueScheduled", mock.Anything, mock.AnythingOfType("time.Time")).Return([]model.SavingsRule{rule}, nil)
+	for i := 0; i < 3; i++ {
+		date := start.AddDate(0, 0, 7*i)
+		ruleRepo.On("RecordScheduledRun", mock.Anything, mock.MatchedBy(func(c *model.SavingsContribution) bool {
+			return c.GoalID == rule.GoalID && *c.RuleID == rule.ID && c.SourceTransactionID == nil && c.Date.Equal(date)
+		}), date.AddDate(0, 0, 7)).Return(nil).Once()
+	}
+
+	svc := NewSavingsRuleService(ruleRepo, goalRepo)
+	count, err := svc.ProcessScheduledRules(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count) // start, +7 and +14 days are all due
+	ruleRepo.AssertExpectations(t)
+	goalRepo.AssertNotCalled(t, "RecordContribution", mock.Anything, mock.Anything)
+}
+
+func TestSavingsRuleService_ProcessScheduledRules_MonthEnd(t *testing.T) {
+	t.Parallel()
+
+	monthly := model.FrequencyMonthly
+	start := time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)
+	rule := model.SavingsRule{
+		ID:        uuid.New(),
+		GoalID:    uuid.New(),
+		UserID:    uuid.New(),
+		Type:      model.SavingsRuleFixedSchedule,
+		Amount:    decimalPtr(decimal.NewFromInt(200000)),
+		Frequency: &monthly,
+		NextRun:   &start,
+		AnchorDay: intPtr(31),
+		IsActive:  true,
+	}
+
+	var runs, nextRuns []time.Time
+	ruleRepo := new(MockSavingsRuleRepo)
+	ruleRepo.On("GetDueScheduled", mock.Anything, mock.AnythingOfType("time.Time")).Return([]model.SavingsRule{rule}, nil)
+	ruleRepo.On("RecordScheduledRun", mock.Anything, mock.AnythingOfType("*model.SavingsContribution"), mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) {
+			runs = append(runs, args.Get(1).(*model.SavingsContribution).Date)
+			nextRuns = append(nextRuns, args.Get(2).(time.Time))
+		}).Return(nil)
+
+	svc := NewSavingsRuleService(ruleRepo, new(MockSavingsGoalRepo))
+	_, err := svc.ProcessScheduledRules(context.Background())
+
+	// February has no 31st: the rule runs on its last day and returns to the 31st in March.
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(runs), 4)
+	assert.Equal(t, []time.Time{
+		start,
+		time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.April, 30, 0, 0, 0, 0, time.UTC),
+	}, runs[:4])
+	assert.Equal(t, runs[1:4], nextRuns[:3])
+}
+
+func TestSavingsRuleService_ProcessScheduledRules_Failure(t *testing.T) {
+	t.Parallel()
+
+	weekly := model.FrequencyWeekly
+	start := time.Now().AddDate(0, 0, -15).Truncate(24 * time.Hour)
+	failing := model.SavingsRule{
+		ID:        uuid.New(),
+		GoalID:    uuid.New(),
+		Type:      model.SavingsRuleFixedSchedule,
+		Amount:    decimalPtr(decimal.NewFromInt(200000)),
+		Frequency: &weekly,
+		NextRun:   &start,
+		IsActive:  true,
+	}
+	other := failing
+	other.ID = uuid.New()
+
+	ruleRepo := new(MockSavingsRuleRepo)
+	ruleRepo.On("GetDueScheduled", mock.Anything, mock.AnythingOfType("time.Time")).Return([]model.SavingsRule{failing, other}, nil)
+	ruleRepo.On("RecordScheduledRun", mock.Anything, mock.MatchedBy(func(c *model.SavingsContribution) bool {
+		return *c.RuleID == failing.ID && c.Date.Equal(start)
+	}), mock.Anything).Return(nil).Once()
+	ruleRepo.On("RecordScheduledRun", mock.Anything, mock.MatchedBy(func(c *model.SavingsContribution) bool {
+		return *c.RuleID == failing.ID
+	}), mock.Anything).Return(errors.New("db error")).Once()
+	ruleRepo.On("RecordScheduledRun", mock.Anything, mock.MatchedBy(func(c *model.SavingsContribution) bool {
+		return *c.RuleID == other.ID
+	}), mock.Anything).Return(nil)
+
+	svc := NewSavingsRuleService(ruleRepo, new(MockSavingsGoalRepo))
+	count, err := svc.ProcessScheduledRules(context.Background())
+
+	// The failing rule stops at its failed run, to be retried next time; the other catches up.
+	assert.ErrorContains(t, err, "db error")
+	assert.Equal(t, 4, count)
+	ruleRepo.AssertNumberOfCalls(t, "RecordScheduledRun", 5)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,9 +10,11 @@ import (
 	"github.com/shopspring/decimal"
 
 	"github.com/wealthpath/backend/internal/model"
-	"github.com/wealthpath/backend/internal/repository"
 )
 
+// ErrDepositRateNotFound is returned when no published deposit rate matches the requested bank and term.
+var ErrDepositRateNotFound = errors.New("deposit rate not found")
+
 // SavingsGoalRepositoryInterface defines the contract for savings goal data access.
 // Implementations must be safe for concurrent use.
 type SavingsGoalRepositoryInterface interface {
@@ -21,11 +24,21 @@ type SavingsGoalRepositoryInterface interface {
 	Update(ctx context.Context, goal *model.SavingsGoal) error
 	Delete(ctx context.Context, id, userID uuid.UUID) error
 	AddContribution(ctx context.Context, id, userID uuid.UUID, amount decimal.Decimal) error
+	ListContributions(ctx context.Context, goalID uuid.UUID) ([]model.SavingsContribution, error)
+}
+
+// DepositRateProvider provides published bank deposit rates for savings projections.
+type DepositRateProvider interface {
+	ListRates(ctx context.Context, productType string, termMonths *int, bankCode string) ([]model.InterestRate, error)
 }
 
 // SavingsGoalService handles business logic for savings goals and contributions.
 type SavingsGoalService struct {
-	repo SavingsGoalRepositoryInterface
+	repo         SavingsGoalRepositoryInterface
+	rateProvider DepositRateProvider
+	memberRepo   SavingsGoalMemberRepositoryInterface
+	userRepo     UserGetter
+	emailSender  EmailSender
 }
 
 // NewSavingsGoalService creates a new SavingsGoalService with the given repository.
@@ -33,6 +46,11 @@ func NewSavingsGoalService(repo SavingsGoalRepositoryInterface) *SavingsGoalServ
 	return &SavingsGoalService{repo: repo}
 }
 
+// SetRateProvider sets the deposit rate provider used to factor interest into projections.
+func (s *SavingsGoalService) SetRateProvider(provider DepositRateProvider) {
+	s.rateProvider = provider
+}
+
 type CreateSavingsGoalInput struct {
 	Name         string          `json:"name"`
 	TargetAmount decimal.Decimal `json:"targetAmount"`
@@ -56,6 +74,13 @@ type ContributeInput struct {
 	Amount decimal.Decimal `json:"amount"`
 }
 
+// ProjectionOptions selects the deposit rate used to grow the balance in a projection.
+// When both fields are empty the projection assumes no interest.
+type ProjectionOptions struct {
+	BankCode   string `json:"bankCode"`   // empty picks the best rate for the term
+	TermMonths int    `json:"termMonths"` // defaults to 12 when a bank is given
+}
+
 // Create creates a new savings goal for the given user.
 // Defaults currency to USD, color to blue, and icon to piggy-bank if not specified.
 func (s *SavingsGoalService) Create(ctx context.Context, userID uuid.UUID, input CreateSavingsGoalInput) (*model.SavingsGoal, error) {
@@ -86,34 +111,39 @@ func (s *SavingsGoalService) Create(ctx context.Context, userID uuid.UUID, input
 	return goal, nil
 }
 
-// Get retrieves a savings goal by its ID.
-func (s *SavingsGoalService) Get(ctx context.Context, id uuid.UUID) (*model.SavingsGoal, error) {
-	goal, err := s.repo.GetByID(ctx, id)
-	if err != nil {
-		return nil, fmt.Errorf("getting savings goal %s: %w", id, err)
-	}
-	return goal, nil
+// Get retrieves a savings goal the user owns or has been given access to.
+// Returns ErrSavingsGoalNotFound if the user has no access.
+func (s *SavingsGoalService) Get(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*model.SavingsGoal, error) {
+	return s.authorize(ctx, id, userID, model.GoalPermissionView)
 }
 
-// List retrieves all savings goals for a user.
+// List retrieves the user's own savings goals followed by goals shared with them.
 func (s *SavingsGoalService) List(ctx context.Context, userID uuid.UUID) ([]model.SavingsGoal, error) {
 	goals, err := s.repo.List(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("listing savings goals for user %s: %w", userID, err)
 	}
+	for i := range goals {
+		goals[i].Permission = model.GoalPermissionOwner
+	}
+
+	if s.memberRepo != nil {
+		shared, err := s.memberRepo.ListSharedGoals(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("listing shared savings goals for user %s: %w", userID, err)
+		}
+		goals = append(goals, shared...)
+	}
+
 	return goals, nil
 }
 
-// Update modifies an existing savings goal.
-// Returns ErrSavingsGoalNotFound if the goal does not exist or belongs to another user.
+// Update modifies an existing savings goal. Members need edit permission.
+// Returns ErrSavingsGoalNotFound if the user has no access to the goal.
 func (s *SavingsGoalService) Update(ctx context.Context, id uuid.UUID, userID uuid.UUID, input UpdateSavingsGoalInput) (*model.SavingsGoal, error) {
-	goal, err := s.repo.GetByID(ctx, id)
+	goal, err := s.authorize(ctx, id, userID, model.GoalPermissionEdit)
 	if err != nil {
-		return nil, fmt.Errorf("fetching savings goal %s for update: %w", id, err)
-	}
-
-	if goal.UserID != userID {
-		return nil, repository.ErrSavingsGoalNotFound
+		return nil, err
 	}
 
 	goal.Name = input.Name
@@ -131,7 +161,7 @@ func (s *SavingsGoalService) Update(ctx context.Context, id uuid.UUID, userID uu
 	return goal, nil
 }
 
-// Delete removes a savings goal by ID for the given user.
+// Delete removes a savings goal by ID. Only the owner can delete a goal.
 func (s *SavingsGoalService) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
 	if err := s.repo.Delete(ctx, id, userID); err != nil {
 		return fmt.Errorf("deleting savings goal %s: %w", id, err)
@@ -139,8 +169,14 @@ func (s *SavingsGoalService) Delete(ctx context.Context, id uuid.UUID, userID uu
 	return nil
 }
 
-// Contribute adds a contribution amount to a savings goal.
+// Contribute adds a contribution amount to a savings goal on behalf of the user.
+// Members need contribute permission; the contribution is recorded against them.
 func (s *SavingsGoalService) Contribute(ctx context.Context, id uuid.UUID, userID uuid.UUID, amount decimal.Decimal) (*model.SavingsGoal, error) {
+	current, err := s.authorize(ctx, id, userID, model.GoalPermissionContribute)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.AddContribution(ctx, id, userID, amount); err != nil {
 		return nil, fmt.Errorf("adding contribution to savings goal %s: %w", id, err)
 	}
@@ -148,5 +184,220 @@ func (s *SavingsGoalService) Contribute(ctx context.Context, id uuid.UUID, userI
 	if err != nil {
 		return nil, fmt.Errorf("fetching updated savings goal %s: %w", id, err)
 	}
+	goal.Permission = current.Permission
 	return goal, nil
 }
+
+// ListContributions retrieves the contribution history of a goal the user can view.
+func (s *SavingsGoalService) ListContributions(ctx context.Context, id uuid.UUID, userID uuid.UUID) ([]model.SavingsContribution, error) {
+	if _, err := s.authorize(ctx, id, userID, model.GoalPermissionView); err != nil {
+		return nil, err
+	}
+
+	contributions, err := s.repo.ListContributions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("listing contributions for savings goal %s: %w", id, err)
+	}
+	return contributions, nil
+}
+
+// GetProjection estimates whether a savings goal will reach its target by the target date.
+// It uses the historical contribution rate and, optionally, a published deposit rate.
+// Returns ErrSavingsGoalNotFound if the user has no access to the goal.
+func (s *SavingsGoalService) GetProjection(ctx context.Context, id uuid.UUID, userID uuid.UUID, opts ProjectionOptions) (*model.SavingsProjection, error) {
+	goal, err := s.authorize(ctx, id, userID, model.GoalPermissionView)
+	if err != nil {
+		return nil, err
+	}
+
+	contributions, err := s.repo.ListContributions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("listing contributions for savings goal %s: %w", id, err)
+	}
+
+	var rate *model.InterestRate
+	if opts.BankCode != "" || opts.TermMonths > 0 {
+		rate, err = s.lookupDepositRate(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return projectSavingsGoal(goal, contributions, rate, time.Now()), nil
+}
+
+// lookupDepositRate finds the deposit rate for the requested bank and term.
+// With no bank code, the highest published rate for the term is used.
+func (s *SavingsGoalService) lookupDepositRate(ctx context.Context, opts ProjectionOptions) (*model.InterestRate, error) {
+	if s.rateProvider == nil {
+		return nil, ErrDepositRateNotFound
+	}
+
+	term := opts.TermMonths
+	if term <= 0 {
+		term = 12
+	}
+
+	rates, err := s.rateProvider.ListRates(ctx, "deposit", &term, opts.BankCode)
+	if err != nil {
+		return nil, fmt.Errorf("listing deposit rates: %w", err)
+	}
+	if len(rates) == 0 {
+		return nil, ErrDepositRateNotFound
+	}
+
+	// Rates are ordered by rate descending within a term
+	return &rates[0], nil
+}
+
+// maxProjectionMonths caps projection simulations at 50 years.
+const maxProjectionMonths = 600
+
+// projectSavingsGoal computes required contributions, projected completion and status
+// for a goal as of now. A nil rate means the balance earns no interest.
+func projectSavingsGoal(goal *model.SavingsGoal, contributions []model.SavingsContribution, rate *model.InterestRate, now time.Time) *model.SavingsProjection {
+	projection := &model.SavingsProjection{
+		GoalID:             goal.ID,
+		TargetAmount:       goal.TargetAmount,
+		CurrentAmount:      goal.CurrentAmount,
+		RemainingAmount:    decimal.Max(goal.TargetAmount.Sub(goal.CurrentAmount), decimal.Zero),
+		TargetDate:         goal.TargetDate,
+		AnnualInterestRate: decimal.Zero,
+	}
+
+	monthlyRate := decimal.Zero
+	if rate != nil {
+		projection.AnnualInterestRate = rate.Rate
+		projection.RateBankCode = rate.BankCode
+		projection.RateTermMonths = rate.TermMonths
+		monthlyRate = rate.Rate.Div(decimal.NewFromInt(100)).Div(decimal.NewFromInt(12))
+	}
+
+	places := currencyPlaces(goal.Currency)
+	projection.AverageMonthlyContribution = averageMonthlyContribution(contributions, now, places)
+
+	if goal.CurrentAmount.GreaterThanOrEqual(goal.TargetAmount) {
+		projection.Status = model.SavingsGoalStatusCompleted
+		projection.ProjectedCompletionDate = &now
+		projection.RequiredMonthlyContribution = decimal.Zero
+		return projection
+	}
+
+	if months, ok := monthsToReach(goal.CurrentAmount, goal.TargetAmount, projection.AverageMonthlyContribution, monthlyRate, places); ok {
+		completion := now.AddDate(0, months, 0)
+		projection.ProjectedCompletionDate = &completion
+	}
+
+	if goal.TargetDate == nil {
+		projection.Status = model.SavingsGoalStatusNoTargetDate
+		return projection
+	}
+
+	projection.MonthsRemaining = monthsBetween(now, *goal.TargetDate)
+	projection.RequiredMonthlyContribution = requiredMonthlyContribution(
+		goal.CurrentAmount, goal.TargetAmount, monthlyRate, projection.MonthsRemaining, places,
+	)
+
+	atTarget := growBalance(goal.CurrentAmount, projection.AverageMonthlyContribution, monthlyRate, projection.MonthsRemaining, places).Round(places)
+	projection.ProjectedAmountAtTarget = &atTarget
+
+	switch {
+	case projection.ProjectedCompletionDate == nil, projection.ProjectedCompletionDate.After(*goal.TargetDate):
+		projection.Status = model.SavingsGoalStatusBehind
+	case projection.ProjectedCompletionDate.Before(goal.TargetDate.AddDate(0, -1, 0)):
+		projection.Status = model.SavingsGoalStatusAhead
+	default:
+		projection.Status = model.SavingsGoalStatusOnTrack
+	}
+
+	return projection
+}
+
+// averageMonthlyContribution returns total contributions divided by the months since the
+// first of them was made, rounded to the currency's minor unit.
+func averageMonthlyContribution(contributions []model.SavingsContribution, now time.Time, places int32) decimal.Decimal {
+	if len(contributions) == 0 {
+		return decimal.Zero
+	}
+
+	total := decimal.Zero
+	since := contributions[0].Date
+	for _, c := range contributions {
+		total = total.Add(c.Amount)
+		if c.Date.Before(since) {
+			since = c.Date
+		}
+	}
+
+	months := monthsBetween(since, now)
+	if months < 1 {
+		months = 1
+	}
+	return total.Div(decimal.NewFromInt(int64(months))).Round(places)
+}
+
+// requiredMonthlyContribution computes the level monthly deposit that grows current into target
+// over the given number of months at the monthly interest rate.
+func requiredMonthlyContribution(current, target, monthlyRate decimal.Decimal, months int, places int32) decimal.Decimal {
+	if months <= 0 {
+		return decimal.Max(target.Sub(current), decimal.Zero)
+	}
+
+	n := decimal.NewFromInt(int64(months))
+	if monthlyRate.IsZero() {
+		return decimal.Max(target.Sub(current), decimal.Zero).Div(n).Round(places)
+	}
+
+	// PMT = (FV - PV(1+r)^n) * r / ((1+r)^n - 1)
+	growth := decimalPow(decimal.NewFromInt(1).Add(monthlyRate), months)
+	needed := target.Sub(current.Mul(growth))
+	if !needed.IsPositive() {
+		return decimal.Zero
+	}
+	return needed.Mul(monthlyRate).Div(growth.Sub(decimal.NewFromInt(1))).Round(places)
+}
+
+// monthsToReach simulates monthly growth until balance reaches target.
+// Returns false if the target is not reachable within maxProjectionMonths.
+func monthsToReach(balance, target, monthlyContribution, monthlyRate decimal.Decimal, places int32) (int, bool) {
+	if !monthlyContribution.IsPositive() && (!monthlyRate.IsPositive() || !balance.IsPositive()) {
+		return 0, false
+	}
+
+	for month := 1; month <= maxProjectionMonths; month++ {
+		balance = balance.Add(balance.Mul(monthlyRate).Round(places)).Add(monthlyContribution)
+		if balance.GreaterThanOrEqual(target) {
+			return month, true
+		}
+	}
+	return 0, false
+}
+
+// growBalance returns the balance after the given months of interest and contributions.
+func growBalance(balance, monthlyContribution, monthlyRate decimal.Decimal, months int, places int32) decimal.Decimal {
+	for i := 0; i < months; i++ {
+		balance = balance.Add(balance.Mul(monthlyRate).Round(places)).Add(monthlyContribution)
+	}
+	return balance
+}
+
+// decimalPow raises base to a non-negative integer power, keeping intermediate precision bounded.
+func decimalPow(base decimal.Decimal, n int) decimal.Decimal {
+	result := decimal.NewFromInt(1)
+	for i := 0; i < n; i++ {
+		result = result.Mul(base).Round(16)
+	}
+	return result
+}
+
+// monthsBetween returns the number of whole months from start to end (zero if end is before start).
+func monthsBetween(start, end time.Time) int {
+	months := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+	if end.Day() < start.Day() {
+		months--
+	}
+	if months < 0 {
+		return 0
+	}
+	return months
+}
@@ -58,6 +58,32 @@ func (m *MockSavingsGoalRepo) AddContribution(ctx context.Context, id, userID uu
 	return args.Error(0)
 }
 
+func (m *MockSavingsGoalRepo) ListContributions(ctx context.Context, goalID uuid.UUID) ([]model.SavingsContribution, error) {
+	args := m.Called(ctx, goalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SavingsContribution), args.Error(1)
+}
+
+func (m *MockSavingsGoalRepo) RecordContribution(ctx context.Context, contribution *model.SavingsContribution) error {
+	args := m.Called(ctx, contribution)
+	return args.Error(0)
+}
+
+// MockDepositRateProvider implements DepositRateProvider for testing
+type MockDepositRateProvider struct {
+	mock.Mock
+}
+
+func (m *MockDepositRateProvider) ListRates(ctx context.Context, productType string, termMonths *int, bankCode string) ([]model.InterestRate, error) {
+	args := m.Called(ctx, productType, termMonths, bankCode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.InterestRate), args.Error(1)
+}
+
 // Table-driven tests with parallel execution (following Go rules)
 func TestSavingsGoalService_Create(t *testing.T) {
 	t.Parallel()
@@ -212,7 +238,7 @@ func TestSavingsGoalService_Get(t *testing.T) {
 			goalID := uuid.New()
 			tt.setupMock(mockRepo, goalID)
 
-			goal, err := service.Get(context.Background(), goalID)
+			goal, err := service.Get(context.Background(), goalID, uuid.Nil)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -355,6 +381,7 @@ func TestSavingsGoalService_Contribute(t *testing.T) {
 		{
 			name: "contribution error",
 			setupMock: func(m *MockSavingsGoalRepo, goalID, userID uuid.UUID) {
+				m.On("GetByID", mock.Anything, goalID).Return(&model.SavingsGoal{ID: goalID, UserID: userID}, nil)
 				m.On("AddContribution", mock.Anything, goalID, userID, decimal.NewFromFloat(500)).Return(errors.New("error"))
 			},
 			wantErr: true,
@@ -385,3 +412,196 @@ func TestSavingsGoalService_Contribute(t *testing.T) {
 		})
 	}
 }
+
+func TestSavingsGoalService_GetProjection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		opts      ProjectionOptions
+		setupMock func(*MockSavingsGoalRepo, *MockDepositRateProvider, uuid.UUID, uuid.UUID)
+		wantErr   error
+		check     func(*testing.T, *model.SavingsProjection)
+	}{
+		{
+			name: "success without interest",
+			setupMock: func(m *MockSavingsGoalRepo, p *MockDepositRateProvider, goalID, userID uuid.UUID) {
+				targetDate := time.Now().AddDate(1, 0, 0)
+				m.On("GetByID", mock.Anything, goalID).Return(&model.SavingsGoal{
+					ID:            goalID,
+					UserID:        userID,
+					TargetAmount:  decimal.NewFromInt(12000),
+					CurrentAmount: decimal.NewFromInt(0),
+					TargetDate:    &targetDate,
+					CreatedAt:     time.Now(),
+				}, nil)
+				m.On("ListContributions", mock.Anything, goalID).Return([]model.SavingsContribution{}, nil)
+			},
+			check: func(t *testing.T, p *model.SavingsProjection) {
+				assert.Equal(t, 12, p.MonthsRemaining)
+				assert.True(t, p.RequiredMonthlyContribution.Equal(decimal.NewFromInt(1000)))
+				assert.True(t, p.AnnualInterestRate.IsZero())
+				assert.Equal(t, model.SavingsGoalStatusBehind, p.Status)
+			},
+		},
+		{
+			name: "success with best deposit rate",
+			opts: ProjectionOptions{TermMonths: 12},
+			setupMock: func(m *MockSavingsGoalRepo, p *MockDepositRateProvider, goalID, userID uuid.UUID) {
+				targetDate := time.Now().AddDate(1, 0, 0)
+				m.On("GetByID", mock.Anything, goalID).Return(&model.SavingsGoal{
+					ID:           goalID,
+					UserID:       userID,
+					TargetAmount: decimal.NewFromInt(12000),
+					TargetDate:   &targetDate,
+					CreatedAt:    time.Now(),
+				}, nil)
+				m.On("ListContributions", mock.Anything, goalID).Return([]model.SavingsContribution{}, nil)
+				p.On("ListRates", mock.Anything, "deposit", mock.Anything, "").Return([]model.InterestRate{
+					{BankCode: "vpbank", TermMonths: 12, Rate: decimal.NewFromFloat(5.3)},
+				}, nil)
+			},
+			check: func(t *testing.T, p *model.SavingsProjection) {
+				assert.Equal(t, "vpbank", p.RateBankCode)
+				assert.True(t, p.AnnualInterestRate.Equal(decimal.NewFromFloat(5.3)))
+				assert.True(t, p.RequiredMonthlyContribution.LessThan(decimal.NewFromInt(1000)))
+			},
+		},
+		{
+			name: "rate not found",
+			opts: ProjectionOptions{BankCode: "unknown"},
+			setupMock: func(m *MockSavingsGoalRepo, p *MockDepositRateProvider, goalID, userID uuid.UUID) {
+				m.On("GetByID", mock.Anything, goalID).Return(&model.SavingsGoal{ID: goalID, UserID: userID}, nil)
+				m.On("ListContributions", mock.Anything, goalID).Return([]model.SavingsContribution{}, nil)
+				p.On("ListRates", mock.Anything, "deposit", mock.Anything, "unknown").Return([]model.InterestRate{}, nil)
+			},
+			wantErr: ErrDepositRateNotFound,
+		},
+		{
+			name: "not owner",
+			setupMock: func(m *MockSavingsGoalRepo, p *MockDepositRateProvider, goalID, userID uuid.UUID) {
+				m.On("GetByID", mock.Anything, goalID).Return(&model.SavingsGoal{ID: goalID, UserID: uuid.New()}, nil)
+			},
+			wantErr: repository.ErrSavingsGoalNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockSavingsGoalRepo)
+			mockRates := new(MockDepositRateProvider)
+			service := NewSavingsGoalService(mockRepo)
+			service.SetRateProvider(mockRates)
+			goalID := uuid.New()
+			userID := uuid.New()
+			tt.setupMock(mockRepo, mockRates, goalID, userID)
+
+			projection, err := service.GetProjection(context.Background(), goalID, userID, tt.opts)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, projection)
+			} else {
+				assert.NoError(t, err)
+				tt.check(t, projection)
+			}
+			mockRepo.AssertExpectations(t)
+			mockRates.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectSavingsGoal_Status(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	created := now.AddDate(0, -4, 0)
+	contributions := []model.SavingsContribution{
+		{Amount: decimal.NewFromInt(1000), Date: created},
+		{Amount: decimal.NewFromInt(1000), Date: created.AddDate(0, 1, 0)},
+		{Amount: decimal.NewFromInt(1000), Date: created.AddDate(0, 2, 0)},
+		{Amount: decimal.NewFromInt(1000), Date: created.AddDate(0, 3, 0)},
+	}
+
+	tests := []struct {
+		name       string
+		target     int64
+		current    int64
+		targetDate *time.Time
+		want       model.SavingsGoalStatus
+	}{
+		{"completed", 4000, 4000, nil, model.SavingsGoalStatusCompleted},
+		{"no target date", 10000, 4000, nil, model.SavingsGoalStatusNoTargetDate},
+		{"on track", 10000, 4000, timePtr(now.AddDate(0, 6, 0)), model.SavingsGoalStatusOnTrack},
+		{"ahead", 10000, 4000, timePtr(now.AddDate(1, 0, 0)), model.SavingsGoalStatusAhead},
+		{"behind", 10000, 4000, timePtr(now.AddDate(0, 3, 0)), model.SavingsGoalStatusBehind},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			goal := &model.SavingsGoal{
+				TargetAmount:  decimal.NewFromInt(tt.target),
+				CurrentAmount: decimal.NewFromInt(tt.current),
+				TargetDate:    tt.targetDate,
+				CreatedAt:     created,
+			}
+
+			projection := projectSavingsGoal(goal, contributions, nil, now)
+
+			assert.Equal(t, tt.want, projection.Status)
+			assert.True(t, projection.AverageMonthlyContribution.Equal(decimal.NewFromInt(1000)))
+		})
+	}
+}
+
+func TestRequiredMonthlyContribution(t *testing.T) {
+	t.Parallel()
+
+	monthlyRate := decimal.NewFromFloat(6).Div(decimal.NewFromInt(1200))
+
+	assert.True(t, requiredMonthlyContribution(decimal.Zero, decimal.NewFromInt(1200), decimal.Zero, 12, 2).Equal(decimal.NewFromInt(100)))
+	assert.True(t, requiredMonthlyContribution(decimal.NewFromInt(2000), decimal.NewFromInt(1000), monthlyRate, 12, 2).IsZero())
+	assert.True(t, requiredMonthlyContribution(decimal.Zero, decimal.NewFromInt(500), decimal.Zero, 0, 2).Equal(decimal.NewFromInt(500)))
+
+	// Standard sinking fund: 12,000 over 12 months at 6% APR needs ~972.80/month
+	pmt := requiredMonthlyContribution(decimal.Zero, decimal.NewFromInt(12000), monthlyRate, 12, 2)
+	assert.True(t, pmt.Equal(decimal.NewFromFloat(972.8)), pmt.String())
+
+	// Đồng have no minor unit
+	pmt = requiredMonthlyContribution(decimal.Zero, decimal.NewFromInt(100000000), decimal.Zero, 7, 0)
+	assert.True(t, pmt.Equal(decimal.NewFromInt(14285714)), pmt.String())
+}
+
+func TestProjectSavingsGoal_CurrencyPlacesAndContributionDates(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC)
+	goal := &model.SavingsGoal{
+		TargetAmount:  decimal.NewFromInt(100000000),
+		CurrentAmount: decimal.NewFromInt(10000000),
+		Currency:      "VND",
+		TargetDate:    timePtr(now.AddDate(0, 7, 0)),
+		// Created long before the first contribution, which the average must not count
+		CreatedAt: now.AddDate(-2, 0, 0),
+	}
+	contributions := []model.SavingsContribution{
+		{Amount: decimal.NewFromInt(6000000), Date: now.AddDate(0, -3, 0), CreatedAt: now},
+		{Amount: decimal.NewFromInt(4000000), Date: now.AddDate(0, -1, 0), CreatedAt: now},
+	}
+
+	projection := projectSavingsGoal(goal, contributions, nil, now)
+
+	assert.Equal(t, "3333333", projection.AverageMonthlyContribution.String())
+	assert.Equal(t, "12857143", projection.RequiredMonthlyContribution.String())
+	assert.Equal(t, "33333331", projection.ProjectedAmountAtTarget.String())
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+)
+
+// Service-level errors for shared savings goals.
+var (
+	ErrGoalPermissionDenied    = errors.New("insufficient permission on savings goal")
+	ErrInvalidGoalPermission   = errors.New("permission must be 'view', 'contribute' or 'edit'")
+	ErrInvalidInvitationEmail  = errors.New("invalid email")
+	ErrInvitationExpired       = errors.New("invitation has expired")
+	ErrInvitationEmailMismatch = errors.New("invitation was sent to a different email")
+	ErrAlreadyGoalMember       = errors.New("user already has access to this savings goal")
+	ErrSharingNotConfigured    = errors.New("savings goal sharing is not configured")
+)
+
+// invitationTTL is how long an invitation can be accepted after it is sent.
+const invitationTTL = 7 * 24 * time.Hour
+
+// SavingsGoalMemberRepositoryInterface defines the contract for shared goal membership data access.
+// Implementations must be safe for concurrent use.
+type SavingsGoalMemberRepositoryInterface interface {
+	GetPermission(ctx context.Context, goalID, userID uuid.UUID) (model.GoalPermission, error)
+	ListMembers(ctx context.Context, goalID uuid.UUID) ([]model.SavingsGoalMember, error)
+	ListSharedGoals(ctx context.Context, userID uuid.UUID) ([]model.SavingsGoal, error)
+	UpdatePermission(ctx context.Context, goalID, userID uuid.UUID, permission model.GoalPermission) error
+	RemoveMember(ctx context.Context, goalID, userID uuid.UUID) error
+	CreateInvitation(ctx context.Context, invitation *model.SavingsGoalInvitation) error
+	GetInvitationByToken(ctx context.Context, token string) (*model.SavingsGoalInvitation, error)
+	ListPendingInvitations(ctx context.Context, email string) ([]model.SavingsGoalInvitation, error)
+	AcceptInvitation(ctx context.Context, invitation *model.SavingsGoalInvitation, userID uuid.UUID) error
+	UpdateInvitationStatus(ctx context.Context, id uuid.UUID, status model.InvitationStatus) error
+}
+
+// UserGetter provides user lookup by ID.
+type UserGetter interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*model.User, error)
+}
+
+type InviteMemberInput struct {
+	Email      string               `json:"email"`
+	Permission model.GoalPermission `json:"permission"`
+}
+
+type UpdateMemberInput struct {
+	Permission model.GoalPermission `json:"permission"`
+}
+
+// SetMemberRepo enables sharing goals with other users.
+// Without it only the owner can access a goal.
+func (s *SavingsGoalService) SetMemberRepo(members SavingsGoalMemberRepositoryInterface, users UserGetter) {
+	s.memberRepo = members
+	s.userRepo = users
+}
+
+// SetEmailSender sets the sender used to deliver sharing invitations.
+func (s *SavingsGoalService) SetEmailSender(sender EmailSender) {
+	s.emailSender = sender
+}
+
+// InviteMember invites a user by email to share a goal. Only the owner can invite.
+func (s *SavingsGoalService) InviteMember(ctx context.Context, goalID, userID uuid.UUID, input InviteMemberInput) (*model.SavingsGoalInvitation, error) {
+	if s.memberRepo == nil {
+		return nil, ErrSharingNotConfigured
+	}
+
+	goal, err := s.authorize(ctx, goalID, userID, model.GoalPermissionOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMemberPermission(input.Permission) {
+		return nil, ErrInvalidGoalPermission
+	}
+	email := strings.TrimSpace(input.Email)
+	if !strings.Contains(email, "@") {
+		return nil, ErrInvalidInvitationEmail
+	}
+
+	members, err := s.memberRepo.ListMembers(ctx, goalID)
+	if err != nil {
+		return nil, fmt.Errorf("listing members of savings goal %s: %w", goalID, err)
+	}
+	for _, member := range members {
+		if strings.EqualFold(member.Email, email) {
+			return nil, ErrAlreadyGoalMember
+		}
+	}
+
+	token, err := newInvitationToken()
+	if err != nil {
+		return nil, fmt.Errorf("generating invitation token: %w", err)
+	}
+
+	invitation := &model.SavingsGoalInvitation{
+		GoalID:     goalID,
+		GoalName:   goal.Name,
+		InviterID:  userID,
+		Email:      email,
+		Permission: input.Permission,
+		Token:      token,
+		Status:     model.InvitationStatusPending,
+		ExpiresAt:  time.Now().Add(invitationTTL),
+	}
+	if err := s.memberRepo.CreateInvitation(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("creating invitation for savings goal %s: %w", goalID, err)
+	}
+
+	s.sendInvitation(ctx, invitation)
+	return invitation, nil
+}
+
+// ListInvitations retrieves pending invitations addressed to the user's email.
+func (s *SavingsGoalService) ListInvitations(ctx context.Context, userID uuid.UUID) ([]model.SavingsGoalInvitation, error) {
+	if s.memberRepo == nil {
+		return nil, ErrSharingNotConfigured
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("getting user %s: %w", userID, err)
+	}
+
+	invitations, err := s.memberRepo.ListPendingInvitations(ctx, user.Email)
+	if err != nil {
+		return nil, fmt.Errorf("listing invitations for user %s: %w", userID, err)
+	}
+	return invitations, nil
+}
+
+// AcceptInvitation adds the user as a member of the invited goal.
+// The invitation must be pending, unexpired and addressed to the user's email.
+func (s *SavingsGoalService) AcceptInvitation(ctx context.Context, token string, userID uuid.UUID) (*model.SavingsGoal, error) {
+	invitation, err := s.respondableInvitation(ctx, token, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	goal, err := s.repo.GetByID(ctx, invitation.GoalID)
+	if err != nil {
+		return nil, fmt.Errorf("getting savings goal %s: %w", invitation.GoalID, err)
+	}
+	if goal.UserID == userID {
+		return nil, ErrAlreadyGoalMember
+	}
+
+	if err := s.memberRepo.AcceptInvitation(ctx, invitation, userID); err != nil {
+		return nil, fmt.Errorf("accepting invitation %s: %w", invitation.ID, err)
+	}
+
+	goal.Permission = invitation.Permission
+	return goal, nil
+}
+
+// DeclineInvitation rejects an invitation addressed to the user's email.
+func (s *SavingsGoalService) DeclineInvitation(ctx context.Context, token string, userID uuid.UUID) error {
+	invitation, err := s.respondableInvitation(ctx, token, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.memberRepo.UpdateInvitationStatus(ctx, invitation.ID, model.InvitationStatusDeclined); err != nil {
+		return fmt.Errorf("declining invitation %s: %w", invitation.ID, err)
+	}
+	return nil
+}
+
+// ListMembers retrieves the owner and members of a goal with each person's total contributions.
+func (s *SavingsGoalService) ListMembers(ctx context.Context, goalID, userID uuid.UUID) ([]model.SavingsGoalMember, error) {
+	if s.memberRepo == nil {
+		return nil, ErrSharingNotConfigured
+	}
+	if _, err := s.authorize(ctx, goalID, userID, model.GoalPermissionView); err != nil {
+		return nil, err
+	}
+
+	members, err := s.memberRepo.ListMembers(ctx, goalID)
+	if err != nil {
+		return nil, fmt.Errorf("listing members of savings goal %s: %w", goalID, err)
+	}
+
+	contributions, err := s.repo.ListContributions(ctx, goalID)
+	if err != nil {
+		return nil, fmt.Errorf("listing contributions for savings goal %s: %w", goalID, err)
+	}
+
+	totals := make(map[uuid.UUID]decimal.Decimal)
+	for _, c := range contributions {
+		totals[c.UserID] = totals[c.UserID].Add(c.Amount)
+	}
+	for i := range members {
+		members[i].TotalContributed = totals[members[i].UserID]
+	}
+
+	return members, nil
+}
+
+// UpdateMemberPermission changes a member's access level. Only the owner can change permissions.
+func (s *SavingsGoalService) UpdateMemberPermission(ctx context.Context, goalID, userID, memberID uuid.UUID, input UpdateMemberInput) error {
+	if s.memberRepo == nil {
+		return ErrSharingNotConfigured
+	}
+	if _, err := s.authorize(ctx, goalID, userID, model.GoalPermissionOwner); err != nil {
+		return err
+	}
+	if !isMemberPermission(input.Permission) {
+		return ErrInvalidGoalPermission
+	}
+
+	if err := s.memberRepo.UpdatePermission(ctx, goalID, memberID, input.Permission); err != nil {
+		return fmt.Errorf("updating member %s of savings goal %s: %w", memberID, goalID, err)
+	}
+	return nil
+}
+
+// RemoveMember revokes a member's access. The owner can remove anyone; members can remove themselves.
+func (s *SavingsGoalService) RemoveMember(ctx context.Context, goalID, userID, memberID uuid.UUID) error {
+	if s.memberRepo == nil {
+		return ErrSharingNotConfigured
+	}
+
+	required := model.GoalPermissionOwner
+	if userID == memberID {
+		required = model.GoalPermissionView
+	}
+	if _, err := s.authorize(ctx, goalID, userID, required); err != nil {
+		return err
+	}
+
+	if err := s.memberRepo.RemoveMember(ctx, goalID, memberID); err != nil {
+		return fmt.Errorf("removing member %s from savings goal %s: %w", memberID, goalID, err)
+	}
+	return nil
+}
+
+// authorize fetches a goal and checks that the user holds at least the required permission.
+// Users without any access get ErrSavingsGoalNotFound so goal IDs are not disclosed.
+func (s *SavingsGoalService) authorize(ctx context.Context, goalID, userID uuid.UUID, required model.GoalPermission) (*model.SavingsGoal, error) {
+	goal, err := s.repo.GetByID(ctx, goalID)
+	if err != nil {
+		return nil, fmt.Errorf("getting savings goal %s: %w", goalID, err)
+	}
+
+	if goal.UserID == userID {
+		goal.Permission = model.GoalPermissionOwner
+		return goal, nil
+	}
+	if s.memberRepo == nil {
+		return nil, repository.ErrSavingsGoalNotFound
+	}
+
+	permission, err := s.memberRepo.GetPermission(ctx, goalID, userID)
+	if errors.Is(err, repository.ErrGoalMemberNotFound) {
+		return nil, repository.ErrSavingsGoalNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting permission on savings goal %s: %w", goalID, err)
+	}
+
+	if permissionRank(permission) < permissionRank(required) {
+		return nil, ErrGoalPermissionDenied
+	}
+
+	goal.Permission = permission
+	return goal, nil
+}
+
+// respondableInvitation loads a pending, unexpired invitation addressed to the user.
+func (s *SavingsGoalService) respondableInvitation(ctx context.Context, token string, userID uuid.UUID) (*model.SavingsGoalInvitation, error) {
+	if s.memberRepo == nil {
+		return nil, ErrSharingNotConfigured
+	}
+
+	invitation, err := s.memberRepo.GetInvitationByToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("getting invitation: %w", err)
+	}
+	if invitation.Status != model.InvitationStatusPending {
+		return nil, repository.ErrInvitationNotFound
+	}
+	if time.Now().After(invitation.ExpiresAt) {
+		return nil, ErrInvitationExpired
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("getting user %s: %w", userID, err)
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, ErrInvitationEmailMismatch
+	}
+
+	return invitation, nil
+}
+
+// sendInvitation emails the invitation link if an email sender is configured.
+// Delivery failures are logged; the invitation stays valid and visible in the app.
+func (s *SavingsGoalService) sendInvitation(ctx context.Context, invitation *model.SavingsGoalInvitation) {
+	if s.emailSender == nil || s.userRepo == nil {
+		return
+	}
+
+	inviter, err := s.userRepo.GetByID(ctx, invitation.InviterID)
+	if err != nil {
+		log.Printf("Failed to load inviter %s: %v", invitation.InviterID, err)
+		return
+	}
+
+	subject := fmt.Sprintf("%s mời bạn cùng tiết kiệm cho \"%s\"", inviter.Name, invitation.GoalName)
+	body := fmt.Sprintf(`
+Xin chào,
+
+%s đã mời bạn tham gia mục tiêu tiết kiệm "%s" trên WealthPath (quyền: %s).
+
+Mã lời mời: %s
+Lời mời hết hạn vào %s.
+
+---
+WealthPath - Quản lý tài chính cá nhân
+	`,
+		inviter.Name,
+		invitation.GoalName,
+		invitation.Permission,
+		invitation.Token,
+		invitation.ExpiresAt.Format("02/01/2006"),
+	)
+
+	if err := s.emailSender.Send(invitation.Email, subject, body); err != nil {
+		log.Printf("Failed to send invitation email to %s: %v", invitation.Email, err)
+	}
+}
+
+// isMemberPermission reports whether p can be granted to a member.
+func isMemberPermission(p model.GoalPermission) bool {
+	switch p {
+	case model.GoalPermissionView, model.GoalPermissionContribute, model.GoalPermissionEdit:
+		return true
+	}
+	return false
+}
+
+// permissionRank orders permissions so that each level includes the ones below it.
+func permissionRank(p model.GoalPermission) int {
+	switch p {
+	case model.GoalPermissionView:
+		return 1
+	case model.GoalPermissionContribute:
+		return 2
+	case model.GoalPermissionEdit:
+		return 3
+	case model.GoalPermissionOwner:
+		return 4
+	}
+	return 0
+}
+
+// newInvitationToken returns a random hex token for invitation links.
+func newInvitationToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wealthpath/backend/internal/model"
+	"github.com/wealthpath/backend/internal/repository"
+)
+
+// MockSavingsGoalMemberRepo implements SavingsGoalMemberRepositoryInterface for testing
+type MockSavingsGoalMemberRepo struct {
+	mock.Mock
+}
+
+func (m *MockSavingsGoalMemberRepo) GetPermission(ctx context.Context, goalID, userID uuid.UUID) (model.GoalPermission, error) {
+	args := m.Called(ctx, goalID, userID)
+	return args.Get(0).(model.GoalPermission), args.Error(1)
+}
+
+func (m *MockSavingsGoalMemberRepo) ListMembers(ctx context.Context, goalID uuid.UUID) ([]model.SavingsGoalMember, error) {
+	args := m.Called(ctx, goalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SavingsGoalMember), args.Error(1)
+}
+
+func (m *MockSavingsGoalMemberRepo) ListSharedGoals(ctx context.Context, userID uuid.UUID) ([]model.SavingsGoal, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SavingsGoal), args.Error(1)
+}
+
+func (m *MockSavingsGoalMemberRepo) UpdatePermission(ctx context.Context, goalID, userID uuid.UUID, permission model.GoalPermission) error {
+	args := m.Called(ctx, goalID, userID, permission)
+	return args.Error(0)
+}
+
+func (m *MockSavingsGoalMemberRepo) RemoveMember(ctx context.Context, goalID, userID uuid.UUID) error {
+	args := m.Called(ctx, goalID, userID)
+	return args.Error(0)
+}
+
+func (m *MockSavingsGoalMemberRepo) CreateInvitation(ctx context.Context, invitation *model.SavingsGoalInvitation) error {
+	args := m.Called(ctx, invitation)
+	if invitation.ID == uuid.Nil {
+		invitation.ID = uuid.New()
+	}
+	return args.Error(0)
+}
+
+func (m *MockSavingsGoalMemberRepo) GetInvitationByToken(ctx context.Context, token string) (*model.SavingsGoalInvitation, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SavingsGoalInvitation), args.Error(1)
+}
+
+func (m *MockSavingsGoalMemberRepo) ListPendingInvitations(ctx context.Context, email string) ([]model.SavingsGoalInvitation, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.SavingsGoalInvitation), args.Error(1)
+}
+
+func (m *MockSavingsGoalMemberRepo) AcceptInvitation(ctx context.Context, invitation *model.SavingsGoalInvitation, userID uuid.UUID) error {
+	args := m.Called(ctx, invitation, userID)
+	return args.Error(0)
+}
+
+func (m *MockSavingsGoalMemberRepo) UpdateInvitationStatus(ctx context.Context, id uuid.UUID, status model.InvitationStatus) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func newSharedSavingsService() (*SavingsGoalService, *MockSavingsGoalRepo, *MockSavingsGoalMemberRepo, *MockUserRepo) {
+	repo := new(MockSavingsGoalRepo)
+	members := new(MockSavingsGoalMemberRepo)
+	users := new(MockUserRepo)
+	svc := NewSavingsGoalService(repo)
+	svc.SetMemberRepo(members, users)
+	return svc, repo, members, users
+}
+
+func TestSavingsGoalService_Contribute_MemberPermissions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		permission model.GoalPermission
+		memberErr  error
+		wantErr    error
+	}{
+		{name: "contributor can contribute", permission: model.GoalPermissionContribute},
+		{name: "editor can contribute", permission: model.GoalPermissionEdit},
+		{name: "viewer cannot contribute", permission: model.GoalPermissionView, wantErr: ErrGoalPermissionDenied},
+		{name: "non member sees not found", memberErr: repository.ErrGoalMemberNotFound, wantErr: repository.ErrSavingsGoalNotFound},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc, repo, members, _ := newSharedSavingsService()
+			goalID := uuid.New()
+			memberID := uuid.New()
+			amount := decimal.NewFromInt(1000000)
+
+			repo.On("GetByID", mock.Anything, goalID).Return(&model.SavingsGoal{ID: goalID, UserID: uuid.New()}, nil)
+			members.On("GetPermission", mock.Anything, goalID, memberID).Return(tt.permission, tt.memberErr)
+			if tt.wantErr == nil {
+				repo.On("AddContribution", mock.Anything, goalID, memberID, amount).Return(nil)
+			}
+
+			goal, err := svc.Contribute(context.Background(), goalID, memberID, amount)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, goal)
+				repo.AssertNotCalled(t, "AddContribution", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.permission, goal.Permission)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSavingsGoalService_Update_RequiresEdit(t *testing.T) {
+	t.Parallel()
+
+	svc, repo, members, _ := newSharedSavingsService()
+	goalID := uuid.New()
+	memberID := uuid.New()
+
+	repo.On("GetByID", mock.Anything, goalID).Return(&model.SavingsGoal{ID: goalID, UserID: uuid.New()}, nil)
+	members.On("GetPermission", mock.Anything, goalID, memberID).Return(model.GoalPermissionContribute, nil)
+
+	goal, err := svc.Update(context.Background(), goalID, memberID, UpdateSavingsGoalInput{Name: "House"})
+
+	assert.ErrorIs(t, err, ErrGoalPermissionDenied)
+	assert.Nil(t, goal)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestSavingsGoalService_List_IncludesSharedGoals(t *testing.T) {
+	t.Parallel()
+
+	svc, repo, members, _ := newSharedSavingsService()
+	userID := uuid.New()
+
+	repo.On("List", mock.Anything, userID).Return([]model.SavingsGoal{{ID: uuid.New(), UserID: userID}}, nil)
+	members.On("ListSharedGoals", mock.Anything, userID).Return([]model.SavingsGoal{
+		{ID: uuid.New(), UserID: uuid.New(), Permission: model.GoalPermissionView},
+	}, nil)
+
+	goals, err := svc.List(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, goals, 2)
+	assert.Equal(t, model.GoalPermissionOwner, goals[0].Permission)
+	assert.Equal(t, model.GoalPermissionView, goals[1].Permission)
+}
+
+func TestSavingsGoalService_InviteMember(t *testing.T) {
+	t.Parallel()
+
+	ownerID := uuid.New()
+	goalID := uuid.New()
+	existing := []model.SavingsGoalMember{
+		{GoalID: goalID, UserID: ownerID, Email: "owner@example.com", Permission: model.GoalPermissionOwner},
+		{GoalID: goalID, UserID: uuid.New(), Email: "partner@example.com", Permission: model.GoalPermissionEdit},
+	}
+
+	tests := []struct {
+		name    string
+		userID  uuid.UUID
+		input   InviteMemberInput
+		wantErr error
+	}{
+		{name: "owner invites", userID: ownerID, input: InviteMemberInput{Email: " friend@example.com ", Permission: model.GoalPermissionContribute}},
+		{name: "invalid permission", userID: ownerID, input: InviteMemberInput{Email: "friend@example.com", Permission: model.GoalPermissionOwner}, wantErr: ErrInvalidGoalPermission},
+		{name: "invalid email", userID: ownerID, input: InviteMemberInput{Email: "friend", Permission: model.GoalPermissionView}, wantErr: ErrInvalidInvitationEmail},
+		{name: "already a member", userID: ownerID, input: InviteMemberInput{Email: "Partner@Example.com", Permission: model.GoalPermissionView}, wantErr: ErrAlreadyGoalMember},
+		{name: "editor cannot invite", userID: existing[1].UserID, input: InviteMemberInput{Email: "friend@example.com", Permission: model.GoalPermissionView}, wantErr: ErrGoalPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc, repo, members, _ := newSharedSavingsService()
+			repo.On("GetByID", mock.Anything, goalID).Return(&model.SavingsGoal{ID: goalID, UserID: ownerID, Name: "House"}, nil)
+			members.On("GetPermission", mock.Anything, goalID, existing[1].UserID).Return(model.GoalPermissionEdit, nil).Maybe()
+			members.On("ListMembers", mock.Anything, goalID).Return(existing, nil).Maybe()
+			members.On("CreateInvitation", mock.Anything, mock.MatchedBy(func(inv *model.SavingsGoalInvitation) bool {
+				return inv.Email == "friend@example.com" && len(inv.Token) == 48 && inv.Status == model.InvitationStatusPending
+			})).Return(nil).Maybe()
+
+			invitation, err := svc.InviteMember(context.Background(), goalID, tt.userID, tt.input)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, invitation)
+				members.AssertNotCalled(t, "CreateInvitation", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "House", invitation.GoalName)
+				assert.True(t, invitation.ExpiresAt.After(time.Now()))
+			}
+		})
+	}
+}
+
+func TestSavingsGoalService_AcceptInvitation(t *testing.T) {
+	t.Parallel()
+
+	goalID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		invitation *model.SavingsGoalInvitation
+		userEmail  string
+		wantErr    error
+	}{
+		{
+			name:       "success",
+			invitation: &model.SavingsGoalInvitation{ID: uuid.New(), GoalID: goalID, Email: "friend@example.com", Permission: model.GoalPermissionContribute, Status: model.InvitationStatusPending, ExpiresAt: time.Now().Add(time.Hour)},
+			userEmail:  "Friend@Example.com",
+		},
+		{
+			name:       "different email",
+			invitation: &model.SavingsGoalInvitation{ID: uuid.New(), GoalID: goalID, Email: "friend@example.com", Permission: model.GoalPermissionView, Status: model.InvitationStatusPending, ExpiresAt: time.Now().Add(time.Hour)},
+			userEmail:  "someone@example.com",
+			wantErr:    ErrInvitationEmailMismatch,
+		},
+		{
+			name:       "expired",
+			invitation: &model.SavingsGoalInvitation{ID: uuid.New(), GoalID: goalID, Email: "friend@example.com", Permission: model.GoalPermissionView, Status: model.InvitationStatusPending, ExpiresAt: time.Now().Add(-time.Hour)},
+			userEmail:  "friend@example.com",
+			wantErr:    ErrInvitationExpired,
+		},
+		{
+			name:       "already declined",
+			invitation: &model.SavingsGoalInvitation{ID: uuid.New(), GoalID: goalID, Email: "friend@example.com", Permission: model.GoalPermissionView, Status: model.InvitationStatusDeclined, ExpiresAt: time.Now().Add(time.Hour)},
+			userEmail:  "friend@example.com",
+			wantErr:    repository.ErrInvitationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc, repo, members, users := newSharedSavingsService()
+			members.On("GetInvitationByToken", mock.Anything, "token").Return(tt.invitation, nil)
+			users.On("GetByID", mock.Anything, userID).Return(&model.User{ID: userID, Email: tt.userEmail}, nil).Maybe()
+			repo.On("GetByID", mock.Anything, goalID).Return(&model.SavingsGoal{ID: goalID, UserID: uuid.New()}, nil).Maybe()
+			members.On("AcceptInvitation", mock.Anything, tt.invitation, userID).Return(nil).Maybe()
+
+			goal, err := svc.AcceptInvitation(context.Background(), "token", userID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, goal)
+				members.AssertNotCalled(t, "AcceptInvitation", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, model.GoalPermissionContribute, goal.Permission)
+			}
+		})
+	}
+}
+
+func TestSavingsGoalService_ListMembers_TotalsPerMember(t *testing.T) {
+	t.Parallel()
+
+	svc, repo, members, _ := newSharedSavingsService()
+	goalID := uuid.New()
+	ownerID := uuid.New()
+	partnerID := uuid.New()
+
+	repo.On("GetByID", mock.Anything, goalID).Return(&model.SavingsGoal{ID: goalID, UserID: ownerID}, nil)
+	members.On("ListMembers", mock.Anything, goalID).Return([]model.SavingsGoalMember{
+		{UserID: ownerID, Permission: model.GoalPermissionOwner},
+		{UserID: partnerID, Permission: model.GoalPermissionContribute},
+	}, nil)
+	repo.On("ListContributions", mock.Anything, goalID).Return([]model.SavingsContribution{
+		{UserID: ownerID, Amount: decimal.NewFromInt(5000000)},
+		{UserID: partnerID, Amount: decimal.NewFromInt(2000000)},
+		{UserID: ownerID, Amount: decimal.NewFromInt(1000000)},
+	}, nil)
+
+	result, err := svc.ListMembers(context.Background(), goalID, ownerID)
+
+	assert.NoError(t, err)
+	assert.True(t, result[0].TotalContributed.Equal(decimal.NewFromInt(6000000)))
+	assert.True(t, result[1].TotalContributed.Equal(decimal.NewFromInt(2000000)))
+}
+
+func TestSavingsGoalService_RemoveMember(t *testing.T) {
+	t.Parallel()
+
+	goalID := uuid.New()
+	ownerID := uuid.New()
+	memberID := uuid.New()
+	otherID := uuid.New()
+
+	tests := []struct {
+		name     string
+		userID   uuid.UUID
+		memberID uuid.UUID
+		wantErr  error
+	}{
+		{name: "owner removes member", userID: ownerID, memberID: memberID},
+		{name: "member leaves", userID: memberID, memberID: memberID},
+		{name: "member cannot remove others", userID: memberID, memberID: otherID, wantErr: ErrGoalPermissionDenied},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc, repo, members, _ := newSharedSavingsService()
+			repo.On("GetByID", mock.Anything, goalID).Return(&model.SavingsGoal{ID: goalID, UserID: ownerID}, nil)
+			members.On("GetPermission", mock.Anything, goalID, memberID).Return(model.GoalPermissionEdit, nil).Maybe()
+			members.On("RemoveMember", mock.Anything, goalID, tt.memberID).Return(nil).Maybe()
+
+			err := svc.RemoveMember(context.Background(), goalID, tt.userID, tt.memberID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				members.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
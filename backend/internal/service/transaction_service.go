@@ -4,7 +4,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +18,10 @@ import (
 	"github.com/wealthpath/backend/pkg/datetime"
 )
 
+// ErrDebtPaymentTransaction is returned when changing an expense that records a debt
+// payment, which follows the payment instead.
+var ErrDebtPaymentTransaction = errors.New("transaction records a debt payment; correct or delete the payment instead")
+
 // TransactionRepositoryInterface defines the contract for transaction data access.
 // Implementations must be safe for concurrent use.
 type TransactionRepositoryInterface interface {
@@ -29,7 +35,20 @@ type TransactionRepositoryInterface interface {
 // TransactionService handles business logic for financial transactions.
 // It enforces validation rules and coordinates repository operations.
 type TransactionService struct {
-	repo TransactionRepositoryInterface
+	repo         TransactionRepositoryInterface
+	cards        CreditCardLookup
+	createdHooks []TransactionCreatedHook
+}
+
+// CreditCardLookup finds the credit card an expense is charged to.
+type CreditCardLookup interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*model.Debt, error)
+}
+
+// TransactionCreatedHook is notified after a transaction has been persisted.
+// Hook failures are logged and never fail the transaction itself.
+type TransactionCreatedHook interface {
+	OnTransactionCreated(ctx context.Context, tx *model.Transaction) error
 }
 
 // NewTransactionService creates a new TransactionService with the given repository.
@@ -38,6 +57,16 @@ func NewTransactionService(repo TransactionRepositoryInterface) *TransactionServ
 	return &TransactionService{repo: repo}
 }
 
+// SetCardRepo enables charging expenses to the user's credit cards.
+func (s *TransactionService) SetCardRepo(cards CreditCardLookup) {
+	s.cards = cards
+}
+
+// AddCreatedHook registers a hook that runs after each successful Create.
+func (s *TransactionService) AddCreatedHook(hook TransactionCreatedHook) {
+	s.createdHooks = append(s.createdHooks, hook)
+}
+
 type CreateTransactionInput struct {
 	Type        model.TransactionType `json:"type"`
 	Amount      decimal.Decimal       `json:"amount"`
@@ -45,6 +74,7 @@ type CreateTransactionInput struct {
 	Category    string                `json:"category"`
 	Description string                `json:"description"`
 	Date        datetime.Date         `json:"date"`
+	DebtID      *uuid.UUID            `json:"debtId,omitempty"` // credit card the expense is charged to
 }
 
 type UpdateTransactionInput struct {
@@ -54,6 +84,7 @@ type UpdateTransactionInput struct {
 	Category    string                `json:"category"`
 	Description string                `json:"description"`
 	Date        datetime.Date         `json:"date"`
+	DebtID      *uuid.UUID            `json:"debtId,omitempty"` // credit card the expense is charged to
 }
 
 type ListTransactionsInput struct {
@@ -66,15 +97,27 @@ type ListTransactionsInput struct {
 }
 
 // Create validates and persists a new transaction for the given user.
-// It sets default currency to USD if not specified and validates the currency code.
+// It sets default currency to USD, or to the card's currency for a card charge, if not
+// specified and validates the currency code. Card charges are added to the card balance.
 func (s *TransactionService) Create(ctx context.Context, userID uuid.UUID, input CreateTransactionInput) (*model.Transaction, error) {
+	card, err := s.chargedCard(ctx, userID, input.DebtID, input.Type)
+	if err != nil {
+		return nil, err
+	}
+
 	curr := input.Currency
 	if curr == "" {
 		curr = string(currency.DefaultCurrency)
+		if card != nil {
+			curr = card.Currency
+		}
 	}
 	if !currency.IsValid(curr) {
 		return nil, fmt.Errorf("invalid currency code: %s", curr)
 	}
+	if card != nil && card.Currency != curr {
+		return nil, ErrInvalidCardCharge
+	}
 
 	tx := &model.Transaction{
 		UserID:      userID,
@@ -84,12 +127,19 @@ func (s *TransactionService) Create(ctx context.Context, userID uuid.UUID, input
 		Category:    input.Category,
 		Description: input.Description,
 		Date:        input.Date.Time,
+		DebtID:      input.DebtID,
 	}
 
 	if err := s.repo.Create(ctx, tx); err != nil {
 		return nil, fmt.Errorf("creating transaction: %w", err)
 	}
 
+	for _, hook := range s.createdHooks {
+		if err := hook.OnTransactionCreated(ctx, tx); err != nil {
+			log.Printf("Transaction %s created hook failed: %v", tx.ID, err)
+		}
+	}
+
 	return tx, nil
 }
 
@@ -130,7 +180,8 @@ func (s *TransactionService) List(ctx context.Context, userID uuid.UUID, input L
 }
 
 // Update modifies an existing transaction.
-// Returns ErrTransactionNotFound if the transaction does not exist or belongs to another user.
+// Returns ErrTransactionNotFound if the transaction does not exist or belongs to another user,
+// and ErrDebtPaymentTransaction if it records a debt payment.
 func (s *TransactionService) Update(ctx context.Context, id uuid.UUID, userID uuid.UUID, input UpdateTransactionInput) (*model.Transaction, error) {
 	tx, err := s.repo.GetByID(ctx, id)
 	if err != nil {
@@ -140,6 +191,9 @@ func (s *TransactionService) Update(ctx context.Context, id uuid.UUID, userID uu
 	if tx.UserID != userID {
 		return nil, repository.ErrTransactionNotFound
 	}
+	if tx.DebtPaymentID != nil {
+		return nil, ErrDebtPaymentTransaction
+	}
 
 	curr := input.Currency
 	if curr != "" && !currency.IsValid(curr) {
@@ -154,6 +208,15 @@ func (s *TransactionService) Update(ctx context.Context, id uuid.UUID, userID uu
 	tx.Category = input.Category
 	tx.Description = input.Description
 	tx.Date = input.Date.Time
+	tx.DebtID = input.DebtID
+
+	card, err := s.chargedCard(ctx, userID, tx.DebtID, tx.Type)
+	if err != nil {
+		return nil, err
+	}
+	if card != nil && card.Currency != tx.Currency {
+		return nil, ErrInvalidCardCharge
+	}
 
 	if err := s.repo.Update(ctx, tx); err != nil {
 		return nil, fmt.Errorf("updating transaction %s: %w", id, err)
@@ -163,10 +226,45 @@ func (s *TransactionService) Update(ctx context.Context, id uuid.UUID, userID uu
 }
 
 // Delete removes a transaction by ID for the given user.
-// Returns ErrTransactionNotFound if the transaction does not exist or belongs to another user.
+// Returns ErrTransactionNotFound if the transaction does not exist or belongs to another user,
+// and ErrDebtPaymentTransaction if it records a debt payment.
 func (s *TransactionService) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	tx, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("fetching transaction %s for delete: %w", id, err)
+	}
+	if tx.UserID != userID {
+		return repository.ErrTransactionNotFound
+	}
+	if tx.DebtPaymentID != nil {
+		return ErrDebtPaymentTransaction
+	}
+
 	if err := s.repo.Delete(ctx, id, userID); err != nil {
 		return fmt.Errorf("deleting transaction %s: %w", id, err)
 	}
 	return nil
 }
+
+// chargedCard returns the user's credit card an expense is charged to, or nil when the
+// transaction is not charged to a card.
+func (s *TransactionService) chargedCard(ctx context.Context, userID uuid.UUID, debtID *uuid.UUID, txType model.TransactionType) (*model.Debt, error) {
+	if debtID == nil {
+		return nil, nil
+	}
+	if s.cards == nil || txType != model.TransactionTypeExpense {
+		return nil, ErrInvalidCardCharge
+	}
+
+	card, err := s.cards.GetByID(ctx, *debtID)
+	if errors.Is(err, repository.ErrDebtNotFound) {
+		return nil, ErrInvalidCardCharge
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching card %s: %w", *debtID, err)
+	}
+	if card.UserID != userID || card.Type != model.DebtTypeCreditCard {
+		return nil, ErrInvalidCardCharge
+	}
+	return card, nil
+}
@@ -394,6 +394,119 @@ func TestTransactionService_Create(t *testing.T) {
 	}
 }
 
+// MockCreatedHook implements TransactionCreatedHook for testing
+type MockCreatedHook struct {
+	mock.Mock
+}
+
+func (m *MockCreatedHook) OnTransactionCreated(ctx context.Context, tx *model.Transaction) error {
+	args := m.Called(ctx, tx)
+	return args.Error(0)
+}
+
+func TestTransactionService_Create_RunsCreatedHooks(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockTransactionRepo)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.Transaction")).Return(nil)
+
+	failing := new(MockCreatedHook)
+	failing.On("OnTransactionCreated", mock.Anything, mock.AnythingOfType("*model.Transaction")).Return(errors.New("hook error"))
+	succeeding := new(MockCreatedHook)
+	succeeding.On("OnTransactionCreated", mock.Anything, mock.AnythingOfType("*model.Transaction")).Return(nil)
+
+	service := NewTransactionService(mockRepo)
+	service.AddCreatedHook(failing)
+	service.AddCreatedHook(succeeding)
+
+	tx, err := service.Create(context.Background(), uuid.New(), CreateTransactionInput{
+		Type:     model.TransactionTypeExpense,
+		Amount:   decimal.NewFromInt(45000),
+		Currency: "VND",
+		Category: "Food",
+	})
+
+	// Hook failures are logged and never fail the transaction
+	assert.NoError(t, err)
+	assert.NotNil(t, tx)
+	failing.AssertExpectations(t)
+	succeeding.AssertExpectations(t)
+}
+
+func TestTransactionService_Create_CardCharge(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	card := &model.Debt{ID: uuid.New(), UserID: userID, Type: model.DebtTypeCreditCard, Currency: "VND"}
+	loan := &model.Debt{ID: uuid.New(), UserID: userID, Type: model.DebtTypePersonalLoan, Currency: "VND"}
+	otherCard := &model.Debt{ID: uuid.New(), UserID: uuid.New(), Type: model.DebtTypeCreditCard, Currency: "VND"}
+
+	tests := []struct {
+		name     string
+		debt     *model.Debt
+		txType   model.TransactionType
+		currency string
+		wantErr  error
+	}{
+		{name: "charged in card currency", debt: card, txType: model.TransactionTypeExpense},
+		{name: "income", debt: card, txType: model.TransactionTypeIncome, wantErr: ErrInvalidCardCharge},
+		{name: "not a credit card", debt: loan, txType: model.TransactionTypeExpense, wantErr: ErrInvalidCardCharge},
+		{name: "another user's card", debt: otherCard, txType: model.TransactionTypeExpense, wantErr: ErrInvalidCardCharge},
+		{name: "other currency", debt: card, txType: model.TransactionTypeExpense, currency: "USD", wantErr: ErrInvalidCardCharge},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := new(MockTransactionRepo)
+			mockCards := new(MockDebtRepo)
+			mockCards.On("GetByID", mock.Anything, tt.debt.ID).Return(tt.debt, nil).Maybe()
+			if tt.wantErr == nil {
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(tx *model.Transaction) bool {
+					return tx.DebtID != nil && *tx.DebtID == tt.debt.ID
+				})).Return(nil)
+			}
+
+			service := NewTransactionService(mockRepo)
+			service.SetCardRepo(mockCards)
+
+			tx, err := service.Create(context.Background(), userID, CreateTransactionInput{
+				Type:     tt.txType,
+				Amount:   decimal.NewFromInt(250000),
+				Currency: tt.currency,
+				Category: "Shopping",
+				DebtID:   &tt.debt.ID,
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "VND", tx.Currency)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTransactionService_Create_CardChargeWithoutCardRepo(t *testing.T) {
+	t.Parallel()
+
+	service := NewTransactionService(new(MockTransactionRepo))
+	cardID := uuid.New()
+
+	_, err := service.Create(context.Background(), uuid.New(), CreateTransactionInput{
+		Type:     model.TransactionTypeExpense,
+		Amount:   decimal.NewFromInt(100),
+		Category: "Shopping",
+		DebtID:   &cardID,
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidCardCharge)
+}
+
 func TestTransactionService_Get(t *testing.T) {
 	t.Parallel()
 
@@ -592,6 +705,7 @@ func TestTransactionService_Delete_Success(t *testing.T) {
 	userID := uuid.New()
 	txID := uuid.New()
 
+	mockRepo.On("GetByID", ctx, txID).Return(&model.Transaction{ID: txID, UserID: userID}, nil)
 	mockRepo.On("Delete", ctx, txID, userID).Return(nil)
 
 	err := service.Delete(ctx, txID, userID)
@@ -607,6 +721,7 @@ func TestTransactionService_Delete_Error(t *testing.T) {
 	userID := uuid.New()
 	txID := uuid.New()
 
+	mockRepo.On("GetByID", ctx, txID).Return(&model.Transaction{ID: txID, UserID: userID}, nil)
 	mockRepo.On("Delete", ctx, txID, userID).Return(errors.New("delete error"))
 
 	err := service.Delete(ctx, txID, userID)
@@ -615,6 +730,42 @@ func TestTransactionService_Delete_Error(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestTransactionService_DebtPaymentTransaction(t *testing.T) {
+	t.Parallel()
+
+	mockRepo := new(MockTransactionRepo)
+	service := NewTransactionService(mockRepo)
+	ctx := context.Background()
+	userID := uuid.New()
+	paymentID := uuid.New()
+	tx := &model.Transaction{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Type:          model.TransactionTypeExpense,
+		Amount:        decimal.NewFromInt(500),
+		Category:      model.CategoryDebtPayments,
+		DebtPaymentID: &paymentID,
+	}
+
+	mockRepo.On("GetByID", ctx, tx.ID).Return(tx, nil)
+
+	_, err := service.Update(ctx, tx.ID, userID, UpdateTransactionInput{
+		Type:     model.TransactionTypeExpense,
+		Amount:   decimal.NewFromInt(400),
+		Category: model.CategoryDebtPayments,
+	})
+	assert.ErrorIs(t, err, ErrDebtPaymentTransaction)
+
+	err = service.Delete(ctx, tx.ID, userID)
+	assert.ErrorIs(t, err, ErrDebtPaymentTransaction)
+
+	err = service.Delete(ctx, tx.ID, uuid.New())
+	assert.ErrorIs(t, err, repository.ErrTransactionNotFound)
+
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
 // Test categories
 func TestExpenseCategories(t *testing.T) {
 	expectedCategories := []string{
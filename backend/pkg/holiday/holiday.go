@@ -0,0 +1,47 @@
+// Package holiday provides public holiday calendars for moving scheduled dates off
+// weekends and holidays onto working days.
+package holiday
+
+import "time"
+
+// Calendar reports which days are public holidays.
+type Calendar interface {
+	IsHoliday(day time.Time) bool
+}
+
+// MaxShift is the most days Previous and Next move a day by. It bounds the days in a
+// row without a working day, such as Tet with the weekends around it.
+const MaxShift = 14
+
+// IsBusinessDay reports whether a day is a working day: a weekday that is not a holiday.
+func IsBusinessDay(cal Calendar, day time.Time) bool {
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		return false
+	}
+	return !cal.IsHoliday(day)
+}
+
+// Previous returns the day if it is a working day, or the last working day before it.
+func Previous(cal Calendar, day time.Time) time.Time {
+	return shift(cal, day, -1)
+}
+
+// Next returns the day if it is a working day, or the first working day after it.
+func Next(cal Calendar, day time.Time) time.Time {
+	return shift(cal, day, 1)
+}
+
+func shift(cal Calendar, day time.Time, step int) time.Time {
+	for i := 0; i < MaxShift; i++ {
+		if IsBusinessDay(cal, day) {
+			return day
+		}
+		day = day.AddDate(0, 0, step)
+	}
+	return day
+}
+
+// date returns the calendar date of t as a comparable key.
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,84 @@
+package holiday
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVietnam_IsHoliday(t *testing.T) {
+	tests := []struct {
+		name string
+		day  time.Time
+		want bool
+	}{
+		{"announced Tet break", ymd(2025, 1, 27), true},
+		{"working day after announced Tet break", ymd(2025, 2, 3), false},
+		{"announced National Day extra day", ymd(2024, 9, 3), true},
+		{"default Tet eve", ymd(2026, 2, 16), true},
+		{"default fourth day of Tet", ymd(2026, 2, 20), true},
+		{"Hung Kings on a Sunday", ymd(2026, 4, 26), true},
+		{"day off for Hung Kings on a Sunday", ymd(2026, 4, 27), true},
+		{"Reunification Day", ymd(2027, 4, 30), true},
+		{"National Day", ymd(2030, 9, 2), true},
+		{"second National Day before it", ymd(2026, 9, 1), true},
+		{"day after National Day with the second day before it", ymd(2026, 9, 3), false},
+		{"second National Day after it", ymd(2027, 9, 3), true},
+		{"ordinary day", ymd(2026, 6, 15), false},
+		{"time of day ignored", time.Date(2026, 5, 1, 15, 30, 0, 0, time.FixedZone("ICT", 7*3600)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Vietnam.IsHoliday(tt.day))
+		})
+	}
+}
+
+func TestVietnam_WeekendHolidaysGiveDistinctDaysOff(t *testing.T) {
+	// 2027: Tet runs Fri 5 Feb to Tue 9 Feb, so Saturday and Sunday give Wednesday and Thursday off.
+	assert.True(t, Vietnam.IsHoliday(ymd(2027, 2, 10)))
+	assert.True(t, Vietnam.IsHoliday(ymd(2027, 2, 11)))
+	assert.False(t, Vietnam.IsHoliday(ymd(2027, 2, 12)))
+}
+
+func TestVietnam_LunarDatesRange(t *testing.T) {
+	// The lunar table ends with 2030: Tet 2031 falls on 23 January, which is not known.
+	assert.True(t, Vietnam.IsHoliday(ymd(2030, 2, 3)))
+	assert.False(t, Vietnam.IsHoliday(ymd(2031, 1, 23)))
+	assert.True(t, Vietnam.IsHoliday(ymd(2031, 1, 1)))
+	assert.True(t, Vietnam.IsHoliday(ymd(2031, 9, 2)))
+}
+
+func TestVietnam_CachesYears(t *testing.T) {
+	Vietnam.IsHoliday(ymd(2029, 6, 1))
+	first, ok := vietnamByYear.Load(2029)
+	assert.True(t, ok)
+
+	Vietnam.IsHoliday(ymd(2029, 7, 1))
+	second, _ := vietnamByYear.Load(2029)
+	assert.Equal(t, reflect.ValueOf(first).Pointer(), reflect.ValueOf(second).Pointer())
+}
+
+func TestPreviousAndNext(t *testing.T) {
+	tests := []struct {
+		name         string
+		day          time.Time
+		wantPrevious time.Time
+		wantNext     time.Time
+	}{
+		{"working day", ymd(2026, 6, 15), ymd(2026, 6, 15), ymd(2026, 6, 15)},
+		{"saturday", ymd(2026, 10, 31), ymd(2026, 10, 30), ymd(2026, 11, 2)},
+		{"Tet", ymd(2025, 1, 31), ymd(2025, 1, 24), ymd(2025, 2, 3)},
+		{"New Year's Day", ymd(2026, 1, 1), ymd(2025, 12, 31), ymd(2026, 1, 2)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantPrevious, Previous(Vietnam, tt.day))
+			assert.Equal(t, tt.wantNext, Next(Vietnam, tt.day))
+		})
+	}
+}
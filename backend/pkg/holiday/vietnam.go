@@ -0,0 +1,119 @@
+package holiday
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Vietnam is the calendar of Vietnam's public holidays under the Labour Code 2019:
+// New Year's Day, Tet, the Hung Kings Commemoration, Reunification Day, Labour Day and
+// National Day. For years whose breaks the government has announced, including the
+// days swapped with weekends, those are used as announced. Otherwise the law's
+// defaults apply: the last day of the lunar year and the first four of the new one for
+// Tet, 2 September and the day before or after it for National Day, and a holiday on a
+// weekend moved to the next working day.
+//
+// Tet and the Hung Kings Commemoration are only known for the years in
+// vietnamLunarDates, 2024 to 2030; other years have the solar holidays alone.
+var Vietnam Calendar = vietnam{}
+
+type vietnam struct{}
+
+// vietnamByYear caches the holidays of each year asked for.
+var vietnamByYear sync.Map // int → map[time.Time]bool
+
+func (vietnam) IsHoliday(day time.Time) bool {
+	holidays, ok := vietnamByYear.Load(day.Year())
+	if !ok {
+		holidays, _ = vietnamByYear.LoadOrStore(day.Year(), vietnamHolidays(day.Year()))
+	}
+	return holidays.(map[time.Time]bool)[date(day)]
+}
+
+// vietnamLunarDates are the solar dates of the holidays set by the lunar calendar: the
+// first day of Tet and the Hung Kings Commemoration, the 10th day of the 3rd month.
+// Extend it before 2031.
+var vietnamLunarDates = map[int]struct{ tet, hungKings time.Time }{
+	2024: {ymd(2024, 2, 10), ymd(2024, 4, 18)},
+	2025: {ymd(2025, 1, 29), ymd(2025, 4, 7)},
+	2026: {ymd(2026, 2, 17), ymd(2026, 4, 26)},
+	2027: {ymd(2027, 2, 6), ymd(2027, 4, 16)},
+	2028: {ymd(2028, 1, 26), ymd(2028, 4, 4)},
+	2029: {ymd(2029, 2, 13), ymd(2029, 4, 23)},
+	2030: {ymd(2030, 2, 3), ymd(2030, 4, 12)},
+}
+
+// vietnamAnnounced are the holiday breaks announced for civil servants, from first to
+// last day off, which banks and most employers follow.
+var vietnamAnnounced = map[int][][2]time.Time{
+	2024: {
+		{ymd(2024, 1, 1), ymd(2024, 1, 1)},
+		{ymd(2024, 2, 8), ymd(2024, 2, 14)},
+		{ymd(2024, 4, 18), ymd(2024, 4, 18)},
+		{ymd(2024, 4, 27), ymd(2024, 5, 1)},
+		{ymd(2024, 8, 31), ymd(2024, 9, 3)},
+	},
+	2025: {
+		{ymd(2025, 1, 1), ymd(2025, 1, 1)},
+		{ymd(2025, 1, 25), ymd(2025, 2, 2)},
+		{ymd(2025, 4, 7), ymd(2025, 4, 7)},
+		{ymd(2025, 4, 30), ymd(2025, 5, 4)},
+		{ymd(2025, 8, 30), ymd(2025, 9, 2)},
+	},
+}
+
+// vietnamHolidays returns the holidays of a year.
+func vietnamHolidays(year int) map[time.Time]bool {
+	holidays := make(map[time.Time]bool)
+	if breaks, ok := vietnamAnnounced[year]; ok {
+		for _, b := range breaks {
+			for d := b[0]; !d.After(b[1]); d = d.AddDate(0, 0, 1) {
+				holidays[d] = true
+			}
+		}
+		return holidays
+	}
+
+	days := []time.Time{ymd(year, 1, 1), ymd(year, 4, 30), ymd(year, 5, 1), ymd(year, 9, 2), nationalDaySecondDay(year)}
+	if lunar, ok := vietnamLunarDates[year]; ok {
+		for i := -1; i < 4; i++ {
+			days = append(days, lunar.tet.AddDate(0, 0, i))
+		}
+		days = append(days, lunar.hungKings)
+	} else {
+		log.Printf("holiday: lunar dates of %d are unknown; Tet and the Hung Kings Commemoration are not holidays", year)
+	}
+	for _, d := range days {
+		holidays[d] = true
+	}
+
+	// A holiday on a weekend gives a day off on the next working day.
+	for _, d := range days {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			continue
+		}
+		off := d
+		for off.Weekday() == time.Saturday || off.Weekday() == time.Sunday || holidays[off] {
+			off = off.AddDate(0, 0, 1)
+		}
+		holidays[off] = true
+	}
+	return holidays
+}
+
+// nationalDaySecondDay returns the second day of National Day, which the government
+// picks from the day before or after 2 September each year. It is taken to be the one
+// announced breaks have used: the day that joins the holiday to a weekend, and
+// otherwise the day before.
+func nationalDaySecondDay(year int) time.Time {
+	switch ymd(year, 9, 2).Weekday() {
+	case time.Sunday, time.Monday, time.Thursday:
+		return ymd(year, 9, 3)
+	}
+	return ymd(year, 9, 1)
+}
+
+func ymd(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
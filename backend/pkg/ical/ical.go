@@ -0,0 +1,133 @@
+// Package ical writes iCalendar (RFC 5545) feeds of all-day events, optionally
+// repeating by an RRULE, with display reminders. Calendar clients match events across
+// refreshes by UID, so UIDs must stay the same for the same event.
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the longest content line RFC 5545 allows before folding.
+const maxLineOctets = 75
+
+// Alarm is a display reminder shown Before the event starts.
+type Alarm struct {
+	Before      time.Duration
+	Description string
+}
+
+// Event is an all-day event on Date, repeating by RRule when set.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Date        time.Time
+	RRule       string // without the "RRULE:" prefix
+	Alarms      []Alarm
+}
+
+// Calendar is a feed of events. Stamp is when the feed was generated.
+type Calendar struct {
+	ProdID  string
+	Name    string
+	Refresh time.Duration // how often clients should refresh the feed
+	Stamp   time.Time
+	Events  []Event
+}
+
+// WriteTo writes the calendar in iCalendar format.
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+	line := func(name, value string) { writeLine(&b, name+":"+value) }
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", c.ProdID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escapeText(c.Name))
+	}
+	if c.Refresh > 0 {
+		line("REFRESH-INTERVAL;VALUE=DURATION", formatDuration(c.Refresh))
+		line("X-PUBLISHED-TTL", formatDuration(c.Refresh))
+	}
+	stamp := c.Stamp.UTC().Format("20060102T150405Z")
+	for _, e := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", e.UID)
+		line("DTSTAMP", stamp)
+		line("DTSTART;VALUE=DATE", e.Date.Format("20060102"))
+		line("DTEND;VALUE=DATE", e.Date.AddDate(0, 0, 1).Format("20060102"))
+		if e.RRule != "" {
+			line("RRULE", e.RRule)
+		}
+		line("SUMMARY", escapeText(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escapeText(e.Description))
+		}
+		line("TRANSP", "TRANSPARENT")
+		for _, a := range e.Alarms {
+			line("BEGIN", "VALARM")
+			line("ACTION", "DISPLAY")
+			line("TRIGGER", "-"+formatDuration(a.Before))
+			line("DESCRIPTION", escapeText(a.Description))
+			line("END", "VALARM")
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+
+	n, err := w.Write(b.Bytes())
+	return int64(n), err
+}
+
+// writeLine writes a content line, folded at 75 octets without splitting a character.
+func writeLine(b *bytes.Buffer, s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = maxLineOctets - 1 // continuation lines start with a space
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escapeText escapes a TEXT property value.
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// formatDuration formats a positive duration as an iCalendar duration such as P1D or PT2H30M.
+func formatDuration(d time.Duration) string {
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	s := "P"
+	if days > 0 {
+		s += fmt.Sprintf("%dD", days)
+	}
+	if d > 0 || days == 0 {
+		s += "T"
+		if h := d / time.Hour; h > 0 {
+			s += fmt.Sprintf("%dH", h)
+			d -= h * time.Hour
+		}
+		if m := d / time.Minute; m > 0 || s == "PT" {
+			s += fmt.Sprintf("%dM", m)
+		}
+	}
+	return s
+}
@@ -0,0 +1,89 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendar_WriteTo(t *testing.T) {
+	cal := &Calendar{
+		ProdID:  "-//WealthPath//Bills//EN",
+		Name:    "WealthPath bills",
+		Refresh: 12 * time.Hour,
+		Stamp:   time.Date(2026, time.October, 18, 9, 30, 0, 0, time.FixedZone("ICT", 7*60*60)),
+		Events: []Event{{
+			UID:     "recurring-1@wealthpath",
+			Summary: "Rent; Q4, due",
+			Date:    time.Date(2026, time.October, 31, 0, 0, 0, 0, time.UTC),
+			RRule:   "FREQ=MONTHLY;BYMONTHDAY=-1",
+			Alarms:  []Alarm{{Before: 24 * time.Hour, Description: "Rent due tomorrow"}},
+		}},
+	}
+
+	var b bytes.Buffer
+	_, err := cal.WriteTo(&b)
+	require.NoError(t, err)
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//WealthPath//Bills//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:WealthPath bills",
+		"REFRESH-INTERVAL;VALUE=DURATION:PT12H",
+		"X-PUBLISHED-TTL:PT12H",
+		"BEGIN:VEVENT",
+		"UID:recurring-1@wealthpath",
+		"DTSTAMP:20261018T023000Z",
+		"DTSTART;VALUE=DATE:20261031",
+		"DTEND;VALUE=DATE:20261101",
+		"RRULE:FREQ=MONTHLY;BYMONTHDAY=-1",
+		`SUMMARY:Rent\; Q4\, due`,
+		"TRANSP:TRANSPARENT",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"TRIGGER:-P1D",
+		"DESCRIPTION:Rent due tomorrow",
+		"END:VALARM",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	assert.Equal(t, want, b.String())
+}
+
+func TestWriteLine_Folds(t *testing.T) {
+	var b bytes.Buffer
+	writeLine(&b, "SUMMARY:"+strings.Repeat("Trả góp điện thoại ", 8))
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	require.Greater(t, len(lines), 1)
+	unfolded := lines[0]
+	for _, l := range lines {
+		assert.LessOrEqual(t, len(l), maxLineOctets)
+	}
+	for _, l := range lines[1:] {
+		require.True(t, strings.HasPrefix(l, " "))
+		unfolded += l[1:]
+	}
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("Trả góp điện thoại ", 8), unfolded)
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		24 * time.Hour:               "P1D",
+		7 * 24 * time.Hour:           "P7D",
+		2*time.Hour + 30*time.Minute: "PT2H30M",
+		26 * time.Hour:               "P1DT2H",
+		0:                            "PT0M",
+	}
+	for d, want := range tests {
+		assert.Equal(t, want, formatDuration(d), d.String())
+	}
+}
@@ -0,0 +1,512 @@
+// Package rrule parses and expands RFC 5545 recurrence rules for date-based schedules.
+// It supports FREQ DAILY, WEEKLY, MONTHLY and YEARLY with INTERVAL, COUNT, UNTIL,
+// BYMONTH, BYMONTHDAY, BYDAY, BYSETPOS and WKST. Occurrences are whole days that keep
+// the time of day and location of the series start.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRule is returned for recurrence rules that cannot be parsed or are not supported.
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// Frequency is the base period of a rule.
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// maxEmptyPeriods stops expanding a rule that has matched nothing for this many
+// periods in a row, such as the 30th of February.
+const maxEmptyPeriods = 1000
+
+// Weekday is a BYDAY entry. N selects the Nth such weekday of the month or year,
+// counting from the end when negative; zero means every such weekday.
+type Weekday struct {
+	Day time.Weekday
+	N   int
+}
+
+// Rule is a parsed recurrence rule.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int        // zero for no limit
+	Until      *time.Time // compared by calendar date
+	ByMonth    []time.Month
+	ByMonthDay []int
+	ByDay      []Weekday
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Parse parses a recurrence rule such as "FREQ=MONTHLY;BYMONTHDAY=15,-1".
+// The "RRULE:" prefix is optional.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	r := &Rule{Interval: 1, WeekStart: time.Monday}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: %q is not NAME=VALUE", ErrInvalidRule, part)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("%w: %s given twice", ErrInvalidRule, key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq = Frequency(value)
+			if r.Freq != Daily && r.Freq != Weekly && r.Freq != Monthly && r.Freq != Yearly {
+				err = fmt.Errorf("FREQ %s is not supported", value)
+			}
+		case "INTERVAL":
+			r.Interval, err = parseInt(value, 1, 1000)
+		case "COUNT":
+			r.Count, err = parseInt(value, 1, 10000)
+		case "UNTIL":
+			var until time.Time
+			until, err = parseUntil(value)
+			r.Until = &until
+		case "BYMONTH":
+			err = parseList(value, func(v string) error {
+				m, err := parseInt(v, 1, 12)
+				r.ByMonth = append(r.ByMonth, time.Month(m))
+				return err
+			})
+		case "BYMONTHDAY":
+			err = parseList(value, func(v string) error {
+				d, err := parseSignedInt(v, 31)
+				r.ByMonthDay = append(r.ByMonthDay, d)
+				return err
+			})
+		case "BYDAY":
+			err = parseList(value, func(v string) error {
+				d, err := parseWeekday(v)
+				r.ByDay = append(r.ByDay, d)
+				return err
+			})
+		case "BYSETPOS":
+			err = parseList(value, func(v string) error {
+				p, err := parseSignedInt(v, 366)
+				r.BySetPos = append(r.BySetPos, p)
+				return err
+			})
+		case "WKST":
+			day, ok := weekdayCodes[value]
+			if !ok {
+				err = fmt.Errorf("WKST %s is not a weekday", value)
+			}
+			r.WeekStart = day
+		default:
+			err = fmt.Errorf("%s is not supported", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+	}
+
+	if err := r.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+	return r, nil
+}
+
+func (r *Rule) validate() error {
+	switch {
+	case r.Freq == "":
+		return errors.New("FREQ is required")
+	case r.Count > 0 && r.Until != nil:
+		return errors.New("COUNT and UNTIL cannot both be set")
+	case r.Freq == Weekly && len(r.ByMonthDay) > 0:
+		return errors.New("BYMONTHDAY is not allowed with FREQ=WEEKLY")
+	case len(r.BySetPos) > 0 && len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) == 0:
+		return errors.New("BYSETPOS needs another BYxxx rule part")
+	}
+	for _, d := range r.ByDay {
+		if d.N == 0 {
+			continue
+		}
+		if r.Freq != Monthly && r.Freq != Yearly {
+			return errors.New("numbered BYDAY is only allowed with FREQ=MONTHLY or YEARLY")
+		}
+		if (r.Freq == Monthly || len(r.ByMonth) > 0) && (d.N > 5 || d.N < -5) {
+			return errors.New("numbered BYDAY must be within -5 and 5 in a month")
+		}
+	}
+	return nil
+}
+
+func parseInt(s string, lo, hi int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < lo || n > hi {
+		return 0, fmt.Errorf("%q must be a number from %d to %d", s, lo, hi)
+	}
+	return n, nil
+}
+
+// parseSignedInt parses a non-zero number from -limit to limit.
+func parseSignedInt(s string, limit int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n == 0 || n > limit || n < -limit {
+		return 0, fmt.Errorf("%q must be a non-zero number from -%d to %d", s, limit, limit)
+	}
+	return n, nil
+}
+
+func parseList(value string, parse func(string) error) error {
+	for _, v := range strings.Split(value, ",") {
+		if err := parse(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseWeekday(s string) (Weekday, error) {
+	if len(s) < 2 {
+		return Weekday{}, fmt.Errorf("%q is not a weekday", s)
+	}
+	day, ok := weekdayCodes[s[len(s)-2:]]
+	if !ok {
+		return Weekday{}, fmt.Errorf("%q is not a weekday", s)
+	}
+	w := Weekday{Day: day}
+	if prefix := s[:len(s)-2]; prefix != "" {
+		n, err := parseSignedInt(strings.TrimPrefix(prefix, "+"), 53)
+		if err != nil {
+			return Weekday{}, err
+		}
+		w.N = n
+	}
+	return w, nil
+}
+
+func parseUntil(s string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("UNTIL %s is not a date", s)
+}
+
+// String formats the rule in canonical form, without the "RRULE:" prefix.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		u := r.Until.UTC()
+		if u.Equal(dateOf(u)) {
+			parts = append(parts, "UNTIL="+u.Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+u.Format("20060102T150405Z"))
+		}
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(len(r.ByMonth), func(i int) int { return int(r.ByMonth[i]) }))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(len(r.ByMonthDay), func(i int) int { return r.ByMonthDay[i] }))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = weekdayNames[d.Day]
+			if d.N != 0 {
+				days[i] = strconv.Itoa(d.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(len(r.BySetPos), func(i int) int { return r.BySetPos[i] }))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+func joinInts(n int, at func(int) int) string {
+	s := make([]string, n)
+	for i := range s {
+		s[i] = strconv.Itoa(at(i))
+	}
+	return strings.Join(s, ",")
+}
+
+// After returns the first occurrence after t, or on t when inclusive, of the series
+// that starts at dtstart. It reports false when the series has ended.
+func (r *Rule) After(dtstart, t time.Time, inclusive bool) (time.Time, bool) {
+	var next time.Time
+	found := false
+	r.each(dtstart, t, func(occ time.Time) bool {
+		if occ.After(t) || (inclusive && occ.Equal(t)) {
+			next, found = occ, true
+			return false
+		}
+		return true
+	})
+	return next, found
+}
+
+// Between returns the occurrences from from through to, inclusive, of the series that
+// starts at dtstart.
+func (r *Rule) Between(dtstart, from, to time.Time) []time.Time {
+	var occurrences []time.Time
+	r.each(dtstart, from, func(occ time.Time) bool {
+		if occ.After(to) {
+			return false
+		}
+		if !occ.Before(from) {
+			occurrences = append(occurrences, occ)
+		}
+		return true
+	})
+	return occurrences
+}
+
+// each calls fn with the occurrences of the series in order until fn returns false or the
+// series ends. Without COUNT, expansion starts at the period containing from.
+func (r *Rule) each(dtstart, from time.Time, fn func(time.Time) bool) {
+	start := dateOf(dtstart)
+	interval := max(r.Interval, 1)
+	var until time.Time
+	if r.Until != nil {
+		until = time.Date(r.Until.Year(), r.Until.Month(), r.Until.Day(), 0, 0, 0, 0, start.Location())
+	}
+
+	period := 0
+	if r.Count == 0 && from.After(dtstart) {
+		period = r.unitsBetween(start, dateOf(from.In(dtstart.Location()))) / interval
+	}
+
+	emitted := 0
+	for empty := 0; empty < maxEmptyPeriods; period++ {
+		days := r.expand(start, r.periodStart(start, period*interval))
+		if len(days) == 0 {
+			empty++
+			continue
+		}
+		empty = 0
+		for _, day := range days {
+			if day.Before(start) {
+				continue
+			}
+			if r.Until != nil && day.After(until) {
+				return
+			}
+			occ := time.Date(day.Year(), day.Month(), day.Day(),
+				dtstart.Hour(), dtstart.Minute(), dtstart.Second(), dtstart.Nanosecond(), dtstart.Location())
+			if !fn(occ) {
+				return
+			}
+			emitted++
+			if r.Count > 0 && emitted >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// unitsBetween counts the whole periods of the rule's frequency from start to day.
+func (r *Rule) unitsBetween(start, day time.Time) int {
+	switch r.Freq {
+	case Daily:
+		return daysBetween(start, day)
+	case Weekly:
+		return daysBetween(r.weekOf(start), day) / 7
+	case Monthly:
+		return (day.Year()-start.Year())*12 + int(day.Month()) - int(start.Month())
+	default:
+		return day.Year() - start.Year()
+	}
+}
+
+// periodStart returns the first day of the period n periods after the one containing start.
+func (r *Rule) periodStart(start time.Time, n int) time.Time {
+	switch r.Freq {
+	case Daily:
+		return start.AddDate(0, 0, n)
+	case Weekly:
+		return r.weekOf(start).AddDate(0, 0, 7*n)
+	case Monthly:
+		return time.Date(start.Year(), start.Month()+time.Month(n), 1, 0, 0, 0, 0, start.Location())
+	default:
+		return time.Date(start.Year()+n, time.January, 1, 0, 0, 0, 0, start.Location())
+	}
+}
+
+func (r *Rule) weekOf(day time.Time) time.Time {
+	offset := (int(day.Weekday()) - int(r.WeekStart) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// expand returns the days of the period starting at first that match the rule, in order.
+func (r *Rule) expand(start, first time.Time) []time.Time {
+	var end time.Time
+	switch r.Freq {
+	case Daily:
+		end = first.AddDate(0, 0, 1)
+	case Weekly:
+		end = first.AddDate(0, 0, 7)
+	case Monthly:
+		end = first.AddDate(0, 1, 0)
+	default:
+		end = first.AddDate(1, 0, 0)
+	}
+
+	var days []time.Time
+	for day := first; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if r.matches(start, day) {
+			days = append(days, day)
+		}
+	}
+	return r.setPositions(days)
+}
+
+func (r *Rule) matches(start, day time.Time) bool {
+	if len(r.ByMonth) > 0 && !containsMonth(r.ByMonth, day.Month()) {
+		return false
+	}
+	if len(r.ByMonthDay) > 0 && !r.matchesMonthDay(day) {
+		return false
+	}
+	if len(r.ByDay) > 0 && !r.matchesWeekday(day) {
+		return false
+	}
+
+	// Without day rule parts, the series repeats on the start's day of the period.
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		switch r.Freq {
+		case Weekly:
+			return day.Weekday() == start.Weekday()
+		case Monthly:
+			return day.Day() == start.Day()
+		case Yearly:
+			if len(r.ByMonth) == 0 && day.Month() != start.Month() {
+				return false
+			}
+			return day.Day() == start.Day()
+		}
+	}
+	return true
+}
+
+func (r *Rule) matchesMonthDay(day time.Time) bool {
+	last := daysIn(day.Year(), day.Month())
+	for _, d := range r.ByMonthDay {
+		if d == day.Day() || (d < 0 && last+d+1 == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchesWeekday(day time.Time) bool {
+	for _, w := range r.ByDay {
+		if w.Day != day.Weekday() {
+			continue
+		}
+		if w.N == 0 {
+			return true
+		}
+
+		// Numbered weekdays count within the month, or the year for yearly rules
+		// without BYMONTH.
+		var first, last int
+		if r.Freq == Yearly && len(r.ByMonth) == 0 {
+			first, last = day.YearDay(), time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+		} else {
+			first, last = day.Day(), daysIn(day.Year(), day.Month())
+		}
+		if w.N > 0 && (first-1)/7+1 == w.N {
+			return true
+		}
+		if w.N < 0 && -((last-first)/7+1) == w.N {
+			return true
+		}
+	}
+	return false
+}
+
+// setPositions keeps the BYSETPOS positions of the period's days, in order.
+func (r *Rule) setPositions(days []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 || len(days) == 0 {
+		return days
+	}
+	keep := make(map[int]bool, len(r.BySetPos))
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(days) + pos
+		}
+		if i >= 0 && i < len(days) {
+			keep[i] = true
+		}
+	}
+	indexes := make([]int, 0, len(keep))
+	for i := range keep {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	selected := make([]time.Time, len(indexes))
+	for j, i := range indexes {
+		selected[j] = days[i]
+	}
+	return selected
+}
+
+func containsMonth(months []time.Month, m time.Month) bool {
+	for _, month := range months {
+		if month == m {
+			return true
+		}
+	}
+	return false
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// daysBetween counts calendar days from a to b, unaffected by daylight saving changes.
+func daysBetween(a, b time.Time) int {
+	ua := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	ub := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(ub.Sub(ua).Hours() / 24)
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,222 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParse_String(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"FREQ=MONTHLY", "FREQ=MONTHLY"},
+		{"RRULE:freq=monthly;bymonthday=15,-1", "FREQ=MONTHLY;BYMONTHDAY=15,-1"},
+		{"FREQ=MONTHLY;INTERVAL=3", "FREQ=MONTHLY;INTERVAL=3"},
+		{"FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,WE,FR", "FREQ=WEEKLY;BYDAY=MO,WE,FR"},
+		{"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1"},
+		{"FREQ=MONTHLY;BYDAY=+2TU;COUNT=6", "FREQ=MONTHLY;COUNT=6;BYDAY=2TU"},
+		{"FREQ=YEARLY;BYMONTH=1,7;BYMONTHDAY=20;UNTIL=20301231", "FREQ=YEARLY;UNTIL=20301231;BYMONTH=1,7;BYMONTHDAY=20"},
+		{"FREQ=DAILY;UNTIL=20270101T100000Z;WKST=SU", "FREQ=DAILY;UNTIL=20270101T100000Z;WKST=SU"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			r, err := Parse(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, r.String())
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"BYMONTHDAY=1",
+		"FREQ=HOURLY",
+		"FREQ=MONTHLY;INTERVAL=0",
+		"FREQ=MONTHLY;COUNT=2;UNTIL=20300101",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=MONTHLY;BYDAY=6FR",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYSETPOS=1",
+		"FREQ=MONTHLY;FREQ=DAILY",
+		"FREQ=MONTHLY;BYHOUR=9",
+		"FREQ=MONTHLY;UNTIL=tomorrow",
+		"FREQ",
+	}
+
+	for _, in := range tests {
+		t.Run(in, func(t *testing.T) {
+			_, err := Parse(in)
+			assert.ErrorIs(t, err, ErrInvalidRule)
+		})
+	}
+}
+
+func TestRule_Between(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		to      time.Time
+		want    []time.Time
+	}{
+		{
+			name:    "15th and last day of each month",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=15,-1",
+			dtstart: date(2027, time.January, 20),
+			to:      date(2027, time.March, 31),
+			want: []time.Time{
+				date(2027, time.January, 31), date(2027, time.February, 15), date(2027, time.February, 28),
+				date(2027, time.March, 15), date(2027, time.March, 31),
+			},
+		},
+		{
+			name:    "every 3 months",
+			rule:    "FREQ=MONTHLY;INTERVAL=3",
+			dtstart: date(2026, time.November, 5),
+			to:      date(2027, time.December, 31),
+			want: []time.Time{
+				date(2026, time.November, 5), date(2027, time.February, 5), date(2027, time.May, 5),
+				date(2027, time.August, 5), date(2027, time.November, 5),
+			},
+		},
+		{
+			name:    "last business day",
+			rule:    "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			dtstart: date(2027, time.January, 1),
+			to:      date(2027, time.April, 30),
+			want: []time.Time{
+				date(2027, time.January, 29), date(2027, time.February, 26), date(2027, time.March, 31),
+				date(2027, time.April, 30),
+			},
+		},
+		{
+			name:    "second Tuesday, three times",
+			rule:    "FREQ=MONTHLY;BYDAY=2TU;COUNT=3",
+			dtstart: date(2026, time.October, 1),
+			to:      date(2027, time.December, 31),
+			want:    []time.Time{date(2026, time.October, 13), date(2026, time.November, 10), date(2026, time.December, 8)},
+		},
+		{
+			name:    "last Friday",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: date(2026, time.October, 1),
+			to:      date(2026, time.December, 31),
+			want:    []time.Time{date(2026, time.October, 30), date(2026, time.November, 27), date(2026, time.December, 25)},
+		},
+		{
+			name:    "monthly on the 31st skips shorter months",
+			rule:    "FREQ=MONTHLY",
+			dtstart: date(2027, time.January, 31),
+			to:      date(2027, time.May, 31),
+			want:    []time.Time{date(2027, time.January, 31), date(2027, time.March, 31), date(2027, time.May, 31)},
+		},
+		{
+			name:    "every other week on Monday and Thursday",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			dtstart: date(2026, time.October, 15),
+			to:      date(2026, time.November, 10),
+			want: []time.Time{
+				date(2026, time.October, 15), date(2026, time.October, 26), date(2026, time.October, 29),
+				date(2026, time.November, 9),
+			},
+		},
+		{
+			name:    "daily until a date",
+			rule:    "FREQ=DAILY;INTERVAL=10;UNTIL=20261120",
+			dtstart: date(2026, time.October, 31),
+			to:      date(2026, time.December, 31),
+			want:    []time.Time{date(2026, time.October, 31), date(2026, time.November, 10), date(2026, time.November, 20)},
+		},
+		{
+			name:    "yearly on start date",
+			rule:    "FREQ=YEARLY",
+			dtstart: date(2024, time.February, 29),
+			to:      date(2032, time.December, 31),
+			want:    []time.Time{date(2024, time.February, 29), date(2028, time.February, 29), date(2032, time.February, 29)},
+		},
+		{
+			name:    "yearly in chosen months",
+			rule:    "FREQ=YEARLY;BYMONTH=1,7;BYMONTHDAY=20",
+			dtstart: date(2026, time.March, 1),
+			to:      date(2027, time.December, 31),
+			want:    []time.Time{date(2026, time.July, 20), date(2027, time.January, 20), date(2027, time.July, 20)},
+		},
+		{
+			name:    "last Monday of the year",
+			rule:    "FREQ=YEARLY;BYDAY=-1MO",
+			dtstart: date(2026, time.January, 1),
+			to:      date(2027, time.December, 31),
+			want:    []time.Time{date(2026, time.December, 28), date(2027, time.December, 27)},
+		},
+		{
+			name:    "impossible date",
+			rule:    "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			dtstart: date(2026, time.January, 1),
+			to:      date(2030, time.December, 31),
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse(tt.rule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, r.Between(tt.dtstart, tt.dtstart, tt.to))
+		})
+	}
+}
+
+func TestRule_After(t *testing.T) {
+	loc := time.FixedZone("ICT", 7*60*60)
+	dtstart := time.Date(2020, time.January, 15, 9, 30, 0, 0, loc)
+	r, err := Parse("FREQ=MONTHLY;BYMONTHDAY=15,-1")
+	require.NoError(t, err)
+
+	next, ok := r.After(dtstart, time.Date(2026, time.October, 18, 12, 0, 0, 0, loc), false)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, time.October, 31, 9, 30, 0, 0, loc), next)
+
+	next, ok = r.After(dtstart, next, false)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, time.November, 15, 9, 30, 0, 0, loc), next)
+
+	next, ok = r.After(dtstart, next, true)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2026, time.November, 15, 9, 30, 0, 0, loc), next)
+}
+
+func TestRule_After_SeriesEnded(t *testing.T) {
+	dtstart := date(2026, time.January, 10)
+
+	counted, err := Parse("FREQ=MONTHLY;COUNT=3")
+	require.NoError(t, err)
+	next, ok := counted.After(dtstart, date(2026, time.February, 10), false)
+	require.True(t, ok)
+	assert.Equal(t, date(2026, time.March, 10), next)
+	_, ok = counted.After(dtstart, next, false)
+	assert.False(t, ok)
+
+	until, err := Parse("FREQ=WEEKLY;UNTIL=20260124")
+	require.NoError(t, err)
+	next, ok = until.After(dtstart, dtstart, false)
+	require.True(t, ok)
+	assert.Equal(t, date(2026, time.January, 17), next)
+	next, ok = until.After(dtstart, next, false)
+	require.True(t, ok)
+	assert.Equal(t, date(2026, time.January, 24), next)
+	_, ok = until.After(dtstart, next, false)
+	assert.False(t, ok)
+}
@@ -52,10 +52,17 @@ CREATE TABLE IF NOT EXISTS transactions (
     category VARCHAR(100) NOT NULL,
     description TEXT,
     date DATE NOT NULL,
+    debt_id UUID,
+    debt_payment_id UUID,
+    recurring_id UUID,
+    occurrence_date DATE,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_recurring_occurrence
+ON transactions(recurring_id, occurrence_date) WHERE recurring_id IS NOT NULL;
+
 CREATE TABLE IF NOT EXISTS budgets (
     id UUID PRIMARY KEY,
     user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
@@ -83,6 +90,34 @@ CREATE TABLE IF NOT EXISTS savings_goals (
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
+CREATE TABLE IF NOT EXISTS savings_rules (
+    id UUID PRIMARY KEY,
+    goal_id UUID NOT NULL REFERENCES savings_goals(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(30) NOT NULL,
+    round_to DECIMAL(15, 2),
+    percentage DECIMAL(5, 2),
+    category VARCHAR(100),
+    amount DECIMAL(15, 2),
+    frequency VARCHAR(20),
+    next_run DATE,
+    anchor_day SMALLINT,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS savings_contributions (
+    id UUID PRIMARY KEY,
+    goal_id UUID NOT NULL REFERENCES savings_goals(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount DECIMAL(15, 2) NOT NULL,
+    date DATE NOT NULL,
+    rule_id UUID REFERENCES savings_rules(id) ON DELETE SET NULL,
+    source_transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
 CREATE TABLE IF NOT EXISTS debts (
     id UUID PRIMARY KEY,
     user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
@@ -96,10 +131,51 @@ CREATE TABLE IF NOT EXISTS debts (
     due_day INTEGER,
     start_date DATE NOT NULL,
     expected_payoff DATE,
+    term_months INTEGER,
+    fixed_rate_months INTEGER,
+    rate_margin DECIMAL(5, 2),
+    reference_bank_code VARCHAR(20),
+    reference_term_months INTEGER,
+    rate_reset_months INTEGER,
+    interest_method VARCHAR(20) NOT NULL DEFAULT 'declining_balance',
+    statement_day INTEGER,
+    grace_period_days INTEGER,
+    minimum_payment_percent DECIMAL(5, 2),
+    merchant VARCHAR(255),
+    installment_fee DECIMAL(15, 2),
+    card_id UUID,
+    credit_limit DECIMAL(15, 2),
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
+CREATE TABLE IF NOT EXISTS debt_payments (
+    id UUID PRIMARY KEY,
+    debt_id UUID NOT NULL REFERENCES debts(id) ON DELETE CASCADE,
+    amount DECIMAL(15, 2) NOT NULL,
+    principal DECIMAL(15, 2) NOT NULL,
+    interest DECIMAL(15, 2) NOT NULL,
+    date DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    split_interest BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE TABLE IF NOT EXISTS credit_card_statements (
+    id UUID PRIMARY KEY,
+    debt_id UUID NOT NULL REFERENCES debts(id) ON DELETE CASCADE,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    due_date DATE NOT NULL,
+    opening_balance DECIMAL(15, 2) NOT NULL,
+    purchases DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    payments DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    interest DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    closing_balance DECIMAL(15, 2) NOT NULL,
+    minimum_due DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (debt_id, period_end)
+);
+
 CREATE TABLE IF NOT EXISTS recurring_transactions (
     id UUID PRIMARY KEY,
     user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
@@ -109,6 +185,12 @@ CREATE TABLE IF NOT EXISTS recurring_transactions (
     category VARCHAR(100) NOT NULL,
     description TEXT,
     frequency VARCHAR(20) NOT NULL,
+    rrule TEXT,
+    amount_type VARCHAR(20) NOT NULL DEFAULT 'fixed',
+    anchor_day SMALLINT,
+    business_day_policy VARCHAR(20) NOT NULL DEFAULT 'keep',
+    goal_id UUID REFERENCES savings_goals(id) ON DELETE SET NULL,
+    debt_id UUID REFERENCES debts(id) ON DELETE SET NULL,
     start_date DATE NOT NULL,
     end_date DATE,
     next_occurrence DATE,
@@ -117,6 +199,36 @@ CREATE TABLE IF NOT EXISTS recurring_transactions (
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
+
+CREATE TABLE IF NOT EXISTS recurring_drafts (
+    id UUID PRIMARY KEY,
+    recurring_id UUID NOT NULL REFERENCES recurring_transactions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    currency VARCHAR(3) DEFAULT 'USD',
+    category VARCHAR(100) NOT NULL,
+    description TEXT,
+    occurrence_date DATE NOT NULL,
+    due_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    transaction_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (recurring_id, occurrence_date)
+);
+
+CREATE TABLE IF NOT EXISTS recurring_exceptions (
+    id UUID PRIMARY KEY,
+    recurring_id UUID NOT NULL REFERENCES recurring_transactions(id) ON DELETE CASCADE,
+    occurrence_date DATE NOT NULL,
+    skip BOOLEAN NOT NULL DEFAULT false,
+    moved_to DATE,
+    amount DECIMAL(15, 2),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (recurring_id, occurrence_date)
+);
 `
 
 // TestEnv holds the test environment
@@ -228,6 +340,8 @@ func SetupTestEnv(t *testing.T) *TestEnv {
 		r.Put("/api/debts/{id}", debtHandler.Update)
 		r.Delete("/api/debts/{id}", debtHandler.Delete)
 		r.Post("/api/debts/{id}/payment", debtHandler.MakePayment)
+		r.Get("/api/debts/{id}/payments", debtHandler.ListPayments)
+		r.Delete("/api/debts/{id}/payments/{paymentId}", debtHandler.DeletePayment)
 
 		r.Get("/api/recurring", recurringHandler.List)
 		r.Post("/api/recurring", recurringHandler.Create)
@@ -526,6 +640,24 @@ func TestE2E_DebtCRUD(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 
+	// Payment history
+	resp, err = env.Request("GET", fmt.Sprintf("/api/debts/%s/payments", debtID), nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var history struct {
+		Payments []struct {
+			ID string `json:"id"`
+		} `json:"payments"`
+	}
+	json.NewDecoder(resp.Body).Decode(&history)
+	require.Len(t, history.Payments, 1)
+
+	// Reverse payment
+	resp, err = env.Request("DELETE", fmt.Sprintf("/api/debts/%s/payments/%s", debtID, history.Payments[0].ID), nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
 	// List debts
 	resp, err = env.Request("GET", "/api/debts", nil)
 	require.NoError(t, err)
@@ -386,7 +386,7 @@ export default function DashboardPage() {
                 )
                 return (
                   <div
-                    key={bill.id}
+                    key={`${bill.id}-${bill.dueDate}`}
                     className={`p-4 rounded-xl border ${
                       isIncome ? "bg-success/5 border-success/20" : "bg-destructive/5 border-destructive/20"
                     }`}
@@ -391,9 +391,11 @@ export default function DashboardPage() {
                 )
                 return (
                   <div
-                    key={bill.id}
+                    key={`${bill.id}-${bill.dueDate}`}
                     className={`p-4 rounded-xl border ${
-                      isIncome ? "bg-success/5 border-success/20" : "bg-destructive/5 border-destructive/20"
+                      bill.skipped
+                        ? "bg-muted/50 border-border opacity-60"
+                        : isIncome ? "bg-success/5 border-success/20" : "bg-destructive/5 border-destructive/20"
                     }`}
                   >
                     <div className="flex items-start justify-between">
@@ -406,15 +408,25 @@ export default function DashboardPage() {
                             : daysUntil === 1
                             ? t('dashboard.tomorrow')
                             : t('dashboard.inDays', { days: daysUntil })}
+                          {bill.skipped
+                            ? ` · ${t('dashboard.skipped')}`
+                            : bill.scheduledDate && ` · ${t('dashboard.moved')}`}
                         </div>
                       </div>
-                      <span
-                        className={`text-sm font-semibold ${
-                          isIncome ? "text-success" : "text-destructive"
-                        }`}
-                      >
-                        {isIncome ? "+" : "-"}{formatCurrency(bill.amount)}
-                      </span>
+                      <div className="text-right">
+                        <span
+                          className={`text-sm font-semibold ${
+                            bill.skipped ? "line-through text-muted-foreground" : isIncome ? "text-success" : "text-destructive"
+                          }`}
+                        >
+                          {isIncome ? "+" : "-"}{formatCurrency(bill.amount)}
+                        </span>
+                        {bill.scheduledAmount && !bill.skipped && (
+                          <p className="text-xs text-muted-foreground line-through">
+                            {formatCurrency(bill.scheduledAmount)}
+                          </p>
+                        )}
+                      </div>
                     </div>
                   </div>
                 )
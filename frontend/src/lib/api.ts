@@ -532,6 +532,9 @@ export interface UpcomingBill {
   category: string
   dueDate: string
   type: "income" | "expense"
+  skipped?: boolean
+  scheduledDate?: string
+  scheduledAmount?: string
 }
 
 export const FREQUENCY_OPTIONS = [
@@ -0,0 +1,15 @@
+-- Contribution history for savings goals, used for projections
+
+CREATE TABLE IF NOT EXISTS savings_contributions (
+    id UUID PRIMARY KEY,
+    goal_id UUID NOT NULL REFERENCES savings_goals(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount DECIMAL(15, 2) NOT NULL,
+    date DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_savings_contributions_goal_id ON savings_contributions(goal_id);
+CREATE INDEX IF NOT EXISTS idx_savings_contributions_date ON savings_contributions(date);
+
+COMMENT ON TABLE savings_contributions IS 'Individual contributions made towards a savings goal';
@@ -0,0 +1,32 @@
+-- Automatic savings rules that move money into goals without manual contributions
+
+CREATE TABLE IF NOT EXISTS savings_rules (
+    id UUID PRIMARY KEY,
+    goal_id UUID NOT NULL REFERENCES savings_goals(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(30) NOT NULL CHECK (type IN ('round_up', 'percent_of_income', 'fixed_schedule')),
+    round_to DECIMAL(15, 2),
+    percentage DECIMAL(5, 2),
+    category VARCHAR(100),
+    amount DECIMAL(15, 2),
+    frequency VARCHAR(20),
+    next_run DATE,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_savings_rules_goal_id ON savings_rules(goal_id);
+CREATE INDEX IF NOT EXISTS idx_savings_rules_user_active ON savings_rules(user_id, is_active);
+CREATE INDEX IF NOT EXISTS idx_savings_rules_next_run ON savings_rules(next_run) WHERE type = 'fixed_schedule';
+
+-- Link contributions back to the rule and transaction that produced them
+ALTER TABLE savings_contributions
+ADD COLUMN IF NOT EXISTS rule_id UUID REFERENCES savings_rules(id) ON DELETE SET NULL,
+ADD COLUMN IF NOT EXISTS source_transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_savings_contributions_source_tx ON savings_contributions(source_transaction_id);
+
+COMMENT ON COLUMN savings_rules.round_to IS 'Round-up increment (e.g., 10000 for VND, 1 for USD)';
+COMMENT ON COLUMN savings_rules.percentage IS 'Share of matching income swept into the goal';
+COMMENT ON COLUMN savings_rules.category IS 'Income category to match (NULL matches any)';
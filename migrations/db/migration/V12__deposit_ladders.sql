@@ -0,0 +1,38 @@
+-- Term deposit ladders built from scraped bank deposit rates
+
+CREATE TABLE IF NOT EXISTS deposit_ladders (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    goal_id UUID REFERENCES savings_goals(id) ON DELETE SET NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'VND',
+    horizon_months INTEGER NOT NULL,
+    start_date DATE NOT NULL,
+    total_interest_simple DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    total_interest_compound DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS deposit_ladder_rungs (
+    id UUID PRIMARY KEY,
+    ladder_id UUID NOT NULL REFERENCES deposit_ladders(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    bank_code VARCHAR(20) NOT NULL,
+    bank_name VARCHAR(100) NOT NULL,
+    term_months INTEGER NOT NULL,
+    rate DECIMAL(5, 2) NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    rollovers INTEGER NOT NULL DEFAULT 1,
+    interest_simple DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    interest_compound DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    final_maturity_date DATE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_deposit_ladders_user_id ON deposit_ladders(user_id);
+CREATE INDEX IF NOT EXISTS idx_deposit_ladders_goal_id ON deposit_ladders(goal_id);
+CREATE INDEX IF NOT EXISTS idx_deposit_ladder_rungs_ladder_id ON deposit_ladder_rungs(ladder_id, position);
+
+COMMENT ON COLUMN deposit_ladder_rungs.rate IS 'Annual deposit rate as percentage at planning time';
+COMMENT ON COLUMN deposit_ladder_rungs.rollovers IS 'Number of consecutive terms within the ladder horizon';
+COMMENT ON COLUMN deposit_ladder_rungs.interest_compound IS 'Interest when principal and interest roll over together';
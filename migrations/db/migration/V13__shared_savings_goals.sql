@@ -0,0 +1,31 @@
+-- Share savings goals with other users by email invitation
+
+CREATE TABLE IF NOT EXISTS savings_goal_members (
+    goal_id UUID NOT NULL REFERENCES savings_goals(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    permission VARCHAR(20) NOT NULL CHECK (permission IN ('view', 'contribute', 'edit')),
+    joined_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (goal_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_savings_goal_members_user_id ON savings_goal_members(user_id);
+
+CREATE TABLE IF NOT EXISTS savings_goal_invitations (
+    id UUID PRIMARY KEY,
+    goal_id UUID NOT NULL REFERENCES savings_goals(id) ON DELETE CASCADE,
+    inviter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    permission VARCHAR(20) NOT NULL CHECK (permission IN ('view', 'contribute', 'edit')),
+    token VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'revoked')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    responded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_savings_goal_invitations_goal_id ON savings_goal_invitations(goal_id);
+CREATE INDEX IF NOT EXISTS idx_savings_goal_invitations_email ON savings_goal_invitations(LOWER(email)) WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_savings_contributions_goal_user ON savings_contributions(goal_id, user_id);
+
+COMMENT ON COLUMN savings_goal_members.permission IS 'view: read only, contribute: view and add money, edit: contribute and change goal details';
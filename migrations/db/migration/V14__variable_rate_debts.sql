@@ -0,0 +1,17 @@
+-- Variable-rate loans: a fixed promotional rate that later floats at a
+-- published mortgage reference rate plus a margin
+
+ALTER TABLE debts
+ADD COLUMN IF NOT EXISTS term_months INTEGER CHECK (term_months > 0),
+ADD COLUMN IF NOT EXISTS fixed_rate_months INTEGER CHECK (fixed_rate_months >= 0),
+ADD COLUMN IF NOT EXISTS rate_margin DECIMAL(5, 2),
+ADD COLUMN IF NOT EXISTS reference_bank_code VARCHAR(20),
+ADD COLUMN IF NOT EXISTS reference_term_months INTEGER,
+ADD COLUMN IF NOT EXISTS rate_reset_months INTEGER CHECK (rate_reset_months > 0);
+
+COMMENT ON COLUMN debts.term_months IS 'Loan term from start_date, used to re-amortize when the rate resets';
+COMMENT ON COLUMN debts.fixed_rate_months IS 'Months from start_date during which interest_rate is fixed';
+COMMENT ON COLUMN debts.rate_margin IS 'Percentage points added to the reference rate after the fixed period';
+COMMENT ON COLUMN debts.reference_bank_code IS 'Bank of the interest_rates mortgage entry used as reference rate';
+COMMENT ON COLUMN debts.reference_term_months IS 'Term of the interest_rates mortgage entry used as reference rate';
+COMMENT ON COLUMN debts.rate_reset_months IS 'How often the floating rate is reset after the fixed period';
@@ -0,0 +1,9 @@
+-- How interest is charged on a debt: level payments on the declining balance
+-- (bank loans), flat interest on the original amount (consumer finance), or
+-- equal principal instalments with interest on the declining balance
+
+ALTER TABLE debts
+ADD COLUMN IF NOT EXISTS interest_method VARCHAR(20) NOT NULL DEFAULT 'declining_balance'
+    CHECK (interest_method IN ('declining_balance', 'flat', 'equal_principal'));
+
+COMMENT ON COLUMN debts.interest_method IS 'declining_balance (annuity), flat (lãi phẳng) or equal_principal (dư nợ giảm dần, gốc đều)';
@@ -0,0 +1,38 @@
+-- Credit card statement cycles: spending is charged to the card, a statement
+-- closes on the statement day and is due after the grace period. Interest is
+-- only charged when the previous statement was not paid in full by its due date
+
+ALTER TABLE debts
+ADD COLUMN IF NOT EXISTS statement_day INTEGER CHECK (statement_day >= 1 AND statement_day <= 31),
+ADD COLUMN IF NOT EXISTS grace_period_days INTEGER CHECK (grace_period_days >= 0),
+ADD COLUMN IF NOT EXISTS minimum_payment_percent DECIMAL(5, 2) CHECK (minimum_payment_percent > 0 AND minimum_payment_percent <= 100);
+
+COMMENT ON COLUMN debts.statement_day IS 'Day of month a credit card statement closes, clamped to the end of shorter months';
+COMMENT ON COLUMN debts.grace_period_days IS 'Days from the statement date to its due date';
+COMMENT ON COLUMN debts.minimum_payment_percent IS 'Minimum due as a percentage of the statement balance; minimum_payment is the floor';
+
+ALTER TABLE transactions
+ADD COLUMN IF NOT EXISTS debt_id UUID REFERENCES debts(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN transactions.debt_id IS 'Credit card the expense was charged to';
+
+CREATE INDEX IF NOT EXISTS idx_transactions_debt_id ON transactions(debt_id) WHERE debt_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS credit_card_statements (
+    id UUID PRIMARY KEY,
+    debt_id UUID NOT NULL REFERENCES debts(id) ON DELETE CASCADE,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    due_date DATE NOT NULL,
+    opening_balance DECIMAL(15, 2) NOT NULL,
+    purchases DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    payments DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    interest DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    closing_balance DECIMAL(15, 2) NOT NULL,
+    minimum_due DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (debt_id, period_end)
+);
+
+COMMENT ON COLUMN credit_card_statements.period_start IS 'Previous statement date; the cycle covers the days after it up to period_end';
+COMMENT ON COLUMN credit_card_statements.closing_balance IS 'Statement balance to pay in full by due_date to avoid interest';
@@ -0,0 +1,10 @@
+-- Debt payments can be recorded as expense transactions so they show up in the
+-- transaction list and cash flow. The transactions follow the payment when it is
+-- corrected or reversed, and are kept as ordinary expenses if the debt is deleted
+
+ALTER TABLE transactions
+ADD COLUMN IF NOT EXISTS debt_payment_id UUID REFERENCES debt_payments(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN transactions.debt_payment_id IS 'Debt payment the expense records; such expenses are changed through the payment';
+
+CREATE INDEX IF NOT EXISTS idx_transactions_debt_payment_id ON transactions(debt_payment_id) WHERE debt_payment_id IS NOT NULL;
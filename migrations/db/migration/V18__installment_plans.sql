@@ -0,0 +1,20 @@
+-- Buy-now-pay-later and 0% installment plans (trả góp 0%): a purchase repaid in
+-- equal monthly instalments plus a conversion fee, optionally charged to a credit
+-- card whose available credit the remaining instalments use up
+
+ALTER TABLE debts DROP CONSTRAINT IF EXISTS debts_type_check;
+ALTER TABLE debts ADD CONSTRAINT debts_type_check
+    CHECK (type IN ('mortgage', 'auto_loan', 'student_loan', 'credit_card', 'personal_loan', 'installment', 'other'));
+
+ALTER TABLE debts
+ADD COLUMN IF NOT EXISTS merchant VARCHAR(255),
+ADD COLUMN IF NOT EXISTS installment_fee DECIMAL(15, 2) CHECK (installment_fee >= 0),
+ADD COLUMN IF NOT EXISTS card_id UUID REFERENCES debts(id) ON DELETE SET NULL,
+ADD COLUMN IF NOT EXISTS credit_limit DECIMAL(15, 2) CHECK (credit_limit >= 0);
+
+COMMENT ON COLUMN debts.merchant IS 'Store the installment plan was bought from';
+COMMENT ON COLUMN debts.installment_fee IS 'Conversion fee of an installment plan, repaid with the instalments';
+COMMENT ON COLUMN debts.card_id IS 'Credit card an installment plan is charged to';
+COMMENT ON COLUMN debts.credit_limit IS 'Credit limit of a credit card';
+
+CREATE INDEX IF NOT EXISTS idx_debts_card_id ON debts(card_id) WHERE card_id IS NOT NULL;
@@ -0,0 +1,7 @@
+-- RFC 5545 recurrence rules for recurring transactions, such as the 15th and last
+-- day of each month or every 3 months. frequency keeps the closest shorthand
+
+ALTER TABLE recurring_transactions
+ADD COLUMN IF NOT EXISTS rrule TEXT;
+
+COMMENT ON COLUMN recurring_transactions.rrule IS 'RFC 5545 RRULE, e.g. FREQ=MONTHLY;BYMONTHDAY=15,-1; NULL repeats at frequency';
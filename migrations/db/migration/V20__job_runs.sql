@@ -0,0 +1,17 @@
+-- Run history of the in-process scheduler's background jobs, such as generating
+-- recurring transactions, with how much each run processed and why it failed
+
+CREATE TABLE IF NOT EXISTS job_runs (
+    id UUID PRIMARY KEY,
+    job VARCHAR(100) NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    processed INTEGER NOT NULL DEFAULT 0,
+    error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_runs_job_started ON job_runs(job, started_at DESC);
+
+COMMENT ON TABLE job_runs IS 'One row per run of a scheduled background job';
+COMMENT ON COLUMN job_runs.processed IS 'Items the run processed, e.g. transactions generated';
+COMMENT ON COLUMN job_runs.error IS 'Why the run failed; NULL when it succeeded';
@@ -0,0 +1,14 @@
+-- Transactions generated by a recurring transaction are linked to it and to the
+-- occurrence they record, so each occurrence is generated at most once even when a
+-- run is retried, and a template's transactions can be listed. They are kept as
+-- ordinary transactions if the template is deleted
+
+ALTER TABLE transactions
+ADD COLUMN IF NOT EXISTS recurring_id UUID REFERENCES recurring_transactions(id) ON DELETE SET NULL,
+ADD COLUMN IF NOT EXISTS occurrence_date DATE;
+
+COMMENT ON COLUMN transactions.recurring_id IS 'Recurring transaction that generated this transaction';
+COMMENT ON COLUMN transactions.occurrence_date IS 'Occurrence of the recurring transaction this transaction records';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_recurring_occurrence
+ON transactions(recurring_id, occurrence_date) WHERE recurring_id IS NOT NULL;
@@ -0,0 +1,11 @@
+-- Secret-token iCalendar feeds of each user's upcoming bills, debt due dates and
+-- savings goal target dates, for subscribing from Google Calendar and the like.
+-- Rotating the token replaces the row so old feed URLs stop working
+
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+COMMENT ON TABLE calendar_feeds IS 'Per-user secret token for the iCalendar feed URL';
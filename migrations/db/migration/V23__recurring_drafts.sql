@@ -0,0 +1,33 @@
+-- Variable-amount recurring transactions such as electricity and water bills. Their
+-- occurrences are generated as pending drafts, at the estimated amount or the average
+-- of recent confirmed amounts, which the user confirms or adjusts before they become
+-- real transactions. Drafts never count towards balances, budgets or reports
+
+ALTER TABLE recurring_transactions
+ADD COLUMN IF NOT EXISTS amount_type VARCHAR(20) NOT NULL DEFAULT 'fixed'
+    CHECK (amount_type IN ('fixed', 'estimated', 'average'));
+
+COMMENT ON COLUMN recurring_transactions.amount_type IS 'fixed generates transactions; estimated and average generate drafts to confirm';
+
+CREATE TABLE IF NOT EXISTS recurring_drafts (
+    id UUID PRIMARY KEY,
+    recurring_id UUID NOT NULL REFERENCES recurring_transactions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('income', 'expense')),
+    amount DECIMAL(15, 2) NOT NULL,
+    currency VARCHAR(3) DEFAULT 'USD',
+    category VARCHAR(100) NOT NULL,
+    description TEXT,
+    occurrence_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'dismissed')),
+    transaction_id UUID REFERENCES transactions(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (recurring_id, occurrence_date)
+);
+
+COMMENT ON TABLE recurring_drafts IS 'Occurrences of variable-amount recurring transactions awaiting confirmation';
+COMMENT ON COLUMN recurring_drafts.amount IS 'Estimated amount, replaced by the actual amount on confirmation';
+
+CREATE INDEX IF NOT EXISTS idx_recurring_drafts_user_pending
+ON recurring_drafts(user_id, occurrence_date) WHERE status = 'pending';
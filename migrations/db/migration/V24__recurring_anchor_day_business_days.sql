@@ -0,0 +1,12 @@
+-- Monthly and yearly recurring transactions fall on an anchor day clamped to the month
+-- end, so a series started on the 31st falls on the last day of shorter months instead
+-- of skipping them. A business-day policy moves occurrences that fall on a weekend or
+-- Vietnamese public holiday to the previous or next working day
+
+ALTER TABLE recurring_transactions
+ADD COLUMN IF NOT EXISTS anchor_day SMALLINT CHECK (anchor_day BETWEEN 1 AND 31),
+ADD COLUMN IF NOT EXISTS business_day_policy VARCHAR(20) NOT NULL DEFAULT 'keep'
+    CHECK (business_day_policy IN ('keep', 'previous', 'next'));
+
+COMMENT ON COLUMN recurring_transactions.anchor_day IS 'Day of month of monthly and yearly occurrences, clamped to the month end; defaults to the start date''s day';
+COMMENT ON COLUMN recurring_transactions.business_day_policy IS 'Where occurrences on weekends and public holidays move to: keep, previous or next working day';
@@ -0,0 +1,24 @@
+-- Per-occurrence exceptions to a recurring transaction, such as skipping rent one month,
+-- moving a payment to another day or a gym fee of 0 for one month, without pausing or
+-- editing the whole template. An exception is keyed by the scheduled occurrence it
+-- changes. Drafts keep the day they are due, which a moved occurrence changes
+
+CREATE TABLE IF NOT EXISTS recurring_exceptions (
+    id UUID PRIMARY KEY,
+    recurring_id UUID NOT NULL REFERENCES recurring_transactions(id) ON DELETE CASCADE,
+    occurrence_date DATE NOT NULL,
+    skip BOOLEAN NOT NULL DEFAULT false,
+    moved_to DATE,
+    amount DECIMAL(15, 2) CHECK (amount >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (recurring_id, occurrence_date),
+    CHECK (skip OR moved_to IS NOT NULL OR amount IS NOT NULL)
+);
+
+COMMENT ON TABLE recurring_exceptions IS 'Skipped, moved or re-priced occurrences of recurring transactions';
+COMMENT ON COLUMN recurring_exceptions.occurrence_date IS 'Scheduled occurrence the exception changes';
+
+ALTER TABLE recurring_drafts ADD COLUMN IF NOT EXISTS due_date DATE;
+UPDATE recurring_drafts SET due_date = occurrence_date WHERE due_date IS NULL;
+ALTER TABLE recurring_drafts ALTER COLUMN due_date SET NOT NULL;
@@ -0,0 +1,11 @@
+-- A recurring expense can pay into a savings goal or towards a debt, such as 2M into the
+-- emergency fund or 5M to the mortgage each month. Each generated occurrence records the
+-- expense and contributes to the goal or records the debt payment
+
+ALTER TABLE recurring_transactions
+ADD COLUMN IF NOT EXISTS goal_id UUID REFERENCES savings_goals(id) ON DELETE SET NULL,
+ADD COLUMN IF NOT EXISTS debt_id UUID REFERENCES debts(id) ON DELETE SET NULL,
+ADD CONSTRAINT recurring_transactions_single_target CHECK (goal_id IS NULL OR debt_id IS NULL);
+
+COMMENT ON COLUMN recurring_transactions.goal_id IS 'Savings goal each occurrence contributes to';
+COMMENT ON COLUMN recurring_transactions.debt_id IS 'Debt each occurrence is a payment of';
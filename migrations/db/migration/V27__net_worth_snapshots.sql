@@ -0,0 +1,20 @@
+-- Daily snapshots of each user's net worth, so its trend can be charted. A scheduled job
+-- replaces the day's snapshots with the current savings goals, term deposits and debts,
+-- so past days keep the last values taken that day. Currencies are never converted:
+-- a user with goals or debts in several currencies has one snapshot per currency
+
+CREATE TABLE IF NOT EXISTS net_worth_snapshots (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    assets DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    liabilities DECIMAL(15, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, date, currency)
+);
+
+CREATE INDEX IF NOT EXISTS idx_net_worth_snapshots_date ON net_worth_snapshots(date);
+
+COMMENT ON TABLE net_worth_snapshots IS 'Net worth of a user in one currency on one day';
+COMMENT ON COLUMN net_worth_snapshots.assets IS 'Savings goals owned and term deposits not attached to a goal';
+COMMENT ON COLUMN net_worth_snapshots.liabilities IS 'Outstanding debt balances';
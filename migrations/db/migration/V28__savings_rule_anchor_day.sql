@@ -0,0 +1,11 @@
+-- Monthly and yearly fixed-schedule savings rules run on an anchor day clamped to the
+-- month end, so a rule started on the 31st runs on the last day of shorter months
+-- instead of skipping them
+
+ALTER TABLE savings_rules
+ADD COLUMN IF NOT EXISTS anchor_day SMALLINT CHECK (anchor_day BETWEEN 1 AND 31);
+
+UPDATE savings_rules SET anchor_day = EXTRACT(DAY FROM next_run)
+WHERE type = 'fixed_schedule' AND next_run IS NOT NULL;
+
+COMMENT ON COLUMN savings_rules.anchor_day IS 'Day of month of monthly and yearly fixed-schedule runs, clamped to the month end';
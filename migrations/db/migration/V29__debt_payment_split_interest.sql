@@ -0,0 +1,13 @@
+-- Whether a debt payment records its interest as a separate Debt Interest expense, so a
+-- correction keeps the split even when the interest is zero and no such expense exists
+
+ALTER TABLE debt_payments
+ADD COLUMN IF NOT EXISTS split_interest BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE debt_payments p SET split_interest = true
+WHERE EXISTS (
+    SELECT 1 FROM transactions t
+    WHERE t.debt_payment_id = p.id AND t.category = 'Debt Interest'
+);
+
+COMMENT ON COLUMN debt_payments.split_interest IS 'Interest is recorded as a separate Debt Interest expense';